@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"linkedin-automation/internal/core"
+	"linkedin-automation/internal/repository"
+
+	"go.uber.org/zap"
+)
+
+// backupPattern matches the files written by automatic backups, whose names sort by time
+const backupPattern = "bot-*.db.gz"
+
+// runBackupCommand writes a gzipped snapshot of the SQLite database and its checksum.
+// It is safe to run while the bot or 'bot serve' is using the database.
+func runBackupCommand(args []string) error {
+	fs := flag.NewFlagSet("backup", flag.ContinueOnError)
+	out := fs.String("out", "", "Backup file to write (default: a timestamped file in database.backup.dir)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	path := *out
+	if path == "" {
+		path = backupPath(&cfg.Database.Backup, time.Now())
+	}
+
+	repo, err := repository.NewRepository(&cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to initialize repository: %w", err)
+	}
+	defer repo.Close()
+
+	info, err := repo.Backup(context.Background(), path)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Backup written to %s (%d bytes, sha256 %s)\n", info.Path, info.Size, info.Checksum)
+	return nil
+}
+
+// runRestoreCommand replaces the SQLite database with a backup after verifying it.
+// The bot and 'bot serve' must be stopped first.
+func runRestoreCommand(args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	in := fs.String("in", "", "Backup file written by 'bot backup' (required)")
+	force := fs.Bool("force", false, "Replace the database even if it has newer activity than the backup")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *in == "" {
+		return fmt.Errorf("-in is required")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	result, err := repository.RestoreBackup(context.Background(), &cfg.Database, *in, *force)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Database restored from %s (latest activity in backup: %s)\n", *in, formatActivity(result.BackupActivity))
+	if !result.ReplacedActivity.IsZero() {
+		fmt.Printf("The replaced database's latest activity was %s\n", formatActivity(result.ReplacedActivity))
+	}
+	return nil
+}
+
+// formatActivity formats a latest-activity time, which is zero for an empty history
+func formatActivity(t time.Time) string {
+	if t.IsZero() {
+		return "none"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+// backupPath names an automatic backup taken at t
+func backupPath(backup *core.BackupConfig, t time.Time) string {
+	dir := backup.Dir
+	if dir == "" {
+		dir = "data/backups" // Default fallback
+	}
+	return filepath.Join(dir, "bot-"+t.UTC().Format("20060102-150405")+".db.gz")
+}
+
+// runPeriodicBackup backs up the database every backup.IntervalHours until ctx is done,
+// starting immediately, and deletes all but the newest backup.Keep backups. Failures are
+// logged and retried at the next interval.
+func runPeriodicBackup(ctx context.Context, repo *repository.Repository, backup *core.BackupConfig, logger *zap.Logger) {
+	if backup.IntervalHours <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(backup.IntervalHours) * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		if info, err := repo.Backup(ctx, backupPath(backup, time.Now())); err != nil {
+			logger.Error("Automatic backup failed", zap.Error(err))
+		} else {
+			logger.Info("Automatic backup written", zap.String("path", info.Path), zap.Int64("bytes", info.Size))
+			if removed, err := pruneBackups(filepath.Dir(info.Path), backup.Keep); err != nil {
+				logger.Warn("Failed to delete old backups", zap.Error(err))
+			} else if removed > 0 {
+				logger.Info("Old backups deleted", zap.Int("count", removed))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// pruneBackups deletes all but the newest keep automatic backups in dir, with their
+// checksum files
+func pruneBackups(dir string, keep int) (int, error) {
+	if keep <= 0 {
+		keep = 7 // Default fallback
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, backupPattern))
+	if err != nil {
+		return 0, err
+	}
+	if len(paths) <= keep {
+		return 0, nil
+	}
+	sort.Strings(paths)
+
+	removed := 0
+	for _, path := range paths[:len(paths)-keep] {
+		if err := os.Remove(path); err != nil {
+			return removed, err
+		}
+		if err := os.Remove(path + ".sha256"); err != nil && !os.IsNotExist(err) {
+			return removed, err
+		}
+		removed++
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"linkedin-automation/internal/core"
+	"linkedin-automation/internal/repository"
+)
+
+// blacklistUsage describes the blacklist subcommands
+const blacklistUsage = `usage:
+  bot blacklist add -type exact_url|url_prefix|company_regex|headline_regex -value VALUE [-reason TEXT]
+  bot blacklist remove -id ID
+  bot blacklist list`
+
+// runBlacklistCommand manages blacklist entries: "add", "remove" and "list". It only
+// touches the database, never the browser.
+func runBlacklistCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing blacklist command\n%s", blacklistUsage)
+	}
+
+	fs := flag.NewFlagSet("blacklist "+args[0], flag.ContinueOnError)
+	patternType := fs.String("type", core.BlacklistExactURL, "Pattern type: exact_url, url_prefix, company_regex or headline_regex")
+	value := fs.String("value", "", "Profile URL, URL prefix or regexp")
+	reason := fs.String("reason", "", "Why matching profiles are excluded, recorded on the profile")
+	id := fs.Uint("id", 0, "Entry ID shown by 'bot blacklist list'")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	repo, err := repository.NewRepository(&cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to initialize repository: %w", err)
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "add":
+		if *value == "" {
+			return fmt.Errorf("-value is required\n%s", blacklistUsage)
+		}
+
+		entry := &core.BlacklistEntry{
+			PatternType: *patternType,
+			Value:       *value,
+			Reason:      *reason,
+		}
+		if err := repo.AddBlacklistEntry(ctx, entry); err != nil {
+			return fmt.Errorf("failed to add blacklist entry: %w", err)
+		}
+		fmt.Printf("Added blacklist entry %d (%s %s)\n", entry.ID, entry.PatternType, entry.Value)
+
+	case "remove":
+		if *id == 0 {
+			return fmt.Errorf("-id is required\n%s", blacklistUsage)
+		}
+		if err := repo.RemoveBlacklistEntry(ctx, *id); err != nil {
+			return fmt.Errorf("failed to remove blacklist entry: %w", err)
+		}
+		fmt.Printf("Removed blacklist entry %d\n", *id)
+
+	case "list":
+		entries, err := repo.ListBlacklist(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list blacklist: %w", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tTYPE\tVALUE\tREASON\tCREATED")
+		for _, entry := range entries {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n",
+				entry.ID,
+				entry.PatternType,
+				entry.Value,
+				entry.Reason,
+				entry.CreatedAt.Format("2006-01-02"),
+			)
+		}
+		return w.Flush()
+
+	default:
+		return fmt.Errorf("unknown blacklist command %q\n%s", args[0], blacklistUsage)
+	}
+
+	return nil
+}
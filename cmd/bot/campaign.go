@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"linkedin-automation/internal/core"
+	"linkedin-automation/internal/repository"
+)
+
+// campaignUsage describes the campaign subcommands
+const campaignUsage = `usage:
+  bot campaign create -name NAME [-note TEMPLATE] [-sequence T1,T2,...] [-budget-share 0.5]
+  bot campaign list
+  bot campaign pause|resume|archive -name NAME`
+
+// runCampaignCommand manages campaign records: "create", "list", and the status changes
+// "pause", "resume" and "archive". It only touches the database, never the browser.
+func runCampaignCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing campaign command\n%s", campaignUsage)
+	}
+
+	fs := flag.NewFlagSet("campaign "+args[0], flag.ContinueOnError)
+	name := fs.String("name", "", "Campaign name")
+	noteTemplate := fs.String("note", "", "Connection note template ({{Name}}); empty uses connection.note_template")
+	sequence := fs.String("sequence", "", "Comma-separated messaging.templates names, one per follow-up message")
+	budgetShare := fs.Float64("budget-share", 0, "Share (0-1] of the daily connection and message limits (0 = no cap of its own)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *name == "" && fs.NArg() > 0 {
+		*name = fs.Arg(0)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	repo, err := repository.NewRepository(&cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to initialize repository: %w", err)
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "create":
+		if *name == "" {
+			return fmt.Errorf("-name is required\n%s", campaignUsage)
+		}
+		if *budgetShare < 0 || *budgetShare > 1 {
+			return fmt.Errorf("-budget-share must be between 0 and 1")
+		}
+
+		campaign := &core.Campaign{
+			Name:             *name,
+			NoteTemplate:     *noteTemplate,
+			DailyBudgetShare: *budgetShare,
+		}
+		if *sequence != "" {
+			var names []string
+			for _, templateName := range strings.Split(*sequence, ",") {
+				templateName = strings.TrimSpace(templateName)
+				if templateName == "" {
+					continue
+				}
+				if _, ok := cfg.Messaging.Templates[strings.ToLower(templateName)]; !ok {
+					return fmt.Errorf("template %q is not defined in messaging.templates", templateName)
+				}
+				names = append(names, templateName)
+			}
+			// Templates past the last message step of messaging.sequence would never be sent
+			if steps := messageSteps(cfg.Messaging.Sequence); len(names) > steps {
+				return fmt.Errorf("-sequence has %d templates but messaging.sequence has %d message step(s); add message steps or drop templates", len(names), steps)
+			}
+			encoded, err := json.Marshal(names)
+			if err != nil {
+				return fmt.Errorf("failed to encode message sequence: %w", err)
+			}
+			campaign.MessageSequence = string(encoded)
+		}
+
+		if err := repo.CreateCampaign(ctx, campaign); err != nil {
+			return fmt.Errorf("failed to create campaign: %w", err)
+		}
+		fmt.Printf("Created campaign %q\n", campaign.Name)
+
+	case "list":
+		campaigns, err := repo.ListCampaigns(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list campaigns: %w", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tSTATUS\tBUDGET SHARE\tSEQUENCE\tCREATED")
+		for _, campaign := range campaigns {
+			share := "-"
+			if campaign.DailyBudgetShare > 0 {
+				share = fmt.Sprintf("%.0f%%", campaign.DailyBudgetShare*100)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+				campaign.Name,
+				campaign.Status,
+				share,
+				campaign.MessageSequence,
+				campaign.CreatedAt.Format("2006-01-02"),
+			)
+		}
+		return w.Flush()
+
+	case "pause", "resume", "archive":
+		if *name == "" {
+			return fmt.Errorf("-name is required\n%s", campaignUsage)
+		}
+
+		status := map[string]string{
+			"pause":   core.CampaignStatusPaused,
+			"resume":  core.CampaignStatusActive,
+			"archive": core.CampaignStatusArchived,
+		}[args[0]]
+		if err := repo.UpdateCampaignStatus(ctx, *name, status); err != nil {
+			return fmt.Errorf("failed to update campaign: %w", err)
+		}
+		fmt.Printf("Campaign %q is now %s\n", *name, status)
+
+	default:
+		return fmt.Errorf("unknown campaign command %q\n%s", args[0], campaignUsage)
+	}
+
+	return nil
+}
+
+// messageSteps counts the message steps of a follow-up sequence; an empty sequence is a
+// single message
+func messageSteps(sequence []core.SequenceStep) int {
+	if len(sequence) == 0 {
+		return 1
+	}
+	steps := 0
+	for _, step := range sequence {
+		if step.Type == core.SequenceStepMessage {
+			steps++
+		}
+	}
+	return steps
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"linkedin-automation/internal/repository"
+)
+
+// newEncryptionKeyEnv holds the key "bot db rekey" re-encrypts the database with, so the
+// key stays out of the shell history and the process list
+const newEncryptionKeyEnv = "LINKEDIN_BOT_DATABASE_NEW_ENCRYPTION_KEY"
+
+// dbUsage describes the db subcommands
+const dbUsage = `usage:
+  ` + newEncryptionKeyEnv + `=... bot db rekey
+  bot db migrate-history`
+
+// runDBCommand maintains the database file: "rekey" re-encrypts it with the key in
+// LINKEDIN_BOT_DATABASE_NEW_ENCRYPTION_KEY, opening it with database.encryption_key, and
+// "migrate-history" converts free-text history details to JSON
+func runDBCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing db command\n%s", dbUsage)
+	}
+
+	switch args[0] {
+	case "rekey":
+		newKey := os.Getenv(newEncryptionKeyEnv)
+		if newKey == "" {
+			return fmt.Errorf("%s is not set\n%s", newEncryptionKeyEnv, dbUsage)
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+		if newKey == cfg.Database.EncryptionKey {
+			return fmt.Errorf("the new encryption key is the current one")
+		}
+
+		repo, err := repository.NewRepository(&cfg.Database)
+		if err != nil {
+			return fmt.Errorf("failed to initialize repository: %w", err)
+		}
+		defer repo.Close()
+
+		if err := repo.Rekey(context.Background(), newKey); err != nil {
+			return err
+		}
+		fmt.Println("Database re-encrypted. Set database.encryption_key (LINKEDIN_BOT_DATABASE_ENCRYPTION_KEY) to the new key.")
+		return nil
+
+	case "migrate-history":
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		repo, err := repository.NewRepository(&cfg.Database)
+		if err != nil {
+			return fmt.Errorf("failed to initialize repository: %w", err)
+		}
+		defer repo.Close()
+
+		converted, err := repo.MigrateHistoryDetails(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to migrate history details (%d rows converted): %w", converted, err)
+		}
+		fmt.Printf("Converted the details of %d history entries to JSON\n", converted)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown db command %q\n%s", args[0], dbUsage)
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// jobPollInterval is how often 'bot serve' checks the job queue when it is idle
+const jobPollInterval = 5 * time.Second
+
+// jobShutdownGrace is how long an interrupted job's run gets to save its state and exit
+const jobShutdownGrace = time.Minute
+
+// runJobQueue runs the jobs queued through POST /jobs while 'bot serve' runs, with
+// api.jobs_enabled. Each job is a separate bot run of this binary, and runs one at a time
+// so they never share the browser. Jobs a previous 'bot serve' left running are requeued.
+func runJobQueue(ctx context.Context, cfg *core.Config, repo core.RepositoryPort, logger *zap.Logger) {
+	if !cfg.Api.JobsEnabled {
+		return
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		logger.Error("Job queue disabled: cannot locate the bot binary", zap.Error(err))
+		return
+	}
+	if requeued, err := repo.RequeueRunningJobs(ctx); err != nil {
+		logger.Error("Failed to requeue interrupted jobs", zap.Error(err))
+	} else if requeued > 0 {
+		logger.Info("Requeued jobs interrupted by a restart", zap.Int64("jobs", requeued))
+	}
+
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			job, err := repo.ClaimNextJob(ctx)
+			if err != nil {
+				logger.Error("Failed to read the job queue", zap.Error(err))
+				break
+			}
+			if job == nil {
+				break
+			}
+			runJob(ctx, exe, repo, job, logger)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runJob runs job to completion and records the outcome. When ctx is cancelled the run
+// is interrupted and the job left running, to be requeued by the next 'bot serve'.
+func runJob(ctx context.Context, exe string, repo core.RepositoryPort, job *core.Job, logger *zap.Logger) {
+	logger.Info("Starting job",
+		zap.Uint("job_id", job.ID),
+		zap.String("mode", job.Mode),
+		zap.String("keyword", job.Keyword),
+		zap.String("campaign", job.Campaign),
+		zap.Int("attempt", job.Attempts),
+	)
+
+	cmd := exec.CommandContext(ctx, exe, jobArgs(job)...)
+	cmd.Cancel = func() error { return cmd.Process.Signal(os.Interrupt) }
+	cmd.WaitDelay = jobShutdownGrace
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	// 'bot serve' already serves the API on its address
+	cmd.Env = append(os.Environ(), "LINKEDIN_BOT_API_ENABLED=false")
+	runErr := cmd.Run()
+
+	if ctx.Err() != nil {
+		logger.Info("Job interrupted, it runs again on the next start", zap.Uint("job_id", job.ID))
+		return
+	}
+
+	// The run context may already be cancelled on shutdown
+	finishCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	status, jobErr := core.JobStatusSucceeded, ""
+	if runErr != nil {
+		status, jobErr = core.JobStatusFailed, runErr.Error()
+		var exitErr *exec.ExitError
+		if errors.As(runErr, &exitErr) {
+			jobErr = "bot run exited with status " + strconv.Itoa(exitErr.ExitCode())
+		}
+	}
+	runID := jobRunID(finishCtx, repo, job, logger)
+	if err := repo.FinishJob(finishCtx, job.ID, status, runID, jobErr); err != nil {
+		logger.Error("Failed to record job outcome", zap.Uint("job_id", job.ID), zap.Error(err))
+		return
+	}
+	logger.Info("Job finished",
+		zap.Uint("job_id", job.ID),
+		zap.String("status", string(status)),
+		zap.String(core.LogFieldRunID, runID),
+	)
+}
+
+// jobArgs returns the bot flags that run job
+func jobArgs(job *core.Job) []string {
+	args := []string{"-config", *configPath, "-log-level", *logLevel, "-log-format", *logFormat}
+	if job.Campaign != "" {
+		args = append(args, "-campaign", job.Campaign)
+	}
+
+	switch job.Mode {
+	case core.JobModeConnect:
+		args = append(args, "-keyword", job.Keyword)
+		if job.Location != "" {
+			args = append(args, "-location", job.Location)
+		}
+		if job.Budget > 0 {
+			args = append(args, "-max", strconv.Itoa(job.Budget))
+		}
+	default:
+		// The other modes are named after their flag
+		args = append(args, "-"+job.Mode)
+	}
+	return args
+}
+
+// jobRunID returns the ID of the first run started since job started, or "" when the
+// run failed before recording one
+func jobRunID(ctx context.Context, repo core.RepositoryPort, job *core.Job, logger *zap.Logger) string {
+	runs, err := repo.ListRunMetadata(ctx, 10)
+	if err != nil {
+		logger.Warn("Failed to find the job's run", zap.Uint("job_id", job.ID), zap.Error(err))
+		return ""
+	}
+
+	// Runs are newest first
+	for i := len(runs) - 1; i >= 0; i-- {
+		if job.StartedAt != nil && !runs[i].StartedAt.Before(*job.StartedAt) {
+			return runs[i].RunID
+		}
+	}
+	return ""
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"linkedin-automation/internal/api"
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// brokerWriter broadcasts each log entry written to it, one JSON line per entry
+type brokerWriter struct {
+	broker *api.EventBroker
+}
+
+func (w brokerWriter) Write(p []byte) (int, error) {
+	// zap reuses p once Write returns, so it is copied into the string
+	w.broker.Broadcast(string(p))
+	return len(p), nil
+}
+
+func (w brokerWriter) Sync() error {
+	return nil
+}
+
+// withLogStream returns logger teed into a new event broker when api.sse_enabled is set,
+// so /events streams every log line as JSON. Otherwise it returns logger and nil.
+func withLogStream(cfg *core.Config, logger *zap.Logger) (*zap.Logger, *api.EventBroker) {
+	if !cfg.Api.SSEEnabled {
+		return logger, nil
+	}
+
+	broker := api.NewEventBroker()
+	encoder := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	stream := zapcore.NewCore(encoder, brokerWriter{broker: broker}, zapcore.DebugLevel)
+	logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, stream)
+	}))
+	return logger, broker
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// newLogger builds the bot's logger. The default json format writes one JSON object per
+// line with the core.LogField* correlation fields; console is zap's development output
+// for reading logs in a terminal.
+func newLogger(level, format string) (*zap.Logger, error) {
+	var zapLevel zapcore.Level
+	if err := zapLevel.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q: %w", level, err)
+	}
+
+	var zapConfig zap.Config
+	switch format {
+	case "json":
+		zapConfig = zap.NewProductionConfig()
+		zapConfig.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+		// Sampling would drop repeated lines, e.g. most of a long connect loop's
+		zapConfig.Sampling = nil
+	case "console":
+		zapConfig = zap.NewDevelopmentConfig()
+	default:
+		return nil, fmt.Errorf("invalid log format %q (json or console)", format)
+	}
+	zapConfig.Level = zap.NewAtomicLevelAt(zapLevel)
+
+	return zapConfig.Build()
+}
+
+// runLogger returns logger with the run's ID and account on every line
+func runLogger(cfg *core.Config, runID string, logger *zap.Logger) *zap.Logger {
+	account := cfg.Database.Account
+	if account == "" {
+		account = core.DefaultAccount // Default fallback
+	}
+	fields := []zap.Field{zap.String(core.LogFieldAccount, account)}
+	if runID != "" {
+		fields = append(fields, zap.String(core.LogFieldRunID, runID))
+	}
+	return logger.With(fields...)
+}
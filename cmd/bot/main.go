@@ -1,40 +1,133 @@
 package main
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"hash/fnv"
+	"io"
+	"net/http"
+	_ "net/http/pprof" // Registers /debug/pprof/ on http.DefaultServeMux, served only with -pprof
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"linkedin-automation/config"
+	"linkedin-automation/internal/api"
 	"linkedin-automation/internal/browser"
 	"linkedin-automation/internal/core"
+	"linkedin-automation/internal/integrations"
+	"linkedin-automation/internal/reports"
 	"linkedin-automation/internal/repository"
+	"linkedin-automation/internal/selectors"
+	"linkedin-automation/internal/state"
 	"linkedin-automation/internal/stealth"
 	"linkedin-automation/internal/workflows"
+	"linkedin-automation/pkg/linkedin"
+	"linkedin-automation/pkg/pacing"
 	"linkedin-automation/pkg/utils"
 
 	"go.uber.org/zap"
 )
 
 var (
-	configPath = flag.String("config", "config/config.yaml", "Path to configuration file")
-	keyword    = flag.String("keyword", "", "Search keyword (required)")
-	maxResults = flag.Int("max", 10, "Maximum number of profiles to connect with")
-	location   = flag.String("location", "", "Location filter for search (optional)")
-	note       = flag.String("note", "", "Connection note template (overrides config)")
-	scan       = flag.Bool("scan", false, "Scan for new connections")
-	followup   = flag.Bool("followup", false, "Send follow-up messages to new connections")
+	configPath      = flag.String("config", "config/config.yaml", "Path to configuration file")
+	keyword         = flag.String("keyword", "", "Search keyword (required)")
+	maxResults      = flag.Int("max", 10, "Maximum number of profiles to connect with")
+	location        = flag.String("location", "", "Location filter for search (optional)")
+	note            = flag.String("note", "", "Connection note template (overrides config)")
+	scan            = flag.Bool("scan", false, "Scan for new connections")
+	followup        = flag.Bool("followup", false, "Send follow-up messages to new connections")
+	scanSent        = flag.Bool("scan-sent", false, "Reconcile sent invitations (detect expired/ignored requests)")
+	scanReplies     = flag.Bool("scan-replies", false, "Open messaged conversations and store replies")
+	enrich          = flag.Bool("enrich", false, "Capture title, company and education for profiles not yet enriched")
+	likePosts       = flag.Int("like-posts", 0, "Like this many posts from the feed (or -like-source) to keep the account active")
+	likeSource      = flag.String("like-source", "", "Feed or hashtag page URL to like posts from (default: home feed)")
+	visit           = flag.Int("visit", 0, "Visit up to this many discovered profiles (or -visit-file) without interacting")
+	visitFile       = flag.String("visit-file", "", "File with one profile URL per line to visit instead of discovered profiles")
+	followCompanies = flag.Int("follow-companies", 0, "Follow up to this many company pages (engagement.follow_companies, then enriched profiles' companies)")
+	commentPost     = flag.String("comment-post", "", "Comment on this post URL using engagement.comment_template (allowlisted authors only)")
+	celebrations    = flag.Bool("celebrations", false, "Message connections with a birthday or work anniversary on the notifications page")
+	exportConns     = flag.String("export-connections", "", "Export all current connections to this CSV file and reconcile them into the database")
+	acceptInvites   = flag.Bool("accept-invitations", false, "Accept or ignore incoming invitations using the invitations include/exclude rules")
+	reportOnly      = flag.Bool("report-only", false, "With -accept-invitations, log each invitation decision without accepting or ignoring anything")
+	inMail          = flag.String("inmail", "", "Send an InMail (inmail.subject / inmail.template) to this profile URL (premium accounts only)")
+	scanNotifs      = flag.Bool("scan-notifications", false, "Store profile views, reactions, accepted invitations and mentions from the notifications page")
+	exportNotifs    = flag.String("export-notifications", "", "Export all stored notifications to this CSV file")
+	groupsStatus    = flag.Bool("groups-status", false, "Revisit groups awaiting join approval and record the approved ones")
+	migrateEncrypt  = flag.Bool("migrate-encryption", false, "Encrypt a plaintext SQLite database in place when database.encryption_key is set")
+	rebuildProjs    = flag.Bool("rebuild-projections", false, "Recompute the analytics_daily table from the full history and exit")
+	previewNote     = flag.Bool("preview-note", false, "Print the follow-up template (or -note) rendered for a sample profile and exit")
+	stdin           = flag.Bool("stdin", false, "Connect with the profile URLs read from standard input, one per line, instead of searching")
+	campaign        = flag.String("campaign", "", "Campaign to run: tags discovered profiles and selects its note, follow-ups and budget (see 'bot campaign')")
+	profileSteps    = flag.Bool("profile", false, "Log the time spent in each workflow step when the run ends")
+	pprofServer     = flag.Bool("pprof", false, "Serve net/http/pprof on debug.pprof_addr for heap, goroutine and CPU profiles")
+	logLevel        = flag.String("log-level", "info", "Lowest level logged: debug, info, warn or error")
+	logFormat       = flag.String("log-format", "json", "Log output: json (one object per line) or console")
+	groupURLs       stringSliceFlag
+	joinGroups      stringSliceFlag
+	skills          stringSliceFlag
+
+	// stdinURLs holds the profile URLs read with -stdin
+	stdinURLs []string
 )
 
+// stdinReadTimeout bounds how long -stdin waits for the input to be closed
+const stdinReadTimeout = 2 * time.Minute
+
+// stringSliceFlag collects repeated occurrences of a flag into a slice
+type stringSliceFlag []string
+
+func (s *stringSliceFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringSliceFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+func init() {
+	flag.Var(&groupURLs, "group-url", "LinkedIn group URL to source members from (repeatable)")
+	flag.Var(&joinGroups, "join-group", "Join this LinkedIn group, or request to join it (repeatable, capped by groups.max_joins_per_week)")
+	flag.Var(&skills, "skill", "Only find people listing this skill (repeatable)")
+}
+
+// startPProfServer serves the net/http/pprof handlers on debug.pprof_addr in the
+// background. The API server uses its own mux, so they are never exposed there.
+func startPProfServer(cfg *core.Config, logger *zap.Logger) {
+	addr := cfg.Debug.PProfAddr
+	if addr == "" {
+		addr = "127.0.0.1:6060" // Default fallback
+	}
+
+	go func() {
+		logger.Info("pprof server listening", zap.String("address", addr))
+		if err := http.ListenAndServe(addr, nil); err != nil {
+			logger.Error("pprof server stopped", zap.Error(err))
+		}
+	}()
+}
+
+// loadConfig loads -config and applies the flags that adjust it for every command
+func loadConfig() (*core.Config, error) {
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Database.MigrateEncryption = *migrateEncrypt
+	return cfg, nil
+}
+
 func main() {
 	flag.Parse()
 
 	// Initialize logger
-	logger, err := zap.NewDevelopment()
+	logger, err := newLogger(*logLevel, *logFormat)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
 		os.Exit(1)
@@ -46,23 +139,153 @@ func main() {
 		zap.String("purpose", "Educational POC"),
 	)
 
+	// "bot campaign ..." manages campaign records without starting the browser
+	if flag.NArg() > 0 && flag.Arg(0) == "campaign" {
+		if err := runCampaignCommand(flag.Args()[1:]); err != nil {
+			logger.Fatal("Campaign command failed", zap.Error(err))
+		}
+		return
+	}
+
+	// "bot blacklist ..." manages blacklist entries
+	if flag.NArg() > 0 && flag.Arg(0) == "blacklist" {
+		if err := runBlacklistCommand(flag.Args()[1:]); err != nil {
+			logger.Fatal("Blacklist command failed", zap.Error(err))
+		}
+		return
+	}
+
+	// "bot profile ..." repairs profile records by hand
+	if flag.NArg() > 0 && flag.Arg(0) == "profile" {
+		if err := runProfileCommand(flag.Args()[1:]); err != nil {
+			logger.Fatal("Profile command failed", zap.Error(err))
+		}
+		return
+	}
+
+	// "bot runs ..." shows recorded runs and their actions
+	if flag.NArg() > 0 && flag.Arg(0) == "runs" {
+		if err := runRunsCommand(flag.Args()[1:]); err != nil {
+			logger.Fatal("Runs command failed", zap.Error(err))
+		}
+		return
+	}
+
+	// "bot stats" summarizes outreach results
+	if flag.NArg() > 0 && flag.Arg(0) == "stats" {
+		if err := runStatsCommand(flag.Args()[1:]); err != nil {
+			logger.Fatal("Stats command failed", zap.Error(err))
+		}
+		return
+	}
+
+	// "bot report" prints or emails a daily report
+	if flag.NArg() > 0 && flag.Arg(0) == "report" {
+		if err := runReportCommand(flag.Args()[1:], logger); err != nil {
+			logger.Fatal("Report command failed", zap.Error(err))
+		}
+		return
+	}
+
+	// "bot db ..." maintains the database file
+	if flag.NArg() > 0 && flag.Arg(0) == "db" {
+		if err := runDBCommand(flag.Args()[1:]); err != nil {
+			logger.Fatal("Database command failed", zap.Error(err))
+		}
+		return
+	}
+
+	// "bot backup" and "bot restore" copy the SQLite database to and from a backup file
+	if flag.NArg() > 0 && flag.Arg(0) == "backup" {
+		if err := runBackupCommand(flag.Args()[1:]); err != nil {
+			logger.Fatal("Backup failed", zap.Error(err))
+		}
+		return
+	}
+	if flag.NArg() > 0 && flag.Arg(0) == "restore" {
+		if err := runRestoreCommand(flag.Args()[1:]); err != nil {
+			logger.Fatal("Restore failed", zap.Error(err))
+		}
+		return
+	}
+
+	// "bot purge" applies database.retention once
+	if flag.NArg() > 0 && flag.Arg(0) == "purge" {
+		if err := runPurgeCommand(logger); err != nil {
+			logger.Fatal("Purge failed", zap.Error(err))
+		}
+		return
+	}
+
+	// -rebuild-projections only touches the database
+	if *rebuildProjs {
+		if err := runRebuildProjections(logger); err != nil {
+			logger.Fatal("Rebuilding projections failed", zap.Error(err))
+		}
+		return
+	}
+
+	// -preview-note only renders a template
+	if *previewNote {
+		if err := runPreviewNote(); err != nil {
+			logger.Fatal("Preview failed", zap.Error(err))
+		}
+		return
+	}
+
+	// "bot serve" only serves the API, web UI and dashboard
+	if flag.NArg() > 0 && flag.Arg(0) == "serve" {
+		if err := runServeCommand(flag.Args()[1:], logger); err != nil {
+			logger.Fatal("API server failed", zap.Error(err))
+		}
+		return
+	}
+
 	// Validate required flags
-	if !*scan && !*followup && *keyword == "" {
-		logger.Fatal("Keyword is required for search mode. Use -keyword flag. Or use -scan / -followup.")
+	if !*scan && !*scanSent && !*scanReplies && !*enrich && *likePosts == 0 && *commentPost == "" && *followCompanies == 0 && *visit == 0 && !*celebrations && *exportConns == "" && !*acceptInvites && *inMail == "" && !*scanNotifs && *exportNotifs == "" && len(joinGroups) == 0 && !*groupsStatus && !*followup && *keyword == "" && len(groupURLs) == 0 && !*stdin {
+		logger.Fatal("Keyword is required for search mode. Use -keyword or -group-url flag. Or use -scan / -scan-sent / -scan-replies / -enrich / -like-posts / -comment-post / -follow-companies / -visit / -celebrations / -export-connections / -accept-invitations / -inmail / -scan-notifications / -export-notifications / -join-group / -groups-status / -followup, or pipe profile URLs into -stdin.")
 	}
 
 	// Load configuration
-	cfg, err := config.Load(*configPath)
+	cfg, err := loadConfig()
 	if err != nil {
 		logger.Fatal("Failed to load configuration", zap.Error(err))
 	}
 
+	// Stream log lines to /events from here on
+	logger, eventBroker := withLogStream(cfg, logger)
+
+	// Every line of the run carries its ID (the one 'bot runs' shows) and account
+	runUUID, err := utils.NewUUID()
+	if err != nil {
+		logger.Warn("Failed to create run ID", zap.Error(err))
+	}
+	logger = runLogger(cfg, runUUID, logger)
+
 	logger.Info("Configuration loaded", zap.String("config_path", *configPath))
 
+	// -pprof serves heap, goroutine and CPU profiles for go tool pprof
+	if *pprofServer {
+		startPProfServer(cfg, logger)
+	}
+
+	// Read piped URLs before the browser starts, so bad input fails fast
+	if *stdin {
+		stdinURLs, err = readStdinURLs(context.Background(), os.Stdin, cfg.Limits.MaxActionsPerDay, logger)
+		if err != nil {
+			logger.Fatal("Failed to read profile URLs from stdin", zap.Error(err))
+		}
+	}
+
 	// Create context with cancellation
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Pick up community selector fixes before touching LinkedIn
+	if cfg.Selectors.AutoUpdate && cfg.Selectors.RemoteURL != "" {
+		updateSelectors(ctx, cfg, logger)
+	}
+
 	// Handle graceful shutdown
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
@@ -77,7 +300,7 @@ func main() {
 
 	// Initialize stealth engine
 	stealthEngine := stealth.NewStealth(&cfg.Stealth)
-	logger.Info("Stealth engine initialized")
+	logger.Info("Stealth engine initialized", zap.String("mouse_profile", cfg.Stealth.MouseProfile))
 
 	// Initialize browser
 	browserInstance := browser.NewInstance(cfg, stealthEngine, logger)
@@ -93,32 +316,307 @@ func main() {
 	logger.Info("Browser initialized")
 
 	// Initialize repository
-	repo, err := repository.NewSQLiteRepository(cfg.Database.Path)
+	sqlRepo, err := repository.NewRepository(&cfg.Database)
 	if err != nil {
 		logger.Fatal("Failed to initialize repository", zap.Error(err))
 	}
+	var repo core.RepositoryPort = sqlRepo
+	if cfg.Database.CacheEnabled {
+		repo = repository.NewCachedRepository(sqlRepo, cfg.Database.CacheSize, cfg.Cache.ProfileTTL)
+	}
 	defer func() {
 		if err := repo.Close(); err != nil {
 			logger.Error("Failed to close repository", zap.Error(err))
 		}
 	}()
 
-	logger.Info("Repository initialized", zap.String("db_path", cfg.Database.Path))
+	logger.Info("Repository initialized", zap.String("driver", cfg.Database.Driver), zap.String("db_path", cfg.Database.Path), zap.Bool("cache", cfg.Database.CacheEnabled))
+
+	if cfg.Api.Enabled {
+		go func() {
+			server := api.NewServer(repo, cfg, logger)
+			server.SetEventBroker(eventBroker)
+			if err := server.Run(ctx); err != nil {
+				logger.Error("API server stopped", zap.Error(err))
+			}
+		}()
+	}
+
+	// Record soft throttling detected from slow page loads
+	browserInstance.SetThrottleHandler(func(ctx context.Context, recentAvg, median time.Duration) {
+		history := core.NewHistory("ThrottleDetected", core.HistoryDetails{Counts: map[string]int{
+			"recent_avg_ms": int(recentAvg.Milliseconds()),
+			"median_ms":     int(median.Milliseconds()),
+		}})
+		if err := repo.CreateHistory(ctx, history); err != nil {
+			logger.Warn("Failed to save history", zap.Error(err))
+		}
+	})
+
+	// Store page load timings to find consistently slow pages
+	browserInstance.SetPageLoadHandler(func(ctx context.Context, url string, metrics *core.PageMetrics) {
+		pageLoad := &core.PageLoad{
+			URL:                url,
+			LoadedAt:           time.Now(),
+			DOMContentLoadedMs: metrics.DOMContentLoaded.Milliseconds(),
+			LoadMs:             metrics.Load.Milliseconds(),
+		}
+		if err := repo.CreatePageLoad(ctx, pageLoad); err != nil {
+			logger.Debug("Failed to save page load", zap.Error(err))
+		}
+	})
 
 	// Initialize workflows
-	authWorkflow := workflows.NewAuthWorkflow(browserInstance, cfg, logger)
-	searchWorkflow := workflows.NewSearchWorkflow(browserInstance, repo, cfg, logger)
-	connectWorkflow := workflows.NewConnectWorkflow(browserInstance, repo, cfg, logger)
-	messagingWorkflow := workflows.NewMessagingWorkflow(browserInstance, repo, cfg, logger)
+	authWorkflow := workflows.NewAuthWorkflow(browserInstance, cfg, workflows.WorkflowLogger(logger, "auth"))
+	searchWorkflow := workflows.NewSearchWorkflow(browserInstance, repo, cfg, workflows.WorkflowLogger(logger, "search"))
+	searchWorkflow.SetMetrics(browserInstance.Metrics())
+	groupWorkflow := workflows.NewGroupSearchWorkflow(browserInstance, repo, cfg, workflows.WorkflowLogger(logger, "group_search"))
+	feedScraper := workflows.NewFeedScraperWorkflow(browserInstance, repo, cfg, workflows.WorkflowLogger(logger, "feed_search"))
+	connectWorkflow := workflows.NewConnectWorkflow(browserInstance, repo, cfg, workflows.WorkflowLogger(logger, "connect"))
+	messagingWorkflow := workflows.NewMessagingWorkflow(browserInstance, repo, cfg, workflows.WorkflowLogger(logger, "followup"))
+	enrichmentWorkflow := workflows.NewEnrichmentWorkflow(browserInstance, repo, cfg, workflows.WorkflowLogger(logger, "enrich"))
+	engagementWorkflow := workflows.NewEngagementWorkflow(browserInstance, repo, cfg, workflows.WorkflowLogger(logger, "engagement"))
+	visitWorkflow := workflows.NewVisitWorkflow(browserInstance, repo, cfg, workflows.WorkflowLogger(logger, "visit"))
+	notificationsWorkflow := workflows.NewNotificationsWorkflow(browserInstance, repo, cfg, workflows.WorkflowLogger(logger, "notifications"))
+	exportWorkflow := workflows.NewExportConnectionsWorkflow(browserInstance, repo, cfg, workflows.WorkflowLogger(logger, "export_connections"))
+	invitationsWorkflow := workflows.NewIncomingInvitationsWorkflow(browserInstance, repo, cfg, workflows.WorkflowLogger(logger, "accept_invitations"))
+	inMailWorkflow := workflows.NewInMailWorkflow(browserInstance, repo, cfg, workflows.WorkflowLogger(logger, "inmail"))
+	groupMembershipWorkflow := workflows.NewGroupMembershipWorkflow(browserInstance, repo, cfg, workflows.WorkflowLogger(logger, "join_group"))
+	warmdownWorkflow := workflows.NewWarmdownWorkflow(browserInstance, repo, cfg, workflows.WorkflowLogger(logger, "warmdown"))
+	prefetcher := workflows.NewProfilePrefetcher(newPrefetchBrowser(cfg, logger), cfg, workflows.WorkflowLogger(logger, "prefetch"))
+	if cfg.Prefetch.Enabled {
+		connectWorkflow.SetPrefetcher(prefetcher)
+	}
+	var sheetsSync *integrations.SheetsSync
+	if cfg.Integrations.GoogleSheets.Enabled {
+		sheetsSync, err = integrations.NewSheetsSync(repo, cfg, logger)
+		if err != nil {
+			logger.Fatal("Failed to set up Google Sheets sync", zap.Error(err))
+		}
+		messagingWorkflow.SetSheetsSync(sheetsSync)
+	}
+	var alerts core.NotifierPort
+	webhookNotifier := integrations.NewWebhookNotifier(cfg, logger)
+	if webhookNotifier != nil {
+		alerts = webhookNotifier
+		authWorkflow.SetNotifier(webhookNotifier)
+		searchWorkflow.SetNotifier(webhookNotifier)
+		connectWorkflow.SetNotifier(webhookNotifier)
+		messagingWorkflow.SetNotifier(webhookNotifier)
+	}
 
 	logger.Info("Workflows initialized")
 
+	// Load run state left behind by a crashed run with the same parameters
+	stateManager := state.NewStateManager(cfg.Session.StatePath)
+	runID := buildRunID()
+	appState, err := stateManager.Load()
+	if err != nil {
+		logger.Warn("Failed to load saved run state, starting fresh", zap.Error(err))
+		appState = nil
+	}
+	if appState != nil && appState.RunID == runID {
+		logger.Info("Resuming previous run",
+			zap.String("state_run_id", runID),
+			zap.Int("profiles_processed", appState.ProfilesProcessed),
+			zap.String("last_profile_url", appState.LastProfileURL),
+		)
+	} else {
+		appState = &state.AppState{
+			RunID:     runID,
+			StartedAt: time.Now(),
+		}
+	}
+
+	// Record the run ID as the first history entry of this session
+	if err := repo.CreateHistory(ctx, core.NewHistory("SessionStart", core.HistoryDetails{RunID: runID})); err != nil {
+		logger.Warn("Failed to save session history", zap.Error(err))
+	}
+
 	// Run main automation loop
-	if err := runAutomation(ctx, cfg, repo, authWorkflow, searchWorkflow, connectWorkflow, messagingWorkflow, logger); err != nil {
-		logger.Fatal("Automation failed", zap.Error(err))
+	runErr := runAutomation(ctx, cfg, repo, stateManager, appState, runUUID, browserInstance, authWorkflow, searchWorkflow, groupWorkflow, feedScraper, connectWorkflow, messagingWorkflow, enrichmentWorkflow, engagementWorkflow, visitWorkflow, notificationsWorkflow, exportWorkflow, invitationsWorkflow, inMailWorkflow, groupMembershipWorkflow, warmdownWorkflow, prefetcher, alerts, NewStepProfiler(*profileSteps), logger)
+
+	// Deliver held alert digests before the process can exit
+	if webhookNotifier != nil {
+		webhookNotifier.Flush()
+	}
+	if runErr != nil {
+		logger.Fatal("Automation failed", zap.Error(runErr))
+	}
+
+	// Let background appends finish, then update today's row of the stats sheet
+	if sheetsSync != nil {
+		sheetsSync.Wait()
+		if err := sheetsSync.SyncDailyStats(ctx, time.Now()); err != nil {
+			logger.Warn("Failed to sync daily stats to Google Sheets", zap.Error(err))
+		}
+	}
+
+	// Clean exit, nothing to resume unless the session ran out of time
+	if len(appState.RemainingProfileURLs) > 0 {
+		logger.Info("Run state kept, run the same command again to continue",
+			zap.Int("remaining_profiles", len(appState.RemainingProfileURLs)))
+	} else if err := stateManager.Clear(); err != nil {
+		logger.Warn("Failed to clear run state", zap.Error(err))
+	}
+
+	logger.Info("Automation completed successfully",
+		zap.Int64("slow_page_loads", browserInstance.Metrics().SlowPageLoads.Load()),
+		zap.Int64("profile_url_validation_errors", browserInstance.Metrics().ProfileURLValidationErrors.Load()),
+	)
+}
+
+// newPrefetchBrowser returns a factory for extra browsers that reuse the saved session cookies
+func newPrefetchBrowser(cfg *core.Config, logger *zap.Logger) workflows.BrowserFactory {
+	return func(ctx context.Context) (core.BrowserPort, error) {
+		instance := browser.NewInstance(cfg, stealth.NewStealth(&cfg.Stealth), logger.Named("prefetch"))
+		if err := instance.Initialize(ctx); err != nil {
+			return nil, fmt.Errorf("failed to initialize browser: %w", err)
+		}
+		if err := instance.LoadCookies(ctx, cfg.Session.CookiesPath); err != nil {
+			_ = instance.Close(ctx)
+			return nil, fmt.Errorf("failed to load session cookies: %w", err)
+		}
+		return instance, nil
 	}
+}
 
-	logger.Info("Automation completed successfully")
+// readURLFile reads one URL per line, skipping blank lines and # comments
+func readURLFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	urls := make([]string, 0)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		urls = append(urls, line)
+	}
+
+	return urls, nil
+}
+
+// readStdinURLs reads newline-delimited profile URLs from r for -stdin. Blank lines and
+// lines starting with # are ignored; malformed URLs and repeats are logged and skipped. At
+// most limit URLs are kept (0 = no limit). Reading stops after stdinReadTimeout, keeping
+// the URLs read so far; it fails only if none were.
+func readStdinURLs(ctx context.Context, r io.Reader, limit int, logger *zap.Logger) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, stdinReadTimeout)
+	defer cancel()
+
+	// The scanner blocks on the pipe, so it runs apart and the loop below waits on ctx too
+	lines := make(chan string)
+	scanErr := make(chan error, 1)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-ctx.Done():
+				return
+			}
+		}
+		scanErr <- scanner.Err()
+	}()
+
+	urls := make([]string, 0)
+	seen := make(map[string]bool)
+	dropped := 0
+	lineNumber := 0
+read:
+	for {
+		select {
+		case <-ctx.Done():
+			if len(urls) == 0 {
+				return nil, fmt.Errorf("no profile URLs read within %s: %w", stdinReadTimeout, ctx.Err())
+			}
+			logger.Warn("Timed out reading stdin, continuing with the URLs read so far",
+				zap.Duration("timeout", stdinReadTimeout), zap.Int("urls", len(urls)))
+			break read
+		case line, ok := <-lines:
+			if !ok {
+				break read
+			}
+			lineNumber++
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			profileURL := core.NormalizeProfileURL(line)
+			if err := linkedin.ValidateProfileURL(profileURL); err != nil {
+				logger.Warn("Skipping malformed profile URL from stdin", zap.Int("line", lineNumber), zap.Error(err))
+				continue
+			}
+			if seen[profileURL] {
+				logger.Debug("Skipping repeated profile URL from stdin", zap.String(core.LogFieldProfileURL, profileURL))
+				continue
+			}
+			seen[profileURL] = true
+
+			if limit > 0 && len(urls) >= limit {
+				dropped++
+				continue
+			}
+			urls = append(urls, profileURL)
+		}
+	}
+
+	select {
+	case err := <-scanErr:
+		if err != nil {
+			return nil, fmt.Errorf("failed to read stdin: %w", err)
+		}
+	default:
+	}
+	if dropped > 0 {
+		logger.Warn("More profile URLs piped than limits.max_actions_per_day, the rest are ignored",
+			zap.Int("limit", limit), zap.Int("ignored", dropped))
+	}
+
+	logger.Info("Profile URLs read from stdin", zap.Int("urls", len(urls)))
+	return urls, nil
+}
+
+// updateSelectors merges newer remote selectors into cfg. Failures are logged and the
+// configured selectors are kept.
+func updateSelectors(ctx context.Context, cfg *core.Config, logger *zap.Logger) {
+	defaults, err := config.DefaultSelectors()
+	if err != nil {
+		logger.Warn("Failed to load default selectors", zap.Error(err))
+		return
+	}
+
+	updater := selectors.NewRemoteUpdater(cfg.Selectors.RemoteURL, defaults, logger)
+	updated, changed, err := updater.CheckAndUpdate(ctx, &cfg.Selectors)
+	if err != nil {
+		logger.Warn("Failed to check for selector updates", zap.Error(err))
+		return
+	}
+	if changed {
+		cfg.Selectors = *updated
+	}
+}
+
+// buildRunID derives a run ID from the search parameters so that re-running the
+// same command after a crash picks up the saved state
+func buildRunID() string {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%s|%s|%d|%s|%s", *keyword, *location, *maxResults, strings.Join(groupURLs, ","), *campaign)
+	if len(skills) > 0 {
+		// Only hashed when set so run IDs of existing saved states are unchanged
+		fmt.Fprintf(h, "|%s", strings.Join(skills, ","))
+	}
+	if *stdin {
+		// A different piped list is a different run, not a resume of the saved one
+		fmt.Fprintf(h, "|stdin:%s", strings.Join(stdinURLs, ","))
+	}
+	return fmt.Sprintf("run-%08x", h.Sum32())
 }
 
 // runAutomation runs the main automation loop
@@ -126,15 +624,68 @@ func runAutomation(
 	ctx context.Context,
 	cfg *core.Config,
 	repo core.RepositoryPort,
+	stateManager *state.StateManager,
+	appState *state.AppState,
+	runUUID string,
+	browserInstance *browser.Instance,
 	authWorkflow *workflows.AuthWorkflow,
 	searchWorkflow *workflows.SearchWorkflow,
+	groupWorkflow *workflows.GroupSearchWorkflow,
+	feedScraper *workflows.FeedScraperWorkflow,
 	connectWorkflow *workflows.ConnectWorkflow,
 	messagingWorkflow *workflows.MessagingWorkflow,
+	enrichmentWorkflow *workflows.EnrichmentWorkflow,
+	engagementWorkflow *workflows.EngagementWorkflow,
+	visitWorkflow *workflows.VisitWorkflow,
+	notificationsWorkflow *workflows.NotificationsWorkflow,
+	exportWorkflow *workflows.ExportConnectionsWorkflow,
+	invitationsWorkflow *workflows.IncomingInvitationsWorkflow,
+	inMailWorkflow *workflows.InMailWorkflow,
+	groupMembershipWorkflow *workflows.GroupMembershipWorkflow,
+	warmdownWorkflow *workflows.WarmdownWorkflow,
+	prefetcher *workflows.ProfilePrefetcher,
+	alerts core.NotifierPort,
+	profiler *StepProfiler,
 	logger *zap.Logger,
-) error {
+) (err error) {
+	sessionStart := time.Now()
+	maxSessionDuration := time.Duration(cfg.Limits.MaxSessionDurationMinutes) * time.Minute
+
+	// With -profile, log where the run's time went however it ends
+	defer profiler.LogSummary(logger)
+
+	// Summarize the day's activity however this run ends
+	defer writeDailyReport(repo, logger)
+	defer refreshHTMLReport(cfg, repo, logger)
+
+	// Record this run so it can be compared with other runs
+	run := startRunMetadata(ctx, cfg, repo, runUUID, logger)
+	defer func() {
+		finishRunMetadata(ctx, repo, run, err, logger)
+		notifyRunFinished(alerts, run)
+	}()
+	if run != nil {
+		// Link every action of the run to it
+		ctx = core.WithRunID(ctx, run.ID)
+	}
+
+	// Show today's quota up front
+	if summary, err := connectWorkflow.GetDailyConnectionSummary(ctx); err != nil {
+		logger.Warn("Failed to summarize today's connections", zap.Error(err))
+	} else {
+		logger.Info("Daily connection summary",
+			zap.Int("sent_today", summary.SentToday),
+			zap.Int("accepted_today", summary.AcceptedToday),
+			zap.Int("limit", summary.Limit),
+			zap.Int("remaining", summary.Remaining),
+			zap.Float64("acceptance_rate", summary.AcceptanceRate),
+			zap.Duration("estimated_time_to_exhaust", summary.EstimatedTimeToExhaust),
+		)
+	}
+
 	// Step 1: Authenticate
 	logger.Info("Step 1: Authenticating...")
-	if err := authWorkflow.Authenticate(ctx); err != nil {
+	if err := profiler.Time("auth", func() error { return authWorkflow.Authenticate(ctx) }); err != nil {
 		return fmt.Errorf("authentication failed: %w", err)
 	}
 	logger.Info("Authentication successful")
@@ -160,29 +711,220 @@ func runAutomation(
 	// Handle Scan Mode
 	if *scan {
 		logger.Info("Running in Scan Mode")
-		if err := messagingWorkflow.ScanNewConnections(ctx); err != nil {
+		if err := profiler.Time("scan", func() error { return messagingWorkflow.ScanNewConnections(ctx) }); err != nil {
 			return fmt.Errorf("scan failed: %w", err)
 		}
-		// If only scanning, we can return here unless followup is also requested
-		if !*followup && *keyword == "" {
-			return nil
+	}
+
+	// Handle Sent Invitations Scan Mode
+	if *scanSent {
+		logger.Info("Running in Sent Invitations Scan Mode")
+		if err := profiler.Time("scan_sent", func() error { return messagingWorkflow.ScanSentInvitations(ctx) }); err != nil {
+			return fmt.Errorf("sent invitations scan failed: %w", err)
+		}
+	}
+
+	// Handle Reply Scan Mode
+	if *scanReplies {
+		logger.Info("Running in Reply Scan Mode")
+		if err := profiler.Time("scan_replies", func() error { return messagingWorkflow.ScanReplies(ctx) }); err != nil {
+			return fmt.Errorf("reply scan failed: %w", err)
+		}
+	}
+
+	// Handle Enrichment Mode
+	if *enrich {
+		logger.Info("Running in Enrichment Mode")
+		if err := profiler.Time("enrich", func() error { return enrichmentWorkflow.EnrichPending(ctx) }); err != nil {
+			return fmt.Errorf("enrichment failed: %w", err)
+		}
+	}
+
+	// Handle Engagement Mode
+	if *likePosts > 0 {
+		logger.Info("Running in Engagement Mode")
+		if err := profiler.Time("like_posts", func() error {
+			_, err := engagementWorkflow.LikePosts(ctx, *likePosts, *likeSource)
+			return err
+		}); err != nil {
+			return fmt.Errorf("liking posts failed: %w", err)
+		}
+	}
+
+	if *commentPost != "" {
+		logger.Info("Running in Comment Mode")
+		if err := profiler.Time("comment_post", func() error {
+			_, err := engagementWorkflow.CommentOnPost(ctx, *commentPost, "")
+			return err
+		}); err != nil {
+			return fmt.Errorf("commenting on post failed: %w", err)
+		}
+	}
+
+	if *followCompanies > 0 {
+		logger.Info("Running in Company Follow Mode")
+		if err := profiler.Time("follow_companies", func() error {
+			_, err := engagementWorkflow.FollowCompanies(ctx, *followCompanies)
+			return err
+		}); err != nil {
+			return fmt.Errorf("following companies failed: %w", err)
+		}
+	}
+
+	if *visit > 0 {
+		logger.Info("Running in Visit Mode")
+		if *visitFile != "" {
+			urls, err := readURLFile(*visitFile)
+			if err != nil {
+				return err
+			}
+			if len(urls) > *visit {
+				urls = urls[:*visit]
+			}
+			err = profiler.Time("visit", func() error {
+				_, err := visitWorkflow.VisitProfiles(ctx, urls)
+				return err
+			})
+		} else {
+			err = profiler.Time("visit", func() error {
+				_, err := visitWorkflow.VisitPending(ctx, *visit)
+				return err
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("visiting profiles failed: %w", err)
 		}
 	}
 
+	if *celebrations {
+		logger.Info("Running in Celebrations Mode")
+		if err := profiler.Time("celebrations", func() error { return notificationsWorkflow.SendCelebrationMessages(ctx) }); err != nil {
+			return fmt.Errorf("celebration messages failed: %w", err)
+		}
+	}
+
+	if *exportConns != "" {
+		logger.Info("Running in Connection Export Mode")
+		var export *workflows.ConnectionExport
+		err := profiler.Time("export_connections", func() (err error) {
+			export, err = exportWorkflow.Export(ctx, *exportConns)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("exporting connections failed: %w", err)
+		}
+		logger.Info("Connections exported",
+			zap.String("out", *exportConns),
+			zap.Int("exported", export.Exported),
+			zap.Int("linkedin_total", export.LinkedInTotal),
+			zap.Int64("db_before", export.DBBefore),
+			zap.Int64("db_after", export.DBAfter),
+			zap.Int("added", export.Added),
+			zap.Int("marked_connected", export.Updated),
+		)
+		if export.LinkedInTotal > 0 && export.Exported != export.LinkedInTotal {
+			logger.Warn("Exported count differs from LinkedIn's total, the list may not have fully loaded",
+				zap.Int("missing", export.LinkedInTotal-export.Exported))
+		}
+		if export.DBAfter > int64(export.Exported) {
+			logger.Warn("Database has connections no longer in the list (removed or restricted)",
+				zap.Int64("extra", export.DBAfter-int64(export.Exported)))
+		}
+	}
+
+	if *acceptInvites {
+		logger.Info("Running in Invitation Acceptance Mode")
+		if err := profiler.Time("accept_invitations", func() error { return invitationsWorkflow.ProcessInvitations(ctx, *reportOnly) }); err != nil {
+			return fmt.Errorf("processing invitations failed: %w", err)
+		}
+	}
+
+	if *inMail != "" {
+		logger.Info("Running in InMail Mode")
+		if err := profiler.Time("inmail", func() error { return inMailWorkflow.SendInMail(ctx, *inMail, "", "") }); err != nil {
+			return fmt.Errorf("sending InMail failed: %w", err)
+		}
+	}
+
+	if *scanNotifs {
+		logger.Info("Running in Notifications Scan Mode")
+		var scan *workflows.NotificationScan
+		err := profiler.Time("scan_notifications", func() (err error) {
+			scan, err = notificationsWorkflow.ScanNotifications(ctx)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("scanning notifications failed: %w", err)
+		}
+		for eventType, count := range scan.ByType {
+			logger.Info("Notifications stored", zap.String("type", eventType), zap.Int("count", count))
+		}
+	}
+
+	if *exportNotifs != "" {
+		logger.Info("Running in Notification Export Mode")
+		var exported int
+		err := profiler.Time("export_notifications", func() (err error) {
+			exported, err = notificationsWorkflow.ExportNotifications(ctx, *exportNotifs)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("exporting notifications failed: %w", err)
+		}
+		logger.Info("Notifications exported", zap.String("out", *exportNotifs), zap.Int("exported", exported))
+	}
+
+	if len(joinGroups) > 0 {
+		logger.Info("Running in Group Join Mode")
+		for i, groupURL := range joinGroups {
+			var state string
+			err := profiler.Time("join_group", func() (err error) {
+				state, err = groupMembershipWorkflow.JoinGroup(ctx, groupURL)
+				return err
+			})
+			if errors.Is(err, workflows.ErrGroupJoinLimitReached) {
+				logger.Warn("Weekly group join limit reached", zap.Int("remaining_groups", len(joinGroups)-i))
+				break
+			}
+			if err != nil {
+				logger.Error("Failed to join group", zap.String("group_url", groupURL), zap.Error(err))
+				continue
+			}
+			logger.Info("Group membership", zap.String("group_url", groupURL), zap.String("state", state))
+			if i < len(joinGroups)-1 {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(utils.RandomCooldown(cfg.Limits.ConnectCooldownMin, cfg.Limits.ConnectCooldownMax)):
+				}
+			}
+		}
+	}
+
+	if *groupsStatus {
+		logger.Info("Running in Group Status Mode")
+		var approved int
+		err := profiler.Time("groups_status", func() (err error) {
+			approved, err = groupMembershipWorkflow.CheckPendingGroups(ctx)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("checking pending groups failed: %w", err)
+		}
+		logger.Info("Group approvals checked", zap.Int("approved", approved))
+	}
+
 	// Handle Follow-up Mode
 	if *followup {
 		logger.Info("Running in Follow-up Mode")
-		if err := messagingWorkflow.SendFollowUpMessages(ctx); err != nil {
+		if err := profiler.Time("followup", func() error { return messagingWorkflow.SendFollowUpMessages(ctx) }); err != nil {
 			return fmt.Errorf("follow-up failed: %w", err)
 		}
-		// If only followup, return here
-		if *keyword == "" {
-			return nil
-		}
 	}
 
-	// If no keyword provided (and we handled scan/followup), we are done
-	if *keyword == "" {
+	// Every other requested mode has run; connecting needs a keyword, group or piped URLs
+	connectRequested := *keyword != "" || len(groupURLs) > 0 || *stdin
+	if !connectRequested {
 		return nil
 	}
 
@@ -203,9 +945,45 @@ func runAutomation(
 		return fmt.Errorf("daily connection limit reached")
 	}
 
+	// Paused and archived campaigns are not run; unknown names are plain tags
+	activeCampaign, err := workflows.ResolveCampaign(ctx, repo, *campaign)
+	if err != nil {
+		return err
+	}
+
+	// A campaign searches and connects in a tab of its own, so the scroll position and
+	// page state of the steps before it (or of an earlier campaign) don't carry over
+	if *campaign != "" {
+		pageName := "campaign:" + *campaign
+		if _, err := browserInstance.NewNamedPage(ctx, pageName); err != nil {
+			return fmt.Errorf("failed to open campaign page: %w", err)
+		}
+		defer func() {
+			if err := browserInstance.ClosePage(pageName); err != nil {
+				logger.Warn("Failed to close campaign page", zap.Error(err))
+			}
+		}()
+		if err := browserInstance.SwitchPage(pageName); err != nil {
+			return fmt.Errorf("failed to switch to campaign page: %w", err)
+		}
+	}
+
+	// Determine note to use: flag overrides the campaign's note, which overrides config
+	noteToUse := *note
+	if noteToUse == "" && activeCampaign != nil {
+		noteToUse = activeCampaign.NoteTemplate
+	}
+	if noteToUse == "" {
+		noteToUse = cfg.Connection.NoteTemplate
+	}
+	if run != nil && noteToUse != "" {
+		run.NoteTemplateHash = md5Hex(noteToUse)
+	}
+
 	// Step 4: Perform search
 	logger.Info("Step 4: Performing search...",
 		zap.String("keyword", *keyword),
+		zap.Strings("group_urls", groupURLs),
 		zap.Int("max_results", *maxResults),
 	)
 
@@ -213,11 +991,58 @@ func runAutomation(
 		Keyword:    *keyword,
 		MaxResults: *maxResults,
 		Location:   *location,
+		GroupURLs:  groupURLs,
+		Campaign:   *campaign,
+		Skills:     skills,
 	}
 
-	profileURLs, err := searchWorkflow.Search(ctx, searchParams)
-	if err != nil {
-		return fmt.Errorf("search failed: %w", err)
+	var profileURLs []string
+	startIndex := 0
+	if len(appState.ProfileURLs) > 0 {
+		// Resuming after a crash: reuse the saved results and continue after the last processed profile
+		profileURLs = appState.ProfileURLs
+		for i, u := range profileURLs {
+			if u == appState.LastProfileURL {
+				startIndex = i + 1
+				break
+			}
+		}
+		logger.Info("Resuming from saved search results",
+			zap.Int("profiles", len(profileURLs)),
+			zap.Int("start_index", startIndex),
+		)
+	} else {
+		if *stdin {
+			profileURLs = stdinURLs
+		} else if len(searchParams.GroupURLs) > 0 {
+			err = profiler.Time("group_search", func() (err error) {
+				profileURLs, err = groupWorkflow.Search(ctx, searchParams)
+				return err
+			})
+		} else if cfg.Search.FeedScraping.Enabled {
+			// The keyword is the feed topic unless topics are configured
+			feedKeywords := cfg.Search.FeedScraping.Keywords
+			if len(feedKeywords) == 0 {
+				feedKeywords = []string{searchParams.Keyword}
+			}
+			err = profiler.Time("feed_search", func() (err error) {
+				profileURLs, err = feedScraper.DiscoverFromFeed(ctx, feedKeywords, searchParams.MaxResults)
+				return err
+			})
+		} else {
+			err = profiler.Time("search", func() (err error) {
+				profileURLs, err = searchWorkflow.Search(ctx, searchParams)
+				return err
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("search failed: %w", err)
+		}
+
+		appState.ProfileURLs = profileURLs
+		if err := stateManager.Save(ctx, appState); err != nil {
+			logger.Warn("Failed to save run state", zap.Error(err))
+		}
 	}
 
 	if len(profileURLs) == 0 {
@@ -229,14 +1054,54 @@ func runAutomation(
 		zap.Int("profiles_found", len(profileURLs)),
 	)
 
+	// Load the remaining profiles in parallel so connected ones are skipped without a visit
+	if cfg.Prefetch.Enabled {
+		if err := profiler.Time("prefetch", func() error { return prefetcher.Prefetch(ctx, profileURLs[startIndex:]) }); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			logger.Warn("Prefetch failed, profiles will be loaded one by one", zap.Error(err))
+		}
+	}
+
 	// Step 5: Send connection requests
 	logger.Info("Step 5: Sending connection requests...")
 
 	connectedCount := 0
 	skippedCount := 0
+	introductionCount := 0
 	errorCount := 0
+	appState.RemainingProfileURLs = nil
+
+	// Cooldowns are paced toward limits.target_connections_per_hour within the cooldown bounds
+	windowEnd, err := utils.WorkingHoursEnd(cfg.Limits.WorkingHoursStart, cfg.Limits.WorkingHoursEnd)
+	if err != nil {
+		logger.Warn("Failed to compute working hours end, pacing ignores it", zap.Error(err))
+	}
+	pacer := pacing.NewPacer(
+		cfg.Limits.TargetConnectionsPerHour,
+		time.Duration(cfg.Limits.ConnectCooldownMin)*time.Minute,
+		time.Duration(cfg.Limits.ConnectCooldownMax)*time.Minute,
+		windowEnd,
+	)
+	hourStart := time.Now()
+	sentThisHour := 0
 
 	for i, profileURL := range profileURLs {
+		if i < startIndex {
+			continue
+		}
+
+		if maxSessionDuration > 0 && time.Since(sessionStart) > maxSessionDuration {
+			logger.Info("Maximum session duration reached, stopping gracefully",
+				zap.Int("max_minutes", cfg.Limits.MaxSessionDurationMinutes),
+				zap.Int("connected", connectedCount),
+				zap.Int("remaining_profiles", len(profileURLs)-i),
+			)
+			endSession(ctx, cfg, repo, stateManager, appState, profileURLs[i:], warmdownWorkflow, logger)
+			return nil
+		}
+
 		// Check context cancellation
 		select {
 		case <-ctx.Done():
@@ -258,61 +1123,109 @@ func runAutomation(
 			break
 		}
 
-		logger.Info("Processing profile",
+		profileLogger := workflows.ProfileLogger(logger, profileURL)
+		profileLogger.Info("Processing profile",
 			zap.Int("index", i+1),
 			zap.Int("total", len(profileURLs)),
-			zap.String("url", profileURL),
 		)
 
-		// Determine note to use: flag overrides config
-		noteToUse := *note
-		if noteToUse == "" {
-			noteToUse = cfg.Connection.NoteTemplate
-		}
-
 		// Send connection request
 		connectParams := &core.ConnectParams{
 			ProfileURL: profileURL,
 			Note:       noteToUse,
+			Campaign:   *campaign,
+			Keyword:    *keyword,
+		}
+
+		err = profiler.Time("connect", func() error { return connectWorkflow.SendConnectionRequest(ctx, connectParams) })
+
+		// Not processed: the profile is retried next run, once LinkedIn's weekly limit resets
+		if errors.Is(err, workflows.ErrInviteLimitReached) {
+			logger.Warn("Weekly invitation limit reached, stopping connections",
+				zap.Int("connected_so_far", connectedCount),
+			)
+			// Shown as a flag on the web UI's status page
+			if err := repo.CreateHistory(ctx, core.NewHistory("InviteLimitReached", core.HistoryDetails{ProfileURL: profileURL})); err != nil {
+				logger.Warn("Failed to save history", zap.Error(err))
+			}
+			break
 		}
 
-		if err := connectWorkflow.SendConnectionRequest(ctx, connectParams); err != nil {
-			logger.Error("Failed to send connection request",
-				zap.String("url", profileURL),
+		// Not processed: the profile is retried when the campaign has budget again
+		if errors.Is(err, workflows.ErrCampaignBudgetReached) {
+			logger.Warn("Campaign budget reached, stopping connections",
+				zap.Int("connected_so_far", connectedCount),
 				zap.Error(err),
 			)
-			errorCount++
-			continue
+			break
+		}
+
+		// Persist progress so a crash resumes after this profile
+		appState.LastProfileURL = profileURL
+		appState.ProfilesProcessed++
+		if errSave := stateManager.Save(ctx, appState); errSave != nil {
+			logger.Warn("Failed to save run state", zap.Error(errSave))
 		}
+		updateRunMetadata(ctx, repo, run, logger)
 
-		// Check if it was skipped (already connected, etc.)
-		shouldSkip, _ := connectWorkflow.ShouldSkipProfile(ctx, profileURL)
-		if shouldSkip {
+		switch {
+		case errors.Is(err, core.ErrProfileInActiveCampaign):
+			// Skipped before the profile was opened, so no cooldown is needed
 			skippedCount++
-			logger.Info("Profile skipped", zap.String("url", profileURL))
-		} else {
-			connectedCount++
-			logger.Info("Connection request sent successfully",
-				zap.String("url", profileURL),
-				zap.Int("total_connected", connectedCount),
-			)
+			profileLogger.Info("Profile skipped", zap.String("reason", "belongs to another active campaign"))
+			continue
+		case errors.Is(err, workflows.ErrIntroductionRequested):
+			// Not a connection request, so it doesn't count toward the hourly pacing
+			introductionCount++
+		case err != nil:
+			profileLogger.Error("Failed to send connection request", zap.Error(err))
+			errorCount++
+			errHistory := core.NewErrorHistory("connect", profileURL, err)
+			errHistory.Keyword = *keyword
+			if errHist := repo.CreateHistory(ctx, errHistory); errHist != nil {
+				logger.Warn("Failed to save error history", zap.Error(errHist))
+			}
+			continue
+		default:
+			// Check if it was skipped (already connected, etc.)
+			var shouldSkip bool
+			_ = profiler.Time("should_skip", func() (err error) {
+				shouldSkip, err = connectWorkflow.ShouldSkipProfile(ctx, profileURL)
+				return err
+			})
+			if shouldSkip {
+				skippedCount++
+				profileLogger.Info("Profile skipped")
+			} else {
+				connectedCount++
+				sentThisHour++
+				profileLogger.Info("Connection request sent successfully",
+					zap.Int("total_connected", connectedCount),
+				)
+			}
 		}
 
 		// Cooldown between connections (except for the last one)
 		if i < len(profileURLs)-1 {
-			cooldown := utils.RandomCooldown(
-				cfg.Limits.ConnectCooldownMin,
-				cfg.Limits.ConnectCooldownMax,
-			)
+			if time.Since(hourStart) >= time.Hour {
+				hourStart = time.Now()
+				sentThisHour = 0
+			}
+			cooldown := pacer.NextDelay(sentThisHour, time.Since(hourStart).Minutes())
 			logger.Info("Cooldown before next connection",
 				zap.String("duration", utils.FormatDuration(cooldown)),
+				zap.Int("sent_this_hour", sentThisHour),
 			)
 
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case <-time.After(cooldown):
-				// Continue
+			if err := profiler.Time("cooldown", func() error {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(cooldown):
+					return nil
+				}
+			}); err != nil {
+				return err
 			}
 		}
 	}
@@ -322,9 +1235,102 @@ func runAutomation(
 		zap.Int("total_profiles", len(profileURLs)),
 		zap.Int("connected", connectedCount),
 		zap.Int("skipped", skippedCount),
+		zap.Int("introductions", introductionCount),
 		zap.Int("errors", errorCount),
 	)
 
 	return nil
 }
 
+// endSession saves the profiles a session had no time for in the run state, so the next
+// run with the same parameters continues with them, then ends the session as configured
+// by limits.session_end_behavior
+func endSession(
+	ctx context.Context,
+	cfg *core.Config,
+	repo core.RepositoryPort,
+	stateManager *state.StateManager,
+	appState *state.AppState,
+	remaining []string,
+	warmdownWorkflow *workflows.WarmdownWorkflow,
+	logger *zap.Logger,
+) {
+	appState.RemainingProfileURLs = remaining
+	if err := stateManager.Save(ctx, appState); err != nil {
+		logger.Warn("Failed to save run state", zap.Error(err))
+	}
+
+	switch cfg.Limits.SessionEndBehavior {
+	case "warmdown":
+		if err := warmdownWorkflow.Run(ctx); err != nil {
+			logger.Warn("Warmdown failed", zap.Error(err))
+		}
+	case "schedule_continuation":
+		scheduleContinuation(ctx, repo, appState, remaining, logger)
+	}
+}
+
+// scheduleContinuation queues a connect job with this run's parameters for 'bot serve'.
+// The job's run gets the same run ID, so it resumes the saved remaining profiles. Runs a
+// job can't repeat (groups, piped URLs, skills) continue when the command is run again.
+func scheduleContinuation(
+	ctx context.Context,
+	repo core.RepositoryPort,
+	appState *state.AppState,
+	remaining []string,
+	logger *zap.Logger,
+) {
+	if *keyword == "" || len(groupURLs) > 0 || *stdin || len(skills) > 0 {
+		logger.Warn("Cannot queue a continuation job for this run, run the same command again to continue",
+			zap.Int("remaining", len(remaining)),
+		)
+		return
+	}
+
+	job := &core.Job{
+		Mode:     core.JobModeConnect,
+		Keyword:  *keyword,
+		Location: *location,
+		Campaign: *campaign,
+		Budget:   *maxResults,
+	}
+	if err := repo.CreateJob(ctx, job); err != nil {
+		logger.Warn("Failed to queue continuation job", zap.Error(err))
+		return
+	}
+	logger.Info("Queued continuation job",
+		zap.Uint("job_id", job.ID),
+		zap.Int("remaining", len(remaining)),
+	)
+
+	if err := repo.CreateHistory(ctx, core.NewHistory("ContinuationScheduled", core.HistoryDetails{
+		RunID:  appState.RunID,
+		Counts: map[string]int{"remaining": len(remaining)},
+	})); err != nil {
+		logger.Warn("Failed to save history", zap.Error(err))
+	}
+}
+
+// writeDailyReport logs today's activity report and saves it as JSON under data/reports
+func writeDailyReport(repo core.RepositoryPort, logger *zap.Logger) {
+	// The run context may already be cancelled on shutdown
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	report, err := reports.NewDailyReportGenerator(repo, logger).Generate(ctx, time.Now())
+	if err != nil {
+		logger.Warn("Failed to generate daily report", zap.Error(err))
+		return
+	}
+
+	if text, err := report.Format("text"); err == nil {
+		logger.Info("Daily report\n" + text)
+	}
+
+	path, err := report.Save("data/reports")
+	if err != nil {
+		logger.Warn("Failed to save daily report", zap.Error(err))
+		return
+	}
+	logger.Info("Daily report saved", zap.String("path", path))
+}
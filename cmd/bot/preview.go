@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"linkedin-automation/internal/workflows"
+)
+
+// previewVars fill the template placeholders in -preview-note output
+var previewVars = map[string]string{
+	"FirstName": "Alex",
+	"Name":      "Alex",
+}
+
+// runPreviewNote prints -note, or messaging.follow_up_template, as it would be sent.
+// Formatting markup is shown converted when messaging.allow_html_formatting is set.
+func runPreviewNote() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	template := *note
+	if template == "" {
+		template = cfg.Messaging.FollowUpTemplate
+	}
+	if template == "" {
+		return fmt.Errorf("no template to preview: pass -note or set messaging.follow_up_template")
+	}
+
+	// Without formatting the markup is typed as-is
+	if !cfg.Messaging.AllowHTMLFormatting {
+		for key, value := range previewVars {
+			template = strings.ReplaceAll(template, "{{"+key+"}}", value)
+		}
+		fmt.Println(template)
+		return nil
+	}
+
+	fmt.Println(workflows.PreviewFormattedMessage(template, previewVars))
+	return nil
+}
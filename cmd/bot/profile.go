@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"linkedin-automation/internal/core"
+	"linkedin-automation/internal/repository"
+)
+
+// profileUsage describes the profile subcommands
+const profileUsage = `usage:
+  bot profile set-status -url PROFILE_URL -status STATUS [-force]
+  bot profile archive -url PROFILE_URL
+  bot profile unarchive -url PROFILE_URL`
+
+// runProfileCommand repairs profile records by hand. "set-status" follows the status
+// state machine unless -force is given; "archive" and "unarchive" take a profile out of
+// and back into every queue.
+func runProfileCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("unknown profile command\n%s", profileUsage)
+	}
+	switch args[0] {
+	case "set-status":
+		return runProfileSetStatus(args[1:])
+	case "archive", "unarchive":
+		return runProfileArchive(args[0], args[1:])
+	default:
+		return fmt.Errorf("unknown profile command\n%s", profileUsage)
+	}
+}
+
+func runProfileSetStatus(args []string) error {
+	fs := flag.NewFlagSet("profile set-status", flag.ContinueOnError)
+	profileURL := fs.String("url", "", "Profile URL")
+	status := fs.String("status", "", "New status, e.g. Connected or Ignored")
+	force := fs.Bool("force", false, "Skip the status transition check")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *profileURL == "" || *status == "" {
+		return fmt.Errorf("-url and -status are required\n%s", profileUsage)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	repo, err := repository.NewRepository(&cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to initialize repository: %w", err)
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+
+	profile, err := repo.GetProfileByURL(ctx, *profileURL)
+	if err != nil {
+		return fmt.Errorf("failed to load profile: %w", err)
+	}
+	if profile == nil {
+		return fmt.Errorf("profile not found: %s", *profileURL)
+	}
+
+	newStatus := core.ProfileStatus(*status)
+	if *force {
+		err = repo.ForceProfileStatus(ctx, *profileURL, newStatus)
+	} else {
+		err = repo.UpdateProfileStatus(ctx, *profileURL, newStatus)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	fmt.Printf("%s: %s -> %s\n", *profileURL, profile.Status, newStatus)
+	return nil
+}
+
+func runProfileArchive(command string, args []string) error {
+	fs := flag.NewFlagSet("profile "+command, flag.ContinueOnError)
+	profileURL := fs.String("url", "", "Profile URL")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *profileURL == "" {
+		return fmt.Errorf("-url is required\n%s", profileUsage)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	repo, err := repository.NewRepository(&cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to initialize repository: %w", err)
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+
+	var changed bool
+	if command == "archive" {
+		changed, err = repo.ArchiveProfile(ctx, *profileURL)
+	} else {
+		changed, err = repo.UnarchiveProfile(ctx, *profileURL)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to %s profile: %w", command, err)
+	}
+	if !changed {
+		if command == "archive" {
+			return fmt.Errorf("no active profile found: %s", *profileURL)
+		}
+		return fmt.Errorf("no archived profile found: %s", *profileURL)
+	}
+
+	fmt.Printf("%s: %sd\n", *profileURL, command)
+	return nil
+}
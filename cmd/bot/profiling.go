@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// StepProfiler adds up the wall-clock time runAutomation spends in each workflow step,
+// with -profile. A disabled profiler only runs the steps.
+type StepProfiler struct {
+	enabled bool
+	mu      sync.Mutex
+	totals  map[string]time.Duration
+	calls   map[string]int
+}
+
+// NewStepProfiler creates a profiler that records steps when enabled
+func NewStepProfiler(enabled bool) *StepProfiler {
+	return &StepProfiler{
+		enabled: enabled,
+		totals:  make(map[string]time.Duration),
+		calls:   make(map[string]int),
+	}
+}
+
+// Time runs fn and adds its duration to step name, whether or not it fails
+func (p *StepProfiler) Time(name string, fn func() error) error {
+	if !p.enabled {
+		return fn()
+	}
+
+	start := time.Now()
+	err := fn()
+	elapsed := time.Since(start)
+
+	p.mu.Lock()
+	p.totals[name] += elapsed
+	p.calls[name]++
+	p.mu.Unlock()
+	return err
+}
+
+// Summary returns the total time spent in each step
+func (p *StepProfiler) Summary() map[string]time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	summary := make(map[string]time.Duration, len(p.totals))
+	for name, total := range p.totals {
+		summary[name] = total
+	}
+	return summary
+}
+
+// LogSummary logs a table of the steps, longest total first, with their average time per
+// call and call count
+func (p *StepProfiler) LogSummary(logger *zap.Logger) {
+	if !p.enabled {
+		return
+	}
+
+	p.mu.Lock()
+	names := make([]string, 0, len(p.totals))
+	for name := range p.totals {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if p.totals[names[i]] != p.totals[names[j]] {
+			return p.totals[names[i]] > p.totals[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STEP\tTOTAL\tAVG\tCALLS")
+	for _, name := range names {
+		total, calls := p.totals[name], p.calls[name]
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\n",
+			name,
+			total.Round(time.Millisecond),
+			(total / time.Duration(calls)).Round(time.Millisecond),
+			calls,
+		)
+	}
+	p.mu.Unlock()
+	w.Flush()
+
+	if len(names) == 0 {
+		logger.Info("Step profile: no steps ran")
+		return
+	}
+	logger.Info("Step profile\n" + buf.String())
+}
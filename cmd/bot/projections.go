@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"linkedin-automation/internal/repository"
+
+	"go.uber.org/zap"
+)
+
+// runRebuildProjections recomputes the analytics_daily table from the full history,
+// e.g. after history rows were edited or deleted by hand
+func runRebuildProjections(logger *zap.Logger) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	repo, err := repository.NewRepository(&cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to initialize repository: %w", err)
+	}
+	defer repo.Close()
+
+	rows, err := repo.RebuildProjections(context.Background())
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Analytics projections rebuilt", zap.Int("rows", rows))
+	return nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"linkedin-automation/internal/core"
+	"linkedin-automation/internal/repository"
+
+	"go.uber.org/zap"
+)
+
+// debugArtifactPattern matches the page dumps workflows write when a page looks wrong
+const debugArtifactPattern = "data/debug_*.html"
+
+// runPurgeCommand applies database.retention once and reports what was removed
+func runPurgeCommand(logger *zap.Logger) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	repo, err := repository.NewRepository(&cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to initialize repository: %w", err)
+	}
+	defer repo.Close()
+
+	_, err = runPurge(context.Background(), repo, &cfg.Database.Retention, logger)
+	return err
+}
+
+// runPurge soft-deletes expired rows, hard-deletes rows past their grace period and
+// removes expired debug artifacts, logging the counts per category
+func runPurge(ctx context.Context, repo core.RepositoryPort, retention *core.RetentionConfig, logger *zap.Logger) (*core.PurgeResult, error) {
+	now := time.Now()
+
+	result, err := repo.PurgeExpired(ctx, retention, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if retention.DebugArtifactDays > 0 {
+		removed, err := purgeDebugArtifacts(debugArtifactPattern, now.AddDate(0, 0, -retention.DebugArtifactDays))
+		if err != nil {
+			return nil, fmt.Errorf("failed to purge debug artifacts: %w", err)
+		}
+		result.DebugArtifacts.Deleted = removed
+	}
+
+	logger.Info("Retention purge complete",
+		zap.Int64("profiles_soft_deleted", result.Profiles.SoftDeleted),
+		zap.Int64("profiles_deleted", result.Profiles.Deleted),
+		zap.Int64("profile_rows_deleted", result.Messages.Deleted),
+		zap.Int64("history_soft_deleted", result.History.SoftDeleted),
+		zap.Int64("history_deleted", result.History.Deleted),
+		zap.Int64("debug_artifacts_deleted", result.DebugArtifacts.Deleted),
+	)
+	return result, nil
+}
+
+// purgeDebugArtifacts removes the files matching pattern last modified before cutoff.
+// Files are not tracked in the database, so they are removed without a grace period.
+func purgeDebugArtifacts(pattern string, cutoff time.Time) (int64, error) {
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return 0, err
+	}
+
+	var removed int64
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() || !info.ModTime().Before(cutoff) {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+
+	return removed, nil
+}
+
+// runPeriodicPurge runs the purge every retention.PurgeIntervalHours until ctx is done,
+// starting immediately. Failures are logged and retried at the next interval.
+func runPeriodicPurge(ctx context.Context, repo core.RepositoryPort, retention *core.RetentionConfig, logger *zap.Logger) {
+	if retention.PurgeIntervalHours <= 0 {
+		return
+	}
+	if retention.ProfileDays == 0 && retention.HistoryDays == 0 && retention.DebugArtifactDays == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(retention.PurgeIntervalHours) * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		if _, err := runPurge(ctx, repo, retention, logger); err != nil {
+			logger.Error("Retention purge failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"linkedin-automation/internal/core"
+	"linkedin-automation/internal/integrations"
+	"linkedin-automation/internal/reports"
+	"linkedin-automation/internal/repository"
+
+	"go.uber.org/zap"
+)
+
+// dailyReportEmailAction is the history action recording a sent daily report email;
+// its target is the report's date, so each day is emailed once
+const dailyReportEmailAction = "DailyReportEmail"
+
+// dailyEmailCheckInterval is how often 'bot serve' checks whether the day's email is due
+const dailyEmailCheckInterval = 10 * time.Minute
+
+// runReportCommand prints the daily report of a day, or with -email sends it through
+// the smtp config block. A day already emailed is skipped unless -force is given. With
+// -html it writes the HTML report of -from to -to (or -campaign) to a file instead.
+func runReportCommand(args []string, logger *zap.Logger) error {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	dateFlag := fs.String("date", "", "Day to report, YYYY-MM-DD (default: today)")
+	format := fs.String("format", "text", "Output format: text, markdown or json")
+	email := fs.Bool("email", false, "Email the report instead of printing it")
+	force := fs.Bool("force", false, "With -email, send even if the day was already emailed")
+	htmlPath := fs.String("html", "", "Write the HTML report to this file")
+	fromFlag := fs.String("from", "", "With -html, first day, YYYY-MM-DD (default: reports.html_days before -to)")
+	toFlag := fs.String("to", "", "With -html, last day, YYYY-MM-DD (default: today)")
+	campaign := fs.String("campaign", "", "With -html, only this campaign's profiles and actions")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	loc, err := cfg.Database.Location()
+	if err != nil {
+		return fmt.Errorf("invalid database.timezone: %w", err)
+	}
+	date := time.Now().In(loc)
+	if *dateFlag != "" {
+		date, err = time.ParseInLocation("2006-01-02", *dateFlag, loc)
+		if err != nil {
+			return fmt.Errorf("invalid -date %q, expected YYYY-MM-DD: %w", *dateFlag, err)
+		}
+	}
+
+	repo, err := repository.NewRepository(&cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to initialize repository: %w", err)
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+	if *htmlPath != "" {
+		opts, err := htmlReportRange(*fromFlag, *toFlag, cfg.Reports.HTMLDays, loc)
+		if err != nil {
+			return err
+		}
+		opts.Campaign = *campaign
+		if err := writeHTMLReport(ctx, cfg, repo, opts, *htmlPath, logger); err != nil {
+			return err
+		}
+		fmt.Printf("HTML report written to %s\n", *htmlPath)
+		return nil
+	}
+
+	report, err := reports.NewDailyReportGenerator(repo, logger).Generate(ctx, date)
+	if err != nil {
+		return fmt.Errorf("failed to generate report: %w", err)
+	}
+
+	if !*email {
+		text, err := report.Format(*format)
+		if err != nil {
+			return err
+		}
+		fmt.Println(text)
+		return nil
+	}
+
+	sent, err := emailDailyReport(ctx, cfg, repo, report, *force, logger)
+	if err != nil {
+		return err
+	}
+	if !sent {
+		fmt.Printf("The report for %s was already emailed; use -force to send it again\n", report.Date.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// htmlReportRange returns the days from and to cover, both YYYY-MM-DD and inclusive.
+// to defaults to today and from to days days before to.
+func htmlReportRange(from, to string, days int, loc *time.Location) (reports.HTMLReportOptions, error) {
+	if days <= 0 {
+		days = 30 // Default fallback
+	}
+
+	now := time.Now().In(loc)
+	last := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if to != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", to, loc)
+		if err != nil {
+			return reports.HTMLReportOptions{}, fmt.Errorf("invalid -to %q, expected YYYY-MM-DD: %w", to, err)
+		}
+		last = parsed
+	}
+	first := last.AddDate(0, 0, -(days - 1))
+	if from != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", from, loc)
+		if err != nil {
+			return reports.HTMLReportOptions{}, fmt.Errorf("invalid -from %q, expected YYYY-MM-DD: %w", from, err)
+		}
+		first = parsed
+	}
+	if last.Before(first) {
+		return reports.HTMLReportOptions{}, fmt.Errorf("-from %s is after -to %s", first.Format("2006-01-02"), last.Format("2006-01-02"))
+	}
+
+	return reports.HTMLReportOptions{Start: first, End: last.AddDate(0, 0, 1)}, nil
+}
+
+// writeHTMLReport generates the HTML report of opts and saves it to path
+func writeHTMLReport(ctx context.Context, cfg *core.Config, repo core.RepositoryPort, opts reports.HTMLReportOptions, path string, logger *zap.Logger) error {
+	report, err := reports.NewHTMLReportGenerator(repo, cfg, logger).Generate(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to generate HTML report: %w", err)
+	}
+	return report.Save(path)
+}
+
+// refreshHTMLReport rewrites reports.html_path with the last reports.html_days days, if
+// set. Failures are logged; the report is not worth failing a run over.
+func refreshHTMLReport(cfg *core.Config, repo core.RepositoryPort, logger *zap.Logger) {
+	if cfg.Reports.HTMLPath == "" {
+		return
+	}
+
+	// The run context may already be cancelled on shutdown
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	loc, err := cfg.Database.Location()
+	if err != nil {
+		logger.Warn("Failed to refresh HTML report", zap.Error(err))
+		return
+	}
+	opts, err := htmlReportRange("", "", cfg.Reports.HTMLDays, loc)
+	if err != nil {
+		logger.Warn("Failed to refresh HTML report", zap.Error(err))
+		return
+	}
+	if err := writeHTMLReport(ctx, cfg, repo, opts, cfg.Reports.HTMLPath, logger); err != nil {
+		logger.Warn("Failed to refresh HTML report", zap.Error(err))
+		return
+	}
+	logger.Info("HTML report refreshed", zap.String("path", cfg.Reports.HTMLPath))
+}
+
+// emailDailyReport sends report to smtp.to and records the send in history. It returns
+// false without sending when the report's day was already emailed and force is not set.
+func emailDailyReport(ctx context.Context, cfg *core.Config, repo core.RepositoryPort, report *reports.DailyReport, force bool, logger *zap.Logger) (bool, error) {
+	mailer := integrations.NewSMTPMailer(cfg, logger)
+	if mailer == nil {
+		return false, fmt.Errorf("smtp.host is required to email the report")
+	}
+
+	day := report.Date.Format("2006-01-02")
+	if !force {
+		sent, err := repo.HasHistoryTarget(ctx, dailyReportEmailAction, day)
+		if err != nil {
+			return false, fmt.Errorf("failed to check for an earlier report email: %w", err)
+		}
+		if sent {
+			return false, nil
+		}
+	}
+
+	email, err := report.RenderEmail(cfg.Limits.MaxActionsPerDay)
+	if err != nil {
+		return false, err
+	}
+	if err := mailer.Send(email.Subject, email.Text, email.HTML); err != nil {
+		return false, fmt.Errorf("failed to send report email: %w", err)
+	}
+
+	if err := repo.CreateHistory(ctx, core.NewHistory(dailyReportEmailAction, core.HistoryDetails{Target: day, Outcome: "sent"})); err != nil {
+		logger.Warn("Report email sent but not recorded; it may be sent again", zap.String("date", day), zap.Error(err))
+	}
+	return true, nil
+}
+
+// runPeriodicDailyEmail emails each active day's report once limits.working_hours_end
+// has passed, while 'bot serve' runs. Days without any recorded action are skipped.
+// Overnight working hours get the report at their end time, covering the day so far.
+func runPeriodicDailyEmail(ctx context.Context, cfg *core.Config, repo core.RepositoryPort, logger *zap.Logger) {
+	if !cfg.SMTP.DailyReport {
+		return
+	}
+
+	loc, err := cfg.Database.Location()
+	if err != nil {
+		logger.Error("Daily report email disabled: invalid database.timezone", zap.Error(err))
+		return
+	}
+	end, err := time.Parse("15:04", cfg.Limits.WorkingHoursEnd)
+	if err != nil {
+		logger.Error("Daily report email disabled: invalid limits.working_hours_end", zap.Error(err))
+		return
+	}
+
+	ticker := time.NewTicker(dailyEmailCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now().In(loc)
+		endToday := time.Date(now.Year(), now.Month(), now.Day(), end.Hour(), end.Minute(), 0, 0, loc)
+		if !now.Before(endToday) {
+			sendDailyEmailIfActive(ctx, cfg, repo, now, logger)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendDailyEmailIfActive emails the report of date's day unless nothing happened that day
+// or it was already emailed
+func sendDailyEmailIfActive(ctx context.Context, cfg *core.Config, repo core.RepositoryPort, date time.Time, logger *zap.Logger) {
+	report, err := reports.NewDailyReportGenerator(repo, logger).Generate(ctx, date)
+	if err != nil {
+		logger.Error("Failed to generate daily report", zap.Error(err))
+		return
+	}
+	if report.Actions == 0 {
+		return
+	}
+
+	if _, err := emailDailyReport(ctx, cfg, repo, report, false, logger); err != nil {
+		logger.Error("Failed to email daily report", zap.Error(err))
+	}
+}
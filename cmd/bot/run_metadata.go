@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// startRunMetadata records the start of a run under runID, the ID its log lines carry.
+// It returns nil if the run could not be recorded; the run goes ahead either way.
+func startRunMetadata(ctx context.Context, cfg *core.Config, repo core.RepositoryPort, runID string, logger *zap.Logger) *core.RunMetadata {
+	if runID == "" {
+		return nil
+	}
+
+	run := &core.RunMetadata{
+		RunID:      runID,
+		StartedAt:  time.Now(),
+		Keyword:    *keyword,
+		Location:   *location,
+		Campaign:   *campaign,
+		Mode:       runMode(),
+		Account:    cfg.Credentials.Email,
+		ExitStatus: core.RunStatusRunning,
+	}
+	if stealthJSON, err := json.Marshal(cfg.Stealth); err == nil {
+		run.StealthConfigHash = md5Hex(string(stealthJSON))
+	}
+
+	if err := repo.CreateRunMetadata(ctx, run); err != nil {
+		logger.Warn("Failed to record run metadata", zap.Error(err))
+		return nil
+	}
+
+	logger.Info("Run started", zap.Uint("id", run.ID), zap.String("mode", run.Mode))
+	return run
+}
+
+// runMode names the modes selected by the command-line flags, in the order
+// runAutomation handles them
+func runMode() string {
+	modes := []struct {
+		name   string
+		active bool
+	}{
+		{"scan", *scan},
+		{"scan-sent", *scanSent},
+		{"scan-replies", *scanReplies},
+		{"enrich", *enrich},
+		{"like-posts", *likePosts > 0},
+		{"comment-post", *commentPost != ""},
+		{"follow-companies", *followCompanies > 0},
+		{"visit", *visit > 0},
+		{"celebrations", *celebrations},
+		{"export-connections", *exportConns != ""},
+		{"accept-invitations", *acceptInvites},
+		{"inmail", *inMail != ""},
+		{"scan-notifications", *scanNotifs},
+		{"export-notifications", *exportNotifs != ""},
+		{"groups-status", *groupsStatus},
+		{"followup", *followup},
+		{"connect", *keyword != "" || len(groupURLs) > 0},
+	}
+
+	var names []string
+	for _, mode := range modes {
+		if mode.active {
+			names = append(names, mode.name)
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// updateRunMetadata recounts the run's connection requests, acceptances, messages and
+// errors from what was recorded since it started, and saves them
+func updateRunMetadata(ctx context.Context, repo core.RepositoryPort, run *core.RunMetadata, logger *zap.Logger) {
+	if run == nil {
+		return
+	}
+
+	counters := []struct {
+		actionType string
+		target     *int64
+	}{
+		{"Connect", &run.ConnectionsSent},
+		{"Message", &run.MessagesSent},
+		{"Error", &run.Errors},
+	}
+	for _, c := range counters {
+		count, err := repo.GetActionCountSince(ctx, c.actionType, run.StartedAt)
+		if err != nil {
+			logger.Warn("Failed to count run actions", zap.String(core.LogFieldAction, c.actionType), zap.Error(err))
+			continue
+		}
+		*c.target = count
+	}
+
+	accepted, err := repo.SearchProfiles(ctx, &core.ProfileFilter{ConnectedAfter: run.StartedAt})
+	if err != nil {
+		logger.Warn("Failed to count run acceptances", zap.Error(err))
+	} else {
+		run.ConnectionsAccepted = int64(len(accepted))
+	}
+
+	if err := repo.UpdateRunMetadata(ctx, run); err != nil {
+		logger.Warn("Failed to update run metadata", zap.Error(err))
+	}
+}
+
+// notifyRunFinished sends the run_finished alert with the run's counters. alerts and run
+// may be nil.
+func notifyRunFinished(alerts core.NotifierPort, run *core.RunMetadata) {
+	if alerts == nil || run == nil || run.CompletedAt == nil {
+		return
+	}
+
+	text := fmt.Sprintf("Run %s %s after %s: %d requests sent, %d accepted, %d messages sent",
+		run.RunID, run.ExitStatus, run.CompletedAt.Sub(run.StartedAt).Round(time.Second),
+		run.ConnectionsSent, run.ConnectionsAccepted, run.MessagesSent)
+	if run.ExitError != "" {
+		text += " (" + run.ExitError + ")"
+	}
+	alerts.Notify(context.Background(), core.Alert{Event: core.AlertEventRunFinished, Text: text})
+}
+
+// finishRunMetadata stores the run's final counters, completion time and exit status.
+// runErr is the error the run ended with; a cancelled runCtx means it was interrupted.
+func finishRunMetadata(runCtx context.Context, repo core.RepositoryPort, run *core.RunMetadata, runErr error, logger *zap.Logger) {
+	if run == nil {
+		return
+	}
+
+	switch {
+	case runCtx.Err() != nil:
+		run.ExitStatus = core.RunStatusInterrupted
+	case runErr != nil:
+		run.ExitStatus = core.RunStatusFailed
+	default:
+		run.ExitStatus = core.RunStatusSucceeded
+	}
+	if runErr != nil {
+		run.ExitError = runErr.Error()
+	}
+
+	// The run context may already be cancelled on shutdown
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	completedAt := time.Now()
+	run.CompletedAt = &completedAt
+	updateRunMetadata(ctx, repo, run, logger)
+
+	logger.Info("Run finished",
+		zap.Uint("id", run.ID),
+		zap.String("exit_status", run.ExitStatus),
+		zap.Int64("connections_sent", run.ConnectionsSent),
+		zap.Int64("connections_accepted", run.ConnectionsAccepted),
+		zap.Int64("messages_sent", run.MessagesSent),
+		zap.Int64("errors", run.Errors),
+	)
+}
+
+// md5Hex returns the hex MD5 digest of s
+func md5Hex(s string) string {
+	sum := md5.Sum([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"linkedin-automation/internal/core"
+	"linkedin-automation/internal/repository"
+)
+
+// runsUsage describes the runs subcommands
+const runsUsage = `usage:
+  bot runs list [-limit 20] [-json]
+  bot runs show ID|RUN_ID [-json]`
+
+// runDetails is the JSON output of "bot runs show"
+type runDetails struct {
+	*core.RunMetadata
+	Actions []*core.History `json:"actions"`
+}
+
+// runRunsCommand shows recorded runs: "list" the most recent ones, "show" one run with
+// the actions it took. -json prints JSON instead of a table.
+func runRunsCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing runs command\n%s", runsUsage)
+	}
+
+	fs := flag.NewFlagSet("runs "+args[0], flag.ContinueOnError)
+	limit := fs.Int("limit", 20, "Number of runs to list")
+	asJSON := fs.Bool("json", false, "Print JSON")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	// Flags may also follow the run ID
+	runID := fs.Arg(0)
+	if fs.NArg() > 1 {
+		if err := fs.Parse(fs.Args()[1:]); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	repo, err := repository.NewRepository(&cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to initialize repository: %w", err)
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "list":
+		runs, err := repo.ListRunMetadata(ctx, *limit)
+		if err != nil {
+			return fmt.Errorf("failed to list runs: %w", err)
+		}
+		if *asJSON {
+			return printJSON(runs)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tSTARTED\tDURATION\tMODE\tKEYWORD/CAMPAIGN\tSTATUS\tSENT\tMESSAGES\tERRORS")
+		for _, run := range runs {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%d\t%d\t%d\n",
+				run.ID,
+				run.StartedAt.Local().Format("2006-01-02 15:04"),
+				runDuration(run),
+				orDash(run.Mode),
+				orDash(runTarget(run)),
+				orDash(run.ExitStatus),
+				run.ConnectionsSent,
+				run.MessagesSent,
+				run.Errors,
+			)
+		}
+		return w.Flush()
+
+	case "show":
+		if runID == "" {
+			return fmt.Errorf("missing run ID\n%s", runsUsage)
+		}
+		run, err := repo.GetRunMetadata(ctx, runID)
+		if err != nil {
+			return fmt.Errorf("failed to load run: %w", err)
+		}
+		if run == nil {
+			return fmt.Errorf("run not found: %s", runID)
+		}
+
+		actions, err := repo.GetRunHistory(ctx, run.ID)
+		if err != nil {
+			return fmt.Errorf("failed to load run actions: %w", err)
+		}
+		if *asJSON {
+			return printJSON(runDetails{RunMetadata: run, Actions: actions})
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "Run:\t%d (%s)\n", run.ID, run.RunID)
+		fmt.Fprintf(w, "Started:\t%s\n", run.StartedAt.Local().Format(time.RFC3339))
+		if run.CompletedAt != nil {
+			fmt.Fprintf(w, "Finished:\t%s (%s)\n", run.CompletedAt.Local().Format(time.RFC3339), runDuration(run))
+		}
+		fmt.Fprintf(w, "Mode:\t%s\n", orDash(run.Mode))
+		fmt.Fprintf(w, "Account:\t%s\n", orDash(run.Account))
+		fmt.Fprintf(w, "Keyword:\t%s\n", orDash(run.Keyword))
+		fmt.Fprintf(w, "Campaign:\t%s\n", orDash(run.Campaign))
+		fmt.Fprintf(w, "Status:\t%s\n", orDash(run.ExitStatus))
+		if run.ExitError != "" {
+			fmt.Fprintf(w, "Error:\t%s\n", run.ExitError)
+		}
+		fmt.Fprintf(w, "Counts:\t%d requests sent, %d accepted, %d messages, %d errors\n",
+			run.ConnectionsSent, run.ConnectionsAccepted, run.MessagesSent, run.Errors)
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		fmt.Printf("\n%d actions\n", len(actions))
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "TIME\tACTION\tDETAILS")
+		for _, action := range actions {
+			fmt.Fprintf(w, "%s\t%s\t%s\n",
+				action.Timestamp.Local().Format("15:04:05"),
+				action.ActionType,
+				action.ParsedDetails().Summary(),
+			)
+		}
+		return w.Flush()
+
+	default:
+		return fmt.Errorf("unknown runs command %q\n%s", args[0], runsUsage)
+	}
+}
+
+// runDuration formats how long a run took, or "running" while it has not finished
+func runDuration(run *core.RunMetadata) string {
+	if run.CompletedAt == nil {
+		return "running"
+	}
+	return run.CompletedAt.Sub(run.StartedAt).Round(time.Second).String()
+}
+
+// runTarget is the keyword or campaign a run worked on
+func runTarget(run *core.RunMetadata) string {
+	if run.Campaign != "" {
+		return run.Campaign
+	}
+	return run.Keyword
+}
+
+// orDash returns s, or "-" when it is empty
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// printJSON writes v to stdout as indented JSON
+func printJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
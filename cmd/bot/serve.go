@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"linkedin-automation/internal/api"
+	"linkedin-automation/internal/repository"
+
+	"go.uber.org/zap"
+)
+
+// runServeCommand serves the REST API, web UI and dashboard until interrupted, without
+// starting the browser. It also runs the database.retention purge and the
+// database.backup backups periodically, emails the daily report with smtp.daily_report
+// and, with api.jobs_enabled, runs the jobs queued through the API.
+func runServeCommand(args []string, logger *zap.Logger) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", "", "Address to listen on, e.g. :8787 (default: api.listen)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if *addr != "" {
+		cfg.Api.Listen = *addr
+	}
+	if cfg.Api.AuthToken == "" && !isLoopbackAddr(cfg.Api.Listen) {
+		logger.Warn("Serving without api.auth_token on a non-loopback address; anyone who can reach it can read the database",
+			zap.String("address", cfg.Api.Listen))
+	}
+
+	logger, eventBroker := withLogStream(cfg, logger)
+
+	repo, err := repository.NewRepository(&cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to initialize repository: %w", err)
+	}
+	defer repo.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go runPeriodicPurge(ctx, repo, &cfg.Database.Retention, logger)
+	go runPeriodicBackup(ctx, repo, &cfg.Database.Backup, logger)
+	go runPeriodicDailyEmail(ctx, cfg, repo, logger)
+	go runJobQueue(ctx, cfg, repo, logger)
+
+	server := api.NewServer(repo, cfg, logger)
+	server.SetEventBroker(eventBroker)
+	return server.Run(ctx)
+}
+
+// isLoopbackAddr reports whether addr only listens on the loopback interface
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
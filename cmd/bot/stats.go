@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"linkedin-automation/internal/core"
+	"linkedin-automation/internal/repository"
+)
+
+// runStatsCommand prints profile counts per status and the overall acceptance rate, or
+// with -by-query the most recent search queries and with -by-source each profile source,
+// and how their profiles did. Archived profiles are left out unless -include-archived is given.
+func runStatsCommand(args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	byQuery := fs.Bool("by-query", false, "Show acceptance per search query")
+	bySource := fs.Bool("by-source", false, "Show acceptance per profile source (search, group, post, ...)")
+	limit := fs.Int("limit", 20, "Number of search queries to show with -by-query")
+	includeArchived := fs.Bool("include-archived", false, "Count archived profiles too")
+	asJSON := fs.Bool("json", false, "Print JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	repo, err := repository.NewRepository(&cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to initialize repository: %w", err)
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+	if *includeArchived {
+		ctx = core.WithArchivedProfiles(ctx)
+	}
+
+	if *byQuery {
+		stats, err := repo.GetSearchQueryStats(ctx, *limit)
+		if err != nil {
+			return fmt.Errorf("failed to load search query stats: %w", err)
+		}
+		if *asJSON {
+			return printJSON(stats)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tDATE\tKEYWORD\tPAGES\tFOUND\tNEW\tREQUESTED\tACCEPTED\tRATE")
+		for _, s := range stats {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%d\t%d\t%d\t%d\t%.0f%%\n",
+				s.QueryID,
+				s.CreatedAt.Local().Format("2006-01-02 15:04"),
+				orDash(s.Keyword),
+				s.PagesCrawled,
+				s.ResultsFound,
+				s.NewProfiles,
+				s.RequestsSent,
+				s.Accepted,
+				s.AcceptanceRate*100,
+			)
+		}
+		return w.Flush()
+	}
+
+	if *bySource {
+		stats, err := repo.GetAcceptanceRateBySource(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load source stats: %w", err)
+		}
+		if *asJSON {
+			return printJSON(stats)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "SOURCE\tPROFILES\tREQUESTED\tACCEPTED\tRATE")
+		for _, s := range stats {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%.0f%%\n",
+				s.Source,
+				s.Profiles,
+				s.RequestsSent,
+				s.Accepted,
+				s.AcceptanceRate*100,
+			)
+		}
+		return w.Flush()
+	}
+
+	counts, err := repo.GetStatusCounts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to count profiles: %w", err)
+	}
+	acceptance, err := repo.GetAcceptanceStats(ctx, time.Time{}, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to load acceptance stats: %w", err)
+	}
+	if *asJSON {
+		return printJSON(map[string]interface{}{
+			"status_counts": counts,
+			"acceptance":    acceptance,
+		})
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STATUS\tPROFILES")
+	for _, c := range counts {
+		fmt.Fprintf(w, "%s\t%d\n", c.Status, c.Count)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("\n%d requests sent, %d accepted (%.0f%%), %.1f days to accept on average\n",
+		acceptance.RequestsSent, acceptance.Accepted, acceptance.AcceptanceRate*100, acceptance.AvgDaysToAccept)
+	return nil
+}
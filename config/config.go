@@ -1,24 +1,39 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"strings"
 
 	"linkedin-automation/internal/core"
+	"linkedin-automation/internal/stealth"
 
 	"github.com/spf13/viper"
 )
 
-// Load loads configuration from config.yaml and environment variables
+// configJSONEnv holds the full configuration as JSON, for environments where
+// writing credentials to a config file is undesirable
+const configJSONEnv = "LINKEDIN_BOT_CONFIG_JSON"
+
+// Load loads configuration from config.yaml and environment variables.
+// If LINKEDIN_BOT_CONFIG_JSON is set it takes precedence over the YAML file.
 func Load(configPath string) (*core.Config, error) {
-	cfg := &core.Config{}
+	if data := os.Getenv(configJSONEnv); data != "" {
+		return LoadFromJSON([]byte(data))
+	}
 
 	// Set default values
 	setDefaults()
 
+	// Enable environment variable support
+	bindEnv()
+
 	// Set config file path
 	if configPath != "" {
+		if _, err := os.Stat(configPath); os.IsNotExist(err) {
+			return nil, fmt.Errorf("no configuration found: %s does not exist and %s is not set", configPath, configJSONEnv)
+		}
 		viper.SetConfigFile(configPath)
 	} else {
 		// Default to config.yaml in current directory
@@ -28,19 +43,44 @@ func Load(configPath string) (*core.Config, error) {
 		viper.AddConfigPath("./config")
 	}
 
-	// Enable environment variable support
-	viper.SetEnvPrefix("LINKEDIN_BOT")
-	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
-	viper.AutomaticEnv()
-
 	// Read config file
 	if err := viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			return nil, fmt.Errorf("error reading config file: %w", err)
+		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
+			return nil, fmt.Errorf("no configuration found: no config.yaml in . or ./config and %s is not set", configJSONEnv)
 		}
-		// Config file not found, but we can continue with defaults and env vars
+		return nil, fmt.Errorf("error reading config file: %w", err)
+	}
+
+	return build()
+}
+
+// LoadFromJSON loads the full configuration from a JSON document. Defaults and
+// environment variable overrides apply as with Load.
+func LoadFromJSON(data []byte) (*core.Config, error) {
+	// Set default values
+	setDefaults()
+
+	viper.SetConfigType("json")
+	bindEnv()
+
+	if err := viper.ReadConfig(bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("error reading JSON config: %w", err)
 	}
 
+	return build()
+}
+
+// bindEnv enables LINKEDIN_BOT_* environment variable overrides
+func bindEnv() {
+	viper.SetEnvPrefix("LINKEDIN_BOT")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	viper.AutomaticEnv()
+}
+
+// build unmarshals the loaded settings, applies credential overrides and validates the result
+func build() (*core.Config, error) {
+	cfg := &core.Config{}
+
 	// Unmarshal into struct
 	if err := viper.Unmarshal(cfg); err != nil {
 		return nil, fmt.Errorf("error unmarshaling config: %w", err)
@@ -54,6 +94,10 @@ func Load(configPath string) (*core.Config, error) {
 		cfg.Credentials.Password = password
 	}
 
+	if err := applyMouseProfile(&cfg.Stealth); err != nil {
+		return nil, err
+	}
+
 	// Validate required fields
 	if err := validateConfig(cfg); err != nil {
 		return nil, fmt.Errorf("config validation failed: %w", err)
@@ -62,12 +106,73 @@ func Load(configPath string) (*core.Config, error) {
 	return cfg, nil
 }
 
+// applyMouseProfile fills the mouse parameters from stealth.mouse_profile. Parameters
+// set explicitly in the config file or environment override the preset.
+func applyMouseProfile(cfg *core.StealthConfig) error {
+	if cfg.MouseProfile == "" {
+		return nil
+	}
+
+	name, preset, err := stealth.ResolveMouseProfile(cfg.MouseProfile)
+	if err != nil {
+		return fmt.Errorf("invalid stealth.mouse_profile: %w", err)
+	}
+	cfg.MouseProfile = name
+
+	fields := []struct {
+		key    string
+		target *float64
+		value  float64
+	}{
+		{"stealth.mouse_speed_min", &cfg.MouseSpeedMin, preset.SpeedMin},
+		{"stealth.mouse_speed_max", &cfg.MouseSpeedMax, preset.SpeedMax},
+		{"stealth.overshoot_chance", &cfg.OvershootChance, preset.OvershootChance},
+		{"stealth.overshoot_dist_min", &cfg.OvershootDistMin, preset.OvershootDistMin},
+		{"stealth.overshoot_dist_max", &cfg.OvershootDistMax, preset.OvershootDistMax},
+		{"stealth.control_point_offset_min", &cfg.ControlPointOffsetMin, preset.ControlPointOffsetMin},
+		{"stealth.control_point_offset_max", &cfg.ControlPointOffsetMax, preset.ControlPointOffsetMax},
+		{"stealth.control_point_spread_min", &cfg.ControlPointSpreadMin, preset.ControlPointSpreadMin},
+		{"stealth.control_point_spread_max", &cfg.ControlPointSpreadMax, preset.ControlPointSpreadMax},
+	}
+	for _, f := range fields {
+		if viper.InConfig(f.key) || os.Getenv(envKey(f.key)) != "" {
+			continue
+		}
+		*f.target = f.value
+	}
+
+	return nil
+}
+
+// envKey returns the LINKEDIN_BOT_* environment variable name for a config key
+func envKey(key string) string {
+	return "LINKEDIN_BOT_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
 // setDefaults sets default configuration values
 func setDefaults() {
 	// Credentials (should be set via env or config)
 	viper.SetDefault("credentials.email", "")
 	viper.SetDefault("credentials.password", "")
 
+	// Browser defaults
+	viper.SetDefault("browser.randomize_launch_args", false)
+	viper.SetDefault("browser.event_batch_size", 3)
+	viper.SetDefault("browser.event_interval_ms", []int{8, 25})
+	viper.SetDefault("browser.keyboard_nav_prob", 0.1)
+	viper.SetDefault("browser.click_verification.enabled", false)
+	viper.SetDefault("browser.click_verification.selector_after_click", "div[role='dialog']")
+
+	viper.SetDefault("security.solve_text_challenges", false)
+
+	// API defaults
+	viper.SetDefault("api.enabled", false)
+	viper.SetDefault("api.listen", "127.0.0.1:8080")
+	viper.SetDefault("api.dashboard_enabled", true)
+	viper.SetDefault("api.sse_enabled", false)
+	viper.SetDefault("api.auth_token", "")
+	viper.SetDefault("api.jobs_enabled", false)
+
 	// Stealth defaults
 	viper.SetDefault("stealth.typing_speed_min", 40)
 	viper.SetDefault("stealth.typing_speed_max", 80)
@@ -75,6 +180,13 @@ func setDefaults() {
 	viper.SetDefault("stealth.mouse_speed_min", 0.5)
 	viper.SetDefault("stealth.mouse_speed_max", 1.5)
 	viper.SetDefault("stealth.overshoot_chance", 0.3)
+	viper.SetDefault("stealth.overshoot_dist_min", 0.1)
+	viper.SetDefault("stealth.overshoot_dist_max", 0.3)
+	viper.SetDefault("stealth.control_point_offset_min", 0.2)
+	viper.SetDefault("stealth.control_point_offset_max", 0.5)
+	viper.SetDefault("stealth.control_point_spread_min", 0.3)
+	viper.SetDefault("stealth.control_point_spread_max", 0.7)
+	viper.SetDefault("stealth.mouse_profile", "") // Empty uses the individual mouse settings
 	viper.SetDefault("stealth.scroll_chunk_min", 50)
 	viper.SetDefault("stealth.scroll_chunk_max", 200)
 	viper.SetDefault("stealth.base_delay_min", 0.1)
@@ -84,6 +196,16 @@ func setDefaults() {
 	viper.SetDefault("stealth.viewport_height_min", 1080)
 	viper.SetDefault("stealth.viewport_height_max", 1080)
 	viper.SetDefault("stealth.debug_stealth", true)
+	viper.SetDefault("stealth.use_keyboard_navigation", false)
+	viper.SetDefault("stealth.ime_simulation", false)
+	viper.SetDefault("stealth.throttle_recovery_pause_minutes", 15)
+	viper.SetDefault("stealth.random_seed", 0)
+	viper.SetDefault("stealth.idle_mouse_drift.enabled", false)
+	viper.SetDefault("stealth.idle_mouse_drift.drift_radius", 15.0)
+	viper.SetDefault("stealth.idle_mouse_drift.drift_steps", 8)
+	viper.SetDefault("stealth.typing_pause_patterns", []map[string]interface{}{
+		{"after_word": 20, "duration": []float64{1.0, 3.0}}, // One pause every 15-25 words
+	})
 
 	// Limits defaults
 	viper.SetDefault("limits.max_actions_per_day", 50)
@@ -91,6 +213,117 @@ func setDefaults() {
 	viper.SetDefault("limits.working_hours_end", "17:00")
 	viper.SetDefault("limits.connect_cooldown_min", 3)
 	viper.SetDefault("limits.connect_cooldown_max", 8)
+	viper.SetDefault("limits.target_connections_per_hour", 0)
+	viper.SetDefault("limits.max_session_duration_minutes", 0)
+	viper.SetDefault("limits.session_end_behavior", "stop")
+
+	// Connection defaults
+	viper.SetDefault("connection.note_length.min", 0)
+	viper.SetDefault("connection.note_length.max", 0)
+	viper.SetDefault("connection.closing_phrases", []string{})
+	viper.SetDefault("connection.use_introduction_requests", false)
+	viper.SetDefault("connection.introduction_template", "Hi {{FirstName}}, I noticed you're connected with {{TargetName}}. Would you be open to introducing us? Happy to return the favour.")
+
+	// Celebration defaults
+	viper.SetDefault("celebrations.max_per_day", 5)
+	viper.SetDefault("celebrations.birthday_template", "Happy birthday, {{FirstName}}! Hope you have a great day.")
+	viper.SetDefault("celebrations.anniversary_template", "Congrats on the work anniversary, {{FirstName}}!")
+
+	// InMail defaults
+	viper.SetDefault("inmail.monthly_credits", 5)
+	viper.SetDefault("inmail.subject", "Quick question, {{FirstName}}")
+	viper.SetDefault("inmail.template", "Hi {{FirstName}}, I came across your profile and would love to connect about your work. Would you be open to a quick chat?")
+
+	// Invitation defaults
+	viper.SetDefault("invitations.max_accepts_per_day", 20)
+	viper.SetDefault("invitations.ignore_excluded", false)
+	viper.SetDefault("invitations.decline_no_mutuals", false)
+	viper.SetDefault("invitations.decline_no_photo", false)
+
+	// Visit defaults
+	viper.SetDefault("visits.max_per_day", 30)
+	viper.SetDefault("visits.dwell_min", 8.0)
+	viper.SetDefault("visits.dwell_max", 20.0)
+	viper.SetDefault("visits.days_before_connect", 0)
+
+	// Search defaults
+	viper.SetDefault("search.mode", "people")
+	viper.SetDefault("search.feed_scraping.enabled", false)
+	viper.SetDefault("search.feed_scraping.keywords", []string{})
+	viper.SetDefault("search.feed_scraping.max_scrolls", 15)
+
+	// Group defaults
+	viper.SetDefault("groups.max_joins_per_week", 5)
+
+	// Prefetch defaults
+	viper.SetDefault("prefetch.enabled", false)
+	viper.SetDefault("prefetch.concurrency", 3)
+
+	// Targeting defaults
+	viper.SetDefault("targeting.max_profile_inactive_days", 0)
+	viper.SetDefault("targeting.require_open_to_work", false)
+	viper.SetDefault("targeting.exclude_open_to_work", false)
+	viper.SetDefault("targeting.blacklist", []string{})
+	viper.SetDefault("targeting.required_profile_skills", []string{})
+	viper.SetDefault("targeting.min_follower_count", 0)
+	viper.SetDefault("targeting.max_follower_count", 0)
+
+	// Engagement defaults
+	viper.SetDefault("engagement.max_likes_per_day", 10)
+	viper.SetDefault("engagement.like_before_connect", false)
+	viper.SetDefault("engagement.max_feed_scrolls", 15)
+	viper.SetDefault("engagement.keywords", []string{})
+	viper.SetDefault("engagement.max_comments_per_day", 3)
+	viper.SetDefault("engagement.comment_cooldown_days", 30)
+	viper.SetDefault("engagement.comment_dry_run", true)
+	viper.SetDefault("engagement.comment_template", "")
+	viper.SetDefault("engagement.comment_snippet", "")
+	viper.SetDefault("engagement.comment_allowlist", []string{})
+	viper.SetDefault("engagement.max_follows_per_day", 10)
+	viper.SetDefault("engagement.follow_companies", []string{})
+	viper.SetDefault("engagement.preferred_post_types", []string{})
+	viper.SetDefault("engagement.require_preferred_type", false)
+
+	// Enrichment defaults
+	viper.SetDefault("enrichment.batch_limit", 10)
+	viper.SetDefault("enrichment.after_connect", false)
+	viper.SetDefault("enrichers.news_provider", "newsapi")
+	viper.SetDefault("enrichers.news_api_key", "")
+	viper.SetDefault("enrichers.crunchbase_api_key", "")
+	viper.SetDefault("enrichers.timeout_seconds", 10)
+
+	// Observability defaults
+	viper.SetDefault("observability.webhooks.url", "")
+	viper.SetDefault("observability.webhooks.events", []string{"captcha", "invite_limit", "run_finished", "connection_accepted"})
+	viper.SetDefault("observability.webhooks.mention", "")
+	viper.SetDefault("observability.webhooks.digest_seconds", 300)
+
+	// SMTP defaults
+	viper.SetDefault("smtp.host", "")
+	viper.SetDefault("smtp.port", 587)
+	viper.SetDefault("smtp.username", "")
+	viper.SetDefault("smtp.password", "")
+	viper.SetDefault("smtp.from", "")
+	viper.SetDefault("smtp.to", []string{})
+	viper.SetDefault("smtp.daily_report", false)
+
+	// Reports defaults
+	viper.SetDefault("reports.html_path", "")
+	viper.SetDefault("reports.html_days", 30)
+
+	// Debug defaults
+	viper.SetDefault("debug.pprof_addr", "127.0.0.1:6060")
+
+	// Personalization defaults
+	viper.SetDefault("personalization.company_research.enabled", false)
+	viper.SetDefault("personalization.company_research.data_source", "file")
+	viper.SetDefault("personalization.company_research.talking_points_path", "data/company_talking_points.yaml")
+	viper.SetDefault("personalization.company_research.cache_ttl_hours", 168)
+	viper.SetDefault("integrations.google_sheets.enabled", false)
+	viper.SetDefault("integrations.google_sheets.spreadsheet_id", "")
+	viper.SetDefault("integrations.google_sheets.sheet_name", "Connections")
+	viper.SetDefault("integrations.google_sheets.summary_sheet_name", "Daily Stats")
+	viper.SetDefault("integrations.google_sheets.credentials_path", "")
 
 	// LinkedIn URLs
 	viper.SetDefault("linkedin.base_url", "https://www.linkedin.com")
@@ -100,25 +333,121 @@ func setDefaults() {
 	// Messaging defaults
 	viper.SetDefault("messaging.follow_up_template", "Hi {{FirstName}}, thanks for connecting! I'd love to keep in touch.")
 	viper.SetDefault("messaging.batch_limit", 5)
+	viper.SetDefault("messaging.daily_limit", 20)
+	viper.SetDefault("messaging.cooldown_min", 2)
+	viper.SetDefault("messaging.cooldown_max", 5)
+	viper.SetDefault("messaging.follow_up_cooldown_min", 0)
+	viper.SetDefault("messaging.follow_up_cooldown_max", 0)
+	viper.SetDefault("messaging.randomize_order", true)
+	viper.SetDefault("messaging.max_messages_per_session", 0)
+	viper.SetDefault("messaging.allow_inmail", false)
+	viper.SetDefault("messaging.inmail_subject", "Great to connect")
+	viper.SetDefault("messaging.scan_max_connections", 200)
+	viper.SetDefault("messaging.scan_max_scrolls", 30)
+	viper.SetDefault("messaging.min_days_since_connected", 1)
+	viper.SetDefault("messaging.max_days_since_connected", 0)
+	viper.SetDefault("messaging.follow_up_order", "newest")
+	viper.SetDefault("messaging.include_unknown_connected_at", true)
+	viper.SetDefault("messaging.send_on_accept", false)
+	viper.SetDefault("messaging.max_on_accept", 3)
+	viper.SetDefault("messaging.reply_scan_limit", 20)
+	viper.SetDefault("messaging.thread_max_messages", 20)
+	viper.SetDefault("messaging.allow_html_formatting", false)
 
 	// Database
+	viper.SetDefault("database.driver", "sqlite")
 	viper.SetDefault("database.path", "data/bot.db")
+	viper.SetDefault("database.dsn", "")
+	viper.SetDefault("database.max_open_conns", 10)
+	viper.SetDefault("database.max_idle_conns", 2)
+	viper.SetDefault("database.conn_max_lifetime_minutes", 30)
+	viper.SetDefault("database.cache_enabled", true)
+	viper.SetDefault("database.cache_size", 1000)
+	viper.SetDefault("database.upsert_status", "transition")
+	viper.SetDefault("database.sqlite_journal_mode", "wal")
+	viper.SetDefault("database.sqlite_busy_timeout_ms", 5000)
+	viper.SetDefault("database.sqlite_foreign_keys", true)
+	viper.SetDefault("database.sqlite_max_open_conns", 1)
+	viper.SetDefault("database.sqlite_max_idle_conns", 1)
+	viper.SetDefault("database.account", "default")
+	viper.SetDefault("database.timezone", "")
+	viper.SetDefault("database.encryption_key", "")
+	viper.SetDefault("database.retention.profile_days", 0)
+	viper.SetDefault("database.retention.history_days", 0)
+	viper.SetDefault("database.retention.debug_artifact_days", 0)
+	viper.SetDefault("database.retention.grace_days", 7)
+	viper.SetDefault("database.retention.purge_interval_hours", 24)
+	viper.SetDefault("database.backup.interval_hours", 0)
+	viper.SetDefault("database.backup.dir", "data/backups")
+	viper.SetDefault("database.backup.keep", 7)
+	viper.SetDefault("cache.profile_ttl", "5m")
 
 	// Session
 	viper.SetDefault("session.cookies_path", "data/cookies.json")
+	viper.SetDefault("sales_navigator.enabled", false)
+	viper.SetDefault("sales_navigator.cookies_path", "data/sales_nav_cookies.json")
+	viper.SetDefault("session.state_path", "data/app_state.json")
 
 	// Selectors (default LinkedIn selectors - may need updates)
-	viper.SetDefault("selectors.login_email_input", "#username")
-	viper.SetDefault("selectors.login_password_input", "#password")
-	viper.SetDefault("selectors.login_submit_button", "button[type='submit']")
-	viper.SetDefault("selectors.feed_container", "#scaffold-feed-main") // Selector for the main feed container
-	viper.SetDefault("selectors.search_input", "input[placeholder*='Search']")
-	// Updated selector to be more generic as class names change
-	viper.SetDefault("selectors.search_results", "li.reusable-search__result-container, .search-results-container, .entity-result")
-	viper.SetDefault("selectors.profile_connect_button", "button[aria-label*='Connect']")
-	viper.SetDefault("selectors.connect_note_textarea", "textarea[name='message']")
-	viper.SetDefault("selectors.connect_send_button", "button[aria-label*='Send']")
-	viper.SetDefault("selectors.two_factor_challenge", "input[type='text'][name='pin']")
+	for key, value := range selectorDefaults {
+		viper.SetDefault("selectors."+key, value)
+	}
+	viper.SetDefault("selectors.version", 0)
+	viper.SetDefault("selectors.remote_url", "")
+	viper.SetDefault("selectors.auto_update", false)
+	viper.SetDefault("selectors.default_timeout", 10)
+	viper.SetDefault("selectors.quick_timeout", 2)
+	viper.SetDefault("selectors.timeouts", map[string]int{})
+}
+
+// selectorDefaults are the shipped LinkedIn selectors, kept in sync with config.yaml.
+// Remote selector updates only replace values that still match these.
+var selectorDefaults = map[string]interface{}{
+	"login_email_input":      "#username",
+	"login_password_input":   "#password",
+	"login_submit_button":    "button[type='submit']",
+	"feed_container":         ".scaffold-layout__main", // Indicator of being logged in
+	"search_input":           "input[placeholder*='Search']",
+	"search_results":         "div[data-view-name='search-entity-result-universal-template']",
+	"profile_connect_button": "button.artdeco-button--primary[aria-label*='Invite'][aria-label*='connect']:not(.pvs-sticky-header-profile-actions__action)",
+	"profile_connect_button_fallbacks": []string{
+		".scaffold-layout__main button.artdeco-button--primary[aria-label*='Invite'][aria-label*='connect']:not(.pvs-sticky-header-profile-actions__action)",
+		".scaffold-layout__main button.artdeco-button--primary[aria-label*='Connect']:not(.pvs-sticky-header-profile-actions__action)",
+		".scaffold-layout__main button[aria-label*='Invite'][aria-label*='connect']:not(.pvs-sticky-header-profile-actions__action)",
+		".scaffold-layout__main button[aria-label*='Connect']:not(.pvs-sticky-header-profile-actions__action)",
+		".scaffold-layout__main button:contains('Connect')",
+	},
+	"profile_more_button": ".scaffold-layout__content button[aria-label='More actions']",
+	"profile_more_button_fallbacks": []string{
+		".scaffold-layout__content button[id*='profile-overflow-action']",
+		".scaffold-layout__content button[aria-label='More actions'].artdeco-button--secondary",
+		".scaffold-layout__content .artdeco-dropdown__trigger",
+	},
+	"profile_more_connect_option": ".artdeco-dropdown__content .artdeco-dropdown__item[aria-label*='Invite'][aria-label*='connect']",
+	"profile_connect_option_fallbacks": []string{
+		".artdeco-dropdown__content .artdeco-dropdown__item span:contains('Connect')",
+		".artdeco-dropdown__content div[aria-label*='Connect']",
+		".artdeco-dropdown__content li-icon[type='connect']",
+	},
+	"connect_modal_add_note_button": "button[aria-label*='Add a note']",
+	"connect_note_textarea":         "textarea[name='message']",
+	"connect_send_button":           "button[aria-label*='Send']",
+	"two_factor_challenge":          "input[type='text'][name='pin']",
+}
+
+// DefaultSelectors returns the shipped selectors, without config file or environment overrides
+func DefaultSelectors() (*core.SelectorsConfig, error) {
+	v := viper.New()
+	for key, value := range selectorDefaults {
+		v.SetDefault("selectors."+key, value)
+	}
+
+	cfg := &core.Config{}
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("error unmarshaling default selectors: %w", err)
+	}
+
+	return &cfg.Selectors, nil
 }
 
 // validateConfig validates that required configuration fields are set
@@ -129,8 +458,140 @@ func validateConfig(cfg *core.Config) error {
 	if cfg.Credentials.Password == "" {
 		return fmt.Errorf("credentials.password is required (set via config or LINKEDIN_BOT_PASSWORD env var)")
 	}
-	if cfg.Database.Path == "" {
-		return fmt.Errorf("database.path is required")
+	switch cfg.Database.Driver {
+	case "", "sqlite":
+		if cfg.Database.Path == "" {
+			return fmt.Errorf("database.path is required")
+		}
+		switch strings.ToLower(cfg.Database.SQLiteJournalMode) {
+		case "", "wal", "delete", "truncate", "persist", "memory", "off":
+		default:
+			return fmt.Errorf("database.sqlite_journal_mode must be wal, delete, truncate, persist, memory or off, got %q", cfg.Database.SQLiteJournalMode)
+		}
+	case "postgres":
+		if cfg.Database.DSN == "" {
+			return fmt.Errorf("database.dsn is required for the postgres driver")
+		}
+		if cfg.Database.EncryptionKey != "" {
+			return fmt.Errorf("database.encryption_key is only supported by the sqlite driver")
+		}
+	default:
+		return fmt.Errorf("database.driver must be sqlite or postgres, got %q", cfg.Database.Driver)
+	}
+	switch cfg.Limits.SessionEndBehavior {
+	case "", "stop", "warmdown", "schedule_continuation":
+	default:
+		return fmt.Errorf("limits.session_end_behavior must be stop, warmdown or schedule_continuation, got %q", cfg.Limits.SessionEndBehavior)
+	}
+	if cfg.Limits.SessionEndBehavior == "schedule_continuation" && !cfg.Api.JobsEnabled {
+		return fmt.Errorf("limits.session_end_behavior schedule_continuation queues a job, so it requires api.jobs_enabled")
+	}
+	if _, err := cfg.Database.Location(); err != nil {
+		return fmt.Errorf("database.timezone must be an IANA time zone such as Europe/Berlin: %w", err)
+	}
+	switch cfg.Search.Mode {
+	case "", core.SearchModePeople, core.SearchModeSalesNavigator:
+	default:
+		return fmt.Errorf("search.mode must be people or sales_navigator, got %q", cfg.Search.Mode)
+	}
+	if cfg.Search.Mode == core.SearchModeSalesNavigator && !cfg.SalesNavigator.Enabled {
+		return fmt.Errorf("search.mode sales_navigator needs sales_navigator.enabled")
+	}
+	switch cfg.Database.UpsertStatus {
+	case "", "transition", "keep", "force":
+	default:
+		return fmt.Errorf("database.upsert_status must be transition, keep or force, got %q", cfg.Database.UpsertStatus)
+	}
+	switch cfg.Messaging.FollowUpOrder {
+	case "", "newest", "oldest":
+	default:
+		return fmt.Errorf("messaging.follow_up_order must be newest or oldest, got %q", cfg.Messaging.FollowUpOrder)
+	}
+	if cfg.Messaging.MaxDaysSinceConnected > 0 && cfg.Messaging.MaxDaysSinceConnected < cfg.Messaging.MinDaysSinceConnected {
+		return fmt.Errorf("messaging.max_days_since_connected must not be below min_days_since_connected")
+	}
+	for _, postType := range cfg.Engagement.PreferredPostTypes {
+		switch postType {
+		case core.PostTypeArticle, core.PostTypeImage, core.PostTypeText, core.PostTypeVideo, core.PostTypeEvent:
+		default:
+			return fmt.Errorf("engagement.preferred_post_types: unknown post type %q (use article, image, text, video or event)", postType)
+		}
+	}
+	switch cfg.Enrichers.NewsProvider {
+	case "", "newsapi", "bing":
+	default:
+		return fmt.Errorf("enrichers.news_provider must be newsapi or bing, got %q", cfg.Enrichers.NewsProvider)
+	}
+	for _, event := range cfg.Observability.Webhooks.Events {
+		switch event {
+		case core.AlertEventCaptcha, core.AlertEventInviteLimit, core.AlertEventRunFinished, core.AlertEventConnectionAccepted:
+		default:
+			return fmt.Errorf("observability.webhooks.events: unknown event %q (use captcha, invite_limit, run_finished or connection_accepted)", event)
+		}
+	}
+	if cfg.Observability.Webhooks.DigestSeconds < 0 {
+		return fmt.Errorf("observability.webhooks.digest_seconds must not be negative")
+	}
+	if cfg.Reports.HTMLDays < 0 {
+		return fmt.Errorf("reports.html_days must not be negative")
+	}
+	if cfg.SMTP.Port < 0 {
+		return fmt.Errorf("smtp.port must not be negative")
+	}
+	if cfg.SMTP.DailyReport {
+		if cfg.SMTP.Host == "" || cfg.SMTP.From == "" || len(cfg.SMTP.To) == 0 {
+			return fmt.Errorf("smtp.daily_report needs smtp.host, smtp.from and smtp.to")
+		}
+	}
+	if research := cfg.Personalization.CompanyResearch; research.Enabled {
+		switch research.DataSource {
+		case "", core.CompanyResearchSourceFile, core.CompanyResearchSourceBing:
+		case core.CompanyResearchSourceCrunchbase:
+			if cfg.Enrichers.CrunchbaseAPIKey == "" {
+				return fmt.Errorf("personalization.company_research.data_source crunchbase needs enrichers.crunchbase_api_key")
+			}
+		default:
+			return fmt.Errorf("personalization.company_research.data_source must be file, crunchbase or bing, got %q", research.DataSource)
+		}
+	}
+	if sheets := cfg.Integrations.GoogleSheets; sheets.Enabled && (sheets.SpreadsheetID == "" || sheets.CredentialsPath == "") {
+		return fmt.Errorf("integrations.google_sheets.spreadsheet_id and credentials_path are required when Google Sheets sync is enabled")
+	}
+	if cfg.Selectors.DefaultTimeout < 0 {
+		return fmt.Errorf("selectors.default_timeout must not be negative")
+	}
+	if cfg.Selectors.QuickTimeout < 0 {
+		return fmt.Errorf("selectors.quick_timeout must not be negative")
+	}
+	for selector, seconds := range cfg.Selectors.Timeouts {
+		if seconds <= 0 {
+			return fmt.Errorf("selectors.timeouts.%s must be positive, got %d", selector, seconds)
+		}
+	}
+	if cfg.Browser.EventBatchSize < 0 {
+		return fmt.Errorf("browser.event_batch_size must not be negative")
+	}
+	if interval := cfg.Browser.EventIntervalMs; len(interval) > 0 && (len(interval) != 2 || interval[0] <= 0 || interval[1] < interval[0]) {
+		return fmt.Errorf("browser.event_interval_ms must be [min, max] with 0 < min <= max, got %v", interval)
+	}
+	if cfg.Browser.KeyboardNavProb < 0 || cfg.Browser.KeyboardNavProb > 1 {
+		return fmt.Errorf("browser.keyboard_nav_prob must be between 0 and 1, got %v", cfg.Browser.KeyboardNavProb)
+	}
+	if cfg.Api.JobsEnabled && cfg.Api.AuthToken == "" {
+		return fmt.Errorf("api.auth_token is required when api.jobs_enabled is set")
+	}
+	if cfg.Browser.ClickVerification.Enabled && cfg.Browser.ClickVerification.SelectorAfterClick == "" {
+		return fmt.Errorf("browser.click_verification.selector_after_click is required when click verification is enabled")
+	}
+	retention := cfg.Database.Retention
+	if retention.ProfileDays < 0 || retention.HistoryDays < 0 || retention.DebugArtifactDays < 0 || retention.GraceDays < 0 {
+		return fmt.Errorf("database.retention days must not be negative")
+	}
+	if cfg.Database.Backup.IntervalHours < 0 || cfg.Database.Backup.Keep < 0 {
+		return fmt.Errorf("database.backup interval_hours and keep must not be negative")
+	}
+	if cfg.Database.Backup.IntervalHours > 0 && cfg.Database.Driver == "postgres" {
+		return fmt.Errorf("database.backup is only supported by the sqlite driver; use pg_dump for postgres")
 	}
 	if cfg.Session.CookiesPath == "" {
 		return fmt.Errorf("session.cookies_path is required")
@@ -0,0 +1,17 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadMissingConfig(t *testing.T) {
+	t.Setenv(configJSONEnv, "")
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	_, err := Load(path)
+	if err == nil || !strings.Contains(err.Error(), "no configuration found") {
+		t.Fatalf("Load(%s) error = %v, want no configuration found", path, err)
+	}
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// authRealm is the basic-auth realm browsers show when asking for the token
+const authRealm = "linkedin-automation"
+
+// requireToken rejects requests that don't carry api.auth_token, either as the basic-auth
+// password (any user name) or as a bearer token. Without a token every request passes.
+func (s *Server) requireToken(next http.Handler) http.Handler {
+	token := s.config.Api.AuthToken
+	if token == "" {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !hasToken(r, token) {
+			w.Header().Set("WWW-Authenticate", `Basic realm="`+authRealm+`", charset="UTF-8"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// hasToken reports whether r carries token, comparing in constant time
+func hasToken(r *http.Request, token string) bool {
+	given := ""
+	if _, password, ok := r.BasicAuth(); ok {
+		given = password
+	} else if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		given = strings.TrimSpace(bearer)
+	}
+	return given != "" && subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1
+}
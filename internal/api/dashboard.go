@@ -0,0 +1,24 @@
+package api
+
+import (
+	"embed"
+	"net/http"
+)
+
+// dashboardFS holds the dashboard page, so the binary needs no files at runtime
+//
+//go:embed dashboard.html
+var dashboardFS embed.FS
+
+// handleDashboard serves the analytics dashboard. The page loads Chart.js from a CDN
+// and reads /stats, /history and /connections/summary, refreshing every 60 seconds.
+func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
+	page, err := dashboardFS.ReadFile("dashboard.html")
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(page)
+}
@@ -0,0 +1,54 @@
+package api
+
+import "sync"
+
+// subscriberBuffer is the number of events held for a subscriber that is behind; later
+// events are dropped for it
+const subscriberBuffer = 256
+
+// EventBroker fans events out to the /events subscribers. Broadcast never blocks, so it
+// is safe to call from the logger: a subscriber that can't keep up misses events.
+type EventBroker struct {
+	mu          sync.Mutex
+	subscribers map[chan string]struct{}
+}
+
+// NewEventBroker creates an event broker without subscribers
+func NewEventBroker() *EventBroker {
+	return &EventBroker{subscribers: make(map[chan string]struct{})}
+}
+
+// Subscribe returns a channel receiving every event broadcast from now on. Pass it to
+// Unsubscribe when done.
+func (b *EventBroker) Subscribe() chan string {
+	ch := make(chan string, subscriberBuffer)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+	return ch
+}
+
+// Unsubscribe stops sending events to ch and closes it
+func (b *EventBroker) Unsubscribe(ch chan string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[ch]; ok {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
+
+// Broadcast sends event to every subscriber with room for it
+func (b *EventBroker) Broadcast(event string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
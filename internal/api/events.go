@@ -0,0 +1,52 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// eventsKeepAlive is how often /events sends a comment line, so proxies keep idle
+// streams open
+const eventsKeepAlive = 30 * time.Second
+
+// handleEvents streams the broker's events (the bot's log lines, as JSON) as server-sent
+// events until the client disconnects
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		s.writeError(w, http.StatusInternalServerError, errors.New("streaming is not supported"))
+		return
+	}
+
+	events := s.broker.Subscribe()
+	defer s.broker.Unsubscribe(events)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(eventsKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		case line := <-events:
+			// A data field ends at a newline, so each line of a multi-line event gets its own
+			for _, part := range strings.Split(strings.TrimRight(line, "\n"), "\n") {
+				fmt.Fprintf(w, "data: %s\n", part)
+			}
+			fmt.Fprint(w, "\n")
+			flusher.Flush()
+		}
+	}
+}
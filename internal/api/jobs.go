@@ -0,0 +1,202 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"linkedin-automation/internal/core"
+	"linkedin-automation/pkg/utils"
+)
+
+// maxJobWait caps the wait parameter of GET /jobs/{id}
+const maxJobWait = 2 * time.Minute
+
+// jobPollInterval is how often a waiting GET /jobs/{id} rereads the job
+const jobPollInterval = time.Second
+
+// maxJobRequestBytes caps the POST /jobs body
+const maxJobRequestBytes = 64 << 10
+
+// JobRequest is the POST /jobs body
+type JobRequest struct {
+	Mode     string `json:"mode"`     // core.JobMode* value
+	Keyword  string `json:"keyword"`  // connect only
+	Location string `json:"location"` // connect only
+	Campaign string `json:"campaign"`
+	Budget   int    `json:"budget"` // Most connection requests to send, connect only
+}
+
+// JobView is the GET /jobs/{id} response: the job and, once finished, its run's counts
+type JobView struct {
+	*core.Job
+	Run *core.RunMetadata `json:"run,omitempty"`
+}
+
+// handleCreateJob queues a job for 'bot serve' and answers 202 with the job and its URL
+func (s *Server) handleCreateJob(w http.ResponseWriter, r *http.Request) {
+	var req JobRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxJobRequestBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, fmt.Errorf("invalid job: %w", err))
+		return
+	}
+
+	job := &core.Job{
+		Mode:     req.Mode,
+		Keyword:  req.Keyword,
+		Location: req.Location,
+		Campaign: req.Campaign,
+		Budget:   req.Budget,
+	}
+	if err := job.Validate(); err != nil {
+		s.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := s.repository.CreateJob(r.Context(), job); err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Location", "/jobs/"+strconv.FormatUint(uint64(job.ID), 10))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	s.writeJSON(w, &JobView{Job: job})
+}
+
+// handleGetJob returns a job. With wait=N it long-polls: it answers once the job's status
+// differs from the one it had when the request came in, or after N seconds (at most 120).
+func (s *Server) handleGetJob(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 0)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, fmt.Errorf("invalid job id %q", r.PathValue("id")))
+		return
+	}
+
+	ctx := r.Context()
+	job, err := s.repository.GetJob(ctx, uint(id))
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if job == nil {
+		s.writeError(w, http.StatusNotFound, fmt.Errorf("job %d not found", id))
+		return
+	}
+
+	wait := time.Duration(queryInt(r, "wait", 0)) * time.Second
+	if wait > maxJobWait {
+		wait = maxJobWait
+	}
+	if wait > 0 && !job.Status.Done() {
+		job, err = s.waitForJob(r, job, wait)
+		if err != nil {
+			s.writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	view := &JobView{Job: job}
+	if job.RunID != "" {
+		if view.Run, err = s.repository.GetRunMetadata(ctx, job.RunID); err != nil {
+			s.writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	s.writeJSON(w, view)
+}
+
+// waitForJob rereads job until its status changes, wait passes or the client goes away,
+// and returns the latest copy
+func (s *Server) waitForJob(r *http.Request, job *core.Job, wait time.Duration) (*core.Job, error) {
+	ctx := r.Context()
+	timeout := time.NewTimer(wait)
+	defer timeout.Stop()
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return job, nil
+		case <-timeout.C:
+			return job, nil
+		case <-ticker.C:
+		}
+
+		latest, err := s.repository.GetJob(ctx, job.ID)
+		if ctx.Err() != nil {
+			return job, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if latest == nil {
+			return job, nil
+		}
+		if latest.Status != job.Status {
+			return latest, nil
+		}
+	}
+}
+
+// MessageBudget is today's follow-up message count against messaging.daily_limit
+type MessageBudget struct {
+	SentToday int64 `json:"sent_today"`
+	Limit     int   `json:"limit"`
+	Remaining int64 `json:"remaining"`
+}
+
+// LimitsView is the GET /limits response
+type LimitsView struct {
+	Connections        *core.ConnectionSummary `json:"connections"`
+	Messages           MessageBudget           `json:"messages"`
+	WorkingHours       string                  `json:"working_hours"`
+	WithinWorkingHours bool                    `json:"within_working_hours"`
+}
+
+// handleLimits returns what is left of today's connection and message limits and
+// whether a run started now would be within working hours
+func (s *Server) handleLimits(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	now := time.Now()
+	if loc, err := s.config.Database.Location(); err == nil {
+		now = now.In(loc)
+	}
+	connections, err := core.GetDailyConnectionSummary(ctx, s.repository, &s.config.Limits, now)
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	messageLimit := s.config.Messaging.DailyLimit
+	if messageLimit <= 0 {
+		messageLimit = 20 // Default fallback
+	}
+	sent, err := s.repository.GetTodayActionCount(ctx, "Message")
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	remaining := int64(messageLimit) - sent
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	limits := &s.config.Limits
+	within, err := utils.IsWithinWorkingHours(limits.WorkingHoursStart, limits.WorkingHoursEnd)
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	s.writeJSON(w, &LimitsView{
+		Connections:        connections,
+		Messages:           MessageBudget{SentToday: sent, Limit: messageLimit, Remaining: remaining},
+		WorkingHours:       limits.WorkingHoursStart + "-" + limits.WorkingHoursEnd,
+		WithinWorkingHours: within,
+	})
+}
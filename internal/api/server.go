@@ -0,0 +1,361 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// statsDays is how far back /stats looks
+const statsDays = 30
+
+// Server is a read-only REST API over the bot's database
+type Server struct {
+	repository core.RepositoryPort
+	config     *core.Config
+	logger     *zap.Logger
+	broker     *EventBroker
+}
+
+// NewServer creates a new API server
+func NewServer(repo core.RepositoryPort, config *core.Config, logger *zap.Logger) *Server {
+	return &Server{
+		repository: repo,
+		config:     config,
+		logger:     logger,
+	}
+}
+
+// SetEventBroker makes /events stream the broker's events when api.sse_enabled is set
+func (s *Server) SetEventBroker(broker *EventBroker) {
+	s.broker = broker
+}
+
+// Handler returns the API routes: /stats, /stats/open-to-work-rate, /history, /profiles, /runs, /connections/summary,
+// /limits, /jobs/{id}, the /api/status, /api/profiles, /api/runs and /api/followups views
+// and, when enabled, their /ui/ pages, /dashboard, /events and POST /jobs. With
+// api.auth_token every route needs the token.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /stats", s.handleStats)
+	mux.HandleFunc("GET /stats/open-to-work-rate", s.handleOpenToWorkRate)
+	mux.HandleFunc("GET /history", s.handleHistory)
+	mux.HandleFunc("GET /profiles", s.handleProfiles)
+	mux.HandleFunc("GET /runs", s.handleRuns)
+	mux.HandleFunc("GET /connections/summary", s.handleConnectionSummary)
+	mux.HandleFunc("GET /limits", s.handleLimits)
+	mux.HandleFunc("GET /jobs/{id}", s.handleGetJob)
+	if s.config.Api.JobsEnabled {
+		mux.HandleFunc("POST /jobs", s.handleCreateJob)
+	}
+	s.handleView(mux, "status", s.loadStatus)
+	s.handleView(mux, "profiles", s.loadProfiles)
+	s.handleView(mux, "runs", s.loadRuns)
+	s.handleView(mux, "followups", s.loadFollowups)
+	if s.config.Api.DashboardEnabled {
+		mux.HandleFunc("GET /dashboard", s.handleDashboard)
+		mux.Handle("GET /{$}", http.RedirectHandler("/ui/status", http.StatusFound))
+	}
+	if s.config.Api.SSEEnabled && s.broker != nil {
+		mux.HandleFunc("GET /events", s.handleEvents)
+	}
+	return s.requireToken(mux)
+}
+
+// Run serves the API on api.listen until ctx is cancelled
+func (s *Server) Run(ctx context.Context) error {
+	listen := s.config.Api.Listen
+	if listen == "" {
+		listen = "127.0.0.1:8080" // Default fallback
+	}
+
+	srv := &http.Server{
+		Addr:              listen,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	s.logger.Info("API server listening",
+		zap.String("address", listen),
+		zap.Bool("dashboard", s.config.Api.DashboardEnabled),
+		zap.Bool("auth", s.config.Api.AuthToken != ""),
+		zap.Bool("jobs", s.config.Api.JobsEnabled),
+	)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Stats is the /stats response
+type Stats struct {
+	DailyConnections []core.StatsBucket           `json:"daily_connections"` // Connection requests per day, past 30 days
+	StatusCounts     map[core.ProfileStatus]int64 `json:"status_counts"`     // Profiles per status
+	AcceptanceRate   float64                      `json:"acceptance_rate"`   // Accepted / requests sent, past 30 days
+	ReplyRate        float64                      `json:"reply_rate"`        // Replied / messaged profiles
+}
+
+// profileStatuses are the statuses counted in /stats
+var profileStatuses = []core.ProfileStatus{
+	core.ProfileStatusDiscovered,
+	core.ProfileStatusScanned,
+	core.ProfileStatusRequestSent,
+	core.ProfileStatusConnected,
+	core.ProfileStatusMessageSent,
+	core.ProfileStatusMessageRestricted,
+	core.ProfileStatusReplied,
+	core.ProfileStatusIgnored,
+	core.ProfileStatusExpired,
+	core.ProfileStatusFailed,
+}
+
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	end := time.Now()
+	start := end.AddDate(0, 0, -statsDays)
+
+	projection, err := s.repository.GetDailyProjection(ctx, start, end)
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	stats := &Stats{
+		DailyConnections: dailyBuckets(projection, "Connect"),
+		StatusCounts:     make(map[core.ProfileStatus]int64),
+	}
+	for _, status := range profileStatuses {
+		count, err := s.repository.CountProfilesByStatus(ctx, status)
+		if err != nil {
+			s.writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if count > 0 {
+			stats.StatusCounts[status] = count
+		}
+	}
+
+	rates, err := s.repository.GetAcceptanceRateByDay(ctx, start, end)
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	var sent, accepted int64
+	for _, rate := range rates {
+		sent += rate.RequestsSent
+		accepted += rate.Accepted
+	}
+	if sent > 0 {
+		stats.AcceptanceRate = float64(accepted) / float64(sent)
+	}
+
+	replied := stats.StatusCounts[core.ProfileStatusReplied]
+	if messaged := replied + stats.StatusCounts[core.ProfileStatusMessageSent]; messaged > 0 {
+		stats.ReplyRate = float64(replied) / float64(messaged)
+	}
+
+	s.writeJSON(w, stats)
+}
+
+// handleOpenToWorkRate returns how many checked profiles show the "Open to Work" frame
+func (s *Server) handleOpenToWorkRate(w http.ResponseWriter, r *http.Request) {
+	rate, err := s.repository.GetOpenToWorkRate(r.Context())
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	s.writeJSON(w, rate)
+}
+
+// handleHistory returns a page of history entries filtered by the query parameters
+// action_type (comma-separated or repeated), profile_url, outcome, start and end
+// (RFC 3339 or YYYY-MM-DD), min_count and max_count (the details' found count), order_by
+// (see core.HistoryOrder), limit and offset. The number of matching entries on all pages
+// is sent in X-Total-Count.
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	query, err := s.historyQuery(r)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	histories, total, err := s.repository.QueryHistory(r.Context(), query)
+	if errors.Is(err, core.ErrInvalidHistoryOrder) {
+		s.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("X-Total-Count", strconv.FormatInt(total, 10))
+	s.writeJSON(w, histories)
+}
+
+// historyQuery reads the /history query parameters
+func (s *Server) historyQuery(r *http.Request) (core.HistoryQuery, error) {
+	values := r.URL.Query()
+	query := core.HistoryQuery{
+		ProfileURL: values.Get("profile_url"),
+		Outcome:    values.Get("outcome"),
+		MinCount:   queryInt(r, "min_count", 0),
+		MaxCount:   queryInt(r, "max_count", 0),
+		OrderBy:    core.HistoryOrder(values.Get("order_by")),
+		Pagination: core.Pagination{
+			Limit:  queryInt(r, "limit", 20),
+			Offset: queryInt(r, "offset", 0),
+		},
+	}
+	for _, value := range values["action_type"] {
+		for _, actionType := range strings.Split(value, ",") {
+			if actionType = strings.TrimSpace(actionType); actionType != "" {
+				query.ActionTypes = append(query.ActionTypes, actionType)
+			}
+		}
+	}
+
+	var err error
+	if query.Start, err = s.queryTime(r, "start"); err != nil {
+		return query, err
+	}
+	if query.End, err = s.queryTime(r, "end"); err != nil {
+		return query, err
+	}
+	return query, nil
+}
+
+// queryTime reads an RFC 3339 time or a YYYY-MM-DD date, which is midnight in
+// database.timezone, or returns nil when the parameter is absent
+func (s *Server) queryTime(r *http.Request, name string) (*time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+
+	loc, err := s.config.Database.Location()
+	if err != nil {
+		loc = time.Local
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: use RFC 3339 or YYYY-MM-DD", name, value)
+	}
+	return &t, nil
+}
+
+func (s *Server) handleProfiles(w http.ResponseWriter, r *http.Request) {
+	limit := queryInt(r, "limit", 100)
+
+	filter := &core.ProfileFilter{
+		Status:   core.ProfileStatus(r.URL.Query().Get("status")),
+		Campaign: r.URL.Query().Get("campaign"),
+	}
+	ctx := r.Context()
+	if queryBool(r, "include_archived") {
+		ctx = core.WithArchivedProfiles(ctx)
+	}
+	profiles, err := s.repository.SearchProfiles(ctx, filter)
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if len(profiles) > limit {
+		profiles = profiles[:limit]
+	}
+
+	s.writeJSON(w, profiles)
+}
+
+func (s *Server) handleRuns(w http.ResponseWriter, r *http.Request) {
+	runs, err := s.repository.ListRunMetadata(r.Context(), queryInt(r, "limit", 50))
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	s.writeJSON(w, runs)
+}
+
+func (s *Server) handleConnectionSummary(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	if loc, err := s.config.Database.Location(); err == nil {
+		now = now.In(loc)
+	}
+	summary, err := core.GetDailyConnectionSummary(r.Context(), s.repository, &s.config.Limits, now)
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	s.writeJSON(w, summary)
+}
+
+// writeJSON writes v as the JSON response body
+func (s *Server) writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		s.logger.Warn("Failed to write API response", zap.Error(err))
+	}
+}
+
+// writeError logs err and writes it as a JSON error response
+func (s *Server) writeError(w http.ResponseWriter, status int, err error) {
+	s.logger.Warn("API request failed", zap.Error(err))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+// queryInt reads a positive integer query parameter, or returns fallback
+func queryInt(r *http.Request, name string, fallback int) int {
+	value, err := strconv.Atoi(r.URL.Query().Get(name))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// queryBool reads a boolean query parameter such as "true" or "1"; anything else is false
+func queryBool(r *http.Request, name string) bool {
+	value, _ := strconv.ParseBool(r.URL.Query().Get(name))
+	return value
+}
+
+// dailyBuckets sums an action type's projection rows over keywords into one bucket per day
+func dailyBuckets(projection []*core.DailyProjection, actionType string) []core.StatsBucket {
+	buckets := make([]core.StatsBucket, 0)
+	for _, row := range projection {
+		if row.ActionType != actionType {
+			continue
+		}
+		if n := len(buckets); n == 0 || !buckets[n-1].BucketStart.Equal(row.Date) {
+			buckets = append(buckets, core.StatsBucket{BucketStart: row.Date.UTC()})
+		}
+		bucket := &buckets[len(buckets)-1]
+		bucket.Count += row.Count
+		bucket.SuccessCount += row.SuccessCount
+		bucket.ErrorCount += row.Count - row.SuccessCount
+	}
+	return buckets
+}
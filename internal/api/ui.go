@@ -0,0 +1,321 @@
+package api
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"linkedin-automation/internal/core"
+	"linkedin-automation/pkg/utils"
+
+	"go.uber.org/zap"
+)
+
+// inviteLimitWindow is how long after LinkedIn refused invitations the status page flags it;
+// the limit is weekly
+const inviteLimitWindow = 7 * 24 * time.Hour
+
+// uiFS holds the web UI templates, so the binary needs no files at runtime
+//
+//go:embed ui/*.html
+var uiFS embed.FS
+
+// uiTemplates maps each view to its page parsed together with the shared layout
+var uiTemplates = func() map[string]*template.Template {
+	templates := make(map[string]*template.Template)
+	for _, view := range []string{"status", "profiles", "runs", "followups"} {
+		templates[view] = template.Must(template.ParseFS(uiFS, "ui/layout.html", "ui/"+view+".html"))
+	}
+	return templates
+}()
+
+// view loads the data of one page; the same data is rendered at /ui/<name> and encoded
+// at /api/<name>. Errors of type badRequest are answered with 400.
+type view func(r *http.Request) (interface{}, error)
+
+// badRequest marks an error caused by the request's parameters
+type badRequest struct{ error }
+
+// handleView registers name's JSON endpoint and, with api.dashboard_enabled, its page
+func (s *Server) handleView(mux *http.ServeMux, name string, load view) {
+	mux.HandleFunc("GET /api/"+name, func(w http.ResponseWriter, r *http.Request) {
+		data, err := load(r)
+		if err != nil {
+			s.writeError(w, viewErrorStatus(err), err)
+			return
+		}
+		s.writeJSON(w, data)
+	})
+
+	if !s.config.Api.DashboardEnabled {
+		return
+	}
+	mux.HandleFunc("GET /ui/"+name, func(w http.ResponseWriter, r *http.Request) {
+		data, err := load(r)
+		if err != nil {
+			s.logger.Warn("UI request failed", zap.String("page", name), zap.Error(err))
+			http.Error(w, err.Error(), viewErrorStatus(err))
+			return
+		}
+		s.renderPage(w, name, data)
+	})
+}
+
+// viewErrorStatus is 400 for badRequest errors and 500 otherwise
+func viewErrorStatus(err error) int {
+	if _, ok := err.(badRequest); ok {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// renderPage renders name's template with data; it renders into a buffer first so a
+// template error still gets a clean 500
+func (s *Server) renderPage(w http.ResponseWriter, name string, data interface{}) {
+	var buf bytes.Buffer
+	if err := uiTemplates[name].ExecuteTemplate(&buf, "layout", map[string]interface{}{
+		"Page": name,
+		"Data": data,
+	}); err != nil {
+		s.logger.Warn("Failed to render UI page", zap.String("page", name), zap.Error(err))
+		http.Error(w, "failed to render page", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(buf.Bytes())
+}
+
+// StatusFlag is a condition that stops or pauses outreach
+type StatusFlag struct {
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// StatusView is today's activity and whether outreach can currently proceed
+type StatusView struct {
+	GeneratedAt  time.Time               `json:"generated_at"`
+	Date         string                  `json:"date"`          // Today, YYYY-MM-DD in database.timezone
+	Actions      map[string]int64        `json:"actions"`       // History entries per action type since midnight
+	Budget       *core.ConnectionSummary `json:"budget"`        // Connection requests sent and left today
+	WorkingHours string                  `json:"working_hours"` // limits.working_hours_start-end
+	Flags        []StatusFlag            `json:"flags"`
+}
+
+// loadStatus builds the status page. Flags cover recent throttling (within
+// stealth.throttle_recovery_pause_minutes), LinkedIn's weekly invitation limit (within
+// the past week), an exhausted daily budget and being outside working hours.
+func (s *Server) loadStatus(r *http.Request) (interface{}, error) {
+	ctx := r.Context()
+	now := time.Now()
+	if loc, err := s.config.Database.Location(); err == nil {
+		now = now.In(loc)
+	}
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	actions, err := s.repository.GetActionCountsByType(ctx, midnight, midnight.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count today's actions: %w", err)
+	}
+	budget, err := core.GetDailyConnectionSummary(ctx, s.repository, &s.config.Limits, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize today's connections: %w", err)
+	}
+
+	limits := &s.config.Limits
+	status := &StatusView{
+		GeneratedAt:  now,
+		Date:         now.Format("2006-01-02"),
+		Actions:      actions,
+		Budget:       budget,
+		WorkingHours: limits.WorkingHoursStart + "-" + limits.WorkingHoursEnd,
+	}
+
+	pauseMinutes := s.config.Stealth.ThrottleRecoveryPauseMinutes
+	if pauseMinutes <= 0 {
+		pauseMinutes = 15 // Default fallback
+	}
+	throttled, err := s.lastHistory(r, "ThrottleDetected", now.Add(-time.Duration(pauseMinutes)*time.Minute))
+	if err != nil {
+		return nil, err
+	}
+	flag := StatusFlag{Name: "throttled", Active: throttled != nil}
+	if throttled != nil {
+		flag.Detail = fmt.Sprintf("Page loads slowed down at %s; actions pause %d minutes", throttled.Timestamp.In(now.Location()).Format("15:04"), pauseMinutes)
+	}
+	status.Flags = append(status.Flags, flag)
+
+	inviteLimit, err := s.lastHistory(r, "InviteLimitReached", now.Add(-inviteLimitWindow))
+	if err != nil {
+		return nil, err
+	}
+	flag = StatusFlag{Name: "invite_limit_reached", Active: inviteLimit != nil}
+	if inviteLimit != nil {
+		flag.Detail = "LinkedIn refused invitations on " + inviteLimit.Timestamp.In(now.Location()).Format("Jan 2 15:04")
+	}
+	status.Flags = append(status.Flags, flag)
+
+	flag = StatusFlag{Name: "daily_limit_reached", Active: budget.Limit > 0 && budget.Remaining == 0}
+	if flag.Active {
+		flag.Detail = fmt.Sprintf("%d of %d connection requests sent today", budget.SentToday, budget.Limit)
+	}
+	status.Flags = append(status.Flags, flag)
+
+	within, err := utils.IsWithinWorkingHours(limits.WorkingHoursStart, limits.WorkingHoursEnd)
+	if err != nil {
+		return nil, fmt.Errorf("invalid working hours: %w", err)
+	}
+	status.Flags = append(status.Flags, StatusFlag{Name: "outside_working_hours", Active: !within})
+
+	return status, nil
+}
+
+// lastHistory returns the newest actionType entry since since, or nil
+func (s *Server) lastHistory(r *http.Request, actionType string, since time.Time) (*core.History, error) {
+	histories, _, err := s.repository.QueryHistory(r.Context(), core.HistoryQuery{
+		ActionTypes: []string{actionType},
+		Start:       &since,
+		Pagination:  core.Pagination{Limit: 1},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s history: %w", actionType, err)
+	}
+	if len(histories) == 0 {
+		return nil, nil
+	}
+	return histories[0], nil
+}
+
+// ProfilesView is one page of profiles matching a status, campaign and search text
+type ProfilesView struct {
+	Status          core.ProfileStatus   `json:"status,omitempty"`
+	Campaign        string               `json:"campaign,omitempty"`
+	Query           string               `json:"q,omitempty"`
+	IncludeArchived bool                 `json:"include_archived,omitempty"`
+	Total           int64                `json:"total"`
+	Limit           int                  `json:"limit"`
+	Offset          int                  `json:"offset"`
+	Profiles        []*core.Profile      `json:"profiles"`
+	Statuses        []core.ProfileStatus `json:"-"` // Choices of the status filter
+}
+
+// PageURL returns the query string of the page starting at offset, keeping the filters
+func (v *ProfilesView) PageURL(offset int) string {
+	values := url.Values{}
+	if v.Status != "" {
+		values.Set("status", string(v.Status))
+	}
+	if v.Campaign != "" {
+		values.Set("campaign", v.Campaign)
+	}
+	if v.Query != "" {
+		values.Set("q", v.Query)
+	}
+	if v.IncludeArchived {
+		values.Set("include_archived", "true")
+	}
+	values.Set("limit", strconv.Itoa(v.Limit))
+	values.Set("offset", strconv.Itoa(offset))
+	return "?" + values.Encode()
+}
+
+// PrevOffset is the offset of the previous page, or -1 on the first page
+func (v *ProfilesView) PrevOffset() int {
+	if v.Offset == 0 {
+		return -1
+	}
+	if v.Offset < v.Limit {
+		return 0
+	}
+	return v.Offset - v.Limit
+}
+
+// NextOffset is the offset of the next page, or -1 on the last page
+func (v *ProfilesView) NextOffset() int {
+	if int64(v.Offset+v.Limit) >= v.Total {
+		return -1
+	}
+	return v.Offset + v.Limit
+}
+
+// loadProfiles reads the query parameters status, campaign, q (a substring of the URL,
+// name, headline or company), include_archived, limit and offset
+func (s *Server) loadProfiles(r *http.Request) (interface{}, error) {
+	values := r.URL.Query()
+	v := &ProfilesView{
+		Status:          core.ProfileStatus(values.Get("status")),
+		Campaign:        values.Get("campaign"),
+		Query:           values.Get("q"),
+		IncludeArchived: queryBool(r, "include_archived"),
+		Limit:           queryInt(r, "limit", 50),
+		Offset:          queryInt(r, "offset", 0),
+		Statuses:        profileStatuses,
+	}
+	if v.Status != "" && !slices.Contains(profileStatuses, v.Status) {
+		return nil, badRequest{fmt.Errorf("invalid status %q", v.Status)}
+	}
+
+	ctx := r.Context()
+	if v.IncludeArchived {
+		ctx = core.WithArchivedProfiles(ctx)
+	}
+	profiles, total, err := s.repository.SearchProfilesPage(ctx, &core.ProfileFilter{
+		Status:   v.Status,
+		Campaign: v.Campaign,
+		Query:    v.Query,
+	}, core.Pagination{Limit: v.Limit, Offset: v.Offset})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search profiles: %w", err)
+	}
+	v.Profiles = profiles
+	v.Total = total
+	return v, nil
+}
+
+// RunsView lists the latest bot runs, newest first
+type RunsView struct {
+	Runs []*core.RunMetadata `json:"runs"`
+}
+
+// loadRuns reads the query parameter limit
+func (s *Server) loadRuns(r *http.Request) (interface{}, error) {
+	runs, err := s.repository.ListRunMetadata(r.Context(), queryInt(r, "limit", 50))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list runs: %w", err)
+	}
+	return &RunsView{Runs: runs}, nil
+}
+
+// FollowupsView lists the connections the next -followup run would message
+type FollowupsView struct {
+	MinDaysSinceConnected int             `json:"min_days_since_connected"`
+	MaxDaysSinceConnected int             `json:"max_days_since_connected"`
+	Profiles              []*core.Profile `json:"profiles"`
+}
+
+// loadFollowups applies the messaging follow-up window and reads the query parameter limit
+func (s *Server) loadFollowups(r *http.Request) (interface{}, error) {
+	messaging := &s.config.Messaging
+	profiles, err := s.repository.GetPendingFollowups(r.Context(), queryInt(r, "limit", 50), &core.FollowUpWindow{
+		MinDaysSinceConnected:     messaging.MinDaysSinceConnected,
+		MaxDaysSinceConnected:     messaging.MaxDaysSinceConnected,
+		Order:                     messaging.FollowUpOrder,
+		IncludeUnknownConnectedAt: messaging.IncludeUnknownConnectedAt,
+		Sequence:                  messaging.Sequence,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending follow-ups: %w", err)
+	}
+	return &FollowupsView{
+		MinDaysSinceConnected: messaging.MinDaysSinceConnected,
+		MaxDaysSinceConnected: messaging.MaxDaysSinceConnected,
+		Profiles:              profiles,
+	}, nil
+}
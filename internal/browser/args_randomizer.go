@@ -0,0 +1,90 @@
+package browser
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+)
+
+// optionalLaunchArg is a Chrome flag that is included in some sessions and not others.
+// Values, when set, are alternatives of which one is picked per session.
+type optionalLaunchArg struct {
+	Flag   string
+	Values []string
+}
+
+// optionalLaunchArgs are flags that don't affect automation but change the browser's
+// fingerprint, so varying them keeps sessions from sharing one exact argument set
+var optionalLaunchArgs = []optionalLaunchArg{
+	{Flag: "--disable-features", Values: []string{"VizDisplayCompositor"}},
+	{Flag: "--force-device-scale-factor", Values: []string{"1"}},
+	{Flag: "--enable-features", Values: []string{"NetworkServiceInProcess"}},
+	{Flag: "--memory-pressure-off"},
+	{Flag: "--js-flags", Values: []string{"--max_old_space_size=2048", "--max_old_space_size=3072", "--max_old_space_size=4096"}},
+}
+
+// LaunchArgsRandomizer varies optional Chrome launch flags between sessions
+type LaunchArgsRandomizer struct {
+	optional []optionalLaunchArg
+}
+
+// NewLaunchArgsRandomizer creates a randomizer over the curated optional flags
+func NewLaunchArgsRandomizer() *LaunchArgsRandomizer {
+	return &LaunchArgsRandomizer{optional: optionalLaunchArgs}
+}
+
+// Randomize returns base with each optional flag independently added or removed.
+// Flags in base that aren't optional are required and always kept. Selection uses
+// crypto/rand so sessions started at the same moment still differ.
+func (r *LaunchArgsRandomizer) Randomize(base []string) []string {
+	args := make([]string, 0, len(base)+len(r.optional))
+	for _, arg := range base {
+		if !r.isOptional(arg) {
+			args = append(args, arg)
+		}
+	}
+
+	for _, opt := range r.optional {
+		if randomInt(2) == 0 {
+			continue
+		}
+		if len(opt.Values) == 0 {
+			args = append(args, opt.Flag)
+			continue
+		}
+		args = append(args, opt.Flag+"="+opt.Values[randomInt(len(opt.Values))])
+	}
+
+	return args
+}
+
+// isOptional reports whether arg is exactly one of the optional flags (any of its values)
+func (r *LaunchArgsRandomizer) isOptional(arg string) bool {
+	name, value, _ := strings.Cut(arg, "=")
+	for _, opt := range r.optional {
+		if opt.Flag != name {
+			continue
+		}
+		if len(opt.Values) == 0 {
+			return value == ""
+		}
+		for _, v := range opt.Values {
+			if v == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// randomInt returns a uniform random number in [0, n) from crypto/rand
+func randomInt(n int) int {
+	if n <= 1 {
+		return 0
+	}
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0
+	}
+	return int(v.Int64())
+}
@@ -0,0 +1,61 @@
+package browser
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// HumanClickVerified clicks selector like HumanClick, then waits up to verifyTimeout for
+// verificationSelector to appear. LinkedIn's React handlers sometimes miss a click that
+// reached the page, so when nothing appears the click is retried once with JSClick.
+//
+// An empty verificationSelector falls back to browser.click_verification.selector_after_click.
+// With browser.click_verification.enabled off, or no selector at all, this is HumanClick.
+func (b *Instance) HumanClickVerified(ctx context.Context, selector, verificationSelector string, verifyTimeout time.Duration) error {
+	verification := b.config.Browser.ClickVerification
+	if verificationSelector == "" {
+		verificationSelector = verification.SelectorAfterClick
+	}
+	if !verification.Enabled || verificationSelector == "" {
+		return b.HumanClick(ctx, selector)
+	}
+	if verifyTimeout <= 0 {
+		verifyTimeout = 5 * time.Second // Default fallback
+	}
+
+	if err := b.HumanClick(ctx, selector); err != nil {
+		return err
+	}
+	if err := b.WaitForElement(ctx, verificationSelector, verifyTimeout); err == nil {
+		b.logger.Debug("Click verified",
+			zap.String("selector", selector),
+			zap.String("verification", verificationSelector),
+		)
+		return nil
+	}
+
+	b.logger.Debug("Click not registered, retrying with JavaScript",
+		zap.String("selector", selector),
+		zap.String("verification", verificationSelector),
+		zap.Duration("timeout", verifyTimeout),
+	)
+	if err := b.JSClick(ctx, selector); err != nil {
+		return fmt.Errorf("fallback click failed: %w", err)
+	}
+	if err := b.WaitForElement(ctx, verificationSelector, verifyTimeout); err != nil {
+		b.logger.Debug("Click verification failed after fallback",
+			zap.String("selector", selector),
+			zap.String("verification", verificationSelector),
+		)
+		return fmt.Errorf("click on %s not registered: %s did not appear: %w", selector, verificationSelector, err)
+	}
+
+	b.logger.Debug("Click verified after JavaScript fallback",
+		zap.String("selector", selector),
+		zap.String("verification", verificationSelector),
+	)
+	return nil
+}
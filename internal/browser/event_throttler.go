@@ -0,0 +1,97 @@
+package browser
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"linkedin-automation/internal/core"
+	"linkedin-automation/internal/stealth"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// EventThrottler dispatches mouse-move events in small batches at jittered intervals,
+// rather than one event at a fixed pace, so their timing doesn't form a regular pattern
+type EventThrottler struct {
+	batchSize   int
+	minInterval time.Duration
+	maxInterval time.Duration
+}
+
+// NewEventThrottler creates a throttler from browser.event_batch_size and
+// browser.event_interval_ms
+func NewEventThrottler(config *core.BrowserConfig) *EventThrottler {
+	batchSize := config.EventBatchSize
+	if batchSize <= 0 {
+		batchSize = 3 // Default fallback
+	}
+
+	minMs, maxMs := 8, 25 // Default fallback
+	if len(config.EventIntervalMs) == 2 && config.EventIntervalMs[0] > 0 && config.EventIntervalMs[1] >= config.EventIntervalMs[0] {
+		minMs, maxMs = config.EventIntervalMs[0], config.EventIntervalMs[1]
+	}
+
+	return &EventThrottler{
+		batchSize:   batchSize,
+		minInterval: time.Duration(minMs) * time.Millisecond,
+		maxInterval: time.Duration(maxMs) * time.Millisecond,
+	}
+}
+
+// newDebugEventThrottler moves one point every 50ms, slow enough to follow with the eye
+func newDebugEventThrottler() *EventThrottler {
+	return &EventThrottler{batchSize: 1, minInterval: 50 * time.Millisecond, maxInterval: 50 * time.Millisecond}
+}
+
+// Flush dispatches events back to back, then waits a random interval before the next
+// batch may go out. It stops at the first event that fails.
+func (t *EventThrottler) Flush(page *rod.Page, events []*proto.InputDispatchMouseEvent) error {
+	for _, event := range events {
+		if err := event.Call(page); err != nil {
+			return err
+		}
+	}
+
+	time.Sleep(t.interval())
+	return nil
+}
+
+// Move dispatches a mouse-move event per path point, batchSize points per Flush. A batch
+// that fails is passed to onError and the movement goes on with the next one.
+func (t *EventThrottler) Move(ctx context.Context, page *rod.Page, path []stealth.Point, onError func(error)) error {
+	batch := make([]*proto.InputDispatchMouseEvent, 0, t.batchSize)
+	for i, point := range path {
+		batch = append(batch, &proto.InputDispatchMouseEvent{
+			Type: proto.InputDispatchMouseEventTypeMouseMoved,
+			X:    point.X,
+			Y:    point.Y,
+		})
+		if len(batch) < t.batchSize && i < len(path)-1 {
+			continue
+		}
+
+		if err := t.Flush(page, batch); err != nil {
+			onError(err)
+		}
+		batch = batch[:0]
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+
+	return nil
+}
+
+// interval picks the pause after a batch, uniformly between the configured bounds
+func (t *EventThrottler) interval() time.Duration {
+	spread := t.maxInterval - t.minInterval
+	if spread <= 0 {
+		return t.minInterval
+	}
+	return t.minInterval + time.Duration(rand.Int63n(int64(spread)+1))
+}
@@ -5,7 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"net/http"
 	"os"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/go-rod/rod/lib/input"
@@ -15,6 +18,7 @@ import (
 
 	"github.com/go-rod/rod"
 	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/rod/lib/launcher/flags"
 	"github.com/go-rod/rod/lib/proto"
 	rodstealth "github.com/go-rod/stealth"
 	"go.uber.org/zap"
@@ -22,33 +26,108 @@ import (
 
 // Instance wraps Rod browser with stealth features
 type Instance struct {
-	browser *rod.Browser
-	page    *rod.Page
-	stealth *stealth.Stealth
-	config  *core.Config
-	logger  *zap.Logger
-	mouseX  float64
-	mouseY  float64
+	browser     *rod.Browser
+	page        *rod.Page
+	pages       map[string]*rod.Page // Named tabs, including the default page
+	currentPage string
+	stealth     *stealth.Stealth
+	drifter     *stealth.IdleDrifter
+	config      *core.Config
+	logger      *zap.Logger
+	mouseX      float64
+	mouseY      float64
+	latency     *LatencyTracker
+	throttler   *EventThrottler // Paces the mouse-move events of clicks
+	onThrottle  ThrottleHandler
+	onPageLoad  PageLoadHandler
+	metrics     *core.AppMetrics
 }
 
+// ThrottleHandler is called when slow page loads suggest LinkedIn is throttling the session
+type ThrottleHandler func(ctx context.Context, recentAvg, median time.Duration)
+
+// PageLoadHandler is called with the timings of every page load, e.g. to store them
+type PageLoadHandler func(ctx context.Context, url string, metrics *core.PageMetrics)
+
+// slowPageLoad is the load time above which a page load is reported as slow
+const slowPageLoad = 10 * time.Second
+
+// baseLaunchArgs are the launch flags used when launch arguments are not randomized.
+// --disable-blink-features=AutomationControlled is required and never removed.
+var baseLaunchArgs = []string{
+	"--disable-blink-features=AutomationControlled",
+	"--disable-web-security",
+	"--disable-features=VizDisplayCompositor",
+}
+
+// applyLaunchArgs sets "--name=value" flags on the launcher. Repeated feature lists are
+// merged, since a later flag of the same name would replace the earlier one.
+func applyLaunchArgs(l *launcher.Launcher, args []string) {
+	values := make(map[string][]string)
+	order := make([]string, 0, len(args))
+	for _, arg := range args {
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+		if _, seen := values[name]; !seen {
+			order = append(order, name)
+			values[name] = nil
+		}
+		if hasValue {
+			values[name] = append(values[name], value)
+		}
+	}
+
+	for _, name := range order {
+		if vals := values[name]; len(vals) > 0 {
+			l.Set(flags.Flag(name), strings.Join(vals, ","))
+		} else {
+			l.Set(flags.Flag(name))
+		}
+	}
+}
+
+// defaultPageName is the name of the tab opened by Initialize
+const defaultPageName = "main"
+
 // NewInstance creates a new browser instance
 func NewInstance(cfg *core.Config, stealthEngine *stealth.Stealth, logger *zap.Logger) *Instance {
 	return &Instance{
 		stealth: stealthEngine,
+		drifter: stealth.NewIdleDrifter(&cfg.Stealth.IdleMouseDrift, cfg.Stealth.RandomSeed),
 		config:  cfg,
 		logger:  logger,
+		latency:   NewLatencyTracker(),
+		throttler: NewEventThrottler(&cfg.Browser),
+		metrics:   &core.AppMetrics{},
 	}
 }
 
+// SetThrottleHandler registers a callback for detected throttling, e.g. to record it
+func (b *Instance) SetThrottleHandler(handler ThrottleHandler) {
+	b.onThrottle = handler
+}
+
+// SetPageLoadHandler registers a callback for page load timings
+func (b *Instance) SetPageLoadHandler(handler PageLoadHandler) {
+	b.onPageLoad = handler
+}
+
+// Metrics returns the counters collected by this instance
+func (b *Instance) Metrics() *core.AppMetrics {
+	return b.metrics
+}
+
 // Initialize sets up the browser instance with stealth features
 func (b *Instance) Initialize(ctx context.Context) error {
 	// Launch browser with stealth flags
 	l := launcher.New().
-		Headless(false). // Set to true for production
-		Set("disable-blink-features", "AutomationControlled").
-		Set("disable-features", "IsolateOrigins,site-per-process").
-		Set("disable-web-security").
-		Set("disable-features", "VizDisplayCompositor")
+		Headless(false) // Set to true for production
+
+	args := baseLaunchArgs
+	if b.config.Browser.RandomizeLaunchArgs {
+		args = NewLaunchArgsRandomizer().Randomize(baseLaunchArgs)
+		b.logger.Info("Randomized browser launch arguments", zap.Strings("args", args))
+	}
+	applyLaunchArgs(l, args)
 
 	browserPath, has := launcher.LookPath()
 	if has {
@@ -65,10 +144,33 @@ func (b *Instance) Initialize(ctx context.Context) error {
 		return fmt.Errorf("failed to connect to browser: %w", err)
 	}
 
-	// Create a new page with stealth
-	b.page, err = rodstealth.Page(b.browser)
+	// Create the default page with stealth
+	page, width, height, err := b.newStealthPage()
 	if err != nil {
-		return fmt.Errorf("failed to create stealth page: %w", err)
+		return err
+	}
+	b.page = page
+	b.pages = map[string]*rod.Page{defaultPageName: page}
+	b.currentPage = defaultPageName
+
+	// Initialize mouse position to center of viewport
+	b.mouseX = float64(width) / 2
+	b.mouseY = float64(height) / 2
+
+	// Randomize User-Agent (optional, Rod handles this)
+	b.logger.Info("Browser initialized",
+		zap.Int("width", width),
+		zap.Int("height", height),
+	)
+
+	return nil
+}
+
+// newStealthPage opens a new tab with stealth patches and a randomized viewport
+func (b *Instance) newStealthPage() (*rod.Page, int, int, error) {
+	page, err := rodstealth.Page(b.browser)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to create stealth page: %w", err)
 	}
 
 	// Randomize viewport size
@@ -82,14 +184,10 @@ func (b *Instance) Initialize(ctx context.Context) error {
 	}
 
 	// Set viewport using WindowSize
-	b.page.MustSetViewport(width, height, 0, false)
-
-	// Initialize mouse position to center of viewport
-	b.mouseX = float64(width) / 2
-	b.mouseY = float64(height) / 2
+	page.MustSetViewport(width, height, 0, false)
 
 	// Inject script to hide webdriver property
-	_, err = b.page.Eval(`() => {
+	_, err = page.Eval(`() => {
 try {
 Object.defineProperty(navigator, 'webdriver', {
 get: () => undefined
@@ -100,12 +198,75 @@ get: () => undefined
 		b.logger.Debug("Failed to manually hide webdriver property (likely handled by stealth)", zap.Error(err))
 	}
 
-	// Randomize User-Agent (optional, Rod handles this)
-	b.logger.Info("Browser initialized",
-		zap.Int("width", width),
-		zap.Int("height", height),
-	)
+	return page, width, height, nil
+}
 
+// NewNamedPage opens a new tab and registers it under name. The current page is
+// unchanged until SwitchPage is called.
+func (b *Instance) NewNamedPage(ctx context.Context, name string) (*rod.Page, error) {
+	if name == "" {
+		return nil, fmt.Errorf("page name is required")
+	}
+	if _, exists := b.pages[name]; exists {
+		return nil, fmt.Errorf("page %q already exists", name)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	page, _, _, err := b.newStealthPage()
+	if err != nil {
+		return nil, err
+	}
+
+	b.pages[name] = page
+	b.logger.Info("Opened named page", zap.String("name", name))
+
+	return page, nil
+}
+
+// SwitchPage makes the named page the target of all subsequent browser actions
+func (b *Instance) SwitchPage(name string) error {
+	page, exists := b.pages[name]
+	if !exists {
+		return fmt.Errorf("page %q not found", name)
+	}
+
+	if _, err := page.Activate(); err != nil {
+		return fmt.Errorf("failed to activate page %q: %w", name, err)
+	}
+
+	b.page = page
+	b.currentPage = name
+	return nil
+}
+
+// ClosePage closes a named page. Closing the current page switches back to the default page.
+func (b *Instance) ClosePage(name string) error {
+	if name == defaultPageName {
+		return fmt.Errorf("the default page cannot be closed")
+	}
+
+	page, exists := b.pages[name]
+	if !exists {
+		return fmt.Errorf("page %q not found", name)
+	}
+
+	if b.currentPage == name {
+		if err := b.SwitchPage(defaultPageName); err != nil {
+			return err
+		}
+	}
+
+	delete(b.pages, name)
+	if err := page.Close(); err != nil {
+		return fmt.Errorf("failed to close page %q: %w", name, err)
+	}
+
+	b.logger.Info("Closed named page", zap.String("name", name))
 	return nil
 }
 
@@ -123,16 +284,91 @@ func (b *Instance) Navigate(ctx context.Context, url string) error {
 	// Random delay before navigation
 	b.stealth.RandomSleep(ctx, 0.5, 1.0)
 
-	if err := b.page.Navigate(url); err != nil {
-		return fmt.Errorf("failed to navigate to %s: %w", url, err)
+	start := time.Now()
+	if !b.useKeyboardNavigation() || !b.keyboardNavigate(ctx, url) {
+		if err := b.page.Navigate(url); err != nil {
+			return fmt.Errorf("failed to navigate to %s: %w", url, err)
+		}
 	}
 
 	// Wait for page load with random delay
 	if err := b.page.WaitLoad(); err != nil {
 		return fmt.Errorf("failed to wait for page load: %w", err)
 	}
+	b.latency.Record(time.Since(start))
+
+	// Let the mouse drift a little while the page is "read"
+	x, y, err := b.drifter.Drift(ctx, b.page, b.mouseX, b.mouseY)
+	if err != nil {
+		b.logger.Debug("Failed to drift mouse", zap.Error(err))
+	}
+	b.mouseX = x
+	b.mouseY = y
+
+	b.recordPageMetrics(ctx, url)
 	b.stealth.RandomSleep(ctx, 1.0, 2.0)
 
+	return b.recoverFromThrottling(ctx)
+}
+
+// recordPageMetrics logs the timings of the page just loaded and reports slow loads
+func (b *Instance) recordPageMetrics(ctx context.Context, url string) {
+	metrics, err := b.GetPagePerformanceMetrics(ctx)
+	if err != nil {
+		b.logger.Debug("Failed to read page performance metrics", zap.Error(err))
+		return
+	}
+
+	b.logger.Debug("Page load metrics",
+		zap.String("url", url),
+		zap.Duration("dom_content_loaded", metrics.DOMContentLoaded),
+		zap.Duration("load", metrics.Load),
+		zap.Duration("first_contentful_paint", metrics.FirstContentfulPaint),
+		zap.Duration("time_to_interactive", metrics.TimeToInteractive),
+	)
+
+	if metrics.Load > slowPageLoad {
+		b.metrics.SlowPageLoads.Add(1)
+		b.logger.Warn("Slow page load", zap.String("url", url), zap.Duration("load", metrics.Load))
+	}
+
+	if b.onPageLoad != nil {
+		b.onPageLoad(ctx, url, metrics)
+	}
+}
+
+// recoverFromThrottling pauses before the next action when recent page loads are much
+// slower than usual, which LinkedIn tends to do before a hard block
+func (b *Instance) recoverFromThrottling(ctx context.Context) error {
+	if !b.latency.IsThrottled() {
+		return nil
+	}
+
+	recentAvg, median := b.latency.Stats()
+	pauseMinutes := b.config.Stealth.ThrottleRecoveryPauseMinutes
+	if pauseMinutes <= 0 {
+		pauseMinutes = 15 // Default fallback
+	}
+
+	b.logger.Warn("Page loads slowed down, possible throttling; pausing",
+		zap.Duration("recent_avg", recentAvg),
+		zap.Duration("median", median),
+		zap.Int("pause_minutes", pauseMinutes),
+	)
+
+	if b.onThrottle != nil {
+		b.onThrottle(ctx, recentAvg, median)
+	}
+
+	// Start over so the slow loads that triggered this pause don't trigger another
+	b.latency.Reset()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Duration(pauseMinutes) * time.Minute):
+	}
+
 	return nil
 }
 
@@ -226,8 +462,25 @@ func (b *Instance) HumanHover(ctx context.Context, selector string) error {
 	return nil
 }
 
-// HumanType types text into an element with human-like behavior
+// HumanType types text into an element with human-like behavior. With
+// stealth.ime_simulation, text with non-ASCII characters is typed by HumanTypeIME.
 func (b *Instance) HumanType(ctx context.Context, selector string, text string) error {
+	if b.config.Stealth.IMESimulation && !stealth.IsASCII(text) {
+		return b.HumanTypeIME(ctx, selector, text)
+	}
+	return b.humanType(ctx, selector, text, false)
+}
+
+// HumanTypeIME types text like HumanType, but enters each non-ASCII character through
+// IME composition (compositionstart, compositionupdate, compositionend) the way a
+// regional keyboard layout or input method does, then commits it with insertText
+func (b *Instance) HumanTypeIME(ctx context.Context, selector string, text string) error {
+	return b.humanType(ctx, selector, text, true)
+}
+
+// humanType runs the stealth engine's typing actions against an element. With ime,
+// non-ASCII keys are composed instead of inserted.
+func (b *Instance) humanType(ctx context.Context, selector string, text string, ime bool) error {
 	if b.page == nil {
 		return fmt.Errorf("browser not initialized")
 	}
@@ -269,6 +522,10 @@ func (b *Instance) HumanType(ctx context.Context, selector string, text string)
 				if err := b.page.Keyboard.Press(input.Backspace); err != nil {
 					return fmt.Errorf("failed to press backspace: %w", err)
 				}
+			} else if ime && !stealth.IsASCII(action.Key) {
+				if err := b.composeText(ctx, action.Key); err != nil {
+					return fmt.Errorf("failed to compose key: %w", err)
+				}
 			} else {
 				// Type character
 				if err := elem.Input(action.Key); err != nil {
@@ -298,6 +555,34 @@ func (b *Instance) HumanType(ctx context.Context, selector string, text string)
 	return nil
 }
 
+// composeText enters each character of text as an IME composition into the focused
+// element. Input.imeSetComposition fires compositionstart and compositionupdate, and
+// committing the character with Input.insertText fires compositionend and input.
+func (b *Instance) composeText(ctx context.Context, text string) error {
+	for _, r := range text {
+		for i, step := range stealth.CompositionSteps(r) {
+			if i > 0 {
+				// The keystroke that turns the dead key's accent into the letter
+				b.stealth.RandomSleep(ctx, 0.08, 0.06)
+			}
+			length := len([]rune(step))
+			err := proto.InputImeSetComposition{
+				Text:           step,
+				SelectionStart: length,
+				SelectionEnd:   length,
+			}.Call(b.page)
+			if err != nil {
+				return fmt.Errorf("failed to set IME composition: %w", err)
+			}
+		}
+
+		if err := (proto.InputInsertText{Text: string(r)}).Call(b.page); err != nil {
+			return fmt.Errorf("failed to commit IME composition: %w", err)
+		}
+	}
+	return nil
+}
+
 // JSClick clicks an element using JavaScript
 func (b *Instance) JSClick(ctx context.Context, selector string) error {
 	if b.page == nil {
@@ -396,42 +681,11 @@ y: rect.top + rect.height / 2
 	// Get mouse path from stealth engine
 	points := b.stealth.GetMouse().GetPath(startX, startY, centerX, centerY, true)
 
-	// In debug mode, log the points and slow down the movement
-	mouseMoveDelay := 10 // Default delay
-	if b.config.Stealth.DebugStealth {
-		mouseMoveDelay = 50 // Slower delay for observation
-		b.logger.Info("Stealth Debug: Mouse path", zap.Int("points", len(points)))
-	}
-
 	// Execute mouse movement using CDP (Chrome DevTools Protocol)
 	// This generates 'isTrusted: true' events which are indistinguishable from real hardware input,
 	// unlike JavaScript-generated events which are easily detected.
-	for _, p := range points {
-		// Move mouse to the next point in the Bezier curve
-		// We use CDP directly via proto.InputDispatchMouseEvent
-		err := proto.InputDispatchMouseEvent{
-			Type: proto.InputDispatchMouseEventTypeMouseMoved,
-			X:    p.X,
-			Y:    p.Y,
-		}.Call(b.page)
-		if err != nil {
-			b.logger.Debug("Failed to move mouse", zap.Error(err))
-		}
-
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
-		}
-
-		// Add micro-delays between movements to simulate human speed
-		delay := time.Duration(mouseMoveDelay) * time.Millisecond
-		if !b.config.Stealth.DebugStealth {
-			// Add random jitter to the delay (5-15ms)
-			jitter := rand.Intn(11) + 5
-			delay = time.Duration(jitter) * time.Millisecond
-		}
-		time.Sleep(delay)
+	if err := b.moveMouse(ctx, points); err != nil {
+		return err
 	}
 
 	// Update mouse position state
@@ -474,6 +728,20 @@ y: rect.top + rect.height / 2
 	return nil
 }
 
+// moveMouse moves the mouse along a path through the event throttler. In debug mode the
+// path is logged and walked one point at a time, slowly enough to watch.
+func (b *Instance) moveMouse(ctx context.Context, points []stealth.Point) error {
+	throttler := b.throttler
+	if b.config.Stealth.DebugStealth {
+		throttler = newDebugEventThrottler()
+		b.logger.Info("Stealth Debug: Mouse path", zap.Int("points", len(points)))
+	}
+
+	return throttler.Move(ctx, b.page, points, func(err error) {
+		b.logger.Debug("Failed to move mouse", zap.Error(err))
+	})
+}
+
 // HumanClickElement clicks a specific element with Bézier curve mouse movement
 func (b *Instance) HumanClickElement(ctx context.Context, elem *rod.Element) error {
 	if b.page == nil {
@@ -522,36 +790,9 @@ y: rect.top + rect.height / 2
 	// Get mouse path from stealth engine
 	points := b.stealth.GetMouse().GetPath(startX, startY, centerX, centerY, true)
 
-	// In debug mode, log the points and slow down the movement
-	mouseMoveDelay := 10 // Default delay
-	if b.config.Stealth.DebugStealth {
-		mouseMoveDelay = 50 // Slower delay for observation
-		b.logger.Info("Stealth Debug: Mouse path", zap.Int("points", len(points)))
-	}
-
 	// Execute mouse movement using CDP
-	for _, p := range points {
-		err := proto.InputDispatchMouseEvent{
-			Type: proto.InputDispatchMouseEventTypeMouseMoved,
-			X:    p.X,
-			Y:    p.Y,
-		}.Call(b.page)
-		if err != nil {
-			b.logger.Debug("Failed to move mouse", zap.Error(err))
-		}
-
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
-		}
-
-		delay := time.Duration(mouseMoveDelay) * time.Millisecond
-		if !b.config.Stealth.DebugStealth {
-			jitter := rand.Intn(11) + 5
-			delay = time.Duration(jitter) * time.Millisecond
-		}
-		time.Sleep(delay)
+	if err := b.moveMouse(ctx, points); err != nil {
+		return err
 	}
 
 	// Update mouse position state
@@ -643,16 +884,60 @@ func (b *Instance) HumanScroll(ctx context.Context, direction string, distance i
 	return nil
 }
 
-// WaitForElement waits for an element to appear with timeout
+// WaitForElement waits for an element to appear with timeout. A selectors.timeouts
+// entry for the selector takes precedence over timeout.
 func (b *Instance) WaitForElement(ctx context.Context, selector string, timeout time.Duration) error {
 	if b.page == nil {
 		return fmt.Errorf("browser not initialized")
 	}
 
+	if override, ok := b.selectorTimeout(selector); ok {
+		timeout = override
+	}
+
 	_, err := b.page.Timeout(timeout).Element(selector)
 	return err
 }
 
+// selectorTimeout returns the selectors.timeouts override for selector, if any. Entries
+// are keyed by selector name (e.g. search_results, covering every fallback of a list) or
+// by the selector string itself. Names are the usual form since viper splits config keys
+// on dots, which most selector strings contain.
+func (b *Instance) selectorTimeout(selector string) (time.Duration, bool) {
+	if b.config == nil || len(b.config.Selectors.Timeouts) == 0 {
+		return 0, false
+	}
+	timeouts := b.config.Selectors.Timeouts
+
+	if seconds, ok := timeouts[selector]; ok && seconds > 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	value := reflect.ValueOf(b.config.Selectors)
+	fields := value.Type()
+	for i := 0; i < fields.NumField(); i++ {
+		seconds, ok := timeouts[fields.Field(i).Tag.Get("mapstructure")]
+		if !ok || seconds <= 0 {
+			continue
+		}
+
+		switch field := value.Field(i).Interface().(type) {
+		case string:
+			if field == selector {
+				return time.Duration(seconds) * time.Second, true
+			}
+		case []string:
+			for _, fallback := range field {
+				if fallback == selector {
+					return time.Duration(seconds) * time.Second, true
+				}
+			}
+		}
+	}
+
+	return 0, false
+}
+
 // GetText extracts text content from an element
 func (b *Instance) GetText(ctx context.Context, selector string) (string, error) {
 	if b.page == nil {
@@ -672,6 +957,33 @@ func (b *Instance) GetText(ctx context.Context, selector string) (string, error)
 	return text, nil
 }
 
+// GetAllText extracts text content for several selectors in a single page.Eval call
+func (b *Instance) GetAllText(ctx context.Context, selectors []string) (map[string]string, error) {
+	if b.page == nil {
+		return nil, fmt.Errorf("browser not initialized")
+	}
+
+	res, err := b.page.Eval(`(selectors) => {
+const result = {};
+for (const sel of selectors) {
+let el = null;
+try { el = document.querySelector(sel); } catch (e) {}
+result[sel] = el ? (el.innerText || el.textContent || "").trim() : "";
+}
+return result;
+}`, selectors)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract text: %w", err)
+	}
+
+	texts := make(map[string]string, len(selectors))
+	if err := res.Value.Unmarshal(&texts); err != nil {
+		return nil, fmt.Errorf("failed to parse extracted text: %w", err)
+	}
+
+	return texts, nil
+}
+
 // GetAttribute gets an attribute value from an element
 func (b *Instance) GetAttribute(ctx context.Context, selector string, attr string) (string, error) {
 	if b.page == nil {
@@ -799,6 +1111,69 @@ func (b *Instance) GetPageHTML(ctx context.Context) (string, error) {
 	return b.page.HTML()
 }
 
+// GetPagePerformanceMetrics returns the Navigation Timing of the current page, measured
+// from navigationStart. Timings the page hasn't reached yet are zero.
+func (b *Instance) GetPagePerformanceMetrics(ctx context.Context) (*core.PageMetrics, error) {
+	if b.page == nil {
+		return nil, fmt.Errorf("browser not initialized")
+	}
+
+	res, err := b.page.Eval(`() => {
+const paint = performance.getEntriesByName("first-contentful-paint")[0];
+return JSON.stringify(Object.assign(performance.timing.toJSON(), {firstContentfulPaint: paint ? paint.startTime : 0}));
+}`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read performance timing: %w", err)
+	}
+
+	var timing struct {
+		NavigationStart          float64 `json:"navigationStart"`
+		DOMInteractive           float64 `json:"domInteractive"`
+		DOMContentLoadedEventEnd float64 `json:"domContentLoadedEventEnd"`
+		LoadEventEnd             float64 `json:"loadEventEnd"`
+		FirstContentfulPaint     float64 `json:"firstContentfulPaint"` // Already relative to navigationStart
+	}
+	if err := json.Unmarshal([]byte(res.Value.Str()), &timing); err != nil {
+		return nil, fmt.Errorf("failed to parse performance timing: %w", err)
+	}
+
+	since := func(ms float64) time.Duration {
+		if ms <= 0 || timing.NavigationStart <= 0 || ms < timing.NavigationStart {
+			return 0
+		}
+		return time.Duration(ms-timing.NavigationStart) * time.Millisecond
+	}
+
+	return &core.PageMetrics{
+		DOMContentLoaded:     since(timing.DOMContentLoadedEventEnd),
+		Load:                 since(timing.LoadEventEnd),
+		FirstContentfulPaint: time.Duration(timing.FirstContentfulPaint * float64(time.Millisecond)),
+		TimeToInteractive:    since(timing.DOMInteractive),
+	}, nil
+}
+
+// GetCookies returns the browser cookies sent to url (the current page when empty)
+func (b *Instance) GetCookies(ctx context.Context, url string) ([]*http.Cookie, error) {
+	if b.page == nil {
+		return nil, fmt.Errorf("browser not initialized")
+	}
+
+	urls := []string{}
+	if url != "" {
+		urls = append(urls, url)
+	}
+	cookies, err := b.page.Cookies(urls)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cookies: %w", err)
+	}
+
+	result := make([]*http.Cookie, 0, len(cookies))
+	for _, c := range cookies {
+		result = append(result, &http.Cookie{Name: c.Name, Value: c.Value, Domain: c.Domain, Path: c.Path})
+	}
+	return result, nil
+}
+
 // SaveCookies saves browser cookies to a file
 func (b *Instance) SaveCookies(ctx context.Context, path string) error {
 	if b.page == nil {
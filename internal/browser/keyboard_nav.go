@@ -0,0 +1,137 @@
+package browser
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"linkedin-automation/internal/stealth"
+
+	"github.com/go-rod/rod/lib/input"
+	"github.com/go-rod/rod/lib/proto"
+	"go.uber.org/zap"
+)
+
+// keyboardNavTimeout bounds the wait for the page typed into the address bar
+const keyboardNavTimeout = 30 * time.Second
+
+// useKeyboardNavigation decides whether the next navigation is typed into the address bar,
+// for browser.keyboard_nav_prob of them when stealth.use_keyboard_navigation is set
+func (b *Instance) useKeyboardNavigation() bool {
+	if !b.config.Stealth.UseKeyboardNavigation {
+		return false
+	}
+	return rand.Float64() < b.config.Browser.KeyboardNavProb
+}
+
+// keyboardNavigate opens url the way a person would: Ctrl+L, Ctrl+A and Delete to clear
+// the address bar, the URL typed key by key, then Enter. It reports whether the page ended
+// up on url; when it didn't (the address bar can't be focused, e.g. headless, or the
+// typed URL went elsewhere) the caller navigates directly.
+//
+// The address bar is only focused with Ctrl+L. LinkedIn's global nav search box looks
+// like one but is page content: a URL typed there would run a people search.
+func (b *Instance) keyboardNavigate(ctx context.Context, url string) bool {
+	if err := b.page.KeyActions().Press(input.ControlLeft).Type(input.KeyL).Do(); err != nil {
+		b.logger.Debug("Failed to focus address bar", zap.Error(err))
+		return false
+	}
+	b.stealth.RandomSleep(ctx, 0.2, 0.3)
+
+	// Focus moving to the browser UI takes it away from the document
+	focused, err := b.page.Eval(`() => document.hasFocus()`)
+	if err != nil || focused.Value.Bool() {
+		b.logger.Debug("Address bar did not take focus, navigating directly", zap.Error(err))
+		return false
+	}
+
+	if err := b.page.KeyActions().Press(input.ControlLeft).Type(input.KeyA).Do(); err != nil {
+		b.logger.Debug("Failed to select address bar text", zap.Error(err))
+		return false
+	}
+	if err := b.page.Keyboard.Type(input.Delete); err != nil {
+		b.logger.Debug("Failed to clear address bar", zap.Error(err))
+		return false
+	}
+
+	if err := b.typeKeys(ctx, url); err != nil {
+		b.logger.Debug("Failed to type URL", zap.Error(err))
+		return false
+	}
+	b.stealth.RandomSleep(ctx, 0.3, 0.5)
+
+	page := b.page.Timeout(keyboardNavTimeout)
+	wait := page.WaitNavigation(proto.PageLifecycleEventNameDOMContentLoaded)
+	if err := b.page.Keyboard.Type(input.Enter); err != nil {
+		b.logger.Debug("Failed to press Enter", zap.Error(err))
+		return false
+	}
+	wait()
+
+	info, err := b.page.Info()
+	if err != nil {
+		b.logger.Debug("Failed to read URL after keyboard navigation", zap.Error(err))
+		return false
+	}
+	if !sameURL(info.URL, url) {
+		b.logger.Debug("Keyboard navigation landed elsewhere, navigating directly",
+			zap.String("url", url), zap.String("landed", info.URL))
+		return false
+	}
+
+	b.logger.Debug("Navigated with the keyboard", zap.String("url", url))
+	return true
+}
+
+// typeKeys types text as key events with the stealth engine's typing rhythm and typos.
+// Characters without a key on a US layout are inserted as text.
+func (b *Instance) typeKeys(ctx context.Context, text string) error {
+	actions, err := b.stealth.GetTypingActions(ctx, text)
+	if err != nil {
+		return fmt.Errorf("failed to generate typing actions: %w", err)
+	}
+
+	for _, action := range actions {
+		if action.Type == stealth.ActionTypeKey {
+			if err := b.typeKey(action.Key); err != nil {
+				return err
+			}
+		}
+
+		if action.Delay > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(action.Delay):
+			}
+		}
+	}
+	return nil
+}
+
+// typeKey presses one key of a typing action
+func (b *Instance) typeKey(key string) error {
+	if key == "\b" {
+		return b.page.Keyboard.Type(input.Backspace)
+	}
+	for _, r := range key {
+		// rod's key map covers printable ASCII and panics on anything else
+		if r < ' ' || r > '~' {
+			if err := b.page.InsertText(string(r)); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := b.page.Keyboard.Type(input.Key(r)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sameURL compares two URLs ignoring a trailing slash, which the browser may add or drop
+func sameURL(a, b string) bool {
+	return strings.TrimRight(a, "/") == strings.TrimRight(b, "/")
+}
@@ -0,0 +1,82 @@
+package browser
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	latencyWindowSize = 10  // Page loads kept in the sliding window
+	latencyRecentSize = 3   // Most recent loads compared against the window
+	throttleFactor    = 3.0 // Recent average must exceed the window median by this factor
+)
+
+// LatencyTracker records page load latencies to detect soft throttling. LinkedIn slows
+// down responses when it suspects automation, usually before a hard block.
+type LatencyTracker struct {
+	mu        sync.Mutex
+	latencies []time.Duration
+}
+
+// NewLatencyTracker creates an empty latency tracker
+func NewLatencyTracker() *LatencyTracker {
+	return &LatencyTracker{
+		latencies: make([]time.Duration, 0, latencyWindowSize),
+	}
+}
+
+// Record adds a page load latency, dropping the oldest once the window is full
+func (t *LatencyTracker) Record(latency time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.latencies) == latencyWindowSize {
+		t.latencies = t.latencies[1:]
+	}
+	t.latencies = append(t.latencies, latency)
+}
+
+// IsThrottled reports whether the average of the last 3 latencies is more than 3x
+// the median of the window. It needs a full window to avoid reacting to a single
+// slow page early in the session.
+func (t *LatencyTracker) IsThrottled() bool {
+	recent, median := t.Stats()
+	if median == 0 {
+		return false
+	}
+	return float64(recent) > throttleFactor*float64(median)
+}
+
+// Stats returns the average of the most recent latencies and the median of the
+// window, or zeros until the window is full
+func (t *LatencyTracker) Stats() (recentAvg, median time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.latencies) < latencyWindowSize {
+		return 0, 0
+	}
+
+	var total time.Duration
+	for _, l := range t.latencies[len(t.latencies)-latencyRecentSize:] {
+		total += l
+	}
+	recentAvg = total / latencyRecentSize
+
+	sorted := make([]time.Duration, len(t.latencies))
+	copy(sorted, t.latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	mid := len(sorted) / 2
+	median = (sorted[mid-1] + sorted[mid]) / 2
+
+	return recentAvg, median
+}
+
+// Reset clears the window, e.g. after pausing to recover from throttling
+func (t *LatencyTracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.latencies = t.latencies[:0]
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ConnectionSummary is today's connection request quota and how it is going
+type ConnectionSummary struct {
+	SentToday              int           `json:"sent_today"`      // Connection requests sent since midnight
+	AcceptedToday          int           `json:"accepted_today"`  // Connections accepted since midnight
+	Limit                  int           `json:"limit"`           // limits.max_actions_per_day
+	Remaining              int           `json:"remaining"`       // Requests left today
+	AcceptanceRate         float64       `json:"acceptance_rate"` // Connected / (Connected + RequestSent) among profiles created today
+	EstimatedTimeToExhaust time.Duration `json:"-"`               // Remaining requests times the average cooldown
+}
+
+// MarshalJSON adds EstimatedTimeToExhaust in seconds, as durations have no JSON form
+func (s ConnectionSummary) MarshalJSON() ([]byte, error) {
+	type summary ConnectionSummary
+	return json.Marshal(struct {
+		summary
+		EstimatedSecondsToExhaust int64 `json:"estimated_seconds_to_exhaust"`
+	}{summary(s), int64(s.EstimatedTimeToExhaust / time.Second)})
+}
+
+// GetDailyConnectionSummary summarizes today's connection requests against limits
+// as of now. Today starts at midnight in now's location, so pass now in
+// database.timezone to match the daily limit check.
+func GetDailyConnectionSummary(ctx context.Context, repo RepositoryPort, limits *LimitsConfig, now time.Time) (*ConnectionSummary, error) {
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	sent, err := repo.GetTodayActionCount(ctx, "Connect")
+	if err != nil {
+		return nil, fmt.Errorf("failed to count today's connection requests: %w", err)
+	}
+	accepted, err := repo.CountProfiles(ctx, &ProfileFilter{ConnectedAfter: startOfDay})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count today's acceptances: %w", err)
+	}
+	connected, err := repo.CountProfiles(ctx, &ProfileFilter{Status: ProfileStatusConnected, CreatedAfter: startOfDay})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count profiles connected today: %w", err)
+	}
+	pending, err := repo.CountProfiles(ctx, &ProfileFilter{Status: ProfileStatusRequestSent, CreatedAfter: startOfDay})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count pending requests: %w", err)
+	}
+
+	summary := &ConnectionSummary{
+		SentToday:     int(sent),
+		AcceptedToday: int(accepted),
+		Limit:         limits.MaxActionsPerDay,
+	}
+	if remaining := summary.Limit - summary.SentToday; remaining > 0 {
+		summary.Remaining = remaining
+	}
+	if connected+pending > 0 {
+		summary.AcceptanceRate = float64(connected) / float64(connected+pending)
+	}
+	summary.EstimatedTimeToExhaust = time.Duration(summary.Remaining) * averageConnectCooldown(limits)
+
+	return summary, nil
+}
+
+// averageConnectCooldown is the expected wait between connection requests: the middle of
+// the cooldown bounds, or the paced interval when limits.target_connections_per_hour is set
+func averageConnectCooldown(limits *LimitsConfig) time.Duration {
+	minDelay := time.Duration(limits.ConnectCooldownMin) * time.Minute
+	maxDelay := time.Duration(limits.ConnectCooldownMax) * time.Minute
+	if maxDelay < minDelay {
+		maxDelay = minDelay
+	}
+	if limits.TargetConnectionsPerHour <= 0 {
+		return (minDelay + maxDelay) / 2
+	}
+
+	paced := time.Hour / time.Duration(limits.TargetConnectionsPerHour)
+	if paced < minDelay {
+		return minDelay
+	}
+	if paced > maxDelay {
+		return maxDelay
+	}
+	return paced
+}
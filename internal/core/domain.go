@@ -1,27 +1,194 @@
 package core
 
-import "time"
+import (
+	"errors"
+	"time"
 
-// Profile Status Constants
-const (
-	ProfileStatusDiscovered  = "Discovered"
-	ProfileStatusScanned     = "Scanned"
-	ProfileStatusRequestSent = "RequestSent"
-	ProfileStatusConnected   = "Connected"
-	ProfileStatusMessageSent = "MessageSent"
-	ProfileStatusIgnored     = "Ignored"
-	ProfileStatusFailed      = "Failed"
+	"gorm.io/gorm"
 )
 
+// DefaultAccount is the account of data stored before accounts were partitioned, and of
+// setups with a single account
+const DefaultAccount = "default"
+
 // Profile represents a LinkedIn profile in the database
 type Profile struct {
-	ID                uint       `gorm:"primaryKey" json:"id"`
-	LinkedInURL       string     `gorm:"uniqueIndex;not null" json:"linkedin_url"`
-	Status            string     `gorm:"index;not null" json:"status"` // Scanned, Connected, Ignored
-	ConnectedAt       *time.Time `json:"connected_at"`
-	LastMessageSentAt *time.Time `json:"last_message_sent_at"`
-	CreatedAt         time.Time  `json:"created_at"`
-	UpdatedAt         time.Time  `json:"updated_at"`
+	ID                  uint          `gorm:"primaryKey" json:"id"`
+	Account             string        `gorm:"uniqueIndex:idx_profiles_account_url,priority:1;not null;default:'default'" json:"account"` // LinkedIn account the profile was found by
+	LinkedInURL         string        `gorm:"uniqueIndex:idx_profiles_account_url,priority:2;not null" json:"linkedin_url"`
+	Status              ProfileStatus `gorm:"index;not null" json:"status"` // See profile_status.go for the allowed transitions
+	ConnectedAt         *time.Time    `gorm:"column:connected_at" json:"connected_at"`
+	LastMessageSentAt   *time.Time    `gorm:"column:last_message_sent_at" json:"last_message_sent_at"`
+	LastSeenPendingAt   *time.Time    `json:"last_seen_pending_at"`                           // Last time the invitation was seen on the sent-invitations page
+	LastActiveAt        *time.Time    `json:"last_active_at"`                                 // Parsed from the profile's "Active X ago" indicator
+	IsOpenToWork        bool          `json:"is_open_to_work"`                                // Profile shows the "Open to Work" frame or banner
+	OpenToWorkCheckedAt *time.Time    `json:"open_to_work_checked_at,omitempty"`              // Last time IsOpenToWork was read from the profile (nil = never)
+	Campaign            string        `gorm:"index" json:"campaign"`                          // Campaign the profile was discovered for
+	Skills              string        `json:"skills,omitempty"`                               // JSON array of skills listed on the profile
+	VisitedAt           *time.Time    `json:"visited_at,omitempty"`                           // Last visit-only pass (see VisitConfig)
+	Name                string        `json:"name,omitempty"`                                 // Display name from the connections list
+	Headline            string        `json:"headline,omitempty"`                             // Headline from the connections list
+	ConnectionDegree    string        `json:"connection_degree,omitempty"`                    // "1st", "2nd" or "3rd" from the profile top card
+	FollowerCount       int64         `json:"follower_count,omitempty"`                       // From the Voyager API (0 = not fetched)
+	BlacklistReason     string        `json:"blacklist_reason,omitempty"`                     // Reason of the blacklist entry the profile matched
+	SourceFeedPostURL   string        `json:"source_feed_post_url,omitempty"`                 // Feed post the profile was discovered on
+	SourceQueryID       *uint         `gorm:"index" json:"source_query_id,omitempty"`         // SearchQuery that discovered the profile
+	Source              string        `gorm:"index;not null;default:'unknown'" json:"source"` // Ingestion path that first stored the profile; see profile_source.go
+	SourceDetail        string        `json:"source_detail,omitempty"`                        // Keyword, group id or post URN within Source
+
+	// Enrichment captured from the Experience and Education sections
+	CurrentTitle         string     `json:"current_title,omitempty"`
+	CurrentCompany       string     `json:"current_company,omitempty"`
+	CurrentCompanyURL    string     `json:"current_company_url,omitempty"`
+	CurrentRoleStartedAt *time.Time `json:"current_role_started_at,omitempty"`
+	School               string     `json:"school,omitempty"`
+	EnrichedAt           *time.Time `json:"enriched_at,omitempty"`
+
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	ArchivedAt *time.Time     `json:"archived_at,omitempty"` // Set with DeletedAt by ArchiveProfile; archived rows are never purged
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`        // Set by the retention purge until the row is hard-deleted, or by ArchiveProfile
+}
+
+// UpsertResult describes what UpsertProfile did with a profile
+type UpsertResult struct {
+	Created        bool          // No profile with this URL existed
+	PreviousStatus ProfileStatus // Stored status before the upsert (empty when Created)
+	StatusChanged  bool          // An existing profile moved to the upserted status
+	Archived       bool          // The URL belongs to an archived profile, which was left alone
+}
+
+// Message directions
+const (
+	MessageDirectionOut = "out"
+	MessageDirectionIn  = "in"
+)
+
+// Message represents a single message exchanged with a profile
+type Message struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	Account      string    `gorm:"index;not null;default:'default'" json:"account"`
+	ProfileID    uint      `gorm:"index;not null" json:"profile_id"`
+	Direction    string    `gorm:"not null" json:"direction"` // out, in
+	Sender       string    `json:"sender,omitempty"`
+	Body         string    `gorm:"type:text" json:"body"`
+	ContentHash  string    `gorm:"index" json:"content_hash,omitempty"` // SHA-256 of Body, used to dedupe scanned messages
+	TemplateName string    `json:"template_name,omitempty"`
+	SequenceStep int       `json:"sequence_step"` // 1-based follow-up step (0 for incoming messages)
+	SentAt       time.Time `gorm:"index;not null" json:"sent_at"`
+	Verified     bool      `json:"verified"` // Confirmed visible in the conversation thread
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Endorsement records that a profile's skills were endorsed. Each profile is endorsed at most once.
+type Endorsement struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	ProfileID  uint      `gorm:"uniqueIndex;not null" json:"profile_id"`
+	Skills     string    `json:"skills"` // JSON array of the skills endorsed
+	EndorsedAt time.Time `gorm:"not null" json:"endorsed_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Follow-up sequence step types
+const (
+	SequenceStepMessage = "message"
+	SequenceStepEndorse = "endorse"
+)
+
+// SequenceStep is one step of the follow-up sequence
+type SequenceStep struct {
+	Type      string `mapstructure:"type"`       // message or endorse
+	Day       int    `mapstructure:"day"`        // Days after the connection was accepted
+	MaxSkills int    `mapstructure:"max_skills"` // Skills endorsed by an endorse step
+}
+
+// FollowedCompany records a company page the account follows. Companies are never
+// unfollowed, so a row here means the company is skipped by later follow runs.
+type FollowedCompany struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	CompanyURL string    `gorm:"uniqueIndex;not null" json:"company_url"`
+	Name       string    `json:"name,omitempty"`
+	FollowedAt time.Time `gorm:"not null" json:"followed_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// CompanyResearch caches the talking points found about a company, shared by all
+// accounts, so they are fetched again only after personalization.company_research.cache_ttl_hours
+type CompanyResearch struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	Company       string    `gorm:"uniqueIndex;not null" json:"company"` // Lower-cased company name
+	TalkingPoints string    `gorm:"type:text" json:"talking_points"`     // JSON array of strings
+	DataSource    string    `json:"data_source"`                         // Source the points came from (see CompanyResearchSource constants)
+	FetchedAt     time.Time `gorm:"not null" json:"fetched_at"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// DeclinedInvite records an inviter whose invitation was ignored, so later invitations
+// from the same person are ignored without evaluating the rules again
+type DeclinedInvite struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	ProfileURL string    `gorm:"uniqueIndex;not null" json:"profile_url"`
+	Name       string    `json:"name,omitempty"`
+	Headline   string    `json:"headline,omitempty"`
+	Rule       string    `json:"rule"`
+	DeclinedAt time.Time `gorm:"not null" json:"declined_at"`
+}
+
+// Notification event types stored from the notifications page
+const (
+	NotificationProfileView    = "profile_view"
+	NotificationReaction       = "reaction"
+	NotificationInviteAccepted = "invite_accepted"
+	NotificationMention        = "mention"
+)
+
+// Notification is an event read from the notifications page, kept for analytics
+type Notification struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	Type        string     `gorm:"index;not null" json:"type"`
+	ActorURL    string     `gorm:"index" json:"actor_url,omitempty"`
+	ActorName   string     `json:"actor_name,omitempty"`
+	Text        string     `gorm:"type:text" json:"text"`
+	OccurredAt  *time.Time `gorm:"index" json:"occurred_at,omitempty"`       // Approximate, from the card's "2h"/"3d" age
+	ContentHash string     `gorm:"uniqueIndex;not null" json:"content_hash"` // SHA-256 of type, actor and text, used to dedupe rescans
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// PageLoad records the load timings of a navigation, used to find consistently slow pages
+type PageLoad struct {
+	ID                 uint      `gorm:"primaryKey" json:"id"`
+	URL                string    `gorm:"index;not null" json:"url"`
+	LoadedAt           time.Time `gorm:"index;not null" json:"loaded_at"`
+	DOMContentLoadedMs int64     `json:"dom_content_loaded_ms"`
+	LoadMs             int64     `json:"load_ms"`
+}
+
+// PageMetrics holds the Navigation Timing of the current page, relative to navigationStart
+type PageMetrics struct {
+	DOMContentLoaded     time.Duration
+	Load                 time.Duration
+	FirstContentfulPaint time.Duration
+	TimeToInteractive    time.Duration // Approximated by domInteractive
+}
+
+// PostComment records a comment posted on someone's post, used for the per-author cooldown
+type PostComment struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	AuthorURL   string    `gorm:"index;not null" json:"author_url"`
+	PostURL     string    `gorm:"not null" json:"post_url"`
+	Body        string    `gorm:"type:text" json:"body"`
+	CommentedAt time.Time `gorm:"index;not null" json:"commented_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ProfileEnrichment holds the role and education data read from a profile page
+type ProfileEnrichment struct {
+	CurrentTitle         string     `json:"current_title"`
+	CurrentCompany       string     `json:"current_company"`
+	CurrentCompanyURL    string     `json:"current_company_url"`
+	CurrentRoleStartedAt *time.Time `json:"current_role_started_at"`
+	School               string     `json:"school"`
 }
 
 // MessageTemplate represents a message template
@@ -29,29 +196,286 @@ type MessageTemplate struct {
 	Body string `json:"body"`
 }
 
+// ProfileGroup links a profile to a LinkedIn group it was discovered in
+type ProfileGroup struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	ProfileID uint      `gorm:"uniqueIndex:idx_profile_group;not null" json:"profile_id"`
+	GroupURL  string    `gorm:"uniqueIndex:idx_profile_group;index;not null" json:"group_url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Group membership states
+const (
+	GroupStateJoined  = "joined"
+	GroupStatePending = "pending" // Join requested, awaiting admin approval
+)
+
+// Group records a LinkedIn group the account joined or asked to join. Groups are never
+// left automatically, so a row here means the group is skipped by later join runs.
+type Group struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	GroupURL    string     `gorm:"uniqueIndex;not null" json:"group_url"`
+	Name        string     `json:"name,omitempty"`
+	State       string     `gorm:"index;not null" json:"state"`
+	RequestedAt time.Time  `gorm:"not null" json:"requested_at"`
+	JoinedAt    *time.Time `json:"joined_at,omitempty"`
+	CheckedAt   *time.Time `json:"checked_at,omitempty"` // Last status check of a pending group
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// Campaign statuses
+const (
+	CampaignStatusActive   = "active"
+	CampaignStatusPaused   = "paused"   // Kept, but no requests or follow-ups are sent
+	CampaignStatusArchived = "archived" // Finished; its profiles may join another campaign
+)
+
+// ErrProfileInActiveCampaign is returned when assigning a profile that already belongs
+// to a different active campaign
+var ErrProfileInActiveCampaign = errors.New("profile already belongs to another active campaign")
+
+// Campaign is an outreach effort with its own note, follow-up messages and share of the
+// daily limits. Profiles reference it by name (Profile.Campaign) and belong to at most
+// one active campaign.
+type Campaign struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	Name             string    `gorm:"uniqueIndex;not null" json:"name"`
+	NoteTemplate     string    `gorm:"type:text" json:"note_template,omitempty"` // Connection note ({{Name}}); empty uses connection.note_template
+	MessageSequence  string    `json:"message_sequence,omitempty"`               // JSON array of messaging.templates names, one per follow-up message
+	DailyBudgetShare float64   `json:"daily_budget_share"`                       // Share (0-1] of the daily connection and message limits; 0 = no cap of its own
+	Status           string    `gorm:"index;not null" json:"status"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// Blacklist entry pattern types
+const (
+	BlacklistExactURL      = "exact_url"      // Value is a profile URL
+	BlacklistURLPrefix     = "url_prefix"     // Value is the start of profile URLs
+	BlacklistCompanyRegex  = "company_regex"  // Value is a regexp matched against the current company
+	BlacklistHeadlineRegex = "headline_regex" // Value is a regexp matched against the headline
+)
+
+// BlacklistEntry excludes matching profiles from searches and connection requests
+type BlacklistEntry struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	PatternType string    `gorm:"uniqueIndex:idx_blacklist_pattern;not null" json:"pattern_type"`
+	Value       string    `gorm:"uniqueIndex:idx_blacklist_pattern;not null" json:"value"`
+	Reason      string    `json:"reason,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Run exit statuses
+const (
+	RunStatusRunning     = "running"
+	RunStatusSucceeded   = "succeeded"
+	RunStatusFailed      = "failed"
+	RunStatusInterrupted = "interrupted"
+)
+
+// RunMetadata describes one bot run, so runs with different keywords, notes or stealth
+// settings can be compared
+type RunMetadata struct {
+	ID                  uint       `gorm:"primaryKey" json:"id"`
+	RunID               string     `gorm:"uniqueIndex;not null" json:"run_id"` // UUID
+	StartedAt           time.Time  `gorm:"index;not null" json:"started_at"`
+	CompletedAt         *time.Time `json:"completed_at,omitempty"`
+	Keyword             string     `json:"keyword,omitempty"`
+	Location            string     `json:"location,omitempty"`
+	Campaign            string     `json:"campaign,omitempty"`
+	Mode                string     `json:"mode,omitempty"`           // Comma-separated modes the run was started with, e.g. "scan,connect"
+	Account             string     `json:"account,omitempty"`        // LinkedIn account email
+	ExitStatus          string     `gorm:"index" json:"exit_status"` // RunStatus* value
+	ExitError           string     `gorm:"type:text" json:"exit_error,omitempty"`
+	NoteTemplateHash    string     `json:"note_template_hash,omitempty"` // MD5 of the note template used for requests
+	ConnectionsSent     int64      `json:"connections_sent"`
+	ConnectionsAccepted int64      `json:"connections_accepted"` // Acceptances detected during the run
+	MessagesSent        int64      `json:"messages_sent"`
+	Errors              int64      `json:"errors"`
+	StealthConfigHash   string     `json:"stealth_config_hash"` // MD5 of the JSON-encoded StealthConfig
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// SearchQuery records one people search and what it yielded, so keywords can be compared
+// by the profiles they found and how many of those connected
+type SearchQuery struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	Account      string    `gorm:"index;not null;default:'default'" json:"account"`
+	Keyword      string    `gorm:"index" json:"keyword"`
+	Filters      string    `gorm:"type:text" json:"filters"` // JSON-encoded SearchParams; see SearchQuery.Params
+	PagesCrawled int       `json:"pages_crawled"`
+	ResultsFound int       `json:"results_found"` // Profile URLs read from the result pages
+	NewProfiles  int       `json:"new_profiles"`  // Of those, profiles not in the database before
+	RunID        *uint     `gorm:"index" json:"run_id,omitempty"`
+	CreatedAt    time.Time `gorm:"index" json:"created_at"`
+}
+
+// SearchQueryStats is a search query with the outreach results of the profiles it found
+type SearchQueryStats struct {
+	QueryID        uint      `json:"query_id"`
+	Keyword        string    `json:"keyword"`
+	Filters        string    `json:"filters"`
+	CreatedAt      time.Time `json:"created_at"`
+	PagesCrawled   int       `json:"pages_crawled"`
+	ResultsFound   int       `json:"results_found"`
+	NewProfiles    int       `json:"new_profiles"`
+	RequestsSent   int64     `json:"requests_sent"` // Profiles a connection request went to
+	Accepted       int64     `json:"accepted"`
+	AcceptanceRate float64   `json:"acceptance_rate"` // Accepted / RequestsSent
+}
+
+// SourceStats is a profile source with the outreach results of its profiles
+type SourceStats struct {
+	Source         string  `json:"source"`
+	Profiles       int64   `json:"profiles"`
+	RequestsSent   int64   `json:"requests_sent"` // Profiles a connection request went to
+	Accepted       int64   `json:"accepted"`
+	AcceptanceRate float64 `json:"acceptance_rate"` // Accepted / RequestsSent
+}
+
+// GroupStats holds acceptance statistics for profiles sourced from a group
+type GroupStats struct {
+	GroupURL       string  `json:"group_url"`
+	Total          int64   `json:"total"`
+	RequestsSent   int64   `json:"requests_sent"`
+	Accepted       int64   `json:"accepted"`
+	AcceptanceRate float64 `json:"acceptance_rate"` // Accepted / RequestsSent
+}
+
+// StatsBucket counts the outcomes of one action type within a time bucket.
+// Count is SuccessCount + ErrorCount.
+type StatsBucket struct {
+	BucketStart  time.Time `json:"bucket_start"`
+	Count        int64     `json:"count"`
+	SuccessCount int64     `json:"success_count"`
+	ErrorCount   int64     `json:"error_count"` // "Error" history entries for this action type
+}
+
+// DailyAcceptanceRate is the share of a day's connection requests that have been accepted
+type DailyAcceptanceRate struct {
+	Date           time.Time `json:"date"`
+	RequestsSent   int64     `json:"requests_sent"`
+	Accepted       int64     `json:"accepted"`
+	AcceptanceRate float64   `json:"acceptance_rate"` // Accepted / RequestsSent
+}
+
+// DailyActionCount is the number of history entries of one action type on a UTC day
+type DailyActionCount struct {
+	Date       time.Time `json:"date"`
+	ActionType string    `json:"action_type"`
+	Count      int64     `json:"count"`
+}
+
+// StatusCount is the number of profiles currently in a status
+type StatusCount struct {
+	Status ProfileStatus `json:"status"`
+	Count  int64         `json:"count"`
+}
+
+// AcceptanceStats summarizes the connection requests sent within a range and how many
+// of them were accepted within the same range
+type AcceptanceStats struct {
+	RequestsSent    int64   `json:"requests_sent"`
+	Accepted        int64   `json:"accepted"`
+	AcceptanceRate  float64 `json:"acceptance_rate"`    // Accepted / RequestsSent
+	AvgDaysToAccept float64 `json:"avg_days_to_accept"` // Mean time from request to connection (0 when none accepted)
+}
+
+// OpenToWorkRate is the share of the profiles checked for the "Open to Work" frame that
+// show it
+type OpenToWorkRate struct {
+	Checked    int64   `json:"checked"`
+	OpenToWork int64   `json:"open_to_work"`
+	Rate       float64 `json:"rate"` // OpenToWork / Checked
+}
+
+// TemplatePerformance counts the outgoing messages built from a template and how many
+// of them got a reply
+type TemplatePerformance struct {
+	TemplateName string  `json:"template_name"`
+	Sent         int64   `json:"sent"`
+	Replied      int64   `json:"replied"`    // Followed by an incoming message from the same profile
+	ReplyRate    float64 `json:"reply_rate"` // Replied / Sent
+}
+
+// Orders in which pending follow-ups are returned
+const (
+	FollowUpOrderNewest = "newest"
+	FollowUpOrderOldest = "oldest"
+)
+
+// FollowUpWindow selects the connections due a follow-up by how long ago they connected
+type FollowUpWindow struct {
+	MinDaysSinceConnected     int    // Skip connections newer than this (0 = no minimum)
+	MaxDaysSinceConnected     int    // Skip connections older than this (0 = no maximum)
+	Order                     string // newest (default) or oldest connected_at first
+	IncludeUnknownConnectedAt bool   // Include rows without connected_at regardless of the day limits; they sort as the oldest
+	// Sequence is the follow-up sequence; only profiles whose next step is due are
+	// returned. Empty means a single message step on day 0.
+	Sequence []SequenceStep
+}
+
+// ProfileFilter narrows a profile search. Zero-valued fields are ignored.
+type ProfileFilter struct {
+	IDs             []uint        `json:"-"`
+	Status          ProfileStatus `json:"status,omitempty"`
+	Campaign        string        `json:"campaign,omitempty"`
+	CreatedAfter    time.Time     `json:"created_after,omitempty"`
+	CreatedBefore   time.Time     `json:"created_before,omitempty"`
+	ConnectedAfter  time.Time     `json:"connected_after,omitempty"`
+	ConnectedBefore time.Time     `json:"connected_before,omitempty"`
+	Query           string        `json:"query,omitempty"` // Case-insensitive substring of the URL, name, headline or current company
+}
+
 // History represents an action log entry
 type History struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	ActionType string   `gorm:"index;not null" json:"action_type"` // Login, Search, Connect
-	Details   string    `gorm:"type:text" json:"details"`
-	Timestamp time.Time `gorm:"index;not null" json:"timestamp"`
+	ID         uint           `gorm:"primaryKey" json:"id"`
+	Account    string         `gorm:"index;not null;default:'default'" json:"account"`
+	ActionType string         `gorm:"index:idx_histories_action_timestamp;not null" json:"action_type"` // Login, Search, Connect
+	Details    string         `gorm:"type:text" json:"details"`                                         // JSON HistoryDetails (free text in older rows); read with ParsedDetails
+	Campaign   string         `gorm:"index" json:"campaign,omitempty"`                                  // Campaign the action was taken for, used for campaign budgets
+	Keyword    string         `json:"keyword,omitempty"`                                                // Search keyword the action came from, if any
+	RunID      *uint          `gorm:"index" json:"run_id,omitempty"`                                    // RunMetadata.ID of the run that took the action, if any
+	Timestamp  time.Time      `gorm:"index;index:idx_histories_action_timestamp;not null" json:"timestamp"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"` // Set by the retention purge until the row is hard-deleted
+}
+
+// DailyProjection is a pre-computed count of one action type per UTC day and search
+// keyword, kept up to date as history is written so stats don't scan all history
+type DailyProjection struct {
+	Account      string    `gorm:"uniqueIndex:idx_analytics_daily_account;not null;default:'default'" json:"account"`
+	Date         time.Time `gorm:"type:date;uniqueIndex:idx_analytics_daily_account;not null" json:"date"`
+	ActionType   string    `gorm:"uniqueIndex:idx_analytics_daily_account;not null" json:"action_type"`
+	Keyword      string    `gorm:"uniqueIndex:idx_analytics_daily_account;not null;default:''" json:"keyword"`
+	Count        int64     `gorm:"not null" json:"count"`         // Successes plus errors logged as "<action>: ..."
+	SuccessCount int64     `gorm:"not null" json:"success_count"` // Entries of the action type itself
+}
+
+// TableName stores projections in analytics_daily
+func (DailyProjection) TableName() string {
+	return "analytics_daily"
 }
 
 // Task represents a workflow task
 type Task struct {
-	Type        string                 `json:"type"`         // Auth, Search, Connect
-	Params      map[string]interface{} `json:"params"`       // Task-specific parameters
-	Priority    int                    `json:"priority"`     // Task priority (higher = more important)
-	RetryCount  int                    `json:"retry_count"`  // Number of retries attempted
-	MaxRetries  int                    `json:"max_retries"`  // Maximum retries allowed
+	Type       string                 `json:"type"`        // Auth, Search, Connect
+	Params     map[string]interface{} `json:"params"`      // Task-specific parameters
+	Priority   int                    `json:"priority"`    // Task priority (higher = more important)
+	RetryCount int                    `json:"retry_count"` // Number of retries attempted
+	MaxRetries int                    `json:"max_retries"` // Maximum retries allowed
 }
 
 // SearchParams holds parameters for a search operation
 type SearchParams struct {
-	Keyword     string `json:"keyword"`
-	MaxResults  int    `json:"max_results"`
-	Location    string `json:"location,omitempty"`
-	Industry    string `json:"industry,omitempty"`
+	Keyword    string   `json:"keyword"`
+	MaxResults int      `json:"max_results"`
+	Location   string   `json:"location,omitempty"`
+	Industry   string   `json:"industry,omitempty"`
+	GroupURLs  []string `json:"group_urls,omitempty"` // Source profiles from these group member lists
+	Campaign   string   `json:"campaign,omitempty"`   // Tag newly discovered profiles with this campaign
+	Skills     []string `json:"skills,omitempty"`     // Only return people listing one of these skills
 }
 
 // ConnectParams holds parameters for a connection request
@@ -59,60 +483,422 @@ type ConnectParams struct {
 	ProfileURL string `json:"profile_url"`
 	Note       string `json:"note"`
 	Name       string `json:"name,omitempty"`
+	Campaign   string `json:"campaign,omitempty"` // Assign the profile to this campaign and count the request against its budget
+	Keyword    string `json:"keyword,omitempty"`  // Search keyword the profile was found with, recorded in history
+}
+
+// DatabaseConfig selects the database backend. SQLite uses Path; server databases
+// such as Postgres use DSN and the connection pool settings.
+type DatabaseConfig struct {
+	Driver                 string          `mapstructure:"driver"` // sqlite (default) or postgres
+	Path                   string          `mapstructure:"path"`
+	DSN                    string          `mapstructure:"dsn"`
+	MaxOpenConns           int             `mapstructure:"max_open_conns"`
+	MaxIdleConns           int             `mapstructure:"max_idle_conns"`
+	ConnMaxLifetimeMinutes int             `mapstructure:"conn_max_lifetime_minutes"`
+	CacheEnabled           bool            `mapstructure:"cache_enabled"`          // Cache profile lookups in memory
+	CacheSize              int             `mapstructure:"cache_size"`             // Most profiles kept in the cache
+	UpsertStatus           string          `mapstructure:"upsert_status"`          // transition (default), keep or force; see UpsertProfile
+	SQLiteJournalMode      string          `mapstructure:"sqlite_journal_mode"`    // wal (default), delete, truncate, persist, memory or off
+	SQLiteBusyTimeoutMs    int             `mapstructure:"sqlite_busy_timeout_ms"` // How long a locked database is retried before failing
+	SQLiteForeignKeys      bool            `mapstructure:"sqlite_foreign_keys"`    // Enforce foreign key constraints
+	SQLiteMaxOpenConns     int             `mapstructure:"sqlite_max_open_conns"`  // 1 (default) serializes access in the pool
+	SQLiteMaxIdleConns     int             `mapstructure:"sqlite_max_idle_conns"`
+	Account                string          `mapstructure:"account"`        // Profiles, history and messages are kept apart per account in a shared database
+	Timezone               string          `mapstructure:"timezone"`       // IANA zone whose midnight resets daily limits (empty = system local time)
+	EncryptionKey          string          `mapstructure:"encryption_key"` // SQLCipher key of the SQLite file; set LINKEDIN_BOT_DATABASE_ENCRYPTION_KEY rather than the config file
+	MigrateEncryption      bool            `mapstructure:"-"`              // Encrypt a plaintext SQLite file in place when a key is set (-migrate-encryption)
+	Retention              RetentionConfig `mapstructure:"retention"`
+	Backup                 BackupConfig    `mapstructure:"backup"`
+}
+
+// Location returns the time zone named by Timezone, or the system's local time zone
+// when it is empty
+func (c *DatabaseConfig) Location() (*time.Location, error) {
+	if c.Timezone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(c.Timezone)
+}
+
+// BackupConfig sets up the automatic backups 'bot serve' takes of the SQLite database
+type BackupConfig struct {
+	IntervalHours int    `mapstructure:"interval_hours"` // How often 'bot serve' takes a backup (0 = never)
+	Dir           string `mapstructure:"dir"`            // Where automatic backups and 'bot backup' without -out write
+	Keep          int    `mapstructure:"keep"`           // Newest automatic backups kept; older ones are deleted
+}
+
+// RetentionConfig sets how long data is kept before the purge removes it. A zero number
+// of days keeps that category forever.
+type RetentionConfig struct {
+	ProfileDays        int `mapstructure:"profile_days"`         // Discovered/Ignored profiles not updated for this long
+	HistoryDays        int `mapstructure:"history_days"`         // History entries older than this
+	DebugArtifactDays  int `mapstructure:"debug_artifact_days"`  // data/debug_*.html page dumps older than this
+	GraceDays          int `mapstructure:"grace_days"`           // Soft-deleted rows are hard-deleted after this long
+	PurgeIntervalHours int `mapstructure:"purge_interval_hours"` // How often 'bot serve' runs the purge (0 = never)
+}
+
+// PurgeCounts is what a purge did to one category of data
+type PurgeCounts struct {
+	SoftDeleted int64 `json:"soft_deleted"` // Newly expired rows hidden from queries
+	Deleted     int64 `json:"deleted"`      // Rows (or files) removed for good
+}
+
+// PurgeResult reports a retention purge per category
+type PurgeResult struct {
+	Profiles       PurgeCounts `json:"profiles"`
+	Messages       PurgeCounts `json:"messages"` // Messages, endorsements and group links of deleted profiles
+	History        PurgeCounts `json:"history"`
+	DebugArtifacts PurgeCounts `json:"debug_artifacts"`
+}
+
+// CacheConfig holds expiry settings for the in-memory repository cache
+type CacheConfig struct {
+	ProfileTTL time.Duration `mapstructure:"profile_ttl"` // How long a cached profile lookup is served
+}
+
+// SecurityConfig holds settings for security challenges shown during login
+type SecurityConfig struct {
+	SolveTextChallenges bool `mapstructure:"solve_text_challenges"` // Answer simple "What is 4+7?" / "Enter the code" challenges before waiting for manual solving
+}
+
+// ApiConfig holds settings for the read-only REST API over the database
+type ApiConfig struct {
+	Enabled          bool   `mapstructure:"enabled"`           // Serve the API while the bot runs ("bot serve" always serves it)
+	Listen           string `mapstructure:"listen"`            // Address to listen on, e.g. 127.0.0.1:8080
+	DashboardEnabled bool   `mapstructure:"dashboard_enabled"` // Serve the analytics dashboard at /dashboard
+	SSEEnabled       bool   `mapstructure:"sse_enabled"`       // Stream log lines as server-sent events at /events (experimental)
+	AuthToken        string `mapstructure:"auth_token"`        // When set, every request needs it as the basic-auth password or a bearer token
+	JobsEnabled      bool   `mapstructure:"jobs_enabled"`      // Accept POST /jobs and run queued jobs in "bot serve" (requires AuthToken)
 }
 
 // StealthConfig holds stealth/humanization parameters
 type StealthConfig struct {
-	TypingSpeedMin   int     `mapstructure:"typing_speed_min"`   // WPM minimum
-	TypingSpeedMax   int     `mapstructure:"typing_speed_max"`   // WPM maximum
-	TypoProbability  float64 `mapstructure:"typo_probability"`    // Probability of typo (0.0-1.0)
-	MouseSpeedMin    float64 `mapstructure:"mouse_speed_min"`     // Minimum mouse speed multiplier
-	MouseSpeedMax    float64 `mapstructure:"mouse_speed_max"`     // Maximum mouse speed multiplier
-	OvershootChance  float64 `mapstructure:"overshoot_chance"`    // Chance of mouse overshoot (0.0-1.0)
-	OvershootDistMin float64 `mapstructure:"overshoot_dist_min"`  // Min overshoot distance factor
-	OvershootDistMax float64 `mapstructure:"overshoot_dist_max"`  // Max overshoot distance factor
-	ControlPointOffsetMin float64 `mapstructure:"control_point_offset_min"` // Min control point offset
-	ControlPointOffsetMax float64 `mapstructure:"control_point_offset_max"` // Max control point offset
-	ControlPointSpreadMin float64 `mapstructure:"control_point_spread_min"` // Min control point spread
-	ControlPointSpreadMax float64 `mapstructure:"control_point_spread_max"` // Max control point spread
-	ScrollChunkMin   int     `mapstructure:"scroll_chunk_min"`    // Minimum scroll chunk size
-	ScrollChunkMax   int     `mapstructure:"scroll_chunk_max"`    // Maximum scroll chunk size
-	BaseDelayMin     float64 `mapstructure:"base_delay_min"`      // Minimum base delay in seconds
-	BaseDelayMax     float64 `mapstructure:"base_delay_max"`      // Maximum base delay in seconds
-	ViewportWidthMin int     `mapstructure:"viewport_width_min"`  // Minimum viewport width
-	ViewportWidthMax int     `mapstructure:"viewport_width_max"`  // Maximum viewport width
-	ViewportHeightMin int    `mapstructure:"viewport_height_min"` // Minimum viewport height
-	ViewportHeightMax int    `mapstructure:"viewport_height_max"` // Maximum viewport height
-	DebugStealth      bool   `mapstructure:"debug_stealth"`       // Enable stealth debugging (slows down actions)
+	TypingSpeedMin               int                  `mapstructure:"typing_speed_min"`                // WPM minimum
+	TypingSpeedMax               int                  `mapstructure:"typing_speed_max"`                // WPM maximum
+	TypoProbability              float64              `mapstructure:"typo_probability"`                // Probability of typo (0.0-1.0)
+	MouseProfile                 string               `mapstructure:"mouse_profile"`                   // Mouse preset: cautious, normal, confident or random
+	MouseSpeedMin                float64              `mapstructure:"mouse_speed_min"`                 // Minimum mouse speed multiplier
+	MouseSpeedMax                float64              `mapstructure:"mouse_speed_max"`                 // Maximum mouse speed multiplier
+	OvershootChance              float64              `mapstructure:"overshoot_chance"`                // Chance of mouse overshoot (0.0-1.0)
+	OvershootDistMin             float64              `mapstructure:"overshoot_dist_min"`              // Min overshoot distance factor
+	OvershootDistMax             float64              `mapstructure:"overshoot_dist_max"`              // Max overshoot distance factor
+	ControlPointOffsetMin        float64              `mapstructure:"control_point_offset_min"`        // Min control point offset
+	ControlPointOffsetMax        float64              `mapstructure:"control_point_offset_max"`        // Max control point offset
+	ControlPointSpreadMin        float64              `mapstructure:"control_point_spread_min"`        // Min control point spread
+	ControlPointSpreadMax        float64              `mapstructure:"control_point_spread_max"`        // Max control point spread
+	ScrollChunkMin               int                  `mapstructure:"scroll_chunk_min"`                // Minimum scroll chunk size
+	ScrollChunkMax               int                  `mapstructure:"scroll_chunk_max"`                // Maximum scroll chunk size
+	BaseDelayMin                 float64              `mapstructure:"base_delay_min"`                  // Minimum base delay in seconds
+	BaseDelayMax                 float64              `mapstructure:"base_delay_max"`                  // Maximum base delay in seconds
+	ViewportWidthMin             int                  `mapstructure:"viewport_width_min"`              // Minimum viewport width
+	ViewportWidthMax             int                  `mapstructure:"viewport_width_max"`              // Maximum viewport width
+	ViewportHeightMin            int                  `mapstructure:"viewport_height_min"`             // Minimum viewport height
+	ViewportHeightMax            int                  `mapstructure:"viewport_height_max"`             // Maximum viewport height
+	DebugStealth                 bool                 `mapstructure:"debug_stealth"`                   // Enable stealth debugging (slows down actions)
+	UseKeyboardNavigation        bool                 `mapstructure:"use_keyboard_navigation"`         // Type some URLs into the address bar instead of navigating directly
+	ThrottleRecoveryPauseMinutes int                  `mapstructure:"throttle_recovery_pause_minutes"` // Pause after slow page loads suggest throttling
+	TypingPausePatterns          []PausePattern       `mapstructure:"typing_pause_patterns"`           // Thought pauses while typing
+	IMESimulation                bool                 `mapstructure:"ime_simulation"`                  // Type non-ASCII characters through IME composition events, as a dead key or input method does
+	IdleMouseDrift               IdleMouseDriftConfig `mapstructure:"idle_mouse_drift"`                // Small mouse movements while a page is read
+	RandomSeed                   int64                `mapstructure:"random_seed"`                     // Fixed seed for reproducible test runs (0 = seed from the clock). Never set in production
+}
+
+// IdleMouseDriftConfig controls the mouse drift after each page load
+type IdleMouseDriftConfig struct {
+	Enabled     bool    `mapstructure:"enabled"`
+	DriftRadius float64 `mapstructure:"drift_radius"` // Max distance from the starting point in pixels
+	DriftSteps  int     `mapstructure:"drift_steps"`  // Number of small movements, 50-100ms apart
+}
+
+// PausePattern inserts a thought pause while typing after roughly every AfterWord words
+type PausePattern struct {
+	AfterWord int        `mapstructure:"after_word"` // Words between pauses, varied by up to 25%
+	Duration  [2]float64 `mapstructure:"duration"`   // Pause length range [min, max] in seconds
+}
+
+// BrowserConfig holds browser launch settings
+type BrowserConfig struct {
+	RandomizeLaunchArgs bool                    `mapstructure:"randomize_launch_args"` // Vary optional Chrome flags between sessions
+	EventBatchSize      int                     `mapstructure:"event_batch_size"`      // Mouse-move events sent together during a click
+	EventIntervalMs     []int                   `mapstructure:"event_interval_ms"`     // [min, max] pause between batches in milliseconds
+	KeyboardNavProb     float64                 `mapstructure:"keyboard_nav_prob"`     // Share of navigations typed into the address bar (see StealthConfig.UseKeyboardNavigation)
+	ClickVerification   ClickVerificationConfig `mapstructure:"click_verification"`
+}
+
+// ClickVerificationConfig controls checking that key clicks took effect, retrying them
+// with a JavaScript click when they didn't (see Instance.HumanClickVerified)
+type ClickVerificationConfig struct {
+	Enabled            bool   `mapstructure:"enabled"`
+	SelectorAfterClick string `mapstructure:"selector_after_click"` // Expected to appear after the Connect click (the invitation modal)
+}
+
+// DebugConfig holds settings for diagnosing the bot itself
+type DebugConfig struct {
+	PProfAddr string `mapstructure:"pprof_addr"` // Address of the net/http/pprof server started with -pprof
 }
 
 // LimitsConfig holds rate limiting and working hours configuration
 type LimitsConfig struct {
-	MaxActionsPerDay int    `mapstructure:"max_actions_per_day"`
-	WorkingHoursStart string `mapstructure:"working_hours_start"` // Format: "09:00"
-	WorkingHoursEnd   string `mapstructure:"working_hours_end"`   // Format: "17:00"
-	ConnectCooldownMin int   `mapstructure:"connect_cooldown_min"` // Minutes
-	ConnectCooldownMax int   `mapstructure:"connect_cooldown_max"` // Minutes
+	MaxActionsPerDay          int    `mapstructure:"max_actions_per_day"`
+	WorkingHoursStart         string `mapstructure:"working_hours_start"`          // Format: "09:00"
+	WorkingHoursEnd           string `mapstructure:"working_hours_end"`            // Format: "17:00"
+	ConnectCooldownMin        int    `mapstructure:"connect_cooldown_min"`         // Minutes
+	ConnectCooldownMax        int    `mapstructure:"connect_cooldown_max"`         // Minutes
+	TargetConnectionsPerHour  int    `mapstructure:"target_connections_per_hour"`  // Pace cooldowns toward this rate, within the cooldown bounds (0 = random cooldowns)
+	MaxSessionDurationMinutes int    `mapstructure:"max_session_duration_minutes"` // Stop sending requests after this long (0 = unlimited)
+	SessionEndBehavior        string `mapstructure:"session_end_behavior"`         // stop (default), warmdown or schedule_continuation
+}
+
+// TargetingConfig holds filters applied to discovered profiles
+type TargetingConfig struct {
+	MaxProfileInactiveDays int      `mapstructure:"max_profile_inactive_days"` // Skip profiles inactive longer than this (0 = disabled)
+	RequireOpenToWork      bool     `mapstructure:"require_open_to_work"`      // Only connect with profiles marked "Open to Work"
+	ExcludeOpenToWork      bool     `mapstructure:"exclude_open_to_work"`      // Skip profiles marked "Open to Work"
+	Blacklist              []string `mapstructure:"blacklist"`                 // Profile URLs never to connect with or engage
+	RequiredProfileSkills  []string `mapstructure:"required_profile_skills"`   // Skip profiles listing none of these skills (empty = disabled)
+	MinFollowerCount       int      `mapstructure:"min_follower_count"`        // Skip profiles with fewer followers (0 = disabled)
+	MaxFollowerCount       int      `mapstructure:"max_follower_count"`        // Skip profiles with more followers, e.g. influencers (0 = disabled)
+}
+
+// EngagementConfig holds settings for warm-up engagement such as liking posts
+type EngagementConfig struct {
+	MaxLikesPerDay    int      `mapstructure:"max_likes_per_day"`
+	LikeBeforeConnect bool     `mapstructure:"like_before_connect"` // Like the profile's most recent post before sending a request
+	MaxFeedScrolls    int      `mapstructure:"max_feed_scrolls"`    // Stop looking for posts to like after this many scrolls
+	Keywords          []string `mapstructure:"keywords"`            // Only like feed posts mentioning one of these (empty = any)
+
+	// Commenting is the riskiest action type, so it is limited to allowlisted authors
+	MaxCommentsPerDay   int      `mapstructure:"max_comments_per_day"`
+	CommentCooldownDays int      `mapstructure:"comment_cooldown_days"` // Never comment on the same author twice within this many days
+	CommentDryRun       bool     `mapstructure:"comment_dry_run"`       // Render and log comments without posting them
+	CommentTemplate     string   `mapstructure:"comment_template"`      // Default template, supports {{FirstName}} and {{Snippet}}
+	CommentSnippet      string   `mapstructure:"comment_snippet"`       // Value for {{Snippet}}
+	CommentAllowlist    []string `mapstructure:"comment_allowlist"`     // Author profile URLs or campaign names eligible for comments
+
+	MaxFollowsPerDay int      `mapstructure:"max_follows_per_day"`
+	FollowCompanies  []string `mapstructure:"follow_companies"` // Company page URLs to follow before those from enriched profiles
+
+	// Post types liked before connecting (see PostType constants; empty = any)
+	PreferredPostTypes   []string `mapstructure:"preferred_post_types"`
+	RequirePreferredType bool     `mapstructure:"require_preferred_type"` // Skip the like when none of the recent posts has a preferred type
+}
+
+// Post types told apart on a profile's activity page
+const (
+	PostTypeArticle = "article"
+	PostTypeImage   = "image"
+	PostTypeText    = "text"
+	PostTypeVideo   = "video"
+	PostTypeEvent   = "event"
+)
+
+// EnrichmentConfig holds settings for profile enrichment
+type EnrichmentConfig struct {
+	BatchLimit   int  `mapstructure:"batch_limit"`   // Profiles enriched per standalone run
+	AfterConnect bool `mapstructure:"after_connect"` // Enrich inline after a successful connection request
+}
+
+// News APIs the news template enricher can use
+const (
+	NewsProviderNewsAPI = "newsapi"
+	NewsProviderBing    = "bing"
+)
+
+// EnrichersConfig holds the external APIs that fill in note template variables LinkedIn
+// pages don't show. Each enricher is enabled by setting its API key.
+type EnrichersConfig struct {
+	NewsProvider     string `mapstructure:"news_provider"`      // newsapi (default) or bing
+	NewsAPIKey       string `mapstructure:"news_api_key"`       // Enables {{LatestCompanyNews}}
+	CrunchbaseAPIKey string `mapstructure:"crunchbase_api_key"` // Enables {{LatestFunding}}
+	TimeoutSeconds   int    `mapstructure:"timeout_seconds"`    // Per API request
+}
+
+// ObservabilityConfig holds the ways the bot reports what it does besides the logs
+type ObservabilityConfig struct {
+	Webhooks WebhooksConfig `mapstructure:"webhooks"`
+}
+
+// WebhooksConfig holds settings for posting alerts to a Slack or Discord incoming webhook
+type WebhooksConfig struct {
+	URL           string   `mapstructure:"url"`            // Slack or Discord incoming webhook URL (empty = disabled)
+	Events        []string `mapstructure:"events"`         // Alert events to post (see AlertEvent constants)
+	Mention       string   `mapstructure:"mention"`        // Prepended to every message, e.g. "<@U012AB3CD>" or "<!here>"
+	DigestSeconds int      `mapstructure:"digest_seconds"` // Alerts of one event within this long of the last post are sent as one digest
+}
+
+// ReportsConfig holds the report files written after each run
+type ReportsConfig struct {
+	HTMLPath string `mapstructure:"html_path"` // Rewrite this HTML report after each run (empty = disabled)
+	HTMLDays int    `mapstructure:"html_days"` // Days the HTML report covers, up to today
+}
+
+// SMTPConfig holds the mail server the daily summary email is sent through
+type SMTPConfig struct {
+	Host        string   `mapstructure:"host"`
+	Port        int      `mapstructure:"port"`     // 587 uses STARTTLS, 465 implicit TLS
+	Username    string   `mapstructure:"username"` // Empty = send without authenticating
+	Password    string   `mapstructure:"password"`
+	From        string   `mapstructure:"from"`
+	To          []string `mapstructure:"to"`
+	DailyReport bool     `mapstructure:"daily_report"` // bot serve emails each active day's summary after working hours
+}
+
+// Alert events a notifier can be configured to deliver
+const (
+	AlertEventCaptcha            = "captcha"             // A security check needs solving in the browser
+	AlertEventInviteLimit        = "invite_limit"        // LinkedIn's weekly invitation limit was hit
+	AlertEventRunFinished        = "run_finished"        // An automation run ended
+	AlertEventConnectionAccepted = "connection_accepted" // Someone accepted a connection request
+)
+
+// Alert is one event delivered through a NotifierPort
+type Alert struct {
+	Event string // See AlertEvent constants
+	Text  string // One line, e.g. "Jane Doe accepted your connection request"
+	URL   string // Optional link, e.g. the profile
+}
+
+// PersonalizationConfig holds settings for personalizing notes beyond the profile's own data
+type PersonalizationConfig struct {
+	CompanyResearch CompanyResearchConfig `mapstructure:"company_research"`
+}
+
+// Sources of company talking points
+const (
+	CompanyResearchSourceFile       = "file"       // User-curated YAML file
+	CompanyResearchSourceCrunchbase = "crunchbase" // Crunchbase organization API (enrichers.crunchbase_api_key)
+	CompanyResearchSourceBing       = "bing"       // Headlines scraped from Bing News search results
+)
+
+// CompanyResearchConfig holds settings for {{TalkingPoint}}, a talking point about the
+// profile's current company
+type CompanyResearchConfig struct {
+	Enabled           bool   `mapstructure:"enabled"`
+	DataSource        string `mapstructure:"data_source"`         // file (default), crunchbase or bing
+	TalkingPointsPath string `mapstructure:"talking_points_path"` // YAML file of company name -> talking points, for the file source
+	CacheTTLHours     int    `mapstructure:"cache_ttl_hours"`     // How long fetched talking points are reused
+}
+
+// IntegrationsConfig holds the external tools data is pushed to
+type IntegrationsConfig struct {
+	GoogleSheets GoogleSheetsConfig `mapstructure:"google_sheets"`
+}
+
+// GoogleSheetsConfig holds settings for appending new connections and daily stats to a
+// spreadsheet shared with a Google service account
+type GoogleSheetsConfig struct {
+	Enabled          bool   `mapstructure:"enabled"`
+	SpreadsheetID    string `mapstructure:"spreadsheet_id"`     // From the spreadsheet URL: /spreadsheets/d/<id>/edit
+	SheetName        string `mapstructure:"sheet_name"`         // Tab new connections are appended to
+	SummarySheetName string `mapstructure:"summary_sheet_name"` // Tab with one row of stats per day
+	CredentialsPath  string `mapstructure:"credentials_path"`   // Service account key JSON
+}
+
+// VisitConfig holds settings for visit-only passes that trigger "viewed your profile" notifications
+type VisitConfig struct {
+	MaxPerDay         int     `mapstructure:"max_per_day"`
+	DwellMin          float64 `mapstructure:"dwell_min"` // Seconds spent reading each profile
+	DwellMax          float64 `mapstructure:"dwell_max"`
+	DaysBeforeConnect int     `mapstructure:"days_before_connect"` // Only connect with profiles visited at least this many days ago (0 = disabled)
+}
+
+// PrefetchConfig holds settings for loading search results in parallel browsers
+type PrefetchConfig struct {
+	Enabled     bool `mapstructure:"enabled"`
+	Concurrency int  `mapstructure:"concurrency"` // Browsers fetching profiles at the same time
+}
+
+// SearchConfig holds settings for discovering profiles to connect with
+type SearchConfig struct {
+	Mode         string             `mapstructure:"mode"` // people (default) or sales_navigator
+	FeedScraping FeedScrapingConfig `mapstructure:"feed_scraping"`
+}
+
+// Supported values of search.mode
+const (
+	SearchModePeople         = "people"          // People search with the LinkedIn session
+	SearchModeSalesNavigator = "sales_navigator" // Load the Sales Navigator session before searching
+)
+
+// SalesNavigatorConfig holds settings for the Sales Navigator session
+type SalesNavigatorConfig struct {
+	Enabled     bool   `mapstructure:"enabled"`      // Sign in to Sales Navigator after the LinkedIn login
+	CookiesPath string `mapstructure:"cookies_path"` // Sales Navigator session, kept apart from session.cookies_path
+}
+
+// FeedScrapingConfig holds settings for discovering profiles from home feed posts
+type FeedScrapingConfig struct {
+	Enabled    bool     `mapstructure:"enabled"`     // Discover connect targets from the feed instead of people search
+	Keywords   []string `mapstructure:"keywords"`    // Topics a post must mention (empty = the -keyword flag)
+	MaxScrolls int      `mapstructure:"max_scrolls"` // Stop reading the feed after this many scrolls
+}
+
+// GroupsConfig holds settings for joining LinkedIn groups
+type GroupsConfig struct {
+	MaxJoinsPerWeek int `mapstructure:"max_joins_per_week"`
+}
+
+// CelebrationConfig holds settings for birthday and work anniversary messages
+type CelebrationConfig struct {
+	MaxPerDay           int    `mapstructure:"max_per_day"`
+	BirthdayTemplate    string `mapstructure:"birthday_template"`    // Supports {{FirstName}}
+	AnniversaryTemplate string `mapstructure:"anniversary_template"` // Supports {{FirstName}} and {{Years}}
+}
+
+// InMailConfig holds settings for InMail outreach to profiles outside the network
+type InMailConfig struct {
+	MonthlyCredits int    `mapstructure:"monthly_credits"` // InMails the bot may send per calendar month
+	Subject        string `mapstructure:"subject"`         // Supports {{FirstName}}
+	Template       string `mapstructure:"template"`        // Supports {{FirstName}}
+}
+
+// InvitationRule matches incoming invitations by a regular expression on one field
+type InvitationRule struct {
+	Name    string `mapstructure:"name"`    // Recorded with each decision the rule triggers
+	Field   string `mapstructure:"field"`   // name, headline or url (empty = name and headline)
+	Pattern string `mapstructure:"pattern"` // Go regular expression, e.g. "(?i)recruit"
+}
+
+// InvitationsConfig holds settings for handling incoming invitations
+type InvitationsConfig struct {
+	MaxAcceptsPerDay int              `mapstructure:"max_accepts_per_day"`
+	Include          []InvitationRule `mapstructure:"include"`         // Accept invitations matching any of these
+	Exclude          []InvitationRule `mapstructure:"exclude"`         // Never accept invitations matching any of these
+	IgnoreExcluded   bool             `mapstructure:"ignore_excluded"` // Click Ignore on excluded invitations instead of leaving them
+
+	// Spam rules, checked before everything else. Matching invitations are always ignored.
+	Decline          []InvitationRule `mapstructure:"decline"`
+	DeclineNoMutuals bool             `mapstructure:"decline_no_mutuals"` // Ignore inviters with zero mutual connections
+	DeclineNoPhoto   bool             `mapstructure:"decline_no_photo"`   // Ignore inviters without a profile photo
 }
 
 // SelectorsConfig holds CSS/XPath selectors
 type SelectorsConfig struct {
-	LoginEmailInput    string `mapstructure:"login_email_input"`
-	LoginPasswordInput string `mapstructure:"login_password_input"`
-	LoginSubmitButton  string `mapstructure:"login_submit_button"`
-	SearchInput        string `mapstructure:"search_input"`
-	SearchResults      string `mapstructure:"search_results"`
-	ProfileConnectBtn  string `mapstructure:"profile_connect_button"`
+	LoginEmailInput               string   `mapstructure:"login_email_input"`
+	LoginPasswordInput            string   `mapstructure:"login_password_input"`
+	LoginSubmitButton             string   `mapstructure:"login_submit_button"`
+	SearchInput                   string   `mapstructure:"search_input"`
+	SearchResults                 string   `mapstructure:"search_results"`
+	ProfileConnectBtn             string   `mapstructure:"profile_connect_button"`
 	ProfileConnectButtonFallbacks []string `mapstructure:"profile_connect_button_fallbacks"`
-	ProfileMoreButton  string `mapstructure:"profile_more_button"`
-	ProfileMoreButtonFallbacks []string `mapstructure:"profile_more_button_fallbacks"`
-	ProfileMoreConnectOption string `mapstructure:"profile_more_connect_option"`
+	ProfileMoreButton             string   `mapstructure:"profile_more_button"`
+	ProfileMoreButtonFallbacks    []string `mapstructure:"profile_more_button_fallbacks"`
+	ProfileMoreConnectOption      string   `mapstructure:"profile_more_connect_option"`
 	ProfileConnectOptionFallbacks []string `mapstructure:"profile_connect_option_fallbacks"`
-	ConnectModalAddNoteButton string `mapstructure:"connect_modal_add_note_button"`
-	ConnectNoteTextarea string `mapstructure:"connect_note_textarea"`
-	ConnectSendButton  string `mapstructure:"connect_send_button"`
-	TwoFactorChallenge string `mapstructure:"two_factor_challenge"`
-	FeedContainer      string `mapstructure:"feed_container"`
+	ConnectModalAddNoteButton     string   `mapstructure:"connect_modal_add_note_button"`
+	ConnectNoteTextarea           string   `mapstructure:"connect_note_textarea"`
+	ConnectSendButton             string   `mapstructure:"connect_send_button"`
+	TwoFactorChallenge            string   `mapstructure:"two_factor_challenge"`
+	FeedContainer                 string   `mapstructure:"feed_container"`
+
+	Version    int    `mapstructure:"version"`     // Version of the selector set, compared against remote updates
+	RemoteURL  string `mapstructure:"remote_url"`  // Community-maintained selectors JSON
+	AutoUpdate bool   `mapstructure:"auto_update"` // Check RemoteURL for newer selectors at startup
+
+	DefaultTimeout int            `mapstructure:"default_timeout"` // Seconds to wait for an element
+	QuickTimeout   int            `mapstructure:"quick_timeout"`   // Seconds to probe for an optional element or a fallback selector
+	Timeouts       map[string]int `mapstructure:"timeouts"`        // Per-selector wait in seconds, keyed by selector name (e.g. search_results) or selector string
 }
 
 // Config represents the application configuration
@@ -121,32 +907,86 @@ type Config struct {
 		Email    string `mapstructure:"email"`
 		Password string `mapstructure:"password"`
 	} `mapstructure:"credentials"`
-	
-	Stealth  StealthConfig  `mapstructure:"stealth"`
-	Limits   LimitsConfig   `mapstructure:"limits"`
-	Selectors SelectorsConfig `mapstructure:"selectors"`
-	
+
+	Stealth         StealthConfig         `mapstructure:"stealth"`
+	Browser         BrowserConfig         `mapstructure:"browser"`
+	Security        SecurityConfig        `mapstructure:"security"`
+	Api             ApiConfig             `mapstructure:"api"`
+	Limits          LimitsConfig          `mapstructure:"limits"`
+	Targeting       TargetingConfig       `mapstructure:"targeting"`
+	Engagement      EngagementConfig      `mapstructure:"engagement"`
+	Enrichment      EnrichmentConfig      `mapstructure:"enrichment"`
+	Enrichers       EnrichersConfig       `mapstructure:"enrichers"`
+	Personalization PersonalizationConfig `mapstructure:"personalization"`
+	Observability   ObservabilityConfig   `mapstructure:"observability"`
+	SMTP            SMTPConfig            `mapstructure:"smtp"`
+	Debug           DebugConfig           `mapstructure:"debug"`
+	Reports         ReportsConfig         `mapstructure:"reports"`
+	Integrations    IntegrationsConfig    `mapstructure:"integrations"`
+	Visits          VisitConfig           `mapstructure:"visits"`
+	Prefetch        PrefetchConfig        `mapstructure:"prefetch"`
+	Search          SearchConfig          `mapstructure:"search"`
+	SalesNavigator  SalesNavigatorConfig  `mapstructure:"sales_navigator"`
+	Groups          GroupsConfig          `mapstructure:"groups"`
+	Celebrations    CelebrationConfig     `mapstructure:"celebrations"`
+	Invitations     InvitationsConfig     `mapstructure:"invitations"`
+	InMail          InMailConfig          `mapstructure:"inmail"`
+	Selectors       SelectorsConfig       `mapstructure:"selectors"`
+
 	LinkedIn struct {
-		BaseURL      string `mapstructure:"base_url"`
-		SearchURL    string `mapstructure:"search_url"`
-		LoginURL     string `mapstructure:"login_url"`
+		BaseURL   string `mapstructure:"base_url"`
+		SearchURL string `mapstructure:"search_url"`
+		LoginURL  string `mapstructure:"login_url"`
 	} `mapstructure:"linkedin"`
-	
-	Database struct {
-		Path string `mapstructure:"path"`
-	} `mapstructure:"database"`
-	
+
+	Database DatabaseConfig `mapstructure:"database"`
+	Cache    CacheConfig    `mapstructure:"cache"`
+
 	Connection struct {
 		NoteTemplate string `mapstructure:"note_template"`
+		NoteLength   struct {
+			Min int `mapstructure:"min"` // Shortest note in characters
+			Max int `mapstructure:"max"` // Longest note in characters (0 = don't vary)
+		} `mapstructure:"note_length"`
+		ClosingPhrases          []string `mapstructure:"closing_phrases"`           // Appended to pad short notes
+		UseIntroductionRequests bool     `mapstructure:"use_introduction_requests"` // Ask a mutual connection for an introduction before connecting
+		IntroductionTemplate    string   `mapstructure:"introduction_template"`     // Supports {{FirstName}}, {{TargetName}} and {{TargetURL}}
 	} `mapstructure:"connection"`
 
 	Messaging struct {
-		FollowUpTemplate string `mapstructure:"follow_up_template"`
-		BatchLimit       int    `mapstructure:"batch_limit"`
+		FollowUpTemplate          string `mapstructure:"follow_up_template"`
+		BatchLimit                int    `mapstructure:"batch_limit"`
+		DailyLimit                int    `mapstructure:"daily_limit"`              // Maximum messages per day
+		CooldownMin               int    `mapstructure:"cooldown_min"`             // Minutes
+		CooldownMax               int    `mapstructure:"cooldown_max"`             // Minutes
+		FollowUpCooldownMin       int    `mapstructure:"follow_up_cooldown_min"`   // Seconds, used instead of CooldownMin/Max when FollowUpCooldownMax > 0
+		FollowUpCooldownMax       int    `mapstructure:"follow_up_cooldown_max"`   // Seconds
+		RandomizeOrder            bool   `mapstructure:"randomize_order"`          // Shuffle pending follow-ups before sending
+		MaxMessagesPerSession     int    `mapstructure:"max_messages_per_session"` // Cap per bot run (0 = no cap)
+		AllowInMail               bool   `mapstructure:"allow_inmail"`             // Send via InMail when normal messaging is unavailable
+		InMailSubject             string `mapstructure:"inmail_subject"`
+		ScanMaxConnections        int    `mapstructure:"scan_max_connections"`         // Stop scanning the connections list after this many cards
+		ScanMaxScrolls            int    `mapstructure:"scan_max_scrolls"`             // Stop scanning the connections list after this many scrolls
+		MinDaysSinceConnected     int    `mapstructure:"min_days_since_connected"`     // Wait this long after acceptance before following up
+		MaxDaysSinceConnected     int    `mapstructure:"max_days_since_connected"`     // Don't follow up connections older than this (0 = no limit)
+		FollowUpOrder             string `mapstructure:"follow_up_order"`              // newest (default) or oldest connections first
+		IncludeUnknownConnectedAt bool   `mapstructure:"include_unknown_connected_at"` // Follow up legacy connections without an acceptance time
+		SendOnAccept              bool   `mapstructure:"send_on_accept"`               // Send the follow-up as soon as a scan detects the acceptance
+		MaxOnAccept               int    `mapstructure:"max_on_accept"`                // Cap on immediate follow-ups per scan
+
+		Templates         map[string]string `mapstructure:"templates"`          // Named follow-up templates
+		CampaignTemplates map[string]string `mapstructure:"campaign_templates"` // Campaign name -> template name
+
+		Sequence []SequenceStep `mapstructure:"sequence"` // Follow-up steps in order (empty = a single message)
+
+		ReplyScanLimit    int `mapstructure:"reply_scan_limit"`    // Conversations opened per reply scan
+		ThreadMaxMessages int `mapstructure:"thread_max_messages"` // Only the last N messages of a thread are extracted
+
+		AllowHTMLFormatting bool `mapstructure:"allow_html_formatting"` // Apply <b>, <i> and <a href> markup in follow-up templates
 	} `mapstructure:"messaging"`
 
 	Session struct {
 		CookiesPath string `mapstructure:"cookies_path"`
+		StatePath   string `mapstructure:"state_path"` // Run state used to resume after a crash
 	} `mapstructure:"session"`
 }
-
@@ -0,0 +1,258 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HistoryDetails is the JSON document stored in History.Details. Each action sets only the
+// fields that apply to it.
+type HistoryDetails struct {
+	ProfileURL string         `json:"profile_url,omitempty"` // Profile the action was taken on
+	Target     string         `json:"target,omitempty"`      // Other object: post URN, company, group, mutual connection, file
+	Template   string         `json:"template,omitempty"`    // Message template name
+	PostType   string         `json:"post_type,omitempty"`   // Type of the post liked (see PostType constants)
+	Outcome    string         `json:"outcome,omitempty"`     // e.g. "sent", a group join state, "accepted" or "ignored"
+	Rule       string         `json:"rule,omitempty"`        // Rule that decided the outcome
+	Keyword    string         `json:"keyword,omitempty"`     // Search keyword(s)
+	Found      int            `json:"found,omitempty"`       // Results found
+	RunID      string         `json:"run_id,omitempty"`      // Run state ID (see RunMetadata.RunID)
+	Counts     map[string]int `json:"counts,omitempty"`      // Summary counters of scans and filters
+	ErrorClass string         `json:"error_class,omitempty"` // "Error" entries: the failed action, lower case (e.g. "connect")
+	Error      string         `json:"error,omitempty"`
+	Note       string         `json:"note,omitempty"` // Free text, e.g. legacy details that could not be parsed
+}
+
+// NewHistory returns a history entry of actionType with details, timestamped now
+func NewHistory(actionType string, details HistoryDetails) *History {
+	return &History{
+		ActionType: actionType,
+		Details:    details.encode(),
+		Timestamp:  time.Now(),
+	}
+}
+
+// NewConnectHistory records a connection request sent to profileURL
+func NewConnectHistory(profileURL string) *History {
+	return NewHistory("Connect", HistoryDetails{ProfileURL: profileURL, Outcome: "sent"})
+}
+
+// NewMessageHistory records a message sent to profileURL from template
+func NewMessageHistory(profileURL, template string) *History {
+	return NewHistory("Message", HistoryDetails{ProfileURL: profileURL, Template: template})
+}
+
+// NewSearchHistory records a people search for keyword that found found profiles
+func NewSearchHistory(keyword string, found int) *History {
+	history := NewHistory("Search", HistoryDetails{Keyword: keyword, Found: found})
+	history.Keyword = keyword
+	return history
+}
+
+// NewErrorHistory records that errorClass (the action, e.g. "connect") failed for
+// profileURL, which may be empty
+func NewErrorHistory(errorClass, profileURL string, err error) *History {
+	details := HistoryDetails{ErrorClass: strings.ToLower(errorClass), ProfileURL: profileURL}
+	if err != nil {
+		details.Error = err.Error()
+	}
+	return NewHistory("Error", details)
+}
+
+func (d HistoryDetails) encode() string {
+	// Plain strings, ints and a string-keyed map always encode
+	data, _ := json.Marshal(d)
+	return string(data)
+}
+
+// ParsedDetails decodes Details, falling back to ParseLegacyHistoryDetails for entries
+// written as free text before details were JSON
+func (h *History) ParsedDetails() HistoryDetails {
+	if details, ok := decodeHistoryDetails(h.Details); ok {
+		return details
+	}
+	return ParseLegacyHistoryDetails(h.ActionType, h.Details)
+}
+
+// decodeHistoryDetails decodes a JSON details document
+func decodeHistoryDetails(raw string) (HistoryDetails, bool) {
+	var details HistoryDetails
+	if !strings.HasPrefix(strings.TrimSpace(raw), "{") {
+		return details, false
+	}
+	if err := json.Unmarshal([]byte(raw), &details); err != nil {
+		return HistoryDetails{}, false
+	}
+	return details, true
+}
+
+// IsLegacyHistoryDetails reports whether raw is free-text details still to be converted
+func IsLegacyHistoryDetails(raw string) bool {
+	if raw == "" {
+		return false
+	}
+	_, ok := decodeHistoryDetails(raw)
+	return !ok
+}
+
+// ParseLegacyHistoryDetails makes a best effort to read the free-text details written
+// for actionType before details were JSON. What can't be read is kept in Note.
+func ParseLegacyHistoryDetails(actionType, raw string) HistoryDetails {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return HistoryDetails{}
+	}
+
+	switch actionType {
+	case "Connect":
+		// "Connected to <url>"
+		if url, found := strings.CutPrefix(raw, "Connected to "); found {
+			return HistoryDetails{ProfileURL: url, Outcome: "sent"}
+		}
+	case "Error":
+		// "<action>: <message>", where the message of connect errors is "<url>: <error>"
+		if class, rest, found := strings.Cut(raw, ":"); found && class != "" && !strings.Contains(class, " ") {
+			details := HistoryDetails{ErrorClass: strings.ToLower(class), Error: strings.TrimSpace(rest)}
+			if url, message, found := strings.Cut(details.Error, ": "); found && isLegacyURL(url) {
+				details.ProfileURL = url
+				details.Error = message
+			}
+			return details
+		}
+		return HistoryDetails{ErrorClass: "unknown", Error: raw}
+	case "Message":
+		// The message body
+		return HistoryDetails{Note: raw}
+	case "IntroductionRequest":
+		// "<target> via <mutual>"
+		if target, mutual, found := strings.Cut(raw, " via "); found {
+			return HistoryDetails{ProfileURL: target, Target: mutual}
+		}
+	case "JoinGroup":
+		// "<group url> (<state>)"
+		if url, state, found := cutParenthesized(raw); found {
+			return HistoryDetails{Target: url, Outcome: state}
+		}
+	case "AcceptInvitation", "IgnoreInvitation":
+		// "<url> (rule: <rule>)"
+		if url, rule, found := cutParenthesized(raw); found {
+			outcome := "accepted"
+			if actionType == "IgnoreInvitation" {
+				outcome = "ignored"
+			}
+			return HistoryDetails{ProfileURL: url, Outcome: outcome, Rule: strings.TrimPrefix(rule, "rule: ")}
+		}
+	case "Celebration":
+		// "<kind>: <url>"; the kind is also the template name
+		if kind, url, found := strings.Cut(raw, ": "); found && isLegacyURL(url) {
+			return HistoryDetails{ProfileURL: url, Template: kind}
+		}
+	}
+
+	// A bare URL or URN
+	if isLegacyURL(raw) || strings.HasPrefix(raw, "urn:") {
+		if strings.Contains(raw, "/in/") {
+			return HistoryDetails{ProfileURL: raw}
+		}
+		return HistoryDetails{Target: raw}
+	}
+
+	// "key=value; key=value" summaries
+	if details, ok := parseLegacyPairs(raw); ok {
+		return details
+	}
+
+	return HistoryDetails{Note: raw}
+}
+
+// isLegacyURL reports whether s is a single http(s) URL
+func isLegacyURL(s string) bool {
+	return (strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://")) && !strings.ContainsAny(s, " \t")
+}
+
+// cutParenthesized splits "<head> (<inner>)"
+func cutParenthesized(s string) (string, string, bool) {
+	open := strings.LastIndex(s, " (")
+	if open < 0 || !strings.HasSuffix(s, ")") {
+		return "", "", false
+	}
+	return s[:open], s[open+2 : len(s)-1], true
+}
+
+// parseLegacyPairs reads "key=value; key=value" details. Numbers become counts, except
+// found; run_id, keyword and keywords have their own fields. Other values are kept in
+// Note together with the original text.
+func parseLegacyPairs(raw string) (HistoryDetails, bool) {
+	details := HistoryDetails{}
+	complete := true
+	for _, part := range strings.Split(raw, ";") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok || key == "" || strings.Contains(key, " ") {
+			return HistoryDetails{}, false
+		}
+		switch key {
+		case "keyword", "keywords":
+			details.Keyword = value
+		case "found":
+			details.Found, _ = strconv.Atoi(value)
+		case "run_id":
+			details.RunID = value
+		default:
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				complete = false
+				continue
+			}
+			if details.Counts == nil {
+				details.Counts = make(map[string]int)
+			}
+			details.Counts[key] = n
+		}
+	}
+	if !complete {
+		details.Note = raw
+	}
+	return details, true
+}
+
+// Summary formats the details on one line for tables, e.g. "profile_url=... outcome=sent"
+func (d HistoryDetails) Summary() string {
+	var parts []string
+	add := func(key, value string) {
+		if value != "" {
+			parts = append(parts, key+"="+value)
+		}
+	}
+	add("profile", d.ProfileURL)
+	add("target", d.Target)
+	add("template", d.Template)
+	add("post_type", d.PostType)
+	add("outcome", d.Outcome)
+	add("rule", d.Rule)
+	add("keyword", d.Keyword)
+	if d.Found > 0 {
+		add("found", strconv.Itoa(d.Found))
+	}
+	add("run_id", d.RunID)
+	keys := make([]string, 0, len(d.Counts))
+	for key := range d.Counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		add(key, strconv.Itoa(d.Counts[key]))
+	}
+	if d.ErrorClass != "" {
+		parts = append(parts, fmt.Sprintf("%s: %s", d.ErrorClass, d.Error))
+	} else {
+		add("error", d.Error)
+	}
+	if d.Note != "" {
+		parts = append(parts, d.Note)
+	}
+	return strings.Join(parts, " ")
+}
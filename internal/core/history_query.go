@@ -0,0 +1,37 @@
+package core
+
+import (
+	"errors"
+	"time"
+)
+
+// HistoryOrder is the order QueryHistory returns history entries in
+type HistoryOrder string
+
+const (
+	HistoryOrderNewest     HistoryOrder = "timestamp_desc"
+	HistoryOrderOldest     HistoryOrder = "timestamp_asc"
+	HistoryOrderActionType HistoryOrder = "action_type_asc" // Then newest first
+)
+
+// ErrInvalidHistoryOrder is returned for a HistoryOrder that is not one of the constants
+var ErrInvalidHistoryOrder = errors.New("invalid history order")
+
+// Pagination selects one page of a result
+type Pagination struct {
+	Limit  int // Most entries returned (0 = 100)
+	Offset int // Entries skipped
+}
+
+// HistoryQuery filters the audit log. Zero fields don't filter.
+type HistoryQuery struct {
+	ActionTypes []string   // Any of these action types
+	ProfileURL  string     // Details profile_url
+	Outcome     string     // Details outcome, e.g. "sent" or "accepted"
+	Start       *time.Time // Inclusive
+	End         *time.Time // Exclusive
+	MinCount    int        // Details found at least this, e.g. searches with results
+	MaxCount    int        // Details found at most this
+	OrderBy     HistoryOrder
+	Pagination  Pagination
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// JobStatus is where a queued job is
+type JobStatus string
+
+const (
+	JobStatusQueued    JobStatus = "queued"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+	JobStatusFailed    JobStatus = "failed"
+)
+
+// Done reports whether a job in this status will not change any more
+func (s JobStatus) Done() bool {
+	return s == JobStatusSucceeded || s == JobStatusFailed
+}
+
+// Job modes, each one bot run with the matching flag
+const (
+	JobModeConnect     = "connect"      // Search for Keyword and send requests
+	JobModeScan        = "scan"         // -scan
+	JobModeScanSent    = "scan-sent"    // -scan-sent
+	JobModeScanReplies = "scan-replies" // -scan-replies
+	JobModeFollowup    = "followup"     // -followup
+	JobModeEnrich      = "enrich"       // -enrich
+)
+
+// ErrInvalidJob is returned for a job that can't be queued
+var ErrInvalidJob = errors.New("invalid job")
+
+// Job is a bot run queued through the API. 'bot serve' runs queued jobs one at a time,
+// oldest first, so they never share the browser.
+type Job struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	Account     string     `gorm:"index;not null;default:'default'" json:"account"` // LinkedIn account the job runs for
+	Mode        string     `gorm:"not null" json:"mode"`                            // JobMode* value
+	Keyword     string     `json:"keyword,omitempty"`
+	Location    string     `json:"location,omitempty"`
+	Campaign    string     `json:"campaign,omitempty"`
+	Budget      int        `json:"budget,omitempty"` // Most connection requests to send (connect only; 0 = the -max default)
+	Status      JobStatus  `gorm:"index;not null" json:"status"`
+	RunID       string     `json:"run_id,omitempty"` // RunMetadata of the run, once finished
+	Error       string     `gorm:"type:text" json:"error,omitempty"`
+	Attempts    int        `json:"attempts"` // Times the job was started; above 1 when a restart interrupted it
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// Validate checks the mode and that only connect jobs carry search parameters; the
+// campaign applies to every mode
+func (j *Job) Validate() error {
+	switch j.Mode {
+	case JobModeConnect:
+		if j.Keyword == "" {
+			return fmt.Errorf("%w: connect needs a keyword", ErrInvalidJob)
+		}
+		if j.Budget < 0 {
+			return fmt.Errorf("%w: budget must not be negative", ErrInvalidJob)
+		}
+	case JobModeScan, JobModeScanSent, JobModeScanReplies, JobModeFollowup, JobModeEnrich:
+		if j.Keyword != "" || j.Location != "" || j.Budget != 0 {
+			return fmt.Errorf("%w: keyword, location and budget only apply to connect", ErrInvalidJob)
+		}
+	default:
+		return fmt.Errorf("%w: unknown mode %q", ErrInvalidJob, j.Mode)
+	}
+	return nil
+}
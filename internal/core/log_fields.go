@@ -0,0 +1,12 @@
+package core
+
+// Stable field names of the bot's structured log lines. Filtering the JSON logs on them
+// (e.g. jq 'select(.profile_url == "...")') reconstructs what happened to one profile or
+// in one run, so they are only ever added, never renamed.
+const (
+	LogFieldRunID      = "run_id"      // RunMetadata.RunID of the run, as shown by 'bot runs'
+	LogFieldAccount    = "account"     // Account partition (database.account)
+	LogFieldWorkflow   = "workflow"    // Workflow that logged the line, e.g. connect or followup
+	LogFieldProfileURL = "profile_url" // Profile being processed
+	LogFieldAction     = "action"      // What the line records, named like history actions: Connect, Message, Skip, ...
+)
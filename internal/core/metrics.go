@@ -0,0 +1,9 @@
+package core
+
+import "sync/atomic"
+
+// AppMetrics holds process-wide counters for the current run
+type AppMetrics struct {
+	SlowPageLoads              atomic.Int64 // Page loads that took longer than 10 seconds
+	ProfileURLValidationErrors atomic.Int64 // Search result links rejected as not a person's profile URL
+}
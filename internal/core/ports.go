@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"net/http"
 	"time"
 )
 
@@ -28,11 +29,19 @@ type BrowserPort interface {
 	// JSClick clicks an element using JavaScript (fallback)
 	JSClick(ctx context.Context, selector string) error
 
+	// HumanClickVerified clicks like HumanClick and, with browser.click_verification,
+	// retries once with JSClick when verificationSelector doesn't appear within verifyTimeout
+	HumanClickVerified(ctx context.Context, selector, verificationSelector string, verifyTimeout time.Duration) error
+
 	// ExecuteScript executes JavaScript on the page
 	ExecuteScript(ctx context.Context, script string) (interface{}, error)
 
 	// GetText extracts text content from an element
 	GetText(ctx context.Context, selector string) (string, error)
+
+	// GetAllText extracts text content for several selectors in a single round trip
+	// Selectors with no matching element map to an empty string
+	GetAllText(ctx context.Context, selectors []string) (map[string]string, error)
 	
 	// GetAttribute gets an attribute value from an element
 	GetAttribute(ctx context.Context, selector string, attr string) (string, error)
@@ -51,7 +60,13 @@ type BrowserPort interface {
 	
 	// GetPageHTML returns the full HTML content of the current page
 	GetPageHTML(ctx context.Context) (string, error)
+
+	// GetPagePerformanceMetrics returns load timings of the current page
+	GetPagePerformanceMetrics(ctx context.Context) (*PageMetrics, error)
 	
+	// GetCookies returns the browser cookies sent to url (the current page when empty)
+	GetCookies(ctx context.Context, url string) ([]*http.Cookie, error)
+
 	// SaveCookies saves browser cookies to a file
 	SaveCookies(ctx context.Context, path string) error
 	
@@ -69,24 +84,154 @@ type BrowserPort interface {
 type RepositoryPort interface {
 	// Profile operations
 	CreateProfile(ctx context.Context, profile *Profile) error
+	UpsertProfile(ctx context.Context, profile *Profile) (*UpsertResult, error)
+	// ArchiveProfile and UnarchiveProfile take a profile out of and back into every queue,
+	// keeping its history; they report whether a profile was changed
+	ArchiveProfile(ctx context.Context, url string) (bool, error)
+	UnarchiveProfile(ctx context.Context, url string) (bool, error)
+	IsProfileArchived(ctx context.Context, url string) (bool, error)
+	BulkCreateProfiles(ctx context.Context, profiles []*Profile) (created int, skipped int, err error)
 	GetProfileByURL(ctx context.Context, url string) (*Profile, error)
-	UpdateProfileStatus(ctx context.Context, url string, status string) error
-	GetProfilesByStatus(ctx context.Context, status string) ([]*Profile, error)
+	UpdateProfileStatus(ctx context.Context, url string, status ProfileStatus) error
+	ForceProfileStatus(ctx context.Context, url string, status ProfileStatus) error
+	GetProfilesByStatus(ctx context.Context, status ProfileStatus) ([]*Profile, error)
+	GetProfilesByStatusPage(ctx context.Context, status ProfileStatus, offset, limit int, orderBy ProfileOrder) ([]*Profile, error)
+	CountProfilesByStatus(ctx context.Context, status ProfileStatus) (int64, error)
+	CountProfiles(ctx context.Context, filter *ProfileFilter) (int64, error)
+	GetConnectedProfilesByName(ctx context.Context, names []string) ([]*Profile, error)
+	SearchProfiles(ctx context.Context, filter *ProfileFilter) ([]*Profile, error)
+	// SearchProfilesPage returns one page of the profiles matching filter, most recently
+	// updated first, and how many match
+	SearchProfilesPage(ctx context.Context, filter *ProfileFilter, page Pagination) ([]*Profile, int64, error)
+	UpdateProfileLastActive(ctx context.Context, url string, lastActive *time.Time) error
+	UpdateProfileOpenToWork(ctx context.Context, url string, openToWork bool) error
+	UpdateProfileFollowerCount(ctx context.Context, url string, followerCount int64) error
+	UpdateProfileIdentity(ctx context.Context, url string, name string, headline string) error
+	GetOpenToWorkRate(ctx context.Context) (*OpenToWorkRate, error)
+	MarkProfileVisited(ctx context.Context, url string, visitedAt time.Time) error
+	GetProfilesToVisit(ctx context.Context, limit int) ([]*Profile, error)
+	UpdateProfileSkills(ctx context.Context, url string, skills []string) error
+	UpdateProfileEnrichment(ctx context.Context, url string, enrichment *ProfileEnrichment) error
+	GetProfilesForEnrichment(ctx context.Context, limit int) ([]*Profile, error)
+
+	// Group operations
+	AddProfileToGroup(ctx context.Context, profileID uint, groupURL string) error
+	GetProfilesByGroup(ctx context.Context, groupURL string) ([]*Profile, error)
+	GetProfilesByLabel(ctx context.Context, label string) ([]*Profile, error) // Campaign name or group URL
+	GetGroupStats(ctx context.Context) ([]*GroupStats, error)
+	SaveGroup(ctx context.Context, group *Group) error
+	GetGroupByURL(ctx context.Context, groupURL string) (*Group, error)
+	GetGroupsByState(ctx context.Context, state string) ([]*Group, error)
 	
+	// Campaign operations
+	CreateCampaign(ctx context.Context, campaign *Campaign) error
+	GetCampaignByName(ctx context.Context, name string) (*Campaign, error)
+	ListCampaigns(ctx context.Context) ([]*Campaign, error)
+	UpdateCampaignStatus(ctx context.Context, name string, status string) error
+	AssignProfileToCampaign(ctx context.Context, profileURL string, campaignName string) error
+
+	// Blacklist operations
+	AddBlacklistEntry(ctx context.Context, entry *BlacklistEntry) error
+	RemoveBlacklistEntry(ctx context.Context, id uint) error
+	ListBlacklist(ctx context.Context) ([]*BlacklistEntry, error)
+	MatchBlacklist(ctx context.Context, profile *Profile) (*BlacklistEntry, error)
+	MarkProfileBlacklisted(ctx context.Context, url string, reason string) error
+
+	// CreateSearchQuery records a finished search and links the profiles it created to it
+	CreateSearchQuery(ctx context.Context, query *SearchQuery, profileIDs []uint) error
+	// GetSearchQueryStats returns the most recent search queries, newest first, with the
+	// requests sent to and accepted by the profiles each one found
+	GetSearchQueryStats(ctx context.Context, limit int) ([]*SearchQueryStats, error)
+	// GetAcceptanceRateBySource returns the requests sent to and accepted by the profiles
+	// of each source
+	GetAcceptanceRateBySource(ctx context.Context) ([]*SourceStats, error)
+
 	// Messaging operations
-	GetPendingFollowups(ctx context.Context, limit int) ([]*Profile, error)
+	GetPendingFollowups(ctx context.Context, limit int, window *FollowUpWindow) ([]*Profile, error)
 	MarkAsConnected(ctx context.Context, linkedinURL string) error
-	LogMessageSent(ctx context.Context, profileID uint, content string) error
+	MarkAsConnectedAt(ctx context.Context, linkedinURL string, connectedAt time.Time) error
+	MarkInvitationPending(ctx context.Context, linkedinURL string) error
+	LogMessageSent(ctx context.Context, message *Message) error
+	CreateMessage(ctx context.Context, message *Message) error
+	CreateMessageIfNotExists(ctx context.Context, message *Message) (bool, error)
+	GetMessagesForProfile(ctx context.Context, profileID uint) ([]*Message, error)
+	GetLastMessageByTemplate(ctx context.Context, profileID uint, templateName string) (*Message, error)
+	UpdateMessage(ctx context.Context, message *Message) error
+	DeleteMessage(ctx context.Context, id uint) error
+
+	// Endorsement operations
+	CreateEndorsement(ctx context.Context, endorsement *Endorsement) error
+	HasEndorsement(ctx context.Context, profileID uint) (bool, error)
+
+	// Declined invitation operations
+	CreateDeclinedInvite(ctx context.Context, invite *DeclinedInvite) error
+	IsInviteDeclined(ctx context.Context, profileURL string) (bool, error)
+
+	// Notification operations
+	CreateNotificationIfNotExists(ctx context.Context, notification *Notification) (bool, error)
+	GetNotificationsByDateRange(ctx context.Context, start, end time.Time) ([]*Notification, error)
+
+	// Page load operations
+	CreatePageLoad(ctx context.Context, pageLoad *PageLoad) error
+
+	// Company operations
+	CreateFollowedCompany(ctx context.Context, company *FollowedCompany) error
+	IsCompanyFollowed(ctx context.Context, companyURL string) (bool, error)
+	GetCompaniesToFollow(ctx context.Context, limit int) ([]string, error)
+
+	// Company research operations
+	GetCompanyResearch(ctx context.Context, company string) (*CompanyResearch, error)
+	SaveCompanyResearch(ctx context.Context, research *CompanyResearch) error
+
+	// Comment operations
+	CreatePostComment(ctx context.Context, comment *PostComment) error
+	GetLastCommentForAuthor(ctx context.Context, authorURL string) (*PostComment, error)
 
 	// History operations
 	CreateHistory(ctx context.Context, history *History) error
 	GetTodayActionCount(ctx context.Context, actionType string) (int64, error)
+	GetActionCountSince(ctx context.Context, actionType string, since time.Time) (int64, error)
+	GetActionCountsByType(ctx context.Context, since, until time.Time) (map[string]int64, error)
+	GetTodayCampaignActionCount(ctx context.Context, actionType string, campaign string) (int64, error)
 	GetHistoryByDateRange(ctx context.Context, start, end time.Time) ([]*History, error)
+	// QueryHistory returns one page of the history entries matching q and the number of
+	// entries matching q on all pages
+	QueryHistory(ctx context.Context, q HistoryQuery) ([]*History, int64, error)
+
+	// CreateJob queues a job for 'bot serve' to run
+	CreateJob(ctx context.Context, job *Job) error
+	// GetJob returns a job by ID, or nil if there is none
+	GetJob(ctx context.Context, id uint) (*Job, error)
+	// ClaimNextJob marks the oldest queued job running and returns it, or nil if none is queued
+	ClaimNextJob(ctx context.Context) (*Job, error)
+	// FinishJob records a job's final status, the run it made and, for failures, the error
+	FinishJob(ctx context.Context, id uint, status JobStatus, runID, jobErr string) error
+	// RequeueRunningJobs puts jobs interrupted by a restart back in the queue
+	RequeueRunningJobs(ctx context.Context) (int64, error)
+	HasIntroductionRequest(ctx context.Context, targetURL string) (bool, error)
+	HasHistoryTarget(ctx context.Context, actionType, target string) (bool, error)
+
+	// Run metadata operations
+	CreateRunMetadata(ctx context.Context, run *RunMetadata) error
+	UpdateRunMetadata(ctx context.Context, run *RunMetadata) error
+	ListRunMetadata(ctx context.Context, limit int) ([]*RunMetadata, error)
+	GetRunMetadata(ctx context.Context, id string) (*RunMetadata, error)
+	GetRunHistory(ctx context.Context, runID uint) ([]*History, error)
+
+	// Statistics
+	GetActionStats(ctx context.Context, actionType string, start, end time.Time, bucketSize time.Duration) ([]StatsBucket, error)
+	GetDailyProjection(ctx context.Context, start, end time.Time) ([]*DailyProjection, error)
+	GetAcceptanceRateByDay(ctx context.Context, start, end time.Time) ([]DailyAcceptanceRate, error)
+	GetDailyActionCounts(ctx context.Context, start, end time.Time) ([]DailyActionCount, error)
+	GetStatusCounts(ctx context.Context) ([]StatusCount, error)
+	GetAcceptanceStats(ctx context.Context, start, end time.Time) (*AcceptanceStats, error)
+	GetTemplatePerformance(ctx context.Context, start, end time.Time) ([]TemplatePerformance, error)
 	
 	// Rate limiting
 	CanPerformAction(ctx context.Context, actionType string, dailyLimit int) (bool, error)
 	
 	// Database management
+	PurgeExpired(ctx context.Context, retention *RetentionConfig, now time.Time) (*PurgeResult, error)
 	Migrate(ctx context.Context) error
 	Close() error
 }
@@ -118,6 +263,12 @@ type AuthWorkflowPort interface {
 	Handle2FA(ctx context.Context) error
 }
 
+// NotifierPort delivers alerts about events the user should act on or know about,
+// e.g. to a chat webhook. Notify must not block on delivery and never fails the caller.
+type NotifierPort interface {
+	Notify(ctx context.Context, alert Alert)
+}
+
 // SearchWorkflowPort defines the interface for search workflow
 type SearchWorkflowPort interface {
 	// Search performs a LinkedIn search and returns profile URLs
@@ -0,0 +1,25 @@
+package core
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrProfileArchived is returned when storing a profile whose URL belongs to an archived
+// profile. Archived profiles stay out of every queue until they are unarchived.
+var ErrProfileArchived = errors.New("profile is archived")
+
+// includeArchivedKey is the context key set by WithArchivedProfiles
+type includeArchivedKey struct{}
+
+// WithArchivedProfiles returns a context whose profile queries also return archived
+// profiles, for exports and stats. Action queues never use it.
+func WithArchivedProfiles(ctx context.Context) context.Context {
+	return context.WithValue(ctx, includeArchivedKey{}, true)
+}
+
+// ArchivedProfilesIncluded reports whether ctx was made by WithArchivedProfiles
+func ArchivedProfilesIncluded(ctx context.Context) bool {
+	included, _ := ctx.Value(includeArchivedKey{}).(bool)
+	return included
+}
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ProfileOrder is the order GetProfilesByStatusPage returns profiles in
+type ProfileOrder string
+
+const (
+	ProfileOrderCreatedAsc  ProfileOrder = "created_at_asc"
+	ProfileOrderCreatedDesc ProfileOrder = "created_at_desc"
+	ProfileOrderUpdatedAsc  ProfileOrder = "updated_at_asc"
+	ProfileOrderUpdatedDesc ProfileOrder = "updated_at_desc"
+)
+
+// ErrInvalidProfileOrder is returned for a ProfileOrder that is not one of the constants
+var ErrInvalidProfileOrder = errors.New("invalid profile order")
+
+// ErrStopIteration stops ForEachProfilePage without an error when returned by its callback
+var ErrStopIteration = errors.New("stop iteration")
+
+// ForEachProfilePage calls fn with successive pages of at most batchSize profiles with
+// status, in orderBy order, until the profiles run out or fn returns an error.
+//
+// fn may move the profiles it is given to another status: the next page starts after
+// the ones that kept status rather than at a fixed offset, so no profile is skipped.
+// With an updated_at order, fn must not update the profiles, as that reorders them.
+func ForEachProfilePage(ctx context.Context, repo RepositoryPort, status ProfileStatus, orderBy ProfileOrder, batchSize int, fn func([]*Profile) error) error {
+	if batchSize <= 0 {
+		batchSize = 100 // Default fallback
+	}
+
+	offset := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		profiles, err := repo.GetProfilesByStatusPage(ctx, status, offset, batchSize, orderBy)
+		if err != nil {
+			return fmt.Errorf("failed to load %s profiles: %w", status, err)
+		}
+		if len(profiles) == 0 {
+			return nil
+		}
+
+		if err := fn(profiles); err != nil {
+			if errors.Is(err, ErrStopIteration) {
+				return nil
+			}
+			return err
+		}
+		if len(profiles) < batchSize {
+			return nil
+		}
+
+		ids := make([]uint, len(profiles))
+		for i, profile := range profiles {
+			ids[i] = profile.ID
+		}
+		kept, err := repo.CountProfiles(ctx, &ProfileFilter{IDs: ids, Status: status})
+		if err != nil {
+			return fmt.Errorf("failed to count %s profiles: %w", status, err)
+		}
+		offset += int(kept)
+	}
+}
@@ -0,0 +1,13 @@
+package core
+
+// Profile sources: the ingestion path that first stored a profile. Profile.SourceDetail
+// narrows it down (search keyword, group id, post URN).
+const (
+	ProfileSourceUnknown    = "unknown"    // Stored before sources were recorded
+	ProfileSourceSearch     = "search"     // People search; detail is the keyword
+	ProfileSourceGroup      = "group"      // Group member list; detail is the group id
+	ProfileSourcePost       = "post"       // Feed post author or commenter; detail is the post URN
+	ProfileSourceScan       = "scan"       // Connections list scan; detail is "scan" or "export"
+	ProfileSourceInvitation = "invitation" // Accepted incoming invitation
+	ProfileSourceConnect    = "connect"    // Connected to directly by URL; detail is the keyword if any
+)
@@ -0,0 +1,106 @@
+package core
+
+import "errors"
+
+// ProfileStatus is where a profile is in the outreach funnel
+type ProfileStatus string
+
+// Profile Status Constants
+const (
+	ProfileStatusDiscovered        ProfileStatus = "Discovered"
+	ProfileStatusScanned           ProfileStatus = "Scanned"
+	ProfileStatusRequestSent       ProfileStatus = "RequestSent"
+	ProfileStatusConnected         ProfileStatus = "Connected"
+	ProfileStatusMessageSent       ProfileStatus = "MessageSent"
+	ProfileStatusMessageRestricted ProfileStatus = "MessageRestricted"
+	ProfileStatusReplied           ProfileStatus = "Replied"
+	ProfileStatusIgnored           ProfileStatus = "Ignored"
+	ProfileStatusExpired           ProfileStatus = "Expired"
+	ProfileStatusFailed            ProfileStatus = "Failed"
+)
+
+// AcceptedStatuses are the statuses of profiles that accepted a connection request
+var AcceptedStatuses = []ProfileStatus{
+	ProfileStatusConnected,
+	ProfileStatusMessageSent,
+	ProfileStatusMessageRestricted,
+	ProfileStatusReplied,
+}
+
+// RequestedStatuses are the statuses of profiles a connection request was sent to
+var RequestedStatuses = append([]ProfileStatus{
+	ProfileStatusRequestSent,
+	ProfileStatusExpired,
+}, AcceptedStatuses...)
+
+// ErrInvalidStatusTransition is returned when a status change is not in profileTransitions
+var ErrInvalidStatusTransition = errors.New("invalid profile status transition")
+
+// profileTransitions is the profile state machine:
+//
+//	Discovered ─┬─> Scanned ──┬─> RequestSent ─┬─> Connected ─┬─> MessageSent ─┬─> Replied
+//	            │             │                ├─> Expired    ├─> Replied      └─> MessageRestricted
+//	            └─────────────┴────────────────┴─> Ignored    └─> MessageRestricted
+//
+// Discovered, Scanned and RequestSent can also go straight to Connected (accepted
+// elsewhere) or Failed. Ignored, Expired and Failed profiles can be retried with a new
+// request or turn out to be connected. MessageRestricted profiles can still reply.
+// Replied is final.
+var profileTransitions = map[ProfileStatus][]ProfileStatus{
+	ProfileStatusDiscovered:        {ProfileStatusScanned, ProfileStatusRequestSent, ProfileStatusConnected, ProfileStatusIgnored, ProfileStatusFailed},
+	ProfileStatusScanned:           {ProfileStatusRequestSent, ProfileStatusConnected, ProfileStatusIgnored, ProfileStatusFailed},
+	ProfileStatusRequestSent:       {ProfileStatusConnected, ProfileStatusExpired, ProfileStatusIgnored, ProfileStatusFailed},
+	ProfileStatusConnected:         {ProfileStatusMessageSent, ProfileStatusMessageRestricted, ProfileStatusReplied},
+	ProfileStatusMessageSent:       {ProfileStatusReplied, ProfileStatusMessageRestricted},
+	ProfileStatusMessageRestricted: {ProfileStatusMessageSent, ProfileStatusReplied},
+	ProfileStatusReplied:           {},
+	ProfileStatusIgnored:           {ProfileStatusRequestSent, ProfileStatusConnected},
+	ProfileStatusExpired:           {ProfileStatusRequestSent, ProfileStatusConnected, ProfileStatusIgnored},
+	ProfileStatusFailed:            {ProfileStatusRequestSent, ProfileStatusConnected, ProfileStatusIgnored},
+}
+
+// IsValidTransition reports whether a profile may move from one status to another.
+// Keeping the same status is always allowed, and so is any move from an unknown or
+// empty status (rows written before statuses were checked).
+func IsValidTransition(from, to ProfileStatus) bool {
+	if from == to {
+		return true
+	}
+	if _, known := profileTransitions[to]; !known {
+		return false
+	}
+
+	allowed, known := profileTransitions[from]
+	if !known {
+		return true
+	}
+	for _, status := range allowed {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// statusRanks orders statuses by how far along the funnel they are
+var statusRanks = map[ProfileStatus]int{
+	ProfileStatusDiscovered:        0,
+	ProfileStatusScanned:           1,
+	ProfileStatusRequestSent:       2,
+	ProfileStatusFailed:            3,
+	ProfileStatusIgnored:           3,
+	ProfileStatusExpired:           3,
+	ProfileStatusConnected:         4,
+	ProfileStatusMessageSent:       5,
+	ProfileStatusMessageRestricted: 5,
+	ProfileStatusReplied:           6,
+}
+
+// StatusRank returns how far along the funnel a status is; higher is more advanced.
+// Unknown and empty statuses rank below Discovered.
+func StatusRank(status ProfileStatus) int {
+	if rank, ok := statusRanks[status]; ok {
+		return rank
+	}
+	return -1
+}
@@ -0,0 +1,67 @@
+package core
+
+import "testing"
+
+// TestProfileTransitions checks every pair of statuses against the state machine drawn
+// above profileTransitions. Pairs not listed here must be rejected.
+func TestProfileTransitions(t *testing.T) {
+	allowed := map[ProfileStatus][]ProfileStatus{
+		ProfileStatusDiscovered:        {ProfileStatusScanned, ProfileStatusRequestSent, ProfileStatusConnected, ProfileStatusIgnored, ProfileStatusFailed},
+		ProfileStatusScanned:           {ProfileStatusRequestSent, ProfileStatusConnected, ProfileStatusIgnored, ProfileStatusFailed},
+		ProfileStatusRequestSent:       {ProfileStatusConnected, ProfileStatusExpired, ProfileStatusIgnored, ProfileStatusFailed},
+		ProfileStatusConnected:         {ProfileStatusMessageSent, ProfileStatusMessageRestricted, ProfileStatusReplied},
+		ProfileStatusMessageSent:       {ProfileStatusReplied, ProfileStatusMessageRestricted},
+		ProfileStatusMessageRestricted: {ProfileStatusMessageSent, ProfileStatusReplied},
+		ProfileStatusReplied:           {},
+		ProfileStatusIgnored:           {ProfileStatusRequestSent, ProfileStatusConnected},
+		ProfileStatusExpired:           {ProfileStatusRequestSent, ProfileStatusConnected, ProfileStatusIgnored},
+		ProfileStatusFailed:            {ProfileStatusRequestSent, ProfileStatusConnected, ProfileStatusIgnored},
+	}
+
+	statuses := []ProfileStatus{
+		ProfileStatusDiscovered,
+		ProfileStatusScanned,
+		ProfileStatusRequestSent,
+		ProfileStatusConnected,
+		ProfileStatusMessageSent,
+		ProfileStatusMessageRestricted,
+		ProfileStatusReplied,
+		ProfileStatusIgnored,
+		ProfileStatusExpired,
+		ProfileStatusFailed,
+	}
+	if len(statuses) != len(profileTransitions) {
+		t.Fatalf("profileTransitions has %d statuses, the test knows %d", len(profileTransitions), len(statuses))
+	}
+
+	for _, from := range statuses {
+		want := map[ProfileStatus]bool{from: true} // Keeping a status is always allowed
+		for _, to := range allowed[from] {
+			want[to] = true
+		}
+		for _, to := range statuses {
+			if got := IsValidTransition(from, to); got != want[to] {
+				t.Errorf("IsValidTransition(%s, %s) = %v, want %v", from, to, got, want[to])
+			}
+		}
+	}
+}
+
+func TestIsValidTransitionUnknownStatuses(t *testing.T) {
+	tests := []struct {
+		from, to ProfileStatus
+		want     bool
+	}{
+		{"", ProfileStatusConnected, true},
+		{"Pending", ProfileStatusMessageSent, true},
+		{ProfileStatusDiscovered, "Pending", false},
+		{ProfileStatusConnected, "", false},
+		{"Pending", "Pending", true},
+	}
+
+	for _, tt := range tests {
+		if got := IsValidTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("IsValidTransition(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"net/url"
+	"strings"
+)
+
+// canonicalProfileHost is the host every normalized profile URL uses
+const canonicalProfileHost = "https://www.linkedin.com"
+
+// NormalizeProfileURL returns the canonical form of a LinkedIn profile URL,
+// https://www.linkedin.com/in/<slug>/, so a person is stored once however LinkedIn
+// linked to them. It accepts relative URLs, country and mobile subdomains
+// (de.linkedin.com), query strings and fragments, missing or extra trailing slashes and
+// sub-pages such as /in/<slug>/recent-activity/. Vanity slugs are lowercased; member ID
+// slugs (ACoAA...) are case-sensitive and kept as they are. Legacy
+// /pub/<name>/<a>/<b>/<c> URLs have no reliable /in/ equivalent, so they keep their
+// form with the same host, case and slash rules. Anything that is not a LinkedIn
+// profile URL is returned trimmed but otherwise unchanged.
+func NormalizeProfileURL(rawURL string) string {
+	trimmed := strings.TrimSpace(rawURL)
+	if trimmed == "" {
+		return ""
+	}
+
+	candidate := trimmed
+	if strings.HasPrefix(candidate, "/") {
+		candidate = canonicalProfileHost + candidate
+	} else if !strings.Contains(candidate, "://") {
+		candidate = "https://" + candidate
+	}
+
+	parsed, err := url.Parse(candidate)
+	if err != nil {
+		return trimmed
+	}
+	host := strings.ToLower(parsed.Hostname())
+	if host != "linkedin.com" && !strings.HasSuffix(host, ".linkedin.com") {
+		return trimmed
+	}
+
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(segments) < 2 || segments[1] == "" {
+		return trimmed
+	}
+
+	switch strings.ToLower(segments[0]) {
+	case "in":
+		return canonicalProfileHost + "/in/" + url.PathEscape(normalizeSlug(segments[1])) + "/"
+	case "pub":
+		parts := segments[1:]
+		if len(parts) > 4 {
+			parts = parts[:4] // Name and the three ID parts
+		}
+		for i, part := range parts {
+			parts[i] = url.PathEscape(strings.ToLower(part))
+		}
+		return canonicalProfileHost + "/pub/" + strings.Join(parts, "/") + "/"
+	}
+
+	return trimmed
+}
+
+// normalizeSlug lowercases a vanity slug. Member ID slugs start with "ACoAA" and are
+// case-sensitive.
+func normalizeSlug(slug string) string {
+	if strings.HasPrefix(slug, "ACoAA") {
+		return slug
+	}
+	return strings.ToLower(slug)
+}
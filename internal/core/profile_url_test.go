@@ -0,0 +1,46 @@
+package core
+
+import "testing"
+
+func TestNormalizeProfileURL(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"canonical", "https://www.linkedin.com/in/jane-doe/", "https://www.linkedin.com/in/jane-doe/"},
+		{"missing trailing slash", "https://www.linkedin.com/in/jane-doe", "https://www.linkedin.com/in/jane-doe/"},
+		{"extra trailing slashes", "https://www.linkedin.com/in/jane-doe//", "https://www.linkedin.com/in/jane-doe/"},
+		{"query string", "https://www.linkedin.com/in/jane-doe/?originalSubdomain=de", "https://www.linkedin.com/in/jane-doe/"},
+		{"tracking params and fragment", "https://www.linkedin.com/in/jane-doe?trk=public_profile&miniProfileUrn=urn%3Ali%3Afs#experience", "https://www.linkedin.com/in/jane-doe/"},
+		{"country subdomain", "https://de.linkedin.com/in/jane-doe/", "https://www.linkedin.com/in/jane-doe/"},
+		{"mobile subdomain", "https://m.linkedin.com/in/jane-doe", "https://www.linkedin.com/in/jane-doe/"},
+		{"bare host", "linkedin.com/in/jane-doe", "https://www.linkedin.com/in/jane-doe/"},
+		{"http scheme", "http://www.linkedin.com/in/jane-doe/", "https://www.linkedin.com/in/jane-doe/"},
+		{"relative", "/in/jane-doe/", "https://www.linkedin.com/in/jane-doe/"},
+		{"surrounding whitespace", "  https://www.linkedin.com/in/jane-doe/\n", "https://www.linkedin.com/in/jane-doe/"},
+		{"uppercase slug", "https://www.linkedin.com/in/Jane-Doe/", "https://www.linkedin.com/in/jane-doe/"},
+		{"uppercase host and path", "HTTPS://WWW.LINKEDIN.COM/IN/JANE-DOE", "https://www.linkedin.com/in/jane-doe/"},
+		{"member ID slug keeps case", "https://www.linkedin.com/in/ACoAAB1cDeF/", "https://www.linkedin.com/in/ACoAAB1cDeF/"},
+		{"sub-page", "https://www.linkedin.com/in/jane-doe/recent-activity/all/", "https://www.linkedin.com/in/jane-doe/"},
+		{"percent-encoded slug", "https://www.linkedin.com/in/j%C3%A9r%C3%B4me-dupont/", "https://www.linkedin.com/in/j%C3%A9r%C3%B4me-dupont/"},
+		{"unencoded non-ASCII slug", "https://www.linkedin.com/in/jérôme-dupont", "https://www.linkedin.com/in/j%C3%A9r%C3%B4me-dupont/"},
+		{"uppercase percent-encoded slug", "https://www.linkedin.com/in/J%C3%89R%C3%94ME/", "https://www.linkedin.com/in/j%C3%A9r%C3%B4me/"},
+		{"pub URL", "https://www.linkedin.com/pub/Jane-Doe/12/345/678", "https://www.linkedin.com/pub/jane-doe/12/345/678/"},
+		{"pub URL with extra segments", "https://uk.linkedin.com/pub/jane-doe/12/345/678/de?trk=x", "https://www.linkedin.com/pub/jane-doe/12/345/678/"},
+		{"in and pub stay distinct", "https://www.linkedin.com/pub/jane-doe/", "https://www.linkedin.com/pub/jane-doe/"},
+		{"company page", "https://www.linkedin.com/company/acme/", "https://www.linkedin.com/company/acme/"},
+		{"in without slug", "https://www.linkedin.com/in/", "https://www.linkedin.com/in/"},
+		{"other host", "https://example.com/in/jane-doe", "https://example.com/in/jane-doe"},
+		{"lookalike host", "https://notlinkedin.com/in/jane-doe", "https://notlinkedin.com/in/jane-doe"},
+		{"empty", "   ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeProfileURL(tt.in); got != tt.want {
+				t.Errorf("NormalizeProfileURL(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package core
+
+import "context"
+
+// runIDKey is the context key of the current run's RunMetadata.ID
+type runIDKey struct{}
+
+// WithRunID returns a context carrying the ID of the run it belongs to. History written
+// with the context is linked to that run.
+func WithRunID(ctx context.Context, runID uint) context.Context {
+	return context.WithValue(ctx, runIDKey{}, runID)
+}
+
+// RunIDFromContext returns the run ID set by WithRunID, if any
+func RunIDFromContext(ctx context.Context) (uint, bool) {
+	runID, ok := ctx.Value(runIDKey{}).(uint)
+	return runID, ok && runID != 0
+}
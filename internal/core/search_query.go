@@ -0,0 +1,29 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// NewSearchQuery starts the record of a search run with params. The filters are the
+// complete params, so SearchQuery.Params gives them back unchanged.
+func NewSearchQuery(params *SearchParams) (*SearchQuery, error) {
+	filters, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode search filters: %w", err)
+	}
+
+	return &SearchQuery{
+		Keyword: params.Keyword,
+		Filters: string(filters),
+	}, nil
+}
+
+// Params decodes the search parameters stored in Filters
+func (q *SearchQuery) Params() (*SearchParams, error) {
+	var params SearchParams
+	if err := json.Unmarshal([]byte(q.Filters), &params); err != nil {
+		return nil, fmt.Errorf("failed to decode search filters of query %d: %w", q.ID, err)
+	}
+	return &params, nil
+}
@@ -0,0 +1,472 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"linkedin-automation/internal/core"
+	"linkedin-automation/internal/reports"
+
+	"go.uber.org/zap"
+)
+
+// Google APIs used by the Sheets sync
+const (
+	sheetsAPIURL      = "https://sheets.googleapis.com/v4/spreadsheets"
+	sheetsScope       = "https://www.googleapis.com/auth/spreadsheets"
+	googleTokenURL    = "https://oauth2.googleapis.com/token"
+	sheetsHTTPTimeout = 30 * time.Second
+)
+
+// Header rows written to empty sheets
+var (
+	profileHeader = []interface{}{"URL", "Name", "Headline", "Company", "Connected At", "Note Template"}
+	statsHeader   = []interface{}{"Date", "Requests Sent", "Accepted", "Messages Sent", "Replies", "Profiles Discovered"}
+)
+
+// SheetsSync appends new connections and daily stats to a Google Sheets spreadsheet,
+// authenticating as the service account in integrations.google_sheets.credentials_path.
+// The spreadsheet must be shared with the service account's email.
+type SheetsSync struct {
+	repository core.RepositoryPort
+	config     *core.Config
+	logger     *zap.Logger
+	client     *http.Client
+	account    *serviceAccount
+
+	tokenMu     sync.Mutex
+	token       string
+	tokenExpiry time.Time
+
+	appendMu  sync.Mutex // Appends run one at a time so only the first writes a header
+	mu        sync.Mutex
+	hasHeader map[string]bool // Sheets known to have a header row
+
+	pending sync.WaitGroup
+}
+
+// serviceAccount is the part of a service account key file the sync needs
+type serviceAccount struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+
+	key *rsa.PrivateKey
+}
+
+// NewSheetsSync creates a Sheets sync from integrations.google_sheets, loading the
+// service account key
+func NewSheetsSync(repo core.RepositoryPort, config *core.Config, logger *zap.Logger) (*SheetsSync, error) {
+	account, err := loadServiceAccount(config.Integrations.GoogleSheets.CredentialsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SheetsSync{
+		repository: repo,
+		config:     config,
+		logger:     logger,
+		client:     &http.Client{Timeout: sheetsHTTPTimeout},
+		account:    account,
+		hasHeader:  make(map[string]bool),
+	}, nil
+}
+
+// loadServiceAccount reads and parses a service account key file
+func loadServiceAccount(path string) (*serviceAccount, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Google credentials: %w", err)
+	}
+
+	var account serviceAccount
+	if err := json.Unmarshal(data, &account); err != nil {
+		return nil, fmt.Errorf("failed to parse Google credentials: %w", err)
+	}
+	if account.ClientEmail == "" || account.PrivateKey == "" {
+		return nil, fmt.Errorf("google credentials in %s are not a service account key", path)
+	}
+	if account.TokenURI == "" {
+		account.TokenURI = googleTokenURL // Default fallback
+	}
+
+	block, _ := pem.Decode([]byte(account.PrivateKey))
+	if block == nil {
+		return nil, fmt.Errorf("google credentials in %s have no PEM private key", path)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Google private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("google private key is not an RSA key")
+	}
+	account.key = key
+
+	return &account, nil
+}
+
+// AppendProfile adds a row for a connected profile to integrations.google_sheets.sheet_name:
+// URL, name, headline, company, connection time and the note template of its campaign
+// (or connection.note_template)
+func (s *SheetsSync) AppendProfile(ctx context.Context, profile *core.Profile) error {
+	connectedAt := ""
+	if profile.ConnectedAt != nil {
+		connectedAt = profile.ConnectedAt.Format(time.RFC3339)
+	}
+
+	row := []interface{}{
+		profile.LinkedInURL,
+		profile.Name,
+		profile.Headline,
+		profile.CurrentCompany,
+		connectedAt,
+		s.noteTemplate(ctx, profile),
+	}
+	if err := s.appendRows(ctx, s.sheetName(), profileHeader, [][]interface{}{row}); err != nil {
+		return fmt.Errorf("failed to append profile to Google Sheets: %w", err)
+	}
+
+	s.logger.Debug("Profile added to Google Sheets", zap.String("url", profile.LinkedInURL))
+	return nil
+}
+
+// AppendProfileAsync runs AppendProfile in the background so the automation doesn't wait
+// on Google. The stored profile is reloaded first, since callers often only hold the URL
+// and status. Failures are logged. Wait blocks until every pending append is done.
+func (s *SheetsSync) AppendProfileAsync(profile *core.Profile) {
+	s.pending.Add(1)
+	go func() {
+		defer s.pending.Done()
+
+		// The run context may be cancelled before the append is done
+		ctx, cancel := context.WithTimeout(context.Background(), 2*sheetsHTTPTimeout)
+		defer cancel()
+
+		stored, err := s.repository.GetProfileByURL(ctx, profile.LinkedInURL)
+		if err != nil {
+			s.logger.Warn("Failed to load profile for Google Sheets", zap.String("url", profile.LinkedInURL), zap.Error(err))
+			return
+		}
+		if stored == nil {
+			stored = profile
+		}
+
+		if err := s.AppendProfile(ctx, stored); err != nil {
+			s.logger.Warn("Google Sheets sync failed", zap.String("url", profile.LinkedInURL), zap.Error(err))
+		}
+	}()
+}
+
+// Wait blocks until the appends started by AppendProfileAsync are done
+func (s *SheetsSync) Wait() {
+	s.pending.Wait()
+}
+
+// SyncDailyStats writes the daily report aggregates for the calendar day containing date
+// (in date's location) to integrations.google_sheets.summary_sheet_name. A day already in
+// the sheet has its row overwritten, so syncing after every run keeps one row per day.
+func (s *SheetsSync) SyncDailyStats(ctx context.Context, date time.Time) error {
+	report, err := reports.NewDailyReportGenerator(s.repository, s.logger).Generate(ctx, date)
+	if err != nil {
+		return err
+	}
+
+	day := report.Date.Format("2006-01-02")
+	row := []interface{}{
+		day,
+		report.ConnectionsSent,
+		report.ConnectionsAccepted,
+		report.MessagesSent,
+		report.MessagesReplied,
+		report.ProfilesDiscovered,
+	}
+
+	sheet := s.summarySheetName()
+	dates, err := s.readColumn(ctx, sheet)
+	if err != nil {
+		return fmt.Errorf("failed to read Google Sheets summary: %w", err)
+	}
+	for i, existing := range dates {
+		if existing == day {
+			rng := fmt.Sprintf("%s!A%d", quoteSheetName(sheet), i+1)
+			if err := s.updateRow(ctx, rng, row); err != nil {
+				return fmt.Errorf("failed to update Google Sheets summary: %w", err)
+			}
+			return nil
+		}
+	}
+
+	if len(dates) > 0 {
+		s.markHeader(sheet)
+	}
+	if err := s.appendRows(ctx, sheet, statsHeader, [][]interface{}{row}); err != nil {
+		return fmt.Errorf("failed to append Google Sheets summary: %w", err)
+	}
+
+	s.logger.Info("Daily stats synced to Google Sheets", zap.String("date", day))
+	return nil
+}
+
+// noteTemplate returns the connection note template the profile's campaign uses
+func (s *SheetsSync) noteTemplate(ctx context.Context, profile *core.Profile) string {
+	if profile.Campaign != "" {
+		campaign, err := s.repository.GetCampaignByName(ctx, profile.Campaign)
+		if err != nil {
+			s.logger.Debug("Failed to load campaign", zap.String("campaign", profile.Campaign), zap.Error(err))
+		} else if campaign != nil && campaign.NoteTemplate != "" {
+			return campaign.NoteTemplate
+		}
+	}
+	return s.config.Connection.NoteTemplate
+}
+
+func (s *SheetsSync) sheetName() string {
+	if name := s.config.Integrations.GoogleSheets.SheetName; name != "" {
+		return name
+	}
+	return "Connections" // Default fallback
+}
+
+func (s *SheetsSync) summarySheetName() string {
+	if name := s.config.Integrations.GoogleSheets.SummarySheetName; name != "" {
+		return name
+	}
+	return "Daily Stats" // Default fallback
+}
+
+// appendRows appends rows after the last row of sheet, writing header first if the
+// sheet is empty
+func (s *SheetsSync) appendRows(ctx context.Context, sheet string, header []interface{}, rows [][]interface{}) error {
+	s.appendMu.Lock()
+	defer s.appendMu.Unlock()
+
+	if !s.headerKnown(sheet) {
+		values, err := s.readColumn(ctx, sheet)
+		if err != nil {
+			return err
+		}
+		if len(values) == 0 {
+			rows = append([][]interface{}{header}, rows...)
+		}
+	}
+
+	query := url.Values{
+		"valueInputOption": {"RAW"},
+		"insertDataOption": {"INSERT_ROWS"},
+	}
+	endpoint := s.valuesURL(quoteSheetName(sheet)+"!A1") + ":append?" + query.Encode()
+	if err := s.call(ctx, http.MethodPost, endpoint, map[string]interface{}{"values": rows}, nil); err != nil {
+		return err
+	}
+
+	s.markHeader(sheet)
+	return nil
+}
+
+// updateRow overwrites the row starting at rng
+func (s *SheetsSync) updateRow(ctx context.Context, rng string, row []interface{}) error {
+	endpoint := s.valuesURL(rng) + "?valueInputOption=RAW"
+	return s.call(ctx, http.MethodPut, endpoint, map[string]interface{}{"values": [][]interface{}{row}}, nil)
+}
+
+// readColumn returns the values of column A of sheet, up to its last non-empty row
+func (s *SheetsSync) readColumn(ctx context.Context, sheet string) ([]string, error) {
+	var response struct {
+		Values [][]interface{} `json:"values"`
+	}
+	if err := s.call(ctx, http.MethodGet, s.valuesURL(quoteSheetName(sheet)+"!A:A"), nil, &response); err != nil {
+		return nil, err
+	}
+
+	values := make([]string, len(response.Values))
+	for i, row := range response.Values {
+		if len(row) > 0 {
+			values[i] = fmt.Sprint(row[0])
+		}
+	}
+	return values, nil
+}
+
+func (s *SheetsSync) headerKnown(sheet string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.hasHeader[sheet]
+}
+
+func (s *SheetsSync) markHeader(sheet string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hasHeader[sheet] = true
+}
+
+// valuesURL returns the values endpoint for an A1 range of the configured spreadsheet
+func (s *SheetsSync) valuesURL(rng string) string {
+	return sheetsAPIURL + "/" + url.PathEscape(s.config.Integrations.GoogleSheets.SpreadsheetID) + "/values/" + url.PathEscape(rng)
+}
+
+// quoteSheetName quotes a sheet name for use in an A1 range
+func quoteSheetName(name string) string {
+	return "'" + strings.ReplaceAll(name, "'", "''") + "'"
+}
+
+// call sends an authorized JSON request to the Sheets API and decodes the response into out
+func (s *SheetsSync) call(ctx context.Context, method, endpoint string, body, out interface{}) error {
+	token, err := s.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d: %s", resp.StatusCode, apiErrorMessage(data))
+	}
+	if out == nil {
+		return nil
+	}
+
+	return json.Unmarshal(data, out)
+}
+
+// apiErrorMessage extracts the message of a Google API error response
+func apiErrorMessage(data []byte) string {
+	var response struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+		Description string `json:"error_description"`
+	}
+	if json.Unmarshal(data, &response) == nil {
+		if response.Error.Message != "" {
+			return response.Error.Message
+		}
+		if response.Description != "" {
+			return response.Description
+		}
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// accessToken returns a cached OAuth access token, exchanging a signed JWT for a new one
+// shortly before the old one expires
+func (s *SheetsSync) accessToken(ctx context.Context) (string, error) {
+	s.tokenMu.Lock()
+	defer s.tokenMu.Unlock()
+
+	if s.token != "" && time.Now().Before(s.tokenExpiry) {
+		return s.token, nil
+	}
+
+	assertion, err := s.account.signedJWT(time.Now())
+	if err != nil {
+		return "", fmt.Errorf("failed to sign Google token request: %w", err)
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.account.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("google token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("google token request failed: status %d: %s", resp.StatusCode, apiErrorMessage(data))
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.Unmarshal(data, &token); err != nil {
+		return "", fmt.Errorf("failed to parse Google token: %w", err)
+	}
+
+	s.token = token.AccessToken
+	// Renew a minute early so a request never goes out with an expired token
+	s.tokenExpiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - time.Minute)
+	return s.token, nil
+}
+
+// signedJWT builds the RS256-signed assertion of the OAuth JWT bearer flow
+func (a *serviceAccount) signedJWT(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss":   a.ClientEmail,
+		"scope": sheetsScope,
+		"aud":   a.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(nil, a.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
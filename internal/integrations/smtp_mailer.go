@@ -0,0 +1,177 @@
+package integrations
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// smtpDialTimeout bounds connecting to the mail server
+const smtpDialTimeout = 30 * time.Second
+
+// SMTPMailer sends multipart emails through the mail server in the smtp config block
+type SMTPMailer struct {
+	config *core.SMTPConfig
+	logger *zap.Logger
+}
+
+// NewSMTPMailer creates a mailer, or returns nil when no SMTP host is set
+func NewSMTPMailer(config *core.Config, logger *zap.Logger) *SMTPMailer {
+	if config.SMTP.Host == "" {
+		return nil
+	}
+	return &SMTPMailer{
+		config: &config.SMTP,
+		logger: logger,
+	}
+}
+
+// Send emails subject to the configured recipients, with a plain-text and an HTML part.
+// Port 465 uses implicit TLS; other ports upgrade with STARTTLS when the server offers it.
+func (m *SMTPMailer) Send(subject, text, html string) error {
+	if m.config.From == "" || len(m.config.To) == 0 {
+		return fmt.Errorf("smtp.from and smtp.to are required to send email")
+	}
+	from, err := mail.ParseAddress(m.config.From)
+	if err != nil {
+		return fmt.Errorf("invalid smtp.from: %w", err)
+	}
+	recipients := make([]string, 0, len(m.config.To))
+	for _, to := range m.config.To {
+		addr, err := mail.ParseAddress(to)
+		if err != nil {
+			return fmt.Errorf("invalid smtp.to address %q: %w", to, err)
+		}
+		recipients = append(recipients, addr.Address)
+	}
+
+	message, err := buildMultipartMessage(m.config.From, m.config.To, subject, text, html)
+	if err != nil {
+		return err
+	}
+
+	client, err := m.dial()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if m.config.Username != "" {
+		auth := smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("smtp authentication failed: %w", err)
+		}
+	}
+	if err := client.Mail(from.Address); err != nil {
+		return fmt.Errorf("smtp MAIL FROM failed: %w", err)
+	}
+	for _, rcpt := range recipients {
+		if err := client.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("smtp RCPT TO %s failed: %w", rcpt, err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp DATA failed: %w", err)
+	}
+	if _, err := w.Write(message); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp server rejected email: %w", err)
+	}
+
+	m.logger.Info("Email sent", zap.String("subject", subject), zap.Strings("to", recipients))
+	return client.Quit()
+}
+
+// dial connects to the mail server and upgrades the connection to TLS
+func (m *SMTPMailer) dial() (*smtp.Client, error) {
+	port := m.config.Port
+	if port <= 0 {
+		port = 587 // Default fallback
+	}
+	addr := net.JoinHostPort(m.config.Host, strconv.Itoa(port))
+	tlsConfig := &tls.Config{ServerName: m.config.Host}
+
+	var conn net.Conn
+	var err error
+	if port == 465 {
+		conn, err = tls.DialWithDialer(&net.Dialer{Timeout: smtpDialTimeout}, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = net.DialTimeout("tcp", addr, smtpDialTimeout)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to smtp server %s: %w", addr, err)
+	}
+
+	client, err := smtp.NewClient(conn, m.config.Host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to start smtp session: %w", err)
+	}
+	if port != 465 {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				client.Close()
+				return nil, fmt.Errorf("smtp STARTTLS failed: %w", err)
+			}
+		}
+	}
+	return client, nil
+}
+
+// buildMultipartMessage builds a multipart/alternative message; mail clients show the
+// last part they can display, so the HTML part comes after the plain-text one
+func buildMultipartMessage(from string, to []string, subject, text, html string) ([]byte, error) {
+	var nonce [12]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, fmt.Errorf("failed to generate MIME boundary: %w", err)
+	}
+	boundary := "alt-" + hex.EncodeToString(nonce[:])
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&b, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", boundary)
+
+	for _, part := range []struct {
+		contentType string
+		body        string
+	}{
+		{"text/plain", text},
+		{"text/html", html},
+	} {
+		fmt.Fprintf(&b, "--%s\r\n", boundary)
+		fmt.Fprintf(&b, "Content-Type: %s; charset=utf-8\r\n", part.contentType)
+		b.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+		qp := quotedprintable.NewWriter(&b)
+		if _, err := qp.Write([]byte(part.body)); err != nil {
+			return nil, fmt.Errorf("failed to encode email body: %w", err)
+		}
+		if err := qp.Close(); err != nil {
+			return nil, fmt.Errorf("failed to encode email body: %w", err)
+		}
+		b.WriteString("\r\n")
+	}
+	fmt.Fprintf(&b, "--%s--\r\n", boundary)
+
+	return b.Bytes(), nil
+}
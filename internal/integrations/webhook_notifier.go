@@ -0,0 +1,241 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// webhookHTTPTimeout bounds each webhook post
+const webhookHTTPTimeout = 10 * time.Second
+
+// digestListLimit is the number of alerts listed in a digest; the rest are counted
+const digestListLimit = 10
+
+// digestTitles head digests of each event, after the alert count
+var digestTitles = map[string]string{
+	core.AlertEventCaptcha:            "security checks need solving",
+	core.AlertEventInviteLimit:        "invitation limit alerts",
+	core.AlertEventRunFinished:        "runs finished",
+	core.AlertEventConnectionAccepted: "connection requests accepted",
+}
+
+// WebhookNotifier posts alerts to the Slack or Discord incoming webhook in
+// observability.webhooks. Posts are sent in the background and failures are logged and
+// dropped. The first alert of an event is posted at once; more of the same event within
+// digest_seconds are held and posted together as one digest.
+type WebhookNotifier struct {
+	config *core.WebhooksConfig
+	logger *zap.Logger
+	client *http.Client
+	events map[string]bool
+
+	mu     sync.Mutex
+	states map[string]*alertState
+
+	pending sync.WaitGroup
+}
+
+// alertState tracks the rate guard of one event
+type alertState struct {
+	lastSent time.Time
+	held     []core.Alert
+	timer    *time.Timer
+}
+
+// NewWebhookNotifier creates a webhook notifier, or returns nil when no webhook URL is set
+func NewWebhookNotifier(config *core.Config, logger *zap.Logger) *WebhookNotifier {
+	webhooks := &config.Observability.Webhooks
+	if webhooks.URL == "" {
+		return nil
+	}
+
+	events := make(map[string]bool, len(webhooks.Events))
+	for _, event := range webhooks.Events {
+		events[event] = true
+	}
+	return &WebhookNotifier{
+		config: webhooks,
+		logger: logger,
+		client: &http.Client{Timeout: webhookHTTPTimeout},
+		events: events,
+		states: make(map[string]*alertState),
+	}
+}
+
+// Notify posts alert, or holds it for the event's next digest. Events not listed in
+// observability.webhooks.events are ignored.
+func (w *WebhookNotifier) Notify(ctx context.Context, alert core.Alert) {
+	if !w.events[alert.Event] {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	state := w.states[alert.Event]
+	if state == nil {
+		state = &alertState{}
+		w.states[alert.Event] = state
+	}
+
+	window := w.digestWindow()
+	now := time.Now()
+	if state.timer == nil && now.Sub(state.lastSent) >= window {
+		state.lastSent = now
+		w.sendAsync(formatAlert(alert))
+		return
+	}
+
+	state.held = append(state.held, alert)
+	if state.timer == nil {
+		event := alert.Event
+		state.timer = time.AfterFunc(state.lastSent.Add(window).Sub(now), func() {
+			w.mu.Lock()
+			defer w.mu.Unlock()
+			w.flushLocked(event)
+		})
+	}
+}
+
+// Flush posts the held digests at once and waits for every post to finish. Call it
+// before the process exits.
+func (w *WebhookNotifier) Flush() {
+	w.mu.Lock()
+	for event, state := range w.states {
+		// A timer that already fired finds nothing left to send
+		if state.timer != nil {
+			state.timer.Stop()
+			w.flushLocked(event)
+		}
+	}
+	w.mu.Unlock()
+
+	w.pending.Wait()
+}
+
+// flushLocked posts the held alerts of event as one message. w.mu must be held.
+func (w *WebhookNotifier) flushLocked(event string) {
+	state := w.states[event]
+	state.timer = nil
+	if len(state.held) == 0 {
+		return
+	}
+
+	held := state.held
+	state.held = nil
+	state.lastSent = time.Now()
+	if len(held) == 1 {
+		w.sendAsync(formatAlert(held[0]))
+		return
+	}
+	w.sendAsync(formatDigest(event, held))
+}
+
+func (w *WebhookNotifier) digestWindow() time.Duration {
+	seconds := w.config.DigestSeconds
+	if seconds <= 0 {
+		seconds = 300 // Default fallback
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// sendAsync posts text in the background. The run context is not used, so alerts about
+// a run ending are still delivered after it is cancelled.
+func (w *WebhookNotifier) sendAsync(text string) {
+	if w.config.Mention != "" {
+		text = w.config.Mention + " " + text
+	}
+
+	w.pending.Add(1)
+	go func() {
+		defer w.pending.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), webhookHTTPTimeout)
+		defer cancel()
+
+		if err := w.post(ctx, text); err != nil {
+			w.logger.Warn("Failed to post webhook alert, dropping it", zap.Error(err))
+		}
+	}()
+}
+
+// post sends text in the payload format of the webhook's service
+func (w *WebhookNotifier) post(ctx context.Context, text string) error {
+	payload := map[string]string{"text": text}
+	if isDiscordWebhook(w.config.URL) {
+		payload = map[string]string{"content": text}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Slack answers 200 and Discord 204
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+// isDiscordWebhook reports whether rawURL is a Discord webhook, which takes "content"
+// where Slack takes "text"
+func isDiscordWebhook(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(parsed.Hostname())
+	return host == "discord.com" || host == "discordapp.com" || strings.HasSuffix(host, ".discord.com")
+}
+
+// formatAlert formats one alert on one line, with its link
+func formatAlert(alert core.Alert) string {
+	if alert.URL == "" {
+		return alert.Text
+	}
+	return alert.Text + " " + alert.URL
+}
+
+// formatDigest formats alerts of one event as a count, then one line per alert
+func formatDigest(event string, alerts []core.Alert) string {
+	title := digestTitles[event]
+	if title == "" {
+		title = event + " alerts"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d %s:", len(alerts), title)
+	for i, alert := range alerts {
+		if i == digestListLimit {
+			fmt.Fprintf(&b, "\n…and %d more", len(alerts)-digestListLimit)
+			break
+		}
+		b.WriteString("\n• " + formatAlert(alert))
+	}
+	return b.String()
+}
@@ -0,0 +1,162 @@
+package linkedin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// voyagerBaseURL is the root of LinkedIn's internal API used by the web app
+const voyagerBaseURL = "https://www.linkedin.com/voyager/api"
+
+// VoyagerProfile holds the profile details read from the Voyager API
+type VoyagerProfile struct {
+	VanityName      string
+	FollowerCount   int64
+	ConnectionCount int64
+	IsInfluencer    bool
+}
+
+// VoyagerClient calls LinkedIn's Voyager API directly with the browser's session, which
+// is much cheaper than opening and parsing a profile page
+type VoyagerClient struct {
+	browser    core.BrowserPort
+	httpClient *http.Client
+	logger     *zap.Logger
+}
+
+// NewVoyagerClient creates a new Voyager API client using the browser's session cookies
+func NewVoyagerClient(browser core.BrowserPort, logger *zap.Logger) *VoyagerClient {
+	return &VoyagerClient{
+		browser:    browser,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		logger:     logger,
+	}
+}
+
+// GetProfileDetails returns the follower and connection counts and the influencer flag
+// of the profile with the given vanity name (the part after /in/ in its URL)
+func (v *VoyagerClient) GetProfileDetails(ctx context.Context, vanityName string) (*VoyagerProfile, error) {
+	if vanityName == "" {
+		return nil, fmt.Errorf("vanity name is required")
+	}
+
+	escaped := url.PathEscape(vanityName)
+
+	var profile struct {
+		Influencer bool `json:"influencer"`
+	}
+	if err := v.get(ctx, "/identity/profiles/"+escaped, &profile); err != nil {
+		return nil, err
+	}
+
+	var network struct {
+		FollowersCount   int64 `json:"followersCount"`
+		ConnectionsCount int64 `json:"connectionsCount"`
+	}
+	if err := v.get(ctx, "/identity/profiles/"+escaped+"/networkinfo", &network); err != nil {
+		return nil, err
+	}
+
+	return &VoyagerProfile{
+		VanityName:      vanityName,
+		FollowerCount:   network.FollowersCount,
+		ConnectionCount: network.ConnectionsCount,
+		IsInfluencer:    profile.Influencer,
+	}, nil
+}
+
+// get requests path from the Voyager API with the session's cookies and CSRF token and
+// decodes the JSON response into out
+func (v *VoyagerClient) get(ctx context.Context, path string, out interface{}) error {
+	cookies, err := v.browser.GetCookies(ctx, "https://www.linkedin.com/")
+	if err != nil {
+		return fmt.Errorf("failed to read session cookies: %w", err)
+	}
+
+	// The CSRF token is the JSESSIONID cookie without its quotes
+	csrfToken := ""
+	for _, cookie := range cookies {
+		if cookie.Name == "JSESSIONID" {
+			csrfToken = strings.Trim(cookie.Value, `"`)
+			break
+		}
+	}
+	if csrfToken == "" {
+		return fmt.Errorf("JSESSIONID cookie not found, not logged in")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, voyagerBaseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build Voyager request: %w", err)
+	}
+	for _, cookie := range cookies {
+		req.AddCookie(&http.Cookie{Name: cookie.Name, Value: cookie.Value})
+	}
+	req.Header.Set("csrf-token", csrfToken)
+	req.Header.Set("accept", "application/json")
+	req.Header.Set("x-restli-protocol-version", "2.0.0")
+	req.Header.Set("x-li-lang", "en_US")
+	if userAgent := v.userAgent(ctx); userAgent != "" {
+		req.Header.Set("user-agent", userAgent)
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("Voyager request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read Voyager response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Voyager request %s returned status %d", path, resp.StatusCode)
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse Voyager response: %w", err)
+	}
+
+	v.logger.Debug("Voyager request", zap.String("path", path), zap.Int("bytes", len(body)))
+	return nil
+}
+
+// userAgent returns the browser's user agent so API calls look like the web app's own
+func (v *VoyagerClient) userAgent(ctx context.Context) string {
+	res, err := v.browser.ExecuteScript(ctx, `() => navigator.userAgent`)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprint(res)
+}
+
+// VanityName returns the vanity name of a profile URL such as
+// https://www.linkedin.com/in/jane-doe/, or "" if the URL is not a profile URL
+func VanityName(profileURL string) string {
+	parsed, err := url.Parse(profileURL)
+	if err != nil {
+		return ""
+	}
+
+	parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] != "in" {
+		return ""
+	}
+
+	name, err := url.PathUnescape(parts[1])
+	if err != nil {
+		return parts[1]
+	}
+	return name
+}
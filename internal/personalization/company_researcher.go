@@ -0,0 +1,356 @@
+package personalization
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// Talking points returned per company
+const (
+	minTalkingPoints = 3
+	maxTalkingPoints = 5
+)
+
+// External sources of company talking points
+const (
+	crunchbaseAPIURL = "https://api.crunchbase.com/api/v4"
+	bingNewsSearch   = "https://www.bing.com/news/search"
+)
+
+// bingUserAgent is sent with Bing scrapes, which serve a stripped page to unknown clients
+const bingUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+
+// bingHeadline matches the title links of Bing News result cards
+var bingHeadline = regexp.MustCompile(`(?s)<a[^>]+class="title"[^>]*>(.*?)</a>`)
+
+// htmlTag matches the markup left inside scraped titles
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// CompanyResearcher finds short talking points about a company for connection notes.
+// Points fetched from Crunchbase or Bing are cached in the company_research table for
+// personalization.company_research.cache_ttl_hours; the YAML file is read once.
+type CompanyResearcher struct {
+	repo   core.RepositoryPort
+	config *core.Config
+	logger *zap.Logger
+	client *http.Client
+
+	fileOnce   sync.Once
+	filePoints map[string][]string
+	fileErr    error
+}
+
+// NewCompanyResearcher creates a company researcher
+func NewCompanyResearcher(repo core.RepositoryPort, config *core.Config, logger *zap.Logger) *CompanyResearcher {
+	timeout := config.Enrichers.TimeoutSeconds
+	if timeout <= 0 {
+		timeout = 10 // Default fallback
+	}
+	return &CompanyResearcher{
+		repo:   repo,
+		config: config,
+		logger: logger,
+		client: &http.Client{Timeout: time.Duration(timeout) * time.Second},
+	}
+}
+
+// GetTalkingPoints returns up to 5 talking points about companyName, e.g. "Raised a
+// Series B in March 2026". It returns none when the source knows nothing about the company.
+func (c *CompanyResearcher) GetTalkingPoints(ctx context.Context, companyName string) ([]string, error) {
+	companyName = strings.TrimSpace(companyName)
+	if companyName == "" {
+		return nil, nil
+	}
+
+	source := c.dataSource()
+	if source == core.CompanyResearchSourceFile {
+		return c.filePointsFor(companyName)
+	}
+
+	cached, err := c.repo.GetCompanyResearch(ctx, companyName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cached company research: %w", err)
+	}
+	if cached != nil && cached.DataSource == source && time.Since(cached.FetchedAt) < c.cacheTTL() {
+		var points []string
+		if err := json.Unmarshal([]byte(cached.TalkingPoints), &points); err == nil {
+			return points, nil
+		}
+	}
+
+	var points []string
+	switch source {
+	case core.CompanyResearchSourceCrunchbase:
+		points, err = c.crunchbasePoints(ctx, companyName)
+	case core.CompanyResearchSourceBing:
+		points, err = c.bingPoints(ctx, companyName)
+	default:
+		return nil, fmt.Errorf("unknown company research data source %q", source)
+	}
+	if err != nil {
+		return nil, err
+	}
+	points = limitPoints(points)
+	if len(points) < minTalkingPoints {
+		c.logger.Debug("Few talking points found",
+			zap.String("company", companyName), zap.Int("points", len(points)))
+	}
+
+	// Empty results are cached too, so unknown companies aren't looked up on every note
+	data, _ := json.Marshal(points)
+	research := &core.CompanyResearch{
+		Company:       companyName,
+		TalkingPoints: string(data),
+		DataSource:    source,
+		FetchedAt:     time.Now(),
+	}
+	if err := c.repo.SaveCompanyResearch(ctx, research); err != nil {
+		c.logger.Warn("Failed to cache company research", zap.String("company", companyName), zap.Error(err))
+	}
+
+	return points, nil
+}
+
+func (c *CompanyResearcher) dataSource() string {
+	if source := c.config.Personalization.CompanyResearch.DataSource; source != "" {
+		return source
+	}
+	return core.CompanyResearchSourceFile // Default fallback
+}
+
+func (c *CompanyResearcher) cacheTTL() time.Duration {
+	hours := c.config.Personalization.CompanyResearch.CacheTTLHours
+	if hours <= 0 {
+		hours = 168 // Default fallback
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// filePointsFor looks companyName up in the talking points file, ignoring case. A missing
+// file is treated as empty.
+func (c *CompanyResearcher) filePointsFor(companyName string) ([]string, error) {
+	c.fileOnce.Do(func() {
+		c.filePoints, c.fileErr = loadTalkingPointsFile(c.talkingPointsPath())
+	})
+	if c.fileErr != nil {
+		return nil, c.fileErr
+	}
+	return limitPoints(c.filePoints[strings.ToLower(companyName)]), nil
+}
+
+func (c *CompanyResearcher) talkingPointsPath() string {
+	if path := c.config.Personalization.CompanyResearch.TalkingPointsPath; path != "" {
+		return path
+	}
+	return "data/company_talking_points.yaml" // Default fallback
+}
+
+// loadTalkingPointsFile reads a YAML map of company name to talking points
+func loadTalkingPointsFile(path string) (map[string][]string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string][]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read talking points file: %w", err)
+	}
+
+	var byCompany map[string][]string
+	if err := yaml.Unmarshal(data, &byCompany); err != nil {
+		return nil, fmt.Errorf("failed to parse talking points file %s: %w", path, err)
+	}
+
+	points := make(map[string][]string, len(byCompany))
+	for company, companyPoints := range byCompany {
+		key := strings.ToLower(strings.TrimSpace(company))
+		points[key] = append(points[key], companyPoints...)
+	}
+	return points, nil
+}
+
+// crunchbasePoints describes the company from its Crunchbase organization profile
+func (c *CompanyResearcher) crunchbasePoints(ctx context.Context, companyName string) ([]string, error) {
+	headers := map[string]string{"X-cb-user-key": c.config.Enrichers.CrunchbaseAPIKey}
+
+	query := url.Values{
+		"query":          {companyName},
+		"collection_ids": {"organizations"},
+		"limit":          {"1"},
+	}
+	var matches struct {
+		Entities []struct {
+			Identifier struct {
+				Permalink string `json:"permalink"`
+			} `json:"identifier"`
+		} `json:"entities"`
+	}
+	if err := c.getJSON(ctx, crunchbaseAPIURL+"/autocompletes?"+query.Encode(), headers, &matches); err != nil {
+		return nil, fmt.Errorf("Crunchbase search failed: %w", err)
+	}
+	if len(matches.Entities) == 0 || matches.Entities[0].Identifier.Permalink == "" {
+		return nil, nil
+	}
+
+	var organization struct {
+		Properties struct {
+			ShortDescription string `json:"short_description"`
+			LastFundingType  string `json:"last_funding_type"`
+			LastFundingAt    string `json:"last_funding_at"`
+			FoundedOn        struct {
+				Value string `json:"value"`
+			} `json:"founded_on"`
+			Categories []struct {
+				Value string `json:"value"`
+			} `json:"categories"`
+			LocationIdentifiers []struct {
+				Value        string `json:"value"`
+				LocationType string `json:"location_type"`
+			} `json:"location_identifiers"`
+		} `json:"properties"`
+	}
+	fields := "short_description,last_funding_type,last_funding_at,founded_on,categories,location_identifiers"
+	path := crunchbaseAPIURL + "/entities/organizations/" + url.PathEscape(matches.Entities[0].Identifier.Permalink) + "?field_ids=" + fields
+	if err := c.getJSON(ctx, path, headers, &organization); err != nil {
+		return nil, fmt.Errorf("Crunchbase organization lookup failed: %w", err)
+	}
+
+	props := organization.Properties
+	points := make([]string, 0, maxTalkingPoints)
+	if description := strings.TrimSpace(props.ShortDescription); description != "" {
+		points = append(points, strings.TrimSuffix(description, "."))
+	}
+	if fundedAt, err := time.Parse("2006-01-02", props.LastFundingAt); err == nil && props.LastFundingType != "" {
+		points = append(points, "Raised a "+fundingRoundName(props.LastFundingType)+" in "+fundedAt.Format("January 2006"))
+	}
+	if len(props.Categories) > 0 {
+		categories := make([]string, 0, 2)
+		for _, category := range props.Categories {
+			if len(categories) < 2 && category.Value != "" {
+				categories = append(categories, category.Value)
+			}
+		}
+		if len(categories) > 0 {
+			points = append(points, "Works in "+strings.Join(categories, " and "))
+		}
+	}
+	for _, location := range props.LocationIdentifiers {
+		if location.LocationType == "city" && location.Value != "" {
+			points = append(points, "Based in "+location.Value)
+			break
+		}
+	}
+	if foundedOn, err := time.Parse("2006-01-02", props.FoundedOn.Value); err == nil {
+		points = append(points, "Founded in "+foundedOn.Format("2006"))
+	}
+
+	return points, nil
+}
+
+// bingPoints scrapes recent headlines about the company from Bing News search results
+func (c *CompanyResearcher) bingPoints(ctx context.Context, companyName string) ([]string, error) {
+	query := url.Values{
+		"q":    {`"` + companyName + `"`},
+		"qft":  {`interval="8"`}, // Past month
+		"form": {"PTFTNR"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, bingNewsSearch+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", bingUserAgent)
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+
+	body, err := c.fetch(req)
+	if err != nil {
+		return nil, fmt.Errorf("Bing News search failed: %w", err)
+	}
+
+	points := make([]string, 0, maxTalkingPoints)
+	seen := make(map[string]bool)
+	for _, match := range bingHeadline.FindAllStringSubmatch(string(body), -1) {
+		headline := strings.Join(strings.Fields(html.UnescapeString(htmlTag.ReplaceAllString(match[1], ""))), " ")
+		if headline == "" || seen[headline] {
+			continue
+		}
+		seen[headline] = true
+		points = append(points, headline)
+	}
+
+	c.logger.Debug("Company headlines scraped", zap.String("company", companyName), zap.Int("headlines", len(points)))
+	return points, nil
+}
+
+// getJSON fetches rawURL with headers and decodes the JSON response into out
+func (c *CompanyResearcher) getJSON(ctx context.Context, rawURL string, headers map[string]string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	body, err := c.fetch(req)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, out)
+}
+
+// fetch sends req and returns the body of a 200 response
+func (c *CompanyResearcher) fetch(req *http.Request) ([]byte, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return body, nil
+}
+
+// limitPoints trims the points and keeps the first maxTalkingPoints non-empty ones
+func limitPoints(points []string) []string {
+	limited := make([]string, 0, maxTalkingPoints)
+	for _, point := range points {
+		if point = strings.TrimSpace(point); point != "" && len(limited) < maxTalkingPoints {
+			limited = append(limited, point)
+		}
+	}
+	return limited
+}
+
+// fundingRoundName turns a Crunchbase funding type like "series_b" into "Series B"
+func fundingRoundName(fundingType string) string {
+	words := strings.Fields(strings.ReplaceAll(fundingType, "_", " "))
+	for i, word := range words {
+		if len(word) == 1 {
+			words[i] = strings.ToUpper(word)
+			continue
+		}
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,340 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// KeywordStat summarizes the profiles found for a search keyword
+type KeywordStat struct {
+	Keyword       string `json:"keyword"`
+	Searches      int    `json:"searches"`
+	ProfilesFound int    `json:"profiles_found"`
+}
+
+// DailyReport summarizes the bot's activity for a single day
+type DailyReport struct {
+	Date                    time.Time      `json:"date"`
+	ConnectionsSent         int            `json:"connections_sent"`
+	ConnectionsAccepted     int            `json:"connections_accepted"`
+	MessagesSent            int            `json:"messages_sent"`
+	MessagesReplied         int            `json:"messages_replied"`
+	ProfilesDiscovered      int            `json:"profiles_discovered"`
+	ErrorsByType            map[string]int `json:"errors_by_type"`
+	NotificationsByType     map[string]int `json:"notifications_by_type"`
+	TopKeywords             []KeywordStat  `json:"top_keywords"`
+	WorkingHoursUsedMinutes int            `json:"working_hours_used_minutes"`
+	NotableEvents           []string       `json:"notable_events,omitempty"` // e.g. "LinkedIn slowed page loads 2 times"
+	Actions                 int            `json:"actions"`                  // History entries of the day, 0 on days the bot didn't run
+}
+
+// notableActions describe the history entries worth calling out in a report, by action
+// type, with the day's count
+var notableActions = []struct {
+	actionType string
+	describe   func(count int) string
+}{
+	{"ThrottleDetected", func(n int) string { return fmt.Sprintf("LinkedIn slowed page loads %s", times(n)) }},
+	{"ContinuationScheduled", func(n int) string { return fmt.Sprintf("Runs ran out of time with profiles left %s", times(n)) }},
+	{"JoinGroup", func(n int) string { return fmt.Sprintf("Group joins requested: %d", n) }},
+	{"IntroductionRequest", func(n int) string { return fmt.Sprintf("Introductions requested: %d", n) }},
+	{"InMail", func(n int) string { return fmt.Sprintf("InMails sent: %d", n) }},
+	{"Celebration", func(n int) string { return fmt.Sprintf("Birthday and work anniversary messages: %d", n) }},
+}
+
+// times formats a count of occurrences, e.g. "once" or "3 times"
+func times(n int) string {
+	if n == 1 {
+		return "once"
+	}
+	return fmt.Sprintf("%d times", n)
+}
+
+// DailyReportGenerator builds daily activity reports from the repository
+type DailyReportGenerator struct {
+	repository core.RepositoryPort
+	logger     *zap.Logger
+}
+
+// NewDailyReportGenerator creates a new daily report generator
+func NewDailyReportGenerator(repo core.RepositoryPort, logger *zap.Logger) *DailyReportGenerator {
+	return &DailyReportGenerator{
+		repository: repo,
+		logger:     logger,
+	}
+}
+
+// maxTopKeywords is the number of keywords listed in a report
+const maxTopKeywords = 5
+
+// Generate builds the report for the calendar day containing date (in date's location)
+func (g *DailyReportGenerator) Generate(ctx context.Context, date time.Time) (*DailyReport, error) {
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	end := start.AddDate(0, 0, 1)
+
+	histories, err := g.repository.GetHistoryByDateRange(ctx, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load history: %w", err)
+	}
+
+	discovered, err := g.repository.SearchProfiles(ctx, &core.ProfileFilter{
+		CreatedAfter:  start,
+		CreatedBefore: end,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load discovered profiles: %w", err)
+	}
+
+	accepted, err := g.repository.SearchProfiles(ctx, &core.ProfileFilter{
+		ConnectedAfter:  start,
+		ConnectedBefore: end,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accepted connections: %w", err)
+	}
+
+	notifications, err := g.repository.GetNotificationsByDateRange(ctx, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load notifications: %w", err)
+	}
+
+	report := &DailyReport{
+		Date:                start,
+		ConnectionsAccepted: len(accepted),
+		ProfilesDiscovered:  len(discovered),
+		ErrorsByType:        make(map[string]int),
+		NotificationsByType: make(map[string]int),
+		TopKeywords:         make([]KeywordStat, 0),
+	}
+
+	for _, notification := range notifications {
+		report.NotificationsByType[notification.Type]++
+	}
+
+	keywords := make(map[string]*KeywordStat)
+	actionCounts := make(map[string]int)
+	report.Actions = len(histories)
+
+	for _, h := range histories {
+		actionCounts[h.ActionType]++
+		switch h.ActionType {
+		case "Connect":
+			report.ConnectionsSent++
+		case "Message":
+			report.MessagesSent++
+		case "Reply":
+			report.MessagesReplied++
+		case "Error":
+			errType := h.ParsedDetails().ErrorClass
+			if errType == "" {
+				errType = "unknown"
+			}
+			report.ErrorsByType[errType]++
+		case "Search":
+			details := h.ParsedDetails()
+			keyword, found := details.Keyword, details.Found
+			if keyword == "" {
+				continue
+			}
+			stat, ok := keywords[keyword]
+			if !ok {
+				stat = &KeywordStat{Keyword: keyword}
+				keywords[keyword] = stat
+			}
+			stat.Searches++
+			stat.ProfilesFound += found
+		}
+	}
+
+	for _, stat := range keywords {
+		report.TopKeywords = append(report.TopKeywords, *stat)
+	}
+	sort.Slice(report.TopKeywords, func(i, j int) bool {
+		if report.TopKeywords[i].ProfilesFound != report.TopKeywords[j].ProfilesFound {
+			return report.TopKeywords[i].ProfilesFound > report.TopKeywords[j].ProfilesFound
+		}
+		return report.TopKeywords[i].Keyword < report.TopKeywords[j].Keyword
+	})
+	if len(report.TopKeywords) > maxTopKeywords {
+		report.TopKeywords = report.TopKeywords[:maxTopKeywords]
+	}
+
+	report.WorkingHoursUsedMinutes = sessionMinutes(histories)
+
+	for _, notable := range notableActions {
+		if count := actionCounts[notable.actionType]; count > 0 {
+			report.NotableEvents = append(report.NotableEvents, notable.describe(count))
+		}
+	}
+
+	return report, nil
+}
+
+// sessionMinutes adds up the time between each SessionStart entry and the last
+// action recorded before the next session started
+func sessionMinutes(histories []*core.History) int {
+	sorted := make([]*core.History, len(histories))
+	copy(sorted, histories)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+
+	var total time.Duration
+	var sessionStart, lastAction time.Time
+	for _, h := range sorted {
+		if h.ActionType == "SessionStart" {
+			if !sessionStart.IsZero() {
+				total += lastAction.Sub(sessionStart)
+			}
+			sessionStart = h.Timestamp
+		}
+		lastAction = h.Timestamp
+	}
+	if !sessionStart.IsZero() {
+		total += lastAction.Sub(sessionStart)
+	}
+
+	return int(total.Minutes())
+}
+
+// Format renders the report as "text", "json" or "markdown"
+func (r *DailyReport) Format(format string) (string, error) {
+	switch format {
+	case "text":
+		return r.formatText(), nil
+	case "json":
+		data, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal report: %w", err)
+		}
+		return string(data), nil
+	case "markdown":
+		return r.formatMarkdown(), nil
+	default:
+		return "", fmt.Errorf("unsupported report format: %s", format)
+	}
+}
+
+func (r *DailyReport) formatText() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Daily report for %s\n", r.Date.Format("2006-01-02"))
+	fmt.Fprintf(&b, "  Connections sent:     %d\n", r.ConnectionsSent)
+	fmt.Fprintf(&b, "  Connections accepted: %d\n", r.ConnectionsAccepted)
+	fmt.Fprintf(&b, "  Messages sent:        %d\n", r.MessagesSent)
+	fmt.Fprintf(&b, "  Messages replied:     %d\n", r.MessagesReplied)
+	fmt.Fprintf(&b, "  Profiles discovered:  %d\n", r.ProfilesDiscovered)
+	fmt.Fprintf(&b, "  Minutes active:       %d\n", r.WorkingHoursUsedMinutes)
+
+	if len(r.ErrorsByType) > 0 {
+		b.WriteString("  Errors:\n")
+		for _, errType := range sortedKeys(r.ErrorsByType) {
+			fmt.Fprintf(&b, "    %s: %d\n", errType, r.ErrorsByType[errType])
+		}
+	}
+
+	if len(r.NotificationsByType) > 0 {
+		b.WriteString("  Notifications:\n")
+		for _, eventType := range sortedKeys(r.NotificationsByType) {
+			fmt.Fprintf(&b, "    %s: %d\n", eventType, r.NotificationsByType[eventType])
+		}
+	}
+
+	if len(r.NotableEvents) > 0 {
+		b.WriteString("  Notable events:\n")
+		for _, event := range r.NotableEvents {
+			fmt.Fprintf(&b, "    %s\n", event)
+		}
+	}
+
+	if len(r.TopKeywords) > 0 {
+		b.WriteString("  Top keywords:\n")
+		for _, k := range r.TopKeywords {
+			fmt.Fprintf(&b, "    %s: %d profiles (%d searches)\n", k.Keyword, k.ProfilesFound, k.Searches)
+		}
+	}
+
+	return b.String()
+}
+
+func (r *DailyReport) formatMarkdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Daily Report: %s\n\n", r.Date.Format("2006-01-02"))
+	b.WriteString("| Metric | Value |\n")
+	b.WriteString("| --- | --- |\n")
+	fmt.Fprintf(&b, "| Connections sent | %d |\n", r.ConnectionsSent)
+	fmt.Fprintf(&b, "| Connections accepted | %d |\n", r.ConnectionsAccepted)
+	fmt.Fprintf(&b, "| Messages sent | %d |\n", r.MessagesSent)
+	fmt.Fprintf(&b, "| Messages replied | %d |\n", r.MessagesReplied)
+	fmt.Fprintf(&b, "| Profiles discovered | %d |\n", r.ProfilesDiscovered)
+	fmt.Fprintf(&b, "| Minutes active | %d |\n", r.WorkingHoursUsedMinutes)
+
+	if len(r.ErrorsByType) > 0 {
+		b.WriteString("\n## Errors\n\n")
+		for _, errType := range sortedKeys(r.ErrorsByType) {
+			fmt.Fprintf(&b, "- %s: %d\n", errType, r.ErrorsByType[errType])
+		}
+	}
+
+	if len(r.NotificationsByType) > 0 {
+		b.WriteString("\n## Notifications\n\n")
+		for _, eventType := range sortedKeys(r.NotificationsByType) {
+			fmt.Fprintf(&b, "- %s: %d\n", eventType, r.NotificationsByType[eventType])
+		}
+	}
+
+	if len(r.NotableEvents) > 0 {
+		b.WriteString("\n## Notable Events\n\n")
+		for _, event := range r.NotableEvents {
+			fmt.Fprintf(&b, "- %s\n", event)
+		}
+	}
+
+	if len(r.TopKeywords) > 0 {
+		b.WriteString("\n## Top Keywords\n\n")
+		b.WriteString("| Keyword | Profiles | Searches |\n")
+		b.WriteString("| --- | --- | --- |\n")
+		for _, k := range r.TopKeywords {
+			fmt.Fprintf(&b, "| %s | %d | %d |\n", k.Keyword, k.ProfilesFound, k.Searches)
+		}
+	}
+
+	return b.String()
+}
+
+// Save writes the JSON report to dir/daily_YYYY-MM-DD.json and returns the path
+func (r *DailyReport) Save(dir string) (string, error) {
+	data, err := r.Format("json")
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create reports directory: %w", err)
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("daily_%s.json", r.Date.Format("2006-01-02")))
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		return "", fmt.Errorf("failed to write report: %w", err)
+	}
+
+	return path, nil
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
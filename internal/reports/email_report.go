@@ -0,0 +1,143 @@
+package reports
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+)
+
+// DailyEmail is a daily report rendered for email, with a plain-text alternative
+type DailyEmail struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// dailyEmailData is what the email templates render
+type dailyEmailData struct {
+	*DailyReport
+	DateLabel       string
+	DailyLimit      int
+	RemainingBudget int
+	Errors          []countRow
+	TotalErrors     int
+}
+
+// countRow is one row of a count table, e.g. an error class
+type countRow struct {
+	Name  string
+	Count int
+}
+
+const dailyEmailHTML = `<!DOCTYPE html>
+<html>
+<body style="font-family: Arial, sans-serif; color: #1d2226;">
+<h2>LinkedIn bot summary for {{.DateLabel}}</h2>
+<table cellpadding="6" style="border-collapse: collapse;">
+<tr><td>Invites sent</td><td><b>{{.ConnectionsSent}}</b></td></tr>
+<tr><td>Acceptances detected</td><td><b>{{.ConnectionsAccepted}}</b></td></tr>
+<tr><td>Messages sent</td><td><b>{{.MessagesSent}}</b></td></tr>
+<tr><td>Replies received</td><td><b>{{.MessagesReplied}}</b></td></tr>
+<tr><td>Profiles discovered</td><td><b>{{.ProfilesDiscovered}}</b></td></tr>
+<tr><td>Errors</td><td><b>{{.TotalErrors}}</b></td></tr>
+<tr><td>Remaining invite budget</td><td><b>{{.RemainingBudget}}</b> of {{.DailyLimit}}</td></tr>
+<tr><td>Minutes active</td><td><b>{{.WorkingHoursUsedMinutes}}</b></td></tr>
+</table>
+{{- if .Errors}}
+<h3>Errors</h3>
+<ul>
+{{- range .Errors}}
+<li>{{.Name}}: {{.Count}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .NotableEvents}}
+<h3>Notable events</h3>
+<ul>
+{{- range .NotableEvents}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .TopKeywords}}
+<h3>Top keywords</h3>
+<ul>
+{{- range .TopKeywords}}
+<li>{{.Keyword}}: {{.ProfilesFound}} profiles ({{.Searches}} searches)</li>
+{{- end}}
+</ul>
+{{- end}}
+</body>
+</html>
+`
+
+const dailyEmailText = `LinkedIn bot summary for {{.DateLabel}}
+
+Invites sent:            {{.ConnectionsSent}}
+Acceptances detected:    {{.ConnectionsAccepted}}
+Messages sent:           {{.MessagesSent}}
+Replies received:        {{.MessagesReplied}}
+Profiles discovered:     {{.ProfilesDiscovered}}
+Errors:                  {{.TotalErrors}}
+Remaining invite budget: {{.RemainingBudget}} of {{.DailyLimit}}
+Minutes active:          {{.WorkingHoursUsedMinutes}}
+{{- if .Errors}}
+
+Errors:
+{{- range .Errors}}
+  {{.Name}}: {{.Count}}
+{{- end}}
+{{- end}}
+{{- if .NotableEvents}}
+
+Notable events:
+{{- range .NotableEvents}}
+  {{.}}
+{{- end}}
+{{- end}}
+{{- if .TopKeywords}}
+
+Top keywords:
+{{- range .TopKeywords}}
+  {{.Keyword}}: {{.ProfilesFound}} profiles ({{.Searches}} searches)
+{{- end}}
+{{- end}}
+`
+
+var (
+	dailyEmailHTMLTemplate = htmltemplate.Must(htmltemplate.New("daily_email_html").Parse(dailyEmailHTML))
+	dailyEmailTextTemplate = texttemplate.Must(texttemplate.New("daily_email_text").Parse(dailyEmailText))
+)
+
+// RenderEmail renders the report as an email. dailyLimit is limits.max_actions_per_day,
+// which the remaining invite budget is counted against.
+func (r *DailyReport) RenderEmail(dailyLimit int) (*DailyEmail, error) {
+	data := dailyEmailData{
+		DailyReport: r,
+		DateLabel:   r.Date.Format("Monday, January 2, 2006"),
+		DailyLimit:  dailyLimit,
+	}
+	if remaining := dailyLimit - r.ConnectionsSent; remaining > 0 {
+		data.RemainingBudget = remaining
+	}
+	for _, errType := range sortedKeys(r.ErrorsByType) {
+		data.Errors = append(data.Errors, countRow{Name: errType, Count: r.ErrorsByType[errType]})
+		data.TotalErrors += r.ErrorsByType[errType]
+	}
+
+	var html, text bytes.Buffer
+	if err := dailyEmailHTMLTemplate.Execute(&html, data); err != nil {
+		return nil, fmt.Errorf("failed to render HTML email: %w", err)
+	}
+	if err := dailyEmailTextTemplate.Execute(&text, data); err != nil {
+		return nil, fmt.Errorf("failed to render text email: %w", err)
+	}
+
+	return &DailyEmail{
+		Subject: fmt.Sprintf("LinkedIn bot daily summary: %d invites, %d accepted (%s)",
+			r.ConnectionsSent, r.ConnectionsAccepted, r.Date.Format("2006-01-02")),
+		Text: text.String(),
+		HTML: html.String(),
+	}, nil
+}
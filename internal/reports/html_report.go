@@ -0,0 +1,447 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// htmlReportTemplate is the report page; its CSS and charts are inline so the file can be
+// opened or served without any other assets
+//
+//go:embed html_report.html
+var htmlReportTemplate string
+
+var htmlReportPage = template.Must(template.New("html_report").Parse(htmlReportTemplate))
+
+// maxRecentActivity is the number of history entries listed in the activity table
+const maxRecentActivity = 50
+
+// Chart geometry of the daily actions chart, in SVG units
+const (
+	chartWidth  = 720
+	chartHeight = 200
+	chartTop    = 10
+	chartLeft   = 30
+)
+
+// chartSeries are the action types stacked in each day's bar, bottom to top
+var chartSeries = []struct {
+	actionType string
+	label      string
+	color      string
+}{
+	{"Connect", "Invites", "#0a66c2"},
+	{"Message", "Messages", "#057642"},
+	{"Reply", "Replies", "#e7a33e"},
+	{"Error", "Errors", "#cc1016"},
+}
+
+// HTMLReportOptions selects what an HTML report covers
+type HTMLReportOptions struct {
+	Start    time.Time // First day, in the report's time zone
+	End      time.Time // Exclusive
+	Campaign string    // Only this campaign's profiles and actions (empty = all)
+}
+
+// HTMLReport is campaign progress over a date range, rendered as a self-contained page
+type HTMLReport struct {
+	Start       time.Time
+	End         time.Time
+	Campaign    string
+	GeneratedAt time.Time
+
+	Days       []DayActions
+	Funnel     []FunnelStep
+	ByTemplate []AcceptanceRow
+	BySource   []AcceptanceRow
+	Recent     []ActivityRow
+
+	Chart       []ChartBar
+	Legend      []ChartLegend
+	Labels      []AxisLabel
+	YMax        int
+	ChartBottom int // y of the chart's baseline
+}
+
+// DayActions counts the charted actions of one day
+type DayActions struct {
+	Date   time.Time
+	Counts map[string]int
+}
+
+// FunnelStep is the number of profiles that got at least this far
+type FunnelStep struct {
+	Label   string
+	Count   int
+	Percent int // Of the first step
+}
+
+// AcceptanceRow is the connection requests sent to a group of profiles and how many were accepted
+type AcceptanceRow struct {
+	Name     string
+	Profiles int
+	Sent     int
+	Accepted int
+	Rate     int // Accepted / Sent, in percent
+}
+
+// ActivityRow is one recent history entry
+type ActivityRow struct {
+	Time    time.Time
+	Action  string
+	Subject string
+	Outcome string
+}
+
+// ChartBar is one segment of a stacked bar
+type ChartBar struct {
+	X, Y, Width, Height int
+	Color               string
+	Title               string
+}
+
+// ChartLegend names a series of the chart
+type ChartLegend struct {
+	Label string
+	Color string
+}
+
+// AxisLabel is a date under the chart
+type AxisLabel struct {
+	X    int
+	Text string
+}
+
+// HTMLReportGenerator builds HTML reports from the repository
+type HTMLReportGenerator struct {
+	repository core.RepositoryPort
+	config     *core.Config
+	logger     *zap.Logger
+}
+
+// NewHTMLReportGenerator creates a new HTML report generator
+func NewHTMLReportGenerator(repo core.RepositoryPort, config *core.Config, logger *zap.Logger) *HTMLReportGenerator {
+	return &HTMLReportGenerator{
+		repository: repo,
+		config:     config,
+		logger:     logger,
+	}
+}
+
+// Generate builds the report for the profiles discovered and the actions taken between
+// opts.Start and opts.End
+func (g *HTMLReportGenerator) Generate(ctx context.Context, opts HTMLReportOptions) (*HTMLReport, error) {
+	if !opts.End.After(opts.Start) {
+		return nil, fmt.Errorf("report range is empty: %s to %s", opts.Start.Format("2006-01-02"), opts.End.Format("2006-01-02"))
+	}
+
+	histories, err := g.repository.GetHistoryByDateRange(ctx, opts.Start, opts.End)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load history: %w", err)
+	}
+	if opts.Campaign != "" {
+		filtered := histories[:0]
+		for _, h := range histories {
+			if h.Campaign == opts.Campaign {
+				filtered = append(filtered, h)
+			}
+		}
+		histories = filtered
+	}
+
+	profiles, err := g.repository.SearchProfiles(ctx, &core.ProfileFilter{
+		Campaign:      opts.Campaign,
+		CreatedAfter:  opts.Start,
+		CreatedBefore: opts.End,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load profiles: %w", err)
+	}
+
+	report := &HTMLReport{
+		Start:       opts.Start,
+		End:         opts.End,
+		Campaign:    opts.Campaign,
+		GeneratedAt: time.Now().In(opts.Start.Location()),
+		Funnel:      funnel(profiles),
+		BySource: acceptanceBy(profiles, func(p *core.Profile) string {
+			return p.Source
+		}),
+		Recent: recentActivity(histories, opts.Start.Location()),
+	}
+
+	templates, err := g.noteTemplates(ctx, profiles)
+	if err != nil {
+		return nil, err
+	}
+	report.ByTemplate = acceptanceBy(profiles, func(p *core.Profile) string {
+		return templates[p.Campaign]
+	})
+
+	report.Days = dailyActions(histories, opts.Start, opts.End)
+	report.buildChart()
+
+	return report, nil
+}
+
+// noteTemplates maps the campaigns of profiles to the connection note their requests
+// used: the campaign's note, or connection.note_template
+func (g *HTMLReportGenerator) noteTemplates(ctx context.Context, profiles []*core.Profile) (map[string]string, error) {
+	defaultNote := g.config.Connection.NoteTemplate
+	if defaultNote == "" {
+		defaultNote = "(no note)"
+	}
+
+	templates := make(map[string]string)
+	for _, p := range profiles {
+		if _, ok := templates[p.Campaign]; ok {
+			continue
+		}
+		templates[p.Campaign] = defaultNote
+		if p.Campaign == "" {
+			continue
+		}
+		campaign, err := g.repository.GetCampaignByName(ctx, p.Campaign)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load campaign %q: %w", p.Campaign, err)
+		}
+		if campaign != nil && campaign.NoteTemplate != "" {
+			templates[p.Campaign] = campaign.NoteTemplate
+		}
+	}
+	return templates, nil
+}
+
+// funnel counts the profiles that reached each outreach step
+func funnel(profiles []*core.Profile) []FunnelStep {
+	steps := []FunnelStep{
+		{Label: "Discovered"},
+		{Label: "Request sent"},
+		{Label: "Accepted"},
+		{Label: "Messaged"},
+		{Label: "Replied"},
+	}
+	for _, p := range profiles {
+		steps[0].Count++
+		if hasStatus(p.Status, core.RequestedStatuses) {
+			steps[1].Count++
+		}
+		if hasStatus(p.Status, core.AcceptedStatuses) {
+			steps[2].Count++
+		}
+		if p.Status == core.ProfileStatusMessageSent || p.Status == core.ProfileStatusReplied {
+			steps[3].Count++
+		}
+		if p.Status == core.ProfileStatusReplied {
+			steps[4].Count++
+		}
+	}
+	for i := range steps {
+		steps[i].Percent = percent(steps[i].Count, steps[0].Count)
+	}
+	return steps
+}
+
+// acceptanceBy groups profiles by key and counts their requests and acceptances, most
+// requests first
+func acceptanceBy(profiles []*core.Profile, key func(*core.Profile) string) []AcceptanceRow {
+	rows := make(map[string]*AcceptanceRow)
+	for _, p := range profiles {
+		name := key(p)
+		if name == "" {
+			name = "unknown"
+		}
+		row, ok := rows[name]
+		if !ok {
+			row = &AcceptanceRow{Name: name}
+			rows[name] = row
+		}
+		row.Profiles++
+		if hasStatus(p.Status, core.RequestedStatuses) {
+			row.Sent++
+		}
+		if hasStatus(p.Status, core.AcceptedStatuses) {
+			row.Accepted++
+		}
+	}
+
+	result := make([]AcceptanceRow, 0, len(rows))
+	for _, row := range rows {
+		row.Rate = percent(row.Accepted, row.Sent)
+		result = append(result, *row)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Sent != result[j].Sent {
+			return result[i].Sent > result[j].Sent
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// dailyActions counts the charted action types per day between start and end
+func dailyActions(histories []*core.History, start, end time.Time) []DayActions {
+	loc := start.Location()
+	var days []DayActions
+	index := make(map[string]int)
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		index[day.Format("2006-01-02")] = len(days)
+		days = append(days, DayActions{Date: day, Counts: make(map[string]int)})
+	}
+
+	for _, h := range histories {
+		if i, ok := index[h.Timestamp.In(loc).Format("2006-01-02")]; ok {
+			days[i].Counts[h.ActionType]++
+		}
+	}
+	return days
+}
+
+// recentActivity lists the latest history entries of histories, which are newest first
+func recentActivity(histories []*core.History, loc *time.Location) []ActivityRow {
+	rows := make([]ActivityRow, 0, maxRecentActivity)
+	for _, h := range histories {
+		if len(rows) == maxRecentActivity {
+			break
+		}
+		details := h.ParsedDetails()
+
+		subject := details.ProfileURL
+		if subject == "" {
+			subject = details.Target
+		}
+		if subject == "" {
+			subject = details.Keyword
+		}
+		outcome := details.Outcome
+		if details.Error != "" {
+			outcome = details.Error
+		}
+
+		rows = append(rows, ActivityRow{
+			Time:    h.Timestamp.In(loc),
+			Action:  h.ActionType,
+			Subject: subject,
+			Outcome: outcome,
+		})
+	}
+	return rows
+}
+
+// buildChart lays out one stacked bar per day, scaled to the busiest day, with about
+// ten dates under it
+func (r *HTMLReport) buildChart() {
+	r.ChartBottom = chartTop + chartHeight
+	for _, series := range chartSeries {
+		r.Legend = append(r.Legend, ChartLegend{Label: series.label, Color: series.color})
+	}
+
+	r.YMax = 1
+	for _, day := range r.Days {
+		total := 0
+		for _, series := range chartSeries {
+			total += day.Counts[series.actionType]
+		}
+		if total > r.YMax {
+			r.YMax = total
+		}
+	}
+	if len(r.Days) == 0 {
+		return
+	}
+
+	slot := (chartWidth - chartLeft) / len(r.Days)
+	step := (len(r.Days) + 9) / 10
+	for i := 0; i < len(r.Days); i += step {
+		r.Labels = append(r.Labels, AxisLabel{X: chartLeft + i*slot + slot/2, Text: r.Days[i].Date.Format("Jan 2")})
+	}
+
+	width := slot * 3 / 4
+	if width < 1 {
+		width = 1
+	}
+	for i, day := range r.Days {
+		x := chartLeft + i*slot + (slot-width)/2
+		base := r.ChartBottom
+		for _, series := range chartSeries {
+			count := day.Counts[series.actionType]
+			if count == 0 {
+				continue
+			}
+			height := count * chartHeight / r.YMax
+			if height < 1 {
+				height = 1
+			}
+			base -= height
+			r.Chart = append(r.Chart, ChartBar{
+				X:      x,
+				Y:      base,
+				Width:  width,
+				Height: height,
+				Color:  series.color,
+				Title:  fmt.Sprintf("%s: %d %s", day.Date.Format("Jan 2"), count, series.label),
+			})
+		}
+	}
+}
+
+// Render writes the report as a complete HTML page
+func (r *HTMLReport) Render() ([]byte, error) {
+	var b bytes.Buffer
+	if err := htmlReportPage.Execute(&b, r); err != nil {
+		return nil, fmt.Errorf("failed to render HTML report: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+// Save writes the page to path. It is written to a temporary file first and renamed, so
+// a web server serving path never sees a partial page.
+func (r *HTMLReport) Save(path string) error {
+	page, err := r.Render()
+	if err != nil {
+		return err
+	}
+
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create report directory: %w", err)
+		}
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, page, 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+func hasStatus(status core.ProfileStatus, statuses []core.ProfileStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func percent(part, whole int) int {
+	if whole == 0 {
+		return 0
+	}
+	return part * 100 / whole
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"reflect"
+
+	"linkedin-automation/internal/core"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// accountScopedTables are the tables partitioned by account. Every statement GORM builds
+// for them is limited to the repository's account, and inserts are stamped with it.
+var accountScopedTables = map[string]bool{
+	"profiles":        true,
+	"histories":       true,
+	"messages":        true,
+	"analytics_daily": true,
+	"search_queries":  true,
+	"jobs":            true,
+}
+
+// registerAccountScope adds GORM callbacks that scope the account-partitioned tables to
+// account, so one database can be shared by several LinkedIn accounts. Raw SQL is not
+// covered and must filter on account itself.
+func registerAccountScope(db *gorm.DB, account string) error {
+	scope := func(tx *gorm.DB) {
+		if !isAccountScoped(tx.Statement) {
+			return
+		}
+		tx.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+			clause.Eq{Column: clause.Column{Table: tx.Statement.Table, Name: "account"}, Value: account},
+		}})
+	}
+	stamp := func(tx *gorm.DB) {
+		if !isAccountScoped(tx.Statement) {
+			return
+		}
+		field := tx.Statement.Schema.LookUpField("account")
+		if field == nil {
+			return
+		}
+		setAccount := func(row reflect.Value) {
+			if _, zero := field.ValueOf(tx.Statement.Context, row); zero {
+				_ = field.Set(tx.Statement.Context, row, account)
+			}
+		}
+		switch rv := tx.Statement.ReflectValue; rv.Kind() {
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < rv.Len(); i++ {
+				setAccount(rv.Index(i))
+			}
+		case reflect.Struct:
+			setAccount(rv)
+		}
+	}
+
+	callbacks := db.Callback()
+	if err := callbacks.Query().Before("gorm:query").Register("account:query", scope); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("account:row", scope); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("account:update", scope); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("account:delete", scope); err != nil {
+		return err
+	}
+	return callbacks.Create().Before("gorm:create").Register("account:create", stamp)
+}
+
+// isAccountScoped reports whether the statement works on an account-partitioned table
+func isAccountScoped(stmt *gorm.Statement) bool {
+	return stmt.Schema != nil && accountScopedTables[stmt.Schema.Table] && stmt.Table == stmt.Schema.Table
+}
+
+// migrateAccounts finishes the account partitioning of a database created before it:
+// rows without an account are given the default one and the unique indexes that
+// ignored the account are dropped (AutoMigrate has created the per-account ones)
+func migrateAccounts(db *gorm.DB) error {
+	for table := range accountScopedTables {
+		if err := db.Exec("UPDATE "+table+" SET account = ? WHERE account IS NULL OR account = ''", core.DefaultAccount).Error; err != nil {
+			return err
+		}
+	}
+
+	migrator := db.Migrator()
+	for model, index := range map[interface{}]string{
+		&core.Profile{}:         "idx_profiles_linked_in_url",
+		&core.DailyProjection{}: "idx_analytics_daily",
+	} {
+		if !migrator.HasIndex(model, index) {
+			continue
+		}
+		if err := migrator.DropIndex(model, index); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,260 @@
+package repository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"linkedin-automation/internal/core"
+)
+
+// newAccountRepositories opens two repositories for different accounts on one SQLite file
+func newAccountRepositories(t *testing.T) (*Repository, *Repository) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "bot.db")
+	alice := newTestRepository(t, core.DatabaseConfig{Path: path, Account: "alice"})
+	bob := newTestRepository(t, core.DatabaseConfig{Path: path, Account: "bob"})
+	return alice, bob
+}
+
+func TestAccountScopeProfiles(t *testing.T) {
+	alice, bob := newAccountRepositories(t)
+	ctx := context.Background()
+
+	url := "https://www.linkedin.com/in/shared-contact/"
+	if err := alice.CreateProfile(ctx, &core.Profile{LinkedInURL: url, Name: "Alice's view", Status: core.ProfileStatusRequestSent}); err != nil {
+		t.Fatalf("CreateProfile(alice): %v", err)
+	}
+
+	profile, err := bob.GetProfileByURL(ctx, url)
+	if err != nil {
+		t.Fatalf("GetProfileByURL(bob): %v", err)
+	}
+	if profile != nil {
+		t.Fatalf("bob sees alice's profile %+v", profile)
+	}
+	sent, err := bob.GetProfilesByStatus(ctx, core.ProfileStatusRequestSent)
+	if err != nil {
+		t.Fatalf("GetProfilesByStatus(bob): %v", err)
+	}
+	if len(sent) != 0 {
+		t.Errorf("GetProfilesByStatus(bob) = %d profiles, want 0", len(sent))
+	}
+
+	// The same URL is a separate profile per account
+	if err := bob.CreateProfile(ctx, &core.Profile{LinkedInURL: url, Name: "Bob's view", Status: core.ProfileStatusDiscovered}); err != nil {
+		t.Fatalf("CreateProfile(bob) with alice's URL: %v", err)
+	}
+	for _, tt := range []struct {
+		repo *Repository
+		name string
+	}{
+		{alice, "Alice's view"},
+		{bob, "Bob's view"},
+	} {
+		profile, err := tt.repo.GetProfileByURL(ctx, url)
+		if err != nil {
+			t.Fatalf("GetProfileByURL(%s): %v", tt.repo.account, err)
+		}
+		if profile == nil || profile.Name != tt.name || profile.Account != tt.repo.account {
+			t.Errorf("GetProfileByURL(%s) = %+v, want %q of account %s", tt.repo.account, profile, tt.name, tt.repo.account)
+		}
+	}
+
+	// Status updates stay in the account
+	if err := bob.UpdateProfileStatus(ctx, url, core.ProfileStatusRequestSent); err != nil {
+		t.Fatalf("UpdateProfileStatus(bob): %v", err)
+	}
+	if err := bob.MarkAsConnected(ctx, url); err != nil {
+		t.Fatalf("MarkAsConnected(bob): %v", err)
+	}
+	profile, err = alice.GetProfileByURL(ctx, url)
+	if err != nil {
+		t.Fatalf("GetProfileByURL(alice): %v", err)
+	}
+	if profile.Status != core.ProfileStatusRequestSent {
+		t.Errorf("alice's profile status = %s after bob connected, want %s", profile.Status, core.ProfileStatusRequestSent)
+	}
+}
+
+func TestAccountScopeActionLimits(t *testing.T) {
+	alice, bob := newAccountRepositories(t)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if err := alice.CreateHistory(ctx, core.NewConnectHistory("https://www.linkedin.com/in/someone/")); err != nil {
+			t.Fatalf("CreateHistory(alice): %v", err)
+		}
+	}
+
+	if count, err := alice.GetTodayActionCount(ctx, "Connect"); err != nil || count != 2 {
+		t.Errorf("GetTodayActionCount(alice) = %d, %v, want 2", count, err)
+	}
+	if count, err := bob.GetTodayActionCount(ctx, "Connect"); err != nil || count != 0 {
+		t.Errorf("GetTodayActionCount(bob) = %d, %v, want 0", count, err)
+	}
+	if ok, err := alice.CanPerformAction(ctx, "Connect", 2); err != nil || ok {
+		t.Errorf("CanPerformAction(alice, limit 2) = %v, %v, want false", ok, err)
+	}
+	if ok, err := bob.CanPerformAction(ctx, "Connect", 2); err != nil || !ok {
+		t.Errorf("CanPerformAction(bob, limit 2) = %v, %v, want true", ok, err)
+	}
+}
+
+func TestAccountScopePendingFollowups(t *testing.T) {
+	alice, bob := newAccountRepositories(t)
+	ctx := context.Background()
+
+	url := "https://www.linkedin.com/in/new-connection/"
+	if err := alice.CreateProfile(ctx, &core.Profile{LinkedInURL: url, Status: core.ProfileStatusRequestSent}); err != nil {
+		t.Fatalf("CreateProfile(alice): %v", err)
+	}
+	if err := alice.MarkAsConnected(ctx, url); err != nil {
+		t.Fatalf("MarkAsConnected(alice): %v", err)
+	}
+
+	if pending, err := alice.GetPendingFollowups(ctx, 10, nil); err != nil || len(pending) != 1 {
+		t.Errorf("GetPendingFollowups(alice) = %d profiles, %v, want 1", len(pending), err)
+	}
+	if pending, err := bob.GetPendingFollowups(ctx, 10, nil); err != nil || len(pending) != 0 {
+		t.Errorf("GetPendingFollowups(bob) = %d profiles, %v, want 0", len(pending), err)
+	}
+}
+
+func TestAccountScopeUpserts(t *testing.T) {
+	alice, bob := newAccountRepositories(t)
+	ctx := context.Background()
+
+	url := "https://www.linkedin.com/in/upserted/"
+	aliceProfile := &core.Profile{LinkedInURL: url, Status: core.ProfileStatusDiscovered}
+	if _, err := alice.UpsertProfile(ctx, aliceProfile); err != nil {
+		t.Fatalf("UpsertProfile(alice): %v", err)
+	}
+
+	bobProfile := &core.Profile{LinkedInURL: url, Status: core.ProfileStatusScanned}
+	result, err := bob.UpsertProfile(ctx, bobProfile)
+	if err != nil {
+		t.Fatalf("UpsertProfile(bob): %v", err)
+	}
+	if !result.Created || bobProfile.ID == aliceProfile.ID || bobProfile.Account != "bob" {
+		t.Errorf("UpsertProfile(bob) of alice's URL = %+v, profile %+v, want a new bob profile", result, bobProfile)
+	}
+	stored, err := alice.GetProfileByURL(ctx, url)
+	if err != nil {
+		t.Fatalf("GetProfileByURL(alice): %v", err)
+	}
+	if stored.Status != core.ProfileStatusDiscovered {
+		t.Errorf("alice's profile status = %s after bob's upsert, want %s", stored.Status, core.ProfileStatusDiscovered)
+	}
+
+	bulk := []string{url, "https://www.linkedin.com/in/alice-only/"}
+	if _, _, err := alice.BulkCreateProfiles(ctx, []*core.Profile{{LinkedInURL: bulk[1], Status: core.ProfileStatusDiscovered}}); err != nil {
+		t.Fatalf("BulkCreateProfiles(alice): %v", err)
+	}
+	profiles := make([]*core.Profile, 0, len(bulk))
+	for _, u := range bulk {
+		profiles = append(profiles, &core.Profile{LinkedInURL: u, Status: core.ProfileStatusDiscovered})
+	}
+	created, skipped, err := bob.BulkCreateProfiles(ctx, profiles)
+	if err != nil {
+		t.Fatalf("BulkCreateProfiles(bob): %v", err)
+	}
+	// bob already has url; alice-only is new to bob
+	if created != 1 || skipped != 1 {
+		t.Errorf("BulkCreateProfiles(bob) = %d created, %d skipped, want 1 and 1", created, skipped)
+	}
+}
+
+func TestAccountScopeRawStats(t *testing.T) {
+	alice, bob := newAccountRepositories(t)
+	ctx := context.Background()
+
+	url := "https://www.linkedin.com/in/stats-contact/"
+	profile := &core.Profile{LinkedInURL: url, Status: core.ProfileStatusRequestSent}
+	if err := alice.CreateProfile(ctx, profile); err != nil {
+		t.Fatalf("CreateProfile(alice): %v", err)
+	}
+	if err := alice.CreateHistory(ctx, core.NewConnectHistory(url)); err != nil {
+		t.Fatalf("CreateHistory(alice): %v", err)
+	}
+	if err := alice.MarkAsConnected(ctx, url); err != nil {
+		t.Fatalf("MarkAsConnected(alice): %v", err)
+	}
+	if err := alice.CreateMessage(ctx, &core.Message{ProfileID: profile.ID, Direction: core.MessageDirectionOut, Body: "Hi", TemplateName: "intro"}); err != nil {
+		t.Fatalf("CreateMessage(alice): %v", err)
+	}
+
+	start := time.Now().Add(-time.Hour)
+	end := time.Now().Add(time.Hour)
+	for _, tt := range []struct {
+		repo *Repository
+		want int
+	}{
+		{alice, 1},
+		{bob, 0},
+	} {
+		account := tt.repo.account
+
+		daily, err := tt.repo.GetDailyActionCounts(ctx, start, end)
+		if err != nil {
+			t.Fatalf("GetDailyActionCounts(%s): %v", account, err)
+		}
+		if len(daily) != tt.want {
+			t.Errorf("GetDailyActionCounts(%s) = %v, want %d rows", account, daily, tt.want)
+		}
+
+		statuses, err := tt.repo.GetStatusCounts(ctx)
+		if err != nil {
+			t.Fatalf("GetStatusCounts(%s): %v", account, err)
+		}
+		if len(statuses) != tt.want {
+			t.Errorf("GetStatusCounts(%s) = %v, want %d rows", account, statuses, tt.want)
+		}
+
+		acceptance, err := tt.repo.GetAcceptanceStats(ctx, start, end)
+		if err != nil {
+			t.Fatalf("GetAcceptanceStats(%s): %v", account, err)
+		}
+		if acceptance.RequestsSent != int64(tt.want) || acceptance.Accepted != int64(tt.want) {
+			t.Errorf("GetAcceptanceStats(%s) = %+v, want %d sent and accepted", account, acceptance, tt.want)
+		}
+
+		templates, err := tt.repo.GetTemplatePerformance(ctx, start, end)
+		if err != nil {
+			t.Fatalf("GetTemplatePerformance(%s): %v", account, err)
+		}
+		if len(templates) != tt.want {
+			t.Errorf("GetTemplatePerformance(%s) = %v, want %d rows", account, templates, tt.want)
+		}
+
+		buckets, err := tt.repo.GetActionStats(ctx, "Connect", start, end, 24*time.Hour)
+		if err != nil {
+			t.Fatalf("GetActionStats(%s): %v", account, err)
+		}
+		if len(buckets) > 2 || countBuckets(buckets) != int64(tt.want) {
+			t.Errorf("GetActionStats(%s) = %v, want %d actions", account, buckets, tt.want)
+		}
+
+		rates, err := tt.repo.GetAcceptanceRateByDay(ctx, start, end)
+		if err != nil {
+			t.Fatalf("GetAcceptanceRateByDay(%s): %v", account, err)
+		}
+		var sent int64
+		for _, rate := range rates {
+			sent += rate.RequestsSent
+		}
+		if sent != int64(tt.want) {
+			t.Errorf("GetAcceptanceRateByDay(%s) = %v, want %d requests", account, rates, tt.want)
+		}
+	}
+}
+
+// countBuckets sums the actions of buckets
+func countBuckets(buckets []core.StatsBucket) int64 {
+	var count int64
+	for _, bucket := range buckets {
+		count += bucket.Count
+	}
+	return count
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Archived profiles are soft-deleted (deleted_at) like profiles awaiting the retention
+// purge, so every default query skips them, and also carry archived_at. The purge and
+// the re-insert of purged URLs leave rows with archived_at alone.
+
+// registerArchivedScope adds GORM callbacks that, for contexts made by
+// core.WithArchivedProfiles, let profile queries return archived profiles as well.
+// Profiles awaiting the purge stay hidden.
+func registerArchivedScope(db *gorm.DB) error {
+	include := func(tx *gorm.DB) {
+		stmt := tx.Statement
+		if stmt.Unscoped || stmt.Schema == nil || stmt.Schema.Table != "profiles" || stmt.Table != "profiles" {
+			return
+		}
+		if !core.ArchivedProfilesIncluded(stmt.Context) {
+			return
+		}
+		stmt.Unscoped = true
+		stmt.AddClause(clause.Where{Exprs: []clause.Expression{clause.Or(
+			clause.Eq{Column: clause.Column{Table: "profiles", Name: "deleted_at"}, Value: nil},
+			clause.Neq{Column: clause.Column{Table: "profiles", Name: "archived_at"}, Value: nil},
+		)}})
+	}
+
+	callbacks := db.Callback()
+	if err := callbacks.Query().Before("gorm:query").Register("archived:query", include); err != nil {
+		return err
+	}
+	return callbacks.Row().Before("gorm:row").Register("archived:row", include)
+}
+
+// ArchiveProfile takes a profile out of every queue without deleting it or its history,
+// e.g. when the person asked not to be contacted. It returns false if no active profile
+// has the URL.
+func (r *Repository) ArchiveProfile(ctx context.Context, url string) (bool, error) {
+	url = core.NormalizeProfileURL(url)
+	now := time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&core.Profile{}).
+		Where("linked_in_url = ?", url).
+		Updates(map[string]interface{}{
+			"deleted_at":  now,
+			"archived_at": now,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// UnarchiveProfile puts an archived profile back with its previous status. It returns
+// false if no archived profile has the URL.
+func (r *Repository) UnarchiveProfile(ctx context.Context, url string) (bool, error) {
+	url = core.NormalizeProfileURL(url)
+	result := r.db.WithContext(ctx).
+		Unscoped().
+		Model(&core.Profile{}).
+		Where("linked_in_url = ? AND archived_at IS NOT NULL", url).
+		Updates(map[string]interface{}{
+			"deleted_at":  nil,
+			"archived_at": nil,
+			"updated_at":  time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// IsProfileArchived reports whether the profile with the URL is archived
+func (r *Repository) IsProfileArchived(ctx context.Context, url string) (bool, error) {
+	return isArchived(r.db.WithContext(ctx), core.NormalizeProfileURL(url))
+}
+
+// isArchived reports whether an archived profile has the URL
+func isArchived(tx *gorm.DB, url string) (bool, error) {
+	var count int64
+	err := tx.Unscoped().
+		Model(&core.Profile{}).
+		Where("linked_in_url = ? AND archived_at IS NOT NULL", url).
+		Count(&count).Error
+	return count > 0, err
+}
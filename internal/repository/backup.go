@@ -0,0 +1,297 @@
+package repository
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Errors returned by Backup and RestoreBackup
+var (
+	ErrBackupUnsupported = errors.New("backups are only supported by the sqlite driver")
+	ErrChecksumMismatch  = errors.New("backup checksum does not match")
+	ErrNewerDatabase     = errors.New("database has activity newer than the backup")
+)
+
+// checksumSuffix names the file next to a backup that holds its SHA-256, in sha256sum format
+const checksumSuffix = ".sha256"
+
+// BackupInfo describes a written backup
+type BackupInfo struct {
+	Path     string
+	Checksum string // Hex SHA-256 of the compressed file
+	Size     int64  // Bytes of the compressed file
+}
+
+// RestoreResult describes a restored backup
+type RestoreResult struct {
+	BackupActivity   time.Time // Latest history entry in the backup (zero if none)
+	ReplacedActivity time.Time // Latest history entry in the database it replaced (zero if none)
+}
+
+// Backup writes a gzipped snapshot of the SQLite database to outPath and its checksum to
+// outPath.sha256. The snapshot is taken with VACUUM INTO, which reads inside a transaction,
+// so it is consistent while other connections keep writing.
+func (r *Repository) Backup(ctx context.Context, outPath string) (*BackupInfo, error) {
+	if r.db.Dialector.Name() != DriverSQLite {
+		return nil, ErrBackupUnsupported
+	}
+
+	if dir := filepath.Dir(outPath); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create backup directory: %w", err)
+		}
+	}
+
+	snapshot := outPath + ".snapshot"
+	if err := os.Remove(snapshot); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to remove leftover snapshot: %w", err)
+	}
+	defer os.Remove(snapshot)
+
+	if err := r.db.WithContext(ctx).Exec("VACUUM INTO ?", snapshot).Error; err != nil {
+		return nil, fmt.Errorf("failed to snapshot database: %w", err)
+	}
+
+	checksum, size, err := gzipFile(snapshot, outPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compress backup: %w", err)
+	}
+
+	line := checksum + "  " + filepath.Base(outPath) + "\n"
+	if err := os.WriteFile(outPath+checksumSuffix, []byte(line), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write backup checksum: %w", err)
+	}
+
+	return &BackupInfo{Path: outPath, Checksum: checksum, Size: size}, nil
+}
+
+// gzipFile compresses src into dst, writing to a temporary file that is renamed into
+// place once complete. It returns the SHA-256 and size of dst.
+func gzipFile(src, dst string) (string, int64, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return "", 0, err
+	}
+	defer in.Close()
+
+	partial := dst + ".partial"
+	out, err := os.Create(partial)
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.Remove(partial)
+
+	hash := sha256.New()
+	counter := &countingWriter{w: io.MultiWriter(out, hash)}
+	gz := gzip.NewWriter(counter)
+	if _, err := io.Copy(gz, in); err != nil {
+		out.Close()
+		return "", 0, err
+	}
+	if err := gz.Close(); err != nil {
+		out.Close()
+		return "", 0, err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return "", 0, err
+	}
+	if err := out.Close(); err != nil {
+		return "", 0, err
+	}
+
+	if err := os.Rename(partial, dst); err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), counter.n, nil
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// VerifyBackup checks a backup against the checksum Backup recorded next to it
+func VerifyBackup(path string) error {
+	recorded, err := os.ReadFile(path + checksumSuffix)
+	if err != nil {
+		return fmt.Errorf("failed to read backup checksum: %w", err)
+	}
+	fields := strings.Fields(string(recorded))
+	if len(fields) == 0 {
+		return fmt.Errorf("%w: %s%s is empty", ErrChecksumMismatch, path, checksumSuffix)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return fmt.Errorf("failed to read backup: %w", err)
+	}
+	if actual := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(actual, fields[0]) {
+		return fmt.Errorf("%w: expected %s, got %s", ErrChecksumMismatch, fields[0], actual)
+	}
+
+	return nil
+}
+
+// RestoreBackup replaces the SQLite database at cfg.Path with a backup written by Backup,
+// then migrates it. The backup's checksum and integrity are checked first. A database
+// whose latest history entry is newer than the backup's is only replaced with force.
+// Nothing else may have the database open while it is restored.
+func RestoreBackup(ctx context.Context, cfg *core.DatabaseConfig, inPath string, force bool) (*RestoreResult, error) {
+	if cfg.Driver != "" && cfg.Driver != DriverSQLite {
+		return nil, ErrBackupUnsupported
+	}
+	if err := VerifyBackup(inPath); err != nil {
+		return nil, err
+	}
+
+	target := sqliteFilePath(cfg.Path)
+	restoring := target + ".restoring"
+	if err := gunzipFile(inPath, restoring); err != nil {
+		os.Remove(restoring)
+		return nil, fmt.Errorf("failed to decompress backup: %w", err)
+	}
+	defer os.Remove(restoring)
+
+	result := &RestoreResult{}
+	backupActivity, err := inspectSQLite(ctx, cfg, restoring, true)
+	if err != nil {
+		return nil, fmt.Errorf("backup is not a usable database: %w", err)
+	}
+	result.BackupActivity = backupActivity
+
+	if _, err := os.Stat(target); err == nil {
+		current, err := inspectSQLite(ctx, cfg, target, false)
+		if err != nil && !force {
+			return nil, fmt.Errorf("failed to read the current database (use force to replace it anyway): %w", err)
+		}
+		result.ReplacedActivity = current
+		if current.After(backupActivity) && !force {
+			return nil, fmt.Errorf("%w: latest activity %s, backup %s",
+				ErrNewerDatabase, current.Format(time.RFC3339), backupActivity.Format(time.RFC3339))
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to check database file: %w", err)
+	}
+
+	if err := os.Rename(restoring, target); err != nil {
+		return nil, fmt.Errorf("failed to replace database: %w", err)
+	}
+	// A WAL left by the replaced database would be replayed into the restored one
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(target + suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to remove stale %s file: %w", suffix, err)
+		}
+	}
+
+	repo, err := NewRepository(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate restored database: %w", err)
+	}
+	if err := repo.Close(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// gunzipFile decompresses src into dst
+func gunzipFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	gz, err := gzip.NewReader(in)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, gz); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// inspectSQLite opens the SQLite file at path with cfg's settings and returns the time of
+// its latest history entry, across accounts. A restored snapshot is opened without a WAL,
+// so renaming it leaves nothing behind, and has its integrity checked.
+func inspectSQLite(ctx context.Context, cfg *core.DatabaseConfig, path string, snapshot bool) (time.Time, error) {
+	inspected := *cfg
+	inspected.Path = path
+	inspected.MigrateEncryption = false
+	if snapshot {
+		inspected.SQLiteJournalMode = "delete"
+	}
+
+	db, err := gorm.Open(dialectors[DriverSQLite](&inspected), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		return time.Time{}, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer sqlDB.Close()
+
+	if snapshot {
+		var result string
+		if err := db.WithContext(ctx).Raw("PRAGMA integrity_check").Scan(&result).Error; err != nil {
+			return time.Time{}, err
+		}
+		if result != "ok" {
+			return time.Time{}, fmt.Errorf("integrity check failed: %s", result)
+		}
+	}
+
+	if !db.Migrator().HasTable(&core.History{}) {
+		return time.Time{}, nil
+	}
+	var latest sql.NullInt64
+	if err := db.WithContext(ctx).Raw("SELECT MAX(CAST(strftime('%s', timestamp) AS INTEGER)) FROM histories").Scan(&latest).Error; err != nil {
+		return time.Time{}, err
+	}
+	if !latest.Valid {
+		return time.Time{}, nil
+	}
+	return time.Unix(latest.Int64, 0), nil
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"gorm.io/gorm"
+)
+
+// regexpCache keeps compiled blacklist patterns so MatchBlacklist doesn't recompile
+// them for every profile
+type regexpCache struct {
+	mu       sync.Mutex
+	compiled map[string]*regexp.Regexp
+}
+
+func newRegexpCache() *regexpCache {
+	return &regexpCache{compiled: make(map[string]*regexp.Regexp)}
+}
+
+// get returns the compiled pattern, compiling it on first use
+func (c *regexpCache) get(pattern string) (*regexp.Regexp, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if re, ok := c.compiled[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	c.compiled[pattern] = re
+	return re, nil
+}
+
+// AddBlacklistEntry validates and stores a blacklist entry. Exact URLs are normalized
+// and regexps must compile.
+func (r *Repository) AddBlacklistEntry(ctx context.Context, entry *core.BlacklistEntry) error {
+	entry.Value = strings.TrimSpace(entry.Value)
+	if entry.Value == "" {
+		return fmt.Errorf("blacklist value is required")
+	}
+
+	switch entry.PatternType {
+	case core.BlacklistExactURL:
+		entry.Value = core.NormalizeProfileURL(entry.Value)
+	case core.BlacklistURLPrefix:
+	case core.BlacklistCompanyRegex, core.BlacklistHeadlineRegex:
+		if _, err := r.regexps.get(entry.Value); err != nil {
+			return fmt.Errorf("invalid blacklist pattern %q: %w", entry.Value, err)
+		}
+	default:
+		return fmt.Errorf("unknown blacklist pattern type %q", entry.PatternType)
+	}
+
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	return r.db.WithContext(ctx).Create(entry).Error
+}
+
+// RemoveBlacklistEntry deletes a blacklist entry by ID
+func (r *Repository) RemoveBlacklistEntry(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&core.BlacklistEntry{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("blacklist entry %d not found", id)
+	}
+	return nil
+}
+
+// ListBlacklist returns all blacklist entries, oldest first
+func (r *Repository) ListBlacklist(ctx context.Context) ([]*core.BlacklistEntry, error) {
+	var entries []*core.BlacklistEntry
+	result := r.db.WithContext(ctx).Order("created_at ASC").Find(&entries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return entries, nil
+}
+
+// MatchBlacklist returns the first blacklist entry matching the profile, or nil if none
+// does. URL entries are looked up by index: the profile URL itself for exact entries and
+// each of its prefixes for prefix entries. Regexps are matched in memory against the
+// profile's current company and headline.
+func (r *Repository) MatchBlacklist(ctx context.Context, profile *core.Profile) (*core.BlacklistEntry, error) {
+	profileURL := core.NormalizeProfileURL(profile.LinkedInURL)
+	if profileURL != "" {
+		prefixes := make([]string, 0, len(profileURL))
+		for i := 1; i <= len(profileURL); i++ {
+			prefixes = append(prefixes, profileURL[:i])
+		}
+
+		var entry core.BlacklistEntry
+		result := r.db.WithContext(ctx).
+			Where("(pattern_type = ? AND value = ?) OR (pattern_type = ? AND value IN ?)",
+				core.BlacklistExactURL, profileURL, core.BlacklistURLPrefix, prefixes).
+			Order("id").
+			First(&entry)
+		if result.Error == nil {
+			return &entry, nil
+		}
+		if result.Error != gorm.ErrRecordNotFound {
+			return nil, result.Error
+		}
+	}
+
+	if profile.CurrentCompany == "" && profile.Headline == "" {
+		return nil, nil
+	}
+
+	var entries []*core.BlacklistEntry
+	result := r.db.WithContext(ctx).
+		Where("pattern_type IN ?", []string{core.BlacklistCompanyRegex, core.BlacklistHeadlineRegex}).
+		Order("id").
+		Find(&entries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, entry := range entries {
+		field := profile.CurrentCompany
+		if entry.PatternType == core.BlacklistHeadlineRegex {
+			field = profile.Headline
+		}
+		if field == "" {
+			continue
+		}
+
+		re, err := r.regexps.get(entry.Value)
+		if err != nil {
+			continue // Rejected by AddBlacklistEntry; only rows written by hand get here
+		}
+		if re.MatchString(field) {
+			return entry, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// MarkProfileBlacklisted records on a stored profile why it is blacklisted
+func (r *Repository) MarkProfileBlacklisted(ctx context.Context, url string, reason string) error {
+	url = core.NormalizeProfileURL(url)
+	result := r.db.WithContext(ctx).
+		Model(&core.Profile{}).
+		Where("linked_in_url = ?", url).
+		Updates(map[string]interface{}{
+			"blacklist_reason": reason,
+			"updated_at":       time.Now(),
+		})
+
+	return result.Error
+}
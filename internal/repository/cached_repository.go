@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"linkedin-automation/internal/core"
+)
+
+// cachedProfile is a GetProfileByURL result; profile is nil for profiles not in the database
+type cachedProfile struct {
+	profile   *core.Profile
+	expiresAt time.Time
+}
+
+// CachedRepository wraps a repository and caches GetProfileByURL results, which are
+// looked up for every profile of a run. Writes that change a profile drop its entry.
+type CachedRepository struct {
+	core.RepositoryPort
+	profiles *LRUCache[string, cachedProfile]
+	ttl      time.Duration
+}
+
+// NewCachedRepository creates a caching decorator holding up to size profiles for ttl
+func NewCachedRepository(repo core.RepositoryPort, size int, ttl time.Duration) *CachedRepository {
+	if ttl <= 0 {
+		ttl = 5 * time.Minute // Default fallback
+	}
+	return &CachedRepository{
+		RepositoryPort: repo,
+		profiles:       NewLRUCache[string, cachedProfile](size),
+		ttl:            ttl,
+	}
+}
+
+// GetProfileByURL returns a cached copy of the profile, loading it on a miss. Lookups
+// that include archived profiles bypass the cache.
+func (c *CachedRepository) GetProfileByURL(ctx context.Context, url string) (*core.Profile, error) {
+	url = core.NormalizeProfileURL(url)
+	if core.ArchivedProfilesIncluded(ctx) {
+		return c.RepositoryPort.GetProfileByURL(ctx, url)
+	}
+	if entry, ok := c.profiles.Get(url); ok && time.Now().Before(entry.expiresAt) {
+		return copyProfile(entry.profile), nil
+	}
+
+	profile, err := c.RepositoryPort.GetProfileByURL(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	c.profiles.Set(url, cachedProfile{profile: copyProfile(profile), expiresAt: time.Now().Add(c.ttl)})
+	return profile, nil
+}
+
+// invalidate drops the cached lookup of a profile URL
+func (c *CachedRepository) invalidate(url string) {
+	c.profiles.Delete(core.NormalizeProfileURL(url))
+}
+
+// copyProfile returns a shallow copy so callers can't change the cached profile
+func copyProfile(profile *core.Profile) *core.Profile {
+	if profile == nil {
+		return nil
+	}
+	copied := *profile
+	return &copied
+}
+
+func (c *CachedRepository) CreateProfile(ctx context.Context, profile *core.Profile) error {
+	defer c.invalidate(profile.LinkedInURL)
+	return c.RepositoryPort.CreateProfile(ctx, profile)
+}
+
+func (c *CachedRepository) UpsertProfile(ctx context.Context, profile *core.Profile) (*core.UpsertResult, error) {
+	defer c.invalidate(profile.LinkedInURL)
+	return c.RepositoryPort.UpsertProfile(ctx, profile)
+}
+
+// BulkCreateProfiles drops every inserted URL, since absent profiles are cached too
+func (c *CachedRepository) BulkCreateProfiles(ctx context.Context, profiles []*core.Profile) (int, int, error) {
+	defer func() {
+		for _, profile := range profiles {
+			c.invalidate(profile.LinkedInURL)
+		}
+	}()
+	return c.RepositoryPort.BulkCreateProfiles(ctx, profiles)
+}
+
+func (c *CachedRepository) ArchiveProfile(ctx context.Context, url string) (bool, error) {
+	defer c.invalidate(url)
+	return c.RepositoryPort.ArchiveProfile(ctx, url)
+}
+
+func (c *CachedRepository) UnarchiveProfile(ctx context.Context, url string) (bool, error) {
+	defer c.invalidate(url)
+	return c.RepositoryPort.UnarchiveProfile(ctx, url)
+}
+
+func (c *CachedRepository) UpdateProfileStatus(ctx context.Context, url string, status core.ProfileStatus) error {
+	defer c.invalidate(url)
+	return c.RepositoryPort.UpdateProfileStatus(ctx, url, status)
+}
+
+func (c *CachedRepository) ForceProfileStatus(ctx context.Context, url string, status core.ProfileStatus) error {
+	defer c.invalidate(url)
+	return c.RepositoryPort.ForceProfileStatus(ctx, url, status)
+}
+
+func (c *CachedRepository) UpdateProfileLastActive(ctx context.Context, url string, lastActive *time.Time) error {
+	defer c.invalidate(url)
+	return c.RepositoryPort.UpdateProfileLastActive(ctx, url, lastActive)
+}
+
+func (c *CachedRepository) UpdateProfileOpenToWork(ctx context.Context, url string, openToWork bool) error {
+	defer c.invalidate(url)
+	return c.RepositoryPort.UpdateProfileOpenToWork(ctx, url, openToWork)
+}
+
+func (c *CachedRepository) UpdateProfileFollowerCount(ctx context.Context, url string, followerCount int64) error {
+	defer c.invalidate(url)
+	return c.RepositoryPort.UpdateProfileFollowerCount(ctx, url, followerCount)
+}
+
+func (c *CachedRepository) UpdateProfileIdentity(ctx context.Context, url string, name string, headline string) error {
+	defer c.invalidate(url)
+	return c.RepositoryPort.UpdateProfileIdentity(ctx, url, name, headline)
+}
+
+func (c *CachedRepository) MarkProfileVisited(ctx context.Context, url string, visitedAt time.Time) error {
+	defer c.invalidate(url)
+	return c.RepositoryPort.MarkProfileVisited(ctx, url, visitedAt)
+}
+
+func (c *CachedRepository) UpdateProfileSkills(ctx context.Context, url string, skills []string) error {
+	defer c.invalidate(url)
+	return c.RepositoryPort.UpdateProfileSkills(ctx, url, skills)
+}
+
+func (c *CachedRepository) UpdateProfileEnrichment(ctx context.Context, url string, enrichment *core.ProfileEnrichment) error {
+	defer c.invalidate(url)
+	return c.RepositoryPort.UpdateProfileEnrichment(ctx, url, enrichment)
+}
+
+func (c *CachedRepository) MarkAsConnected(ctx context.Context, linkedinURL string) error {
+	defer c.invalidate(linkedinURL)
+	return c.RepositoryPort.MarkAsConnected(ctx, linkedinURL)
+}
+
+func (c *CachedRepository) MarkAsConnectedAt(ctx context.Context, linkedinURL string, connectedAt time.Time) error {
+	defer c.invalidate(linkedinURL)
+	return c.RepositoryPort.MarkAsConnectedAt(ctx, linkedinURL, connectedAt)
+}
+
+func (c *CachedRepository) MarkInvitationPending(ctx context.Context, linkedinURL string) error {
+	defer c.invalidate(linkedinURL)
+	return c.RepositoryPort.MarkInvitationPending(ctx, linkedinURL)
+}
+
+func (c *CachedRepository) AssignProfileToCampaign(ctx context.Context, profileURL string, campaignName string) error {
+	defer c.invalidate(profileURL)
+	return c.RepositoryPort.AssignProfileToCampaign(ctx, profileURL, campaignName)
+}
+
+func (c *CachedRepository) MarkProfileBlacklisted(ctx context.Context, url string, reason string) error {
+	defer c.invalidate(url)
+	return c.RepositoryPort.MarkProfileBlacklisted(ctx, url, reason)
+}
+
+// LogMessageSent updates the profile by ID, so the whole cache is dropped
+func (c *CachedRepository) LogMessageSent(ctx context.Context, message *core.Message) error {
+	defer c.profiles.Purge()
+	return c.RepositoryPort.LogMessageSent(ctx, message)
+}
+
+// PurgeExpired deletes profiles in bulk, so the whole cache is dropped
+func (c *CachedRepository) PurgeExpired(ctx context.Context, retention *core.RetentionConfig, now time.Time) (*core.PurgeResult, error) {
+	defer c.profiles.Purge()
+	return c.RepositoryPort.PurgeExpired(ctx, retention, now)
+}
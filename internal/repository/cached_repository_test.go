@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"linkedin-automation/internal/core"
+)
+
+// countingRepository counts the GetProfileByURL calls that reach the database
+type countingRepository struct {
+	core.RepositoryPort
+	lookups int
+}
+
+func (c *countingRepository) GetProfileByURL(ctx context.Context, url string) (*core.Profile, error) {
+	c.lookups++
+	return c.RepositoryPort.GetProfileByURL(ctx, url)
+}
+
+// BenchmarkCachedRepository replays the lookups of a typical 50-profile run, where every
+// profile URL is checked on each of 20 passes (search dedupe, skip checks, connect and
+// follow-up), and reports how many reached the database
+func BenchmarkCachedRepository(b *testing.B) {
+	const (
+		profiles = 50
+		passes   = 20
+	)
+	ctx := context.Background()
+	repo, err := NewRepository(&core.DatabaseConfig{Path: filepath.Join(b.TempDir(), "bot.db")})
+	if err != nil {
+		b.Fatalf("NewRepository: %v", err)
+	}
+	defer repo.Close()
+
+	urls := make([]string, profiles)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("https://www.linkedin.com/in/profile-%d/", i)
+		if i%2 == 0 { // Half of the run's profiles are already stored
+			if err := repo.CreateProfile(ctx, &core.Profile{LinkedInURL: urls[i], Status: core.ProfileStatusDiscovered}); err != nil {
+				b.Fatalf("CreateProfile: %v", err)
+			}
+		}
+	}
+
+	run := func(b *testing.B, port core.RepositoryPort) {
+		for pass := 0; pass < passes; pass++ {
+			for _, url := range urls {
+				if _, err := port.GetProfileByURL(ctx, url); err != nil {
+					b.Fatalf("GetProfileByURL: %v", err)
+				}
+			}
+		}
+	}
+
+	b.Run("uncached", func(b *testing.B) {
+		counter := &countingRepository{RepositoryPort: repo}
+		for i := 0; i < b.N; i++ {
+			run(b, counter)
+		}
+		b.ReportMetric(float64(counter.lookups)/float64(b.N), "db_lookups/run")
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		counter := &countingRepository{RepositoryPort: repo}
+		for i := 0; i < b.N; i++ {
+			run(b, NewCachedRepository(counter, 1000, 5*time.Minute)) // A fresh cache per run
+		}
+		perRun := float64(counter.lookups) / float64(b.N)
+		reduction := 1 - perRun/float64(profiles*passes)
+		b.ReportMetric(perRun, "db_lookups/run")
+		b.ReportMetric(reduction*100, "%_fewer_lookups")
+		if reduction <= 0.9 {
+			b.Errorf("cache saved %.0f%% of database lookups, want more than 90%%", reduction*100)
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetCompanyResearch returns the cached talking points of company, or nil if there are none
+func (r *Repository) GetCompanyResearch(ctx context.Context, company string) (*core.CompanyResearch, error) {
+	var research core.CompanyResearch
+	result := r.db.WithContext(ctx).Where("company = ?", companyKey(company)).First(&research)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &research, nil
+}
+
+// SaveCompanyResearch stores the talking points of a company, replacing earlier ones
+func (r *Repository) SaveCompanyResearch(ctx context.Context, research *core.CompanyResearch) error {
+	research.Company = companyKey(research.Company)
+	if research.FetchedAt.IsZero() {
+		research.FetchedAt = time.Now()
+	}
+
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "company"}},
+		DoUpdates: clause.AssignmentColumns([]string{"talking_points", "data_source", "fetched_at", "updated_at"}),
+	}).Create(research).Error
+}
+
+// companyKey is the cache key of a company name
+func companyKey(company string) string {
+	return strings.ToLower(strings.TrimSpace(company))
+}
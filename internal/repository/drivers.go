@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// Supported values of database.driver
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+)
+
+// dialectors maps database.driver to the GORM dialector that opens it. Drivers other
+// than SQLite register themselves from files behind a build tag, so their dependencies
+// are only needed when they are used.
+var dialectors = map[string]func(cfg *core.DatabaseConfig) gorm.Dialector{
+	DriverSQLite: func(cfg *core.DatabaseConfig) gorm.Dialector {
+		if cfg.EncryptionKey != "" {
+			return encryptedSQLite(cfg)
+		}
+		return sqlite.Open(sqliteDSN(cfg))
+	},
+}
+
+// sqliteDSN adds the journal mode, busy timeout and foreign key settings to the SQLite
+// path. They are connection parameters rather than one-off PRAGMAs so that every
+// connection the pool opens gets them. Parameters already in the path win.
+func sqliteDSN(cfg *core.DatabaseConfig) string {
+	return withSQLiteParams(cfg, "_journal_mode="+strings.ToUpper(sqliteJournalMode(cfg)))
+}
+
+// sqliteJournalMode is database.sqlite_journal_mode or its default
+func sqliteJournalMode(cfg *core.DatabaseConfig) string {
+	if cfg.SQLiteJournalMode == "" {
+		return "wal" // Default fallback
+	}
+	return cfg.SQLiteJournalMode
+}
+
+// withSQLiteParams adds extra and the busy timeout, foreign key and locking parameters
+// to the SQLite path
+func withSQLiteParams(cfg *core.DatabaseConfig, extra ...string) string {
+	busyTimeout := cfg.SQLiteBusyTimeoutMs
+	if busyTimeout <= 0 {
+		busyTimeout = 5000 // Default fallback
+	}
+	foreignKeys := "0"
+	if cfg.SQLiteForeignKeys {
+		foreignKeys = "1"
+	}
+
+	params := append(extra,
+		"_busy_timeout="+strconv.Itoa(busyTimeout),
+		"_foreign_keys="+foreignKeys,
+		// Take the write lock when a transaction starts, so a transaction that reads
+		// before writing waits for the busy timeout instead of failing to upgrade its lock
+		"_txlock=immediate",
+	)
+
+	dsn := cfg.Path
+	for _, param := range params {
+		name := param[:strings.Index(param, "=")+1]
+		if strings.Contains(dsn, "?"+name) || strings.Contains(dsn, "&"+name) {
+			continue
+		}
+		if strings.Contains(dsn, "?") {
+			dsn += "&" + param
+		} else {
+			dsn += "?" + param
+		}
+	}
+	return dsn
+}
+
+// configureSQLitePool limits SQLite to a single open connection by default: SQLite
+// allows one writer at a time, and serializing in the pool avoids "database is locked"
+// errors between the bot's own goroutines
+func configureSQLitePool(db *gorm.DB, cfg *core.DatabaseConfig) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to access connection pool: %w", err)
+	}
+
+	maxOpen := cfg.SQLiteMaxOpenConns
+	if maxOpen <= 0 {
+		maxOpen = 1 // Default fallback
+	}
+	maxIdle := cfg.SQLiteMaxIdleConns
+	if maxIdle <= 0 {
+		maxIdle = 1 // Default fallback
+	}
+
+	sqlDB.SetMaxOpenConns(maxOpen)
+	sqlDB.SetMaxIdleConns(maxIdle)
+
+	return nil
+}
+
+// availableDrivers lists the drivers compiled into this build
+func availableDrivers() []string {
+	names := make([]string, 0, len(dialectors))
+	for name := range dialectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// configurePool applies the connection pool settings used by server databases
+func configurePool(db *gorm.DB, cfg *core.DatabaseConfig) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to access connection pool: %w", err)
+	}
+
+	maxOpen := cfg.MaxOpenConns
+	if maxOpen <= 0 {
+		maxOpen = 10 // Default fallback
+	}
+	maxIdle := cfg.MaxIdleConns
+	if maxIdle <= 0 {
+		maxIdle = 2 // Default fallback
+	}
+	lifetime := cfg.ConnMaxLifetimeMinutes
+	if lifetime <= 0 {
+		lifetime = 30 // Default fallback
+	}
+
+	sqlDB.SetMaxOpenConns(maxOpen)
+	sqlDB.SetMaxIdleConns(maxIdle)
+	sqlDB.SetConnMaxLifetime(time.Duration(lifetime) * time.Minute)
+
+	return nil
+}
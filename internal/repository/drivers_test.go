@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"gorm.io/gorm"
+)
+
+// postgresTestDSNEnv points the backend tests at a Postgres database. The postgres
+// backend is skipped when it is unset.
+const postgresTestDSNEnv = "LINKEDIN_BOT_TEST_POSTGRES_DSN"
+
+func TestBackends(t *testing.T) {
+	// Each run gets its own account, so runs against a shared Postgres database don't see
+	// each other's rows
+	account := fmt.Sprintf("test-%d", time.Now().UnixNano())
+
+	backends := []struct {
+		name string
+		cfg  core.DatabaseConfig
+	}{
+		{"sqlite", core.DatabaseConfig{Driver: DriverSQLite, Path: filepath.Join(t.TempDir(), "bot.db"), Account: account}},
+		{"postgres", core.DatabaseConfig{Driver: DriverPostgres, DSN: os.Getenv(postgresTestDSNEnv), Account: account}},
+	}
+
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			if _, ok := dialectors[backend.cfg.Driver]; !ok {
+				_, err := NewRepository(&backend.cfg)
+				if err == nil || !strings.Contains(err.Error(), "-tags "+backend.cfg.Driver) {
+					t.Fatalf("NewRepository without the %s driver = %v, want an error naming the build tag", backend.cfg.Driver, err)
+				}
+				t.Skipf("%s driver not included in this build", backend.cfg.Driver)
+			}
+			if backend.cfg.Driver == DriverPostgres && backend.cfg.DSN == "" {
+				t.Skipf("%s is not set", postgresTestDSNEnv)
+			}
+
+			repo, err := NewRepository(&backend.cfg)
+			if err != nil {
+				t.Fatalf("NewRepository: %v", err)
+			}
+			t.Cleanup(func() { _ = repo.Close() })
+
+			ctx := context.Background()
+			url := "https://www.linkedin.com/in/backend-test"
+			if err := repo.CreateProfile(ctx, &core.Profile{LinkedInURL: url, Name: "Backend Test", Status: core.ProfileStatusDiscovered}); err != nil {
+				t.Fatalf("CreateProfile: %v", err)
+			}
+			if err := repo.UpdateProfileStatus(ctx, url, core.ProfileStatusRequestSent); err != nil {
+				t.Fatalf("UpdateProfileStatus: %v", err)
+			}
+			if err := repo.CreateHistory(ctx, core.NewConnectHistory(url)); err != nil {
+				t.Fatalf("CreateHistory: %v", err)
+			}
+
+			profile, err := repo.GetProfileByURL(ctx, url)
+			if err != nil {
+				t.Fatalf("GetProfileByURL: %v", err)
+			}
+			if profile == nil || profile.Status != core.ProfileStatusRequestSent {
+				t.Fatalf("GetProfileByURL = %+v, want status %s", profile, core.ProfileStatusRequestSent)
+			}
+
+			count, err := repo.GetTodayActionCount(ctx, "Connect")
+			if err != nil {
+				t.Fatalf("GetTodayActionCount: %v", err)
+			}
+			if count != 1 {
+				t.Errorf("GetTodayActionCount = %d, want 1", count)
+			}
+		})
+	}
+}
+
+func TestSQLiteConcurrentReadWrite(t *testing.T) {
+	// One connection (the default) serializes in the pool; several rely on WAL, the busy
+	// timeout and immediate write transactions
+	for _, conns := range []int{1, 4} {
+		t.Run(fmt.Sprintf("%d connections", conns), func(t *testing.T) {
+			repo := newTestRepository(t, core.DatabaseConfig{SQLiteMaxOpenConns: conns, SQLiteMaxIdleConns: conns})
+			testSQLiteConcurrentReadWrite(t, repo)
+		})
+	}
+}
+
+func testSQLiteConcurrentReadWrite(t *testing.T, repo *Repository) {
+	const (
+		writers          = 4
+		readers          = 4
+		writesPerWriter  = 25
+		readsPerReader   = 50
+		writeTransaction = 20 * time.Millisecond
+	)
+	ctx := context.Background()
+	url := func(writer, i int) string {
+		return fmt.Sprintf("https://www.linkedin.com/in/writer-%d-profile-%d/", writer, i)
+	}
+
+	errs := make(chan error, writers*writesPerWriter+readers*readsPerReader+1)
+	var wg sync.WaitGroup
+
+	// A long write transaction that readers and writers run into
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		errs <- repo.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&core.Profile{LinkedInURL: url(-1, 0), Status: core.ProfileStatusDiscovered}).Error; err != nil {
+				return err
+			}
+			time.Sleep(writeTransaction)
+			return nil
+		})
+	}()
+
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < writesPerWriter; i++ {
+				if err := repo.CreateProfile(ctx, &core.Profile{LinkedInURL: url(w, i), Status: core.ProfileStatusDiscovered}); err != nil {
+					errs <- fmt.Errorf("CreateProfile: %w", err)
+				}
+				if err := repo.CreateHistory(ctx, core.NewConnectHistory(url(w, i))); err != nil {
+					errs <- fmt.Errorf("CreateHistory: %w", err)
+				}
+			}
+		}(w)
+	}
+
+	for r := 0; r < readers; r++ {
+		wg.Add(1)
+		go func(r int) {
+			defer wg.Done()
+			for i := 0; i < readsPerReader; i++ {
+				if _, err := repo.GetProfileByURL(ctx, url(r%writers, i%writesPerWriter)); err != nil {
+					errs <- fmt.Errorf("GetProfileByURL: %w", err)
+				}
+				if _, err := repo.GetTodayActionCount(ctx, "Connect"); err != nil {
+					errs <- fmt.Errorf("GetTodayActionCount: %w", err)
+				}
+			}
+		}(r)
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+
+	count, err := repo.GetTodayActionCount(ctx, "Connect")
+	if err != nil {
+		t.Fatalf("GetTodayActionCount: %v", err)
+	}
+	if count != writers*writesPerWriter {
+		t.Errorf("GetTodayActionCount = %d after the writes, want %d", count, writers*writesPerWriter)
+	}
+}
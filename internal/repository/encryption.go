@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"linkedin-automation/internal/core"
+
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// Errors returned when database.encryption_key is set
+var (
+	ErrEncryptionUnsupported = errors.New("database encryption needs the bot built against SQLCipher (see README)")
+	ErrWrongEncryptionKey    = errors.New("wrong database.encryption_key, or the file is not an encrypted database")
+	ErrPlaintextDatabase     = errors.New("database is not encrypted")
+)
+
+// sqliteHeader starts every plaintext SQLite file. SQLCipher files start with a random salt.
+const sqliteHeader = "SQLite format 3\x00"
+
+// sqlcipherConnector opens SQLite connections unlocked by a ConnectHook
+type sqlcipherConnector struct {
+	driver *sqlite3.SQLiteDriver
+	dsn    string
+}
+
+// Connect opens a new connection to the database
+func (c *sqlcipherConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+// Driver returns the underlying SQLite driver
+func (c *sqlcipherConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// encryptedSQLite opens database.path with database.encryption_key. The key must be the
+// first statement that reads the file, so the journal mode is set after it instead of
+// as a DSN parameter.
+func encryptedSQLite(cfg *core.DatabaseConfig) gorm.Dialector {
+	key := cfg.EncryptionKey
+	journalMode := strings.ToUpper(sqliteJournalMode(cfg))
+
+	connector := &sqlcipherConnector{
+		driver: &sqlite3.SQLiteDriver{ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			if err := unlockSQLCipher(conn, key); err != nil {
+				return err
+			}
+			_, err := conn.Exec("PRAGMA journal_mode = "+journalMode, nil)
+			return err
+		}},
+		dsn: withSQLiteParams(cfg),
+	}
+
+	return &sqlite.Dialector{Conn: sql.OpenDB(connector)}
+}
+
+// unlockSQLCipher applies key to a new connection and checks that it opens the file
+func unlockSQLCipher(conn *sqlite3.SQLiteConn, key string) error {
+	if _, err := conn.Exec("PRAGMA key = "+quoteSQL(key), nil); err != nil {
+		return fmt.Errorf("failed to set encryption key: %w", err)
+	}
+
+	// Plain SQLite ignores PRAGMA key and would write the data unencrypted
+	rows, err := conn.Query("PRAGMA cipher_version", nil)
+	if err != nil {
+		return fmt.Errorf("failed to check SQLCipher support: %w", err)
+	}
+	err = rows.Next(make([]driver.Value, 1))
+	rows.Close()
+	if err == io.EOF {
+		return ErrEncryptionUnsupported
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check SQLCipher support: %w", err)
+	}
+
+	// A wrong key only shows once the file is read
+	if _, err := conn.Exec("SELECT count(*) FROM sqlite_master", nil); err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.Code == sqlite3.ErrNotADB {
+			return ErrWrongEncryptionKey
+		}
+		return fmt.Errorf("failed to read encrypted database: %w", err)
+	}
+
+	return nil
+}
+
+// prepareEncryptedSQLite runs before an encrypted SQLite database is opened. A plaintext
+// file at database.path is encrypted in place when cfg.MigrateEncryption is set, and
+// refused otherwise.
+func prepareEncryptedSQLite(cfg *core.DatabaseConfig) error {
+	path := sqliteFilePath(cfg.Path)
+	plaintext, err := isPlaintextSQLite(path)
+	if err != nil {
+		return err
+	}
+	if !plaintext {
+		return nil
+	}
+	if !cfg.MigrateEncryption {
+		return fmt.Errorf("%w: %s holds plaintext data; run the bot once with -migrate-encryption (e.g. bot -migrate-encryption runs list) to encrypt it in place", ErrPlaintextDatabase, path)
+	}
+	return encryptSQLiteFile(path, cfg.EncryptionKey)
+}
+
+// sqliteFilePath strips the file: scheme and the connection parameters from a SQLite path
+func sqliteFilePath(path string) string {
+	path = strings.TrimPrefix(path, "file:")
+	if i := strings.Index(path, "?"); i >= 0 {
+		path = path[:i]
+	}
+	return path
+}
+
+// isPlaintextSQLite reports whether path is an unencrypted SQLite file. Missing and empty
+// files are not: SQLCipher encrypts them from the first write.
+func isPlaintextSQLite(path string) (bool, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to open database file: %w", err)
+	}
+	defer file.Close()
+
+	header := make([]byte, len(sqliteHeader))
+	if _, err := io.ReadFull(file, header); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read database file: %w", err)
+	}
+	return string(header) == sqliteHeader, nil
+}
+
+// encryptSQLiteFile replaces the plaintext database at path with a copy encrypted with key.
+// The copy is written next to it and renamed over it, so a failure leaves the original.
+func encryptSQLiteFile(path, key string) error {
+	encryptedPath := path + ".encrypting"
+	if err := os.Remove(encryptedPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove leftover encrypted copy: %w", err)
+	}
+
+	if err := exportEncrypted(path, encryptedPath, key); err != nil {
+		os.Remove(encryptedPath)
+		return fmt.Errorf("failed to encrypt database: %w", err)
+	}
+
+	if err := os.Rename(encryptedPath, path); err != nil {
+		os.Remove(encryptedPath)
+		return fmt.Errorf("failed to replace plaintext database: %w", err)
+	}
+	// The checkpoint emptied them; they belong to the plaintext file
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(path + suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove plaintext %s file: %w", suffix, err)
+		}
+	}
+
+	return nil
+}
+
+// exportEncrypted copies the plaintext database at path into a new database at
+// encryptedPath, encrypted with key
+func exportEncrypted(path, encryptedPath, key string) error {
+	db, err := sql.Open(sqlite.DriverName, path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	// ATTACH only applies to the connection it runs on
+	db.SetMaxOpenConns(1)
+
+	var version string
+	if err := db.QueryRow("PRAGMA cipher_version").Scan(&version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrEncryptionUnsupported
+		}
+		return err
+	}
+
+	// Fold the WAL into the main file so the export sees every row
+	if _, err := db.Exec("PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return err
+	}
+	if _, err := db.Exec("ATTACH DATABASE ? AS encrypted KEY ?", encryptedPath, key); err != nil {
+		return err
+	}
+	if _, err := db.Exec("SELECT sqlcipher_export('encrypted')"); err != nil {
+		return err
+	}
+	_, err = db.Exec("DETACH DATABASE encrypted")
+	return err
+}
+
+// Rekey re-encrypts the database with newKey. The repository must be closed afterwards:
+// connections it opens later would still use the old key.
+func (r *Repository) Rekey(ctx context.Context, newKey string) error {
+	if !r.encrypted {
+		return fmt.Errorf("%w: set database.encryption_key first", ErrPlaintextDatabase)
+	}
+	if newKey == "" {
+		return fmt.Errorf("new encryption key is required")
+	}
+
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to access connection pool: %w", err)
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to open connection: %w", err)
+	}
+	defer conn.Close()
+
+	var journalMode string
+	if err := conn.QueryRowContext(ctx, "PRAGMA journal_mode").Scan(&journalMode); err != nil {
+		return fmt.Errorf("failed to read journal mode: %w", err)
+	}
+	// Rekeying rewrites every page; outside WAL mode none are left behind in the WAL
+	if _, err := conn.ExecContext(ctx, "PRAGMA journal_mode = DELETE"); err != nil {
+		return fmt.Errorf("failed to leave %s journal mode: %w", journalMode, err)
+	}
+	if _, err := conn.ExecContext(ctx, "PRAGMA rekey = "+quoteSQL(newKey)); err != nil {
+		return fmt.Errorf("failed to rekey database: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, "PRAGMA journal_mode = "+journalMode); err != nil {
+		return fmt.Errorf("failed to restore %s journal mode: %w", journalMode, err)
+	}
+
+	return nil
+}
+
+// quoteSQL quotes s as an SQL string literal, for PRAGMAs that don't take parameters
+func quoteSQL(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
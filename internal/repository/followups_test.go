@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// newTestRepository opens a SQLite repository in a temporary directory, closed when the
+// test ends
+func newTestRepository(t *testing.T, cfg core.DatabaseConfig) *Repository {
+	t.Helper()
+	if cfg.Path == "" {
+		cfg.Path = filepath.Join(t.TempDir(), "bot.db")
+	}
+	repo, err := NewRepository(&cfg)
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+	return repo
+}
+
+// createLegacyProfilesTable creates a profiles table the way AutoMigrate did before
+// connected_at and last_message_sent_at existed
+func createLegacyProfilesTable(t *testing.T, path string) {
+	t.Helper()
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("open legacy database: %v", err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec("CREATE TABLE `profiles` (`id` integer PRIMARY KEY AUTOINCREMENT,`linked_in_url` text NOT NULL,`name` text,`status` text NOT NULL,`created_at` datetime,`updated_at` datetime)")
+	if err != nil {
+		t.Fatalf("create legacy profiles table: %v", err)
+	}
+}
+
+func TestMarkAsConnectedThenPendingFollowups(t *testing.T) {
+	tests := []struct {
+		name   string
+		legacy bool
+	}{
+		{"new database", false},
+		{"migrated legacy database", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "bot.db")
+			if tt.legacy {
+				createLegacyProfilesTable(t, path)
+			}
+			repo := newTestRepository(t, core.DatabaseConfig{Path: path})
+			ctx := context.Background()
+
+			url := "https://www.linkedin.com/in/jane-doe/"
+			if err := repo.CreateProfile(ctx, &core.Profile{LinkedInURL: url, Name: "Jane Doe", Status: core.ProfileStatusRequestSent}); err != nil {
+				t.Fatalf("CreateProfile: %v", err)
+			}
+
+			pending, err := repo.GetPendingFollowups(ctx, 10, nil)
+			if err != nil {
+				t.Fatalf("GetPendingFollowups: %v", err)
+			}
+			if len(pending) != 0 {
+				t.Fatalf("GetPendingFollowups before connecting = %d profiles, want 0", len(pending))
+			}
+
+			if err := repo.MarkAsConnected(ctx, url); err != nil {
+				t.Fatalf("MarkAsConnected: %v", err)
+			}
+
+			pending, err = repo.GetPendingFollowups(ctx, 10, nil)
+			if err != nil {
+				t.Fatalf("GetPendingFollowups: %v", err)
+			}
+			if len(pending) != 1 || pending[0].LinkedInURL != url {
+				t.Fatalf("GetPendingFollowups = %v, want only %s", pending, url)
+			}
+			if pending[0].ConnectedAt == nil {
+				t.Errorf("ConnectedAt not set by MarkAsConnected")
+			}
+
+			message := &core.Message{ProfileID: pending[0].ID, Body: "Thanks for connecting", SequenceStep: 1}
+			if err := repo.LogMessageSent(ctx, message); err != nil {
+				t.Fatalf("LogMessageSent: %v", err)
+			}
+
+			pending, err = repo.GetPendingFollowups(ctx, 10, nil)
+			if err != nil {
+				t.Fatalf("GetPendingFollowups: %v", err)
+			}
+			if len(pending) != 0 {
+				t.Errorf("GetPendingFollowups after messaging = %d profiles, want 0", len(pending))
+			}
+
+			profile, err := repo.GetProfileByURL(ctx, url)
+			if err != nil {
+				t.Fatalf("GetProfileByURL: %v", err)
+			}
+			if profile.Status != core.ProfileStatusMessageSent || profile.LastMessageSentAt == nil {
+				t.Errorf("profile after LogMessageSent: status %s, last_message_sent_at %v", profile.Status, profile.LastMessageSentAt)
+			}
+		})
+	}
+}
+
+func TestPendingFollowupsSequence(t *testing.T) {
+	repo := newTestRepository(t, core.DatabaseConfig{})
+	ctx := context.Background()
+	window := &core.FollowUpWindow{
+		IncludeUnknownConnectedAt: true,
+		Sequence: []core.SequenceStep{
+			{Type: core.SequenceStepMessage, Day: 1},
+			{Type: core.SequenceStepEndorse, Day: 2},
+			{Type: core.SequenceStepMessage, Day: 4},
+		},
+	}
+
+	connect := func(slug string, daysAgo int) *core.Profile {
+		t.Helper()
+		url := "https://www.linkedin.com/in/" + slug + "/"
+		if err := repo.CreateProfile(ctx, &core.Profile{LinkedInURL: url, Status: core.ProfileStatusRequestSent}); err != nil {
+			t.Fatalf("CreateProfile: %v", err)
+		}
+		if err := repo.MarkAsConnectedAt(ctx, url, time.Now().AddDate(0, 0, -daysAgo)); err != nil {
+			t.Fatalf("MarkAsConnectedAt: %v", err)
+		}
+		profile, err := repo.GetProfileByURL(ctx, url)
+		if err != nil {
+			t.Fatalf("GetProfileByURL: %v", err)
+		}
+		return profile
+	}
+	sendStep := func(profile *core.Profile, step int) {
+		t.Helper()
+		if err := repo.LogMessageSent(ctx, &core.Message{ProfileID: profile.ID, Body: "Hi", SequenceStep: step}); err != nil {
+			t.Fatalf("LogMessageSent: %v", err)
+		}
+	}
+	assertPending := func(when string, want ...*core.Profile) {
+		t.Helper()
+		pending, err := repo.GetPendingFollowups(ctx, 10, window)
+		if err != nil {
+			t.Fatalf("GetPendingFollowups: %v", err)
+		}
+		var got, wantURLs []string
+		for _, profile := range pending {
+			got = append(got, profile.LinkedInURL)
+		}
+		for _, profile := range want {
+			wantURLs = append(wantURLs, profile.LinkedInURL)
+		}
+		if !reflect.DeepEqual(got, wantURLs) {
+			t.Fatalf("GetPendingFollowups %s = %v, want %v", when, got, wantURLs)
+		}
+	}
+
+	// Newest connections first
+	connect("today", 0) // No step due yet
+	week := connect("week-ago", 7)
+	middle := connect("three-days-ago", 3)
+	assertPending("after connecting", middle, week)
+
+	sendStep(week, 1)
+	sendStep(middle, 1)
+	// Both are due the endorsement; after it only week-ago is due message step 2
+	assertPending("after step 1", middle, week)
+	for _, profile := range []*core.Profile{week, middle} {
+		if err := repo.CreateEndorsement(ctx, &core.Endorsement{ProfileID: profile.ID}); err != nil {
+			t.Fatalf("CreateEndorsement: %v", err)
+		}
+	}
+	assertPending("after the endorsements", week)
+
+	sendStep(week, 2)
+	assertPending("after the last step")
+
+	// A reply ends the sequence
+	if err := repo.UpdateProfileStatus(ctx, middle.LinkedInURL, core.ProfileStatusReplied); err != nil {
+		t.Fatalf("UpdateProfileStatus: %v", err)
+	}
+	if err := repo.GetDB().Model(&core.Profile{}).Where("id = ?", middle.ID).
+		Update("connected_at", time.Now().AddDate(0, 0, -7)).Error; err != nil {
+		t.Fatalf("backdate connected_at: %v", err)
+	}
+	assertPending("after a reply")
+
+	// Profiles messaged before the Message table count as having sent step 1
+	legacy := connect("legacy", 7)
+	if err := repo.GetDB().Model(&core.Profile{}).Where("id = ?", legacy.ID).Updates(map[string]interface{}{
+		"status":               core.ProfileStatusMessageSent,
+		"last_message_sent_at": time.Now().AddDate(0, 0, -6),
+	}).Error; err != nil {
+		t.Fatalf("mark legacy profile messaged: %v", err)
+	}
+	if err := repo.CreateEndorsement(ctx, &core.Endorsement{ProfileID: legacy.ID}); err != nil {
+		t.Fatalf("CreateEndorsement: %v", err)
+	}
+	assertPending("with a legacy messaged profile", legacy)
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+
+	"linkedin-automation/internal/core"
+)
+
+// historyMigrationBatch is the number of history rows converted per query
+const historyMigrationBatch = 500
+
+// MigrateHistoryDetails converts the free-text details of history entries written before
+// details were JSON, across accounts and including soft-deleted rows. Details that can't
+// be read are kept in the note field. It returns the number of rows converted and can be
+// run again safely.
+func (r *Repository) MigrateHistoryDetails(ctx context.Context) (int, error) {
+	converted := 0
+	var lastID uint
+	for {
+		var rows []struct {
+			ID         uint
+			ActionType string
+			Details    string
+		}
+		if err := r.db.WithContext(ctx).Raw(`
+			SELECT id, action_type, details FROM histories
+			WHERE id > ? AND details IS NOT NULL AND details <> '' AND details NOT LIKE '{%'
+			ORDER BY id
+			LIMIT ?`,
+			lastID, historyMigrationBatch,
+		).Scan(&rows).Error; err != nil {
+			return converted, err
+		}
+		if len(rows) == 0 {
+			return converted, nil
+		}
+
+		tx := r.db.WithContext(ctx).Begin()
+		for _, row := range rows {
+			lastID = row.ID
+			if !core.IsLegacyHistoryDetails(row.Details) {
+				continue
+			}
+			details := core.NewHistory(row.ActionType, core.ParseLegacyHistoryDetails(row.ActionType, row.Details)).Details
+			if err := tx.Exec("UPDATE histories SET details = ? WHERE id = ?", details, row.ID).Error; err != nil {
+				tx.Rollback()
+				return converted, err
+			}
+			converted++
+		}
+		if err := tx.Commit().Error; err != nil {
+			return converted, err
+		}
+	}
+}
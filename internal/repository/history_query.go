@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"linkedin-automation/internal/core"
+
+	"gorm.io/gorm"
+)
+
+// maxHistoryPageSize caps HistoryQuery.Pagination.Limit
+const maxHistoryPageSize = 1000
+
+var historyOrderClauses = map[core.HistoryOrder]string{
+	core.HistoryOrderNewest:     "timestamp DESC, id DESC",
+	core.HistoryOrderOldest:     "timestamp ASC, id ASC",
+	core.HistoryOrderActionType: "action_type ASC, timestamp DESC, id DESC",
+}
+
+// QueryHistory returns one page of the history entries matching q, and how many match.
+// On SQLite the query is pinned to the (action_type, timestamp) index when filtering by
+// action type, or the timestamp index when filtering by time, as the planner otherwise
+// tends to scan the table for the JSON details conditions.
+func (r *Repository) QueryHistory(ctx context.Context, q core.HistoryQuery) ([]*core.History, int64, error) {
+	if q.OrderBy == "" {
+		q.OrderBy = core.HistoryOrderNewest // Default fallback
+	}
+	order, ok := historyOrderClauses[q.OrderBy]
+	if !ok {
+		return nil, 0, fmt.Errorf("%w: %q", core.ErrInvalidHistoryOrder, q.OrderBy)
+	}
+
+	limit := q.Pagination.Limit
+	if limit <= 0 {
+		limit = 100 // Default fallback
+	}
+	if limit > maxHistoryPageSize {
+		limit = maxHistoryPageSize
+	}
+	offset := q.Pagination.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	var total int64
+	if err := r.historyQuery(ctx, q).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var histories []*core.History
+	result := r.historyQuery(ctx, q).
+		Order(order).
+		Offset(offset).
+		Limit(limit).
+		Find(&histories)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	return histories, total, nil
+}
+
+// historyQuery returns a histories query restricted to q, with an index hint on SQLite
+func (r *Repository) historyQuery(ctx context.Context, q core.HistoryQuery) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&core.History{})
+	if r.db.Dialector.Name() != DriverPostgres {
+		if hint := historyIndexHint(q); hint != "" {
+			query = query.Table("histories INDEXED BY " + hint)
+		}
+	}
+	return query.Scopes(r.historyScopes(q)...)
+}
+
+// historyIndexHint names the index that serves q best, or "" to leave it to the planner
+func historyIndexHint(q core.HistoryQuery) string {
+	switch {
+	case len(q.ActionTypes) > 0:
+		return "idx_histories_action_timestamp"
+	case q.Start != nil || q.End != nil:
+		return "idx_histories_timestamp"
+	default:
+		return ""
+	}
+}
+
+// historyScopes builds one scope per set field of q
+func (r *Repository) historyScopes(q core.HistoryQuery) []func(*gorm.DB) *gorm.DB {
+	var scopes []func(*gorm.DB) *gorm.DB
+	where := func(condition string, args ...interface{}) {
+		scopes = append(scopes, func(tx *gorm.DB) *gorm.DB {
+			return tx.Where(condition, args...)
+		})
+	}
+
+	if len(q.ActionTypes) > 0 {
+		where("action_type IN ?", q.ActionTypes)
+	}
+	if q.Start != nil {
+		where(r.epochSeconds("timestamp")+" >= ?", q.Start.Unix())
+	}
+	if q.End != nil {
+		where(r.epochSeconds("timestamp")+" < ?", q.End.Unix())
+	}
+	if q.ProfileURL != "" {
+		where(r.detailsField("details", "profile_url")+" = ?", q.ProfileURL)
+	}
+	if q.Outcome != "" {
+		where(r.detailsField("details", "outcome")+" = ?", q.Outcome)
+	}
+	found := "CAST(" + r.detailsField("details", "found") + " AS INTEGER)"
+	if q.MinCount > 0 {
+		where(found+" >= ?", q.MinCount)
+	}
+	if q.MaxCount > 0 {
+		where(found+" <= ?", q.MaxCount)
+	}
+
+	return scopes
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"gorm.io/gorm"
+)
+
+// CreateJob queues job
+func (r *Repository) CreateJob(ctx context.Context, job *core.Job) error {
+	job.Status = core.JobStatusQueued
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+// GetJob returns the job with id, or nil if there is none
+func (r *Repository) GetJob(ctx context.Context, id uint) (*core.Job, error) {
+	var job core.Job
+	result := r.db.WithContext(ctx).First(&job, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &job, nil
+}
+
+// ClaimNextJob marks the oldest queued job running and returns it, or nil when the queue
+// is empty
+func (r *Repository) ClaimNextJob(ctx context.Context) (*core.Job, error) {
+	var claimed *core.Job
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var job core.Job
+		result := tx.Where("status = ?", core.JobStatusQueued).Order("id ASC").First(&job)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		now := time.Now()
+		updated := tx.Model(&core.Job{}).
+			Where("id = ? AND status = ?", job.ID, core.JobStatusQueued).
+			Updates(map[string]interface{}{
+				"status":     core.JobStatusRunning,
+				"started_at": now,
+				"attempts":   gorm.Expr("attempts + 1"),
+			})
+		if updated.Error != nil {
+			return updated.Error
+		}
+		if updated.RowsAffected == 0 {
+			return nil
+		}
+
+		job.Status = core.JobStatusRunning
+		job.StartedAt = &now
+		job.Attempts++
+		claimed = &job
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
+// FinishJob records a job's final status, the run it made (if any) and, for failures,
+// the error
+func (r *Repository) FinishJob(ctx context.Context, id uint, status core.JobStatus, runID, jobErr string) error {
+	return r.db.WithContext(ctx).Model(&core.Job{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       status,
+		"run_id":       runID,
+		"error":        jobErr,
+		"completed_at": time.Now(),
+	}).Error
+}
+
+// RequeueRunningJobs puts jobs left running by a stopped 'bot serve' back in the queue,
+// and returns how many there were
+func (r *Repository) RequeueRunningJobs(ctx context.Context) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&core.Job{}).
+		Where("status = ?", core.JobStatusRunning).
+		Update("status", core.JobStatusQueued)
+	return result.RowsAffected, result.Error
+}
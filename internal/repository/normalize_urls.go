@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+
+	"linkedin-automation/internal/core"
+
+	"gorm.io/gorm"
+)
+
+// normalizeStoredURLs rewrites profile URLs stored before URLs were normalized at the
+// repository boundary, so lookups with core.NormalizeProfileURL find them. Profile rows
+// that turn out to be the same person are merged.
+func (r *Repository) normalizeStoredURLs(ctx context.Context) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := mergeProfileURLs(tx); err != nil {
+			return err
+		}
+		if err := normalizeURLColumn(tx, &core.DeclinedInvite{}, "profile_url", true); err != nil {
+			return err
+		}
+		if err := normalizeURLColumn(tx, &core.PostComment{}, "author_url", false); err != nil {
+			return err
+		}
+		return normalizeURLColumn(tx, &core.Notification{}, "actor_url", false)
+	})
+}
+
+// mergeProfileURLs normalizes profiles.linked_in_url. Of the rows sharing a normalized
+// URL, the one with the most advanced status (the oldest on a tie) is kept; the others'
+// messages, endorsements and group memberships move to it before they are deleted.
+func mergeProfileURLs(tx *gorm.DB) error {
+	var profiles []*core.Profile
+	if err := tx.Select("id", "linked_in_url", "status").Order("id").Find(&profiles).Error; err != nil {
+		return err
+	}
+
+	groups := make(map[string][]*core.Profile)
+	for _, profile := range profiles {
+		normalized := core.NormalizeProfileURL(profile.LinkedInURL)
+		groups[normalized] = append(groups[normalized], profile)
+	}
+
+	for normalized, group := range groups {
+		if len(group) == 1 && group[0].LinkedInURL == normalized {
+			continue
+		}
+
+		keeper := group[0]
+		for _, profile := range group[1:] {
+			if core.StatusRank(profile.Status) > core.StatusRank(keeper.Status) {
+				keeper = profile
+			}
+		}
+
+		for _, profile := range group {
+			if profile.ID == keeper.ID {
+				continue
+			}
+			if err := moveProfileRows(tx, profile.ID, keeper.ID); err != nil {
+				return err
+			}
+			if err := tx.Unscoped().Delete(&core.Profile{}, profile.ID).Error; err != nil {
+				return err
+			}
+		}
+
+		if keeper.LinkedInURL != normalized {
+			if err := tx.Model(&core.Profile{}).Where("id = ?", keeper.ID).Update("linked_in_url", normalized).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// moveProfileRows reassigns rows referencing a duplicate profile to the kept one,
+// dropping those the kept profile already has
+func moveProfileRows(tx *gorm.DB, fromID, toID uint) error {
+	if err := tx.Model(&core.Message{}).Where("profile_id = ?", fromID).Update("profile_id", toID).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("profile_id = ? AND EXISTS (SELECT 1 FROM endorsements kept WHERE kept.profile_id = ?)", fromID, toID).
+		Delete(&core.Endorsement{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&core.Endorsement{}).Where("profile_id = ?", fromID).Update("profile_id", toID).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("profile_id = ? AND group_url IN (SELECT kept.group_url FROM profile_groups kept WHERE kept.profile_id = ?)", fromID, toID).
+		Delete(&core.ProfileGroup{}).Error; err != nil {
+		return err
+	}
+	return tx.Model(&core.ProfileGroup{}).Where("profile_id = ?", fromID).Update("profile_id", toID).Error
+}
+
+// normalizeURLColumn rewrites a profile URL column to its normalized form. For a unique
+// column, rows whose normalized URL is already stored are deleted instead.
+func normalizeURLColumn(tx *gorm.DB, model interface{}, column string, unique bool) error {
+	var values []string
+	if err := tx.Model(model).Distinct(column).Pluck(column, &values).Error; err != nil {
+		return err
+	}
+
+	for _, value := range values {
+		normalized := core.NormalizeProfileURL(value)
+		if normalized == value {
+			continue
+		}
+
+		if unique {
+			var count int64
+			if err := tx.Model(model).Where(column+" = ?", normalized).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				if err := tx.Where(column+" = ?", value).Delete(model).Error; err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		if err := tx.Model(model).Where(column+" = ?", value).Update(column, normalized).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
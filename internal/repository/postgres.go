@@ -0,0 +1,18 @@
+//go:build postgres
+
+package repository
+
+import (
+	"linkedin-automation/internal/core"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Build with -tags postgres (after `go get gorm.io/driver/postgres`) to enable
+// database.driver: postgres
+func init() {
+	dialectors[DriverPostgres] = func(cfg *core.DatabaseConfig) gorm.Dialector {
+		return postgres.Open(cfg.DSN)
+	}
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+
+	"linkedin-automation/internal/core"
+
+	"gorm.io/gorm"
+)
+
+// backfillProfileSources gives profiles stored before sources were recorded the source
+// "unknown", across accounts
+func backfillProfileSources(db *gorm.DB) error {
+	return db.Exec("UPDATE profiles SET source = ? WHERE source IS NULL OR source = ''", core.ProfileSourceUnknown).Error
+}
+
+// GetAcceptanceRateBySource returns, per profile source, how many profiles it stored, the
+// connection requests sent to them and how many of those were accepted, most profiles first
+func (r *Repository) GetAcceptanceRateBySource(ctx context.Context) ([]*core.SourceStats, error) {
+	var stats []*core.SourceStats
+	result := r.db.WithContext(ctx).
+		Model(&core.Profile{}).
+		Select(`source,
+			COUNT(*) AS profiles,
+			COALESCE(SUM(CASE WHEN status IN (?) THEN 1 ELSE 0 END), 0) AS requests_sent,
+			COALESCE(SUM(CASE WHEN status IN (?) THEN 1 ELSE 0 END), 0) AS accepted`,
+			requestedStatuses, acceptedStatuses,
+		).
+		Group("source").
+		Order("profiles DESC, source").
+		Scan(&stats)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, s := range stats {
+		if s.RequestsSent > 0 {
+			s.AcceptanceRate = float64(s.Accepted) / float64(s.RequestsSent)
+		}
+	}
+
+	return stats, nil
+}
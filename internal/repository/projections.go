@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ProjectionUpdater keeps the analytics_daily table (core.DailyProjection) in step with
+// history, so daily stats read one row per day and action instead of scanning history
+type ProjectionUpdater struct {
+	db *gorm.DB
+}
+
+// NewProjectionUpdater creates a projection updater over the given database
+func NewProjectionUpdater(db *gorm.DB) *ProjectionUpdater {
+	return &ProjectionUpdater{db: db}
+}
+
+// Apply adds a history entry to its day's projection row, creating the row if needed.
+// It runs on tx so the entry and its projection are written together.
+func (p *ProjectionUpdater) Apply(tx *gorm.DB, history *core.History) error {
+	row := projectionFor(history)
+	return tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "account"}, {Name: "date"}, {Name: "action_type"}, {Name: "keyword"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"count":         gorm.Expr("analytics_daily.count + ?", row.Count),
+			"success_count": gorm.Expr("analytics_daily.success_count + ?", row.SuccessCount),
+		}),
+	}).Create(row).Error
+}
+
+// Rebuild replaces all projection rows with ones computed from the full history. It
+// returns the number of rows written.
+func (p *ProjectionUpdater) Rebuild(ctx context.Context) (int, error) {
+	written := 0
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&core.DailyProjection{}).Error; err != nil {
+			return err
+		}
+
+		type projectionKey struct {
+			date       time.Time
+			actionType string
+			keyword    string
+		}
+		rows := make(map[projectionKey]*core.DailyProjection)
+		order := make([]projectionKey, 0)
+
+		var batch []*core.History
+		result := tx.Order("id").FindInBatches(&batch, 1000, func(_ *gorm.DB, _ int) error {
+			for _, history := range batch {
+				row := projectionFor(history)
+				key := projectionKey{row.Date, row.ActionType, row.Keyword}
+				if existing, ok := rows[key]; ok {
+					existing.Count += row.Count
+					existing.SuccessCount += row.SuccessCount
+					continue
+				}
+				rows[key] = row
+				order = append(order, key)
+			}
+			return nil
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		projections := make([]*core.DailyProjection, 0, len(order))
+		for _, key := range order {
+			projections = append(projections, rows[key])
+		}
+		if len(projections) > 0 {
+			if err := tx.CreateInBatches(projections, 200).Error; err != nil {
+				return err
+			}
+		}
+		written = len(projections)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return written, nil
+}
+
+// rebuildIfEmpty fills the projection table from history when it has no rows yet,
+// e.g. right after it was added to an existing database
+func (p *ProjectionUpdater) rebuildIfEmpty(ctx context.Context) error {
+	var projections, histories int64
+	if err := p.db.WithContext(ctx).Model(&core.DailyProjection{}).Count(&projections).Error; err != nil {
+		return err
+	}
+	if projections > 0 {
+		return nil
+	}
+	if err := p.db.WithContext(ctx).Model(&core.History{}).Count(&histories).Error; err != nil {
+		return err
+	}
+	if histories == 0 {
+		return nil
+	}
+
+	_, err := p.Rebuild(ctx)
+	return err
+}
+
+// projectionFor returns the projection row counting a single history entry. Errors count
+// toward the action in their error class (e.g. "connect") without counting as a success,
+// like in GetActionStats.
+func projectionFor(history *core.History) *core.DailyProjection {
+	ts := history.Timestamp.UTC()
+	row := &core.DailyProjection{
+		Date:         time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, time.UTC),
+		ActionType:   history.ActionType,
+		Keyword:      history.Keyword,
+		Count:        1,
+		SuccessCount: 1,
+	}
+
+	if history.ActionType == "Error" {
+		if action := history.ParsedDetails().ErrorClass; action != "" && action != "unknown" {
+			row.ActionType = strings.ToUpper(action[:1]) + action[1:]
+			row.SuccessCount = 0
+		}
+	}
+
+	return row
+}
+
+// GetDailyProjection returns the projection rows for the UTC days from start to end
+// (inclusive), ordered by day, action type and keyword
+func (r *Repository) GetDailyProjection(ctx context.Context, start, end time.Time) ([]*core.DailyProjection, error) {
+	startDay := start.UTC().Truncate(24 * time.Hour)
+	endDay := end.UTC().Truncate(24 * time.Hour)
+
+	var rows []*core.DailyProjection
+	result := r.db.WithContext(ctx).
+		Where("date >= ? AND date <= ?", startDay, endDay).
+		Order("date ASC, action_type ASC, keyword ASC").
+		Find(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return rows, nil
+}
+
+// RebuildProjections recomputes the analytics_daily table from the full history
+func (r *Repository) RebuildProjections(ctx context.Context) (int, error) {
+	return r.projections.Rebuild(ctx)
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"gorm.io/gorm"
+)
+
+// purgeableStatuses are the statuses of profiles that never got as far as a connection
+// request, the only profiles the retention purge removes
+var purgeableStatuses = []core.ProfileStatus{core.ProfileStatusDiscovered, core.ProfileStatusIgnored}
+
+// purgeBatchSize bounds the profile IDs deleted per statement
+const purgeBatchSize = 500
+
+// PurgeExpired applies the retention policy as of now. Expired profiles and history are
+// soft-deleted, and rows soft-deleted more than retention.GraceDays ago are deleted for
+// good together with the messages, endorsements and group links of deleted profiles.
+// Profiles with a connection are never purged. Each category runs in its own transaction.
+func (r *Repository) PurgeExpired(ctx context.Context, retention *core.RetentionConfig, now time.Time) (*core.PurgeResult, error) {
+	result := &core.PurgeResult{}
+	graceCutoff := now.AddDate(0, 0, -retention.GraceDays)
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if retention.ProfileDays > 0 {
+			soft := tx.Where("status IN ? AND connected_at IS NULL AND updated_at < ?",
+				purgeableStatuses, now.AddDate(0, 0, -retention.ProfileDays)).
+				Delete(&core.Profile{})
+			if soft.Error != nil {
+				return soft.Error
+			}
+			result.Profiles.SoftDeleted = soft.RowsAffected
+		}
+
+		var ids []uint
+		if err := tx.Unscoped().Model(&core.Profile{}).
+			Where("deleted_at IS NOT NULL AND deleted_at <= ? AND connected_at IS NULL AND archived_at IS NULL", graceCutoff).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		messages, profiles, err := hardDeleteProfiles(tx, ids)
+		if err != nil {
+			return err
+		}
+		result.Messages.Deleted = messages
+		result.Profiles.Deleted = profiles
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge profiles: %w", err)
+	}
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if retention.HistoryDays > 0 {
+			soft := tx.Where(r.epochSeconds("timestamp")+" < ?", now.AddDate(0, 0, -retention.HistoryDays).Unix()).Delete(&core.History{})
+			if soft.Error != nil {
+				return soft.Error
+			}
+			result.History.SoftDeleted = soft.RowsAffected
+		}
+
+		hard := tx.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at <= ?", graceCutoff).Delete(&core.History{})
+		if hard.Error != nil {
+			return hard.Error
+		}
+		result.History.Deleted = hard.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge history: %w", err)
+	}
+
+	return result, nil
+}
+
+// hardDeleteProfiles removes the given profiles and every row referencing them, returning
+// the number of referencing rows and of profiles deleted
+func hardDeleteProfiles(tx *gorm.DB, ids []uint) (int64, int64, error) {
+	var children, profiles int64
+	for start := 0; start < len(ids); start += purgeBatchSize {
+		end := start + purgeBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		batch := ids[start:end]
+
+		for _, model := range []interface{}{&core.Message{}, &core.Endorsement{}, &core.ProfileGroup{}} {
+			deleted := tx.Where("profile_id IN ?", batch).Delete(model)
+			if deleted.Error != nil {
+				return 0, 0, deleted.Error
+			}
+			children += deleted.RowsAffected
+		}
+
+		deleted := tx.Unscoped().Delete(&core.Profile{}, batch)
+		if deleted.Error != nil {
+			return 0, 0, deleted.Error
+		}
+		profiles += deleted.RowsAffected
+	}
+
+	return children, profiles, nil
+}
+
+// clearPurgedProfiles hard-deletes soft-deleted profiles with the given URLs so the URLs
+// can be stored again, e.g. when a purged profile turns up in a new search. Archived
+// profiles are kept.
+func clearPurgedProfiles(tx *gorm.DB, linkedinURLs ...string) error {
+	var ids []uint
+	if err := tx.Unscoped().Model(&core.Profile{}).
+		Where("linked_in_url IN ? AND deleted_at IS NOT NULL AND archived_at IS NULL", linkedinURLs).
+		Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+	_, _, err := hardDeleteProfiles(tx, ids)
+	return err
+}
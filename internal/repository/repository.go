@@ -0,0 +1,1786 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+// Supported values of database.upsert_status
+const (
+	UpsertStatusTransition = "transition" // Apply the new status only when IsValidTransition allows it
+	UpsertStatusKeep       = "keep"       // Never change the status of an existing profile
+	UpsertStatusForce      = "force"      // Always overwrite the status, downgrades included
+)
+
+// Repository implements RepositoryPort via GORM on any registered database driver
+type Repository struct {
+	db           *gorm.DB
+	account      string         // database.account; profiles, history and messages are scoped to it
+	encrypted    bool           // SQLite opened with database.encryption_key
+	location     *time.Location // database.timezone; today starts at midnight here
+	upsertStatus string
+	regexps      *regexpCache // Compiled blacklist patterns
+	projections  *ProjectionUpdater
+}
+
+// NewRepository opens the database selected by database.driver (sqlite by default)
+// and migrates the schema
+func NewRepository(cfg *core.DatabaseConfig) (*Repository, error) {
+	driver := cfg.Driver
+	if driver == "" {
+		driver = DriverSQLite // Default fallback
+	}
+
+	open, ok := dialectors[driver]
+	if !ok && driver == DriverPostgres {
+		return nil, fmt.Errorf("database driver %q is not included in this build: run `go get gorm.io/driver/postgres` and build with -tags postgres", driver)
+	}
+	if !ok {
+		return nil, fmt.Errorf("unsupported database driver %q (available: %s)", driver, strings.Join(availableDrivers(), ", "))
+	}
+
+	// Configure GORM logger (silent in production, can be verbose for debugging)
+	config := &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	}
+
+	encrypted := driver == DriverSQLite && cfg.EncryptionKey != ""
+	if encrypted {
+		if err := prepareEncryptedSQLite(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	db, err := gorm.Open(open(cfg), config)
+	if err != nil {
+		return nil, err
+	}
+
+	account := cfg.Account
+	if account == "" {
+		account = core.DefaultAccount // Default fallback
+	}
+	if err := registerAccountScope(db, account); err != nil {
+		return nil, fmt.Errorf("failed to scope database to account: %w", err)
+	}
+	if err := registerArchivedScope(db); err != nil {
+		return nil, fmt.Errorf("failed to register archived profile scope: %w", err)
+	}
+
+	if driver == DriverSQLite {
+		err = configureSQLitePool(db, cfg)
+	} else {
+		err = configurePool(db, cfg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	upsertStatus := cfg.UpsertStatus
+	if upsertStatus == "" {
+		upsertStatus = UpsertStatusTransition // Default fallback
+	}
+
+	location, err := cfg.Location()
+	if err != nil {
+		return nil, fmt.Errorf("invalid database.timezone: %w", err)
+	}
+
+	repo := &Repository{
+		db:           db,
+		account:      account,
+		encrypted:    encrypted,
+		location:     location,
+		upsertStatus: upsertStatus,
+		regexps:      newRegexpCache(),
+		projections:  NewProjectionUpdater(db),
+	}
+
+	// Auto-migrate schema
+	if err := repo.Migrate(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// Migrate runs database migrations
+func (r *Repository) Migrate(ctx context.Context) error {
+	err := r.db.WithContext(ctx).AutoMigrate(
+		&core.Profile{},
+		&core.History{},
+		&core.ProfileGroup{},
+		&core.Message{},
+		&core.PostComment{},
+		&core.Endorsement{},
+		&core.FollowedCompany{},
+		&core.PageLoad{},
+		&core.DeclinedInvite{},
+		&core.Notification{},
+		&core.Group{},
+		&core.Campaign{},
+		&core.RunMetadata{},
+		&core.BlacklistEntry{},
+		&core.DailyProjection{},
+		&core.SearchQuery{},
+		&core.CompanyResearch{},
+		&core.Job{},
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := migrateAccounts(r.db.WithContext(ctx)); err != nil {
+		return fmt.Errorf("failed to migrate accounts: %w", err)
+	}
+	if err := backfillProfileSources(r.db.WithContext(ctx)); err != nil {
+		return fmt.Errorf("failed to backfill profile sources: %w", err)
+	}
+	if err := r.normalizeStoredURLs(ctx); err != nil {
+		return fmt.Errorf("failed to normalize stored profile URLs: %w", err)
+	}
+	if err := r.projections.rebuildIfEmpty(ctx); err != nil {
+		return fmt.Errorf("failed to build analytics projections: %w", err)
+	}
+	return nil
+}
+
+// CreateProfile creates a new profile record. It fails with core.ErrProfileArchived when
+// an archived profile has the URL.
+func (r *Repository) CreateProfile(ctx context.Context, profile *core.Profile) error {
+	profile.LinkedInURL = core.NormalizeProfileURL(profile.LinkedInURL)
+	if profile.CreatedAt.IsZero() {
+		profile.CreatedAt = time.Now()
+	}
+	if profile.UpdatedAt.IsZero() {
+		profile.UpdatedAt = time.Now()
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := clearPurgedProfiles(tx, profile.LinkedInURL); err != nil {
+			return err
+		}
+		if archived, err := isArchived(tx, profile.LinkedInURL); err != nil {
+			return err
+		} else if archived {
+			return fmt.Errorf("%w: %s", core.ErrProfileArchived, profile.LinkedInURL)
+		}
+		return tx.Create(profile).Error
+	})
+}
+
+// BulkCreateProfiles inserts the profiles that are not stored yet in one transaction,
+// in batches of 100. Profiles whose URL is already stored (archived included), or
+// repeated within profiles, are skipped and left with ID 0; created profiles get their
+// ID set.
+func (r *Repository) BulkCreateProfiles(ctx context.Context, profiles []*core.Profile) (int, int, error) {
+	if len(profiles) == 0 {
+		return 0, 0, nil
+	}
+
+	now := time.Now()
+	urls := make([]string, 0, len(profiles))
+	for _, profile := range profiles {
+		profile.LinkedInURL = core.NormalizeProfileURL(profile.LinkedInURL)
+		if profile.CreatedAt.IsZero() {
+			profile.CreatedAt = now
+		}
+		if profile.UpdatedAt.IsZero() {
+			profile.UpdatedAt = now
+		}
+		urls = append(urls, profile.LinkedInURL)
+	}
+
+	var created int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := clearPurgedProfiles(tx, urls...); err != nil {
+			return err
+		}
+
+		var stored []string
+		if err := tx.Unscoped().Model(&core.Profile{}).
+			Where("linked_in_url IN ? AND (deleted_at IS NULL OR archived_at IS NOT NULL)", urls).
+			Pluck("linked_in_url", &stored).Error; err != nil {
+			return err
+		}
+		seen := make(map[string]bool, len(profiles))
+		for _, url := range stored {
+			seen[url] = true
+		}
+
+		fresh := make([]*core.Profile, 0, len(profiles))
+		for _, profile := range profiles {
+			if seen[profile.LinkedInURL] {
+				continue
+			}
+			seen[profile.LinkedInURL] = true
+			fresh = append(fresh, profile)
+		}
+		if len(fresh) == 0 {
+			return nil
+		}
+
+		// Stored rows were filtered out above; the conflict clause only covers a
+		// concurrent writer on a server database
+		insert := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "account"}, {Name: "linked_in_url"}},
+			DoNothing: true,
+		}).CreateInBatches(fresh, 100)
+		if insert.Error != nil {
+			return insert.Error
+		}
+		created = insert.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return int(created), len(profiles) - int(created), nil
+}
+
+// UpsertProfile creates the profile, or updates the stored profile with the same URL.
+// Inserting relies on the unique index on linkedin_url, so concurrent upserts of one
+// URL never fail. For an existing profile updated_at is always bumped and the status
+// follows database.upsert_status; a status change to Connected also stores
+// profile.ConnectedAt. Other fields of an existing profile are left alone, and archived
+// profiles are not changed at all. On success profile holds the stored row.
+func (r *Repository) UpsertProfile(ctx context.Context, profile *core.Profile) (*core.UpsertResult, error) {
+	profile.LinkedInURL = core.NormalizeProfileURL(profile.LinkedInURL)
+	now := time.Now()
+	if profile.CreatedAt.IsZero() {
+		profile.CreatedAt = now
+	}
+	profile.UpdatedAt = now
+
+	result := &core.UpsertResult{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := clearPurgedProfiles(tx, profile.LinkedInURL); err != nil {
+			return err
+		}
+		insert := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "account"}, {Name: "linked_in_url"}},
+			DoNothing: true,
+		}).Create(profile)
+		if insert.Error != nil {
+			return insert.Error
+		}
+		if insert.RowsAffected > 0 {
+			result.Created = true
+			return nil
+		}
+
+		var existing core.Profile
+		if err := tx.Unscoped().Where("linked_in_url = ?", profile.LinkedInURL).First(&existing).Error; err != nil {
+			return err
+		}
+		if existing.ArchivedAt != nil {
+			result.PreviousStatus = existing.Status
+			result.Archived = true
+			*profile = existing
+			return nil
+		}
+		result.PreviousStatus = existing.Status
+
+		updates := map[string]interface{}{"updated_at": now}
+		if r.shouldUpsertStatus(existing.Status, profile.Status) {
+			updates["status"] = profile.Status
+			if profile.Status == core.ProfileStatusConnected && profile.ConnectedAt != nil {
+				updates["connected_at"] = profile.ConnectedAt
+			}
+			result.StatusChanged = true
+		}
+
+		if err := tx.Model(&existing).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		var stored core.Profile
+		if err := tx.First(&stored, existing.ID).Error; err != nil {
+			return err
+		}
+		*profile = stored
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// shouldUpsertStatus reports whether an upsert moves a stored profile to a new status
+func (r *Repository) shouldUpsertStatus(from, to core.ProfileStatus) bool {
+	if to == "" || from == to {
+		return false
+	}
+	switch r.upsertStatus {
+	case UpsertStatusKeep:
+		return false
+	case UpsertStatusForce:
+		return true
+	default:
+		return core.IsValidTransition(from, to)
+	}
+}
+
+// GetProfileByURL retrieves a profile by LinkedIn URL
+func (r *Repository) GetProfileByURL(ctx context.Context, url string) (*core.Profile, error) {
+	url = core.NormalizeProfileURL(url)
+	var profile core.Profile
+	result := r.db.WithContext(ctx).Where("linked_in_url = ?", url).First(&profile)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil // Profile not found, not an error
+		}
+		return nil, result.Error
+	}
+
+	return &profile, nil
+}
+
+// UpdateProfileStatus updates the status of a profile. Moves the state machine does not
+// allow (see core.IsValidTransition) are rejected with core.ErrInvalidStatusTransition.
+func (r *Repository) UpdateProfileStatus(ctx context.Context, url string, status core.ProfileStatus) error {
+	url = core.NormalizeProfileURL(url)
+	if err := r.checkTransition(ctx, url, status); err != nil {
+		return err
+	}
+	return r.ForceProfileStatus(ctx, url, status)
+}
+
+// ForceProfileStatus sets a profile's status without checking the transition, for
+// manual repairs
+func (r *Repository) ForceProfileStatus(ctx context.Context, url string, status core.ProfileStatus) error {
+	url = core.NormalizeProfileURL(url)
+	profile := &core.Profile{
+		UpdatedAt: time.Now(),
+		Status:    status,
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&core.Profile{}).
+		Where("linked_in_url = ?", url).
+		Updates(profile)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+// checkTransition returns an error if the profile may not move to status. Unknown
+// profiles pass, since updating them changes nothing.
+func (r *Repository) checkTransition(ctx context.Context, url string, status core.ProfileStatus) error {
+	var profile core.Profile
+	result := r.db.WithContext(ctx).Select("status").Where("linked_in_url = ?", url).First(&profile)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil
+		}
+		return result.Error
+	}
+
+	if !core.IsValidTransition(profile.Status, status) {
+		return fmt.Errorf("%w: %s -> %s (%s)", core.ErrInvalidStatusTransition, profile.Status, status, url)
+	}
+	return nil
+}
+
+// UpdateProfileLastActive stores the profile's last activity time
+func (r *Repository) UpdateProfileLastActive(ctx context.Context, url string, lastActive *time.Time) error {
+	url = core.NormalizeProfileURL(url)
+	result := r.db.WithContext(ctx).
+		Model(&core.Profile{}).
+		Where("linked_in_url = ?", url).
+		Updates(map[string]interface{}{
+			"last_active_at": lastActive,
+			"updated_at":     time.Now(),
+		})
+
+	return result.Error
+}
+
+// UpdateProfileOpenToWork stores whether the profile is marked "Open to Work"
+func (r *Repository) UpdateProfileOpenToWork(ctx context.Context, url string, openToWork bool) error {
+	url = core.NormalizeProfileURL(url)
+	result := r.db.WithContext(ctx).
+		Model(&core.Profile{}).
+		Where("linked_in_url = ?", url).
+		Updates(map[string]interface{}{
+			"is_open_to_work":         openToWork,
+			"open_to_work_checked_at": time.Now(),
+			"updated_at":              time.Now(),
+		})
+
+	return result.Error
+}
+
+// UpdateProfileFollowerCount stores a profile's follower count
+func (r *Repository) UpdateProfileFollowerCount(ctx context.Context, url string, followerCount int64) error {
+	url = core.NormalizeProfileURL(url)
+	result := r.db.WithContext(ctx).
+		Model(&core.Profile{}).
+		Where("linked_in_url = ?", url).
+		Updates(map[string]interface{}{
+			"follower_count": followerCount,
+			"updated_at":     time.Now(),
+		})
+
+	return result.Error
+}
+
+// UpdateProfileIdentity stores the name and headline shown for a profile. Empty values
+// leave the stored ones unchanged.
+func (r *Repository) UpdateProfileIdentity(ctx context.Context, url string, name string, headline string) error {
+	url = core.NormalizeProfileURL(url)
+	updates := map[string]interface{}{"updated_at": time.Now()}
+	if name != "" {
+		updates["name"] = name
+	}
+	if headline != "" {
+		updates["headline"] = headline
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&core.Profile{}).
+		Where("linked_in_url = ?", url).
+		Updates(updates)
+
+	return result.Error
+}
+
+// MarkProfileVisited stores when the profile was last visited
+func (r *Repository) MarkProfileVisited(ctx context.Context, url string, visitedAt time.Time) error {
+	url = core.NormalizeProfileURL(url)
+	result := r.db.WithContext(ctx).
+		Model(&core.Profile{}).
+		Where("linked_in_url = ?", url).
+		Updates(map[string]interface{}{
+			"visited_at": &visitedAt,
+			"updated_at": time.Now(),
+		})
+
+	return result.Error
+}
+
+// GetProfilesToVisit returns discovered profiles that have not been visited, oldest first
+func (r *Repository) GetProfilesToVisit(ctx context.Context, limit int) ([]*core.Profile, error) {
+	var profiles []*core.Profile
+	result := r.db.WithContext(ctx).
+		Where("status = ? AND visited_at IS NULL", core.ProfileStatusDiscovered).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&profiles)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return profiles, nil
+}
+
+// UpdateProfileSkills stores the profile's skills as a JSON array
+func (r *Repository) UpdateProfileSkills(ctx context.Context, url string, skills []string) error {
+	url = core.NormalizeProfileURL(url)
+	data, err := json.Marshal(skills)
+	if err != nil {
+		return err
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&core.Profile{}).
+		Where("linked_in_url = ?", url).
+		Updates(map[string]interface{}{
+			"skills":     string(data),
+			"updated_at": time.Now(),
+		})
+
+	return result.Error
+}
+
+// UpdateProfileEnrichment stores role and education data and marks the profile enriched
+func (r *Repository) UpdateProfileEnrichment(ctx context.Context, url string, enrichment *core.ProfileEnrichment) error {
+	url = core.NormalizeProfileURL(url)
+	now := time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&core.Profile{}).
+		Where("linked_in_url = ?", url).
+		Updates(map[string]interface{}{
+			"current_title":           enrichment.CurrentTitle,
+			"current_company":         enrichment.CurrentCompany,
+			"current_company_url":     enrichment.CurrentCompanyURL,
+			"current_role_started_at": enrichment.CurrentRoleStartedAt,
+			"school":                  enrichment.School,
+			"enriched_at":             &now,
+			"updated_at":              now,
+		})
+
+	return result.Error
+}
+
+// GetProfilesForEnrichment returns profiles that have not been enriched yet, oldest first
+func (r *Repository) GetProfilesForEnrichment(ctx context.Context, limit int) ([]*core.Profile, error) {
+	var profiles []*core.Profile
+	result := r.db.WithContext(ctx).
+		Where("enriched_at IS NULL AND status <> ?", core.ProfileStatusIgnored).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&profiles)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return profiles, nil
+}
+
+// GetOpenToWorkRate returns the share of the profiles checked for the "Open to Work"
+// frame that show it. Profiles never checked are left out rather than counted as not
+// open to work; open ones from before the check time was recorded count as checked.
+func (r *Repository) GetOpenToWorkRate(ctx context.Context) (*core.OpenToWorkRate, error) {
+	rate := &core.OpenToWorkRate{}
+	if err := r.db.WithContext(ctx).
+		Model(&core.Profile{}).
+		Where("open_to_work_checked_at IS NOT NULL OR is_open_to_work = ?", true).
+		Count(&rate.Checked).Error; err != nil {
+		return nil, err
+	}
+	if rate.Checked == 0 {
+		return rate, nil
+	}
+
+	if err := r.db.WithContext(ctx).
+		Model(&core.Profile{}).
+		Where("is_open_to_work = ?", true).
+		Count(&rate.OpenToWork).Error; err != nil {
+		return nil, err
+	}
+
+	rate.Rate = float64(rate.OpenToWork) / float64(rate.Checked)
+	return rate, nil
+}
+
+// GetProfilesByStatus retrieves all profiles with a specific status
+func (r *Repository) GetProfilesByStatus(ctx context.Context, status core.ProfileStatus) ([]*core.Profile, error) {
+	var profiles []*core.Profile
+	result := r.db.WithContext(ctx).Where("status = ?", status).Find(&profiles)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return profiles, nil
+}
+
+// profileOrderClauses are the ORDER BY clauses of the core.ProfileOrder values. Only
+// these are ever put into SQL; id breaks ties so pages don't overlap.
+var profileOrderClauses = map[core.ProfileOrder]string{
+	core.ProfileOrderCreatedAsc:  "created_at ASC, id ASC",
+	core.ProfileOrderCreatedDesc: "created_at DESC, id DESC",
+	core.ProfileOrderUpdatedAsc:  "updated_at ASC, id ASC",
+	core.ProfileOrderUpdatedDesc: "updated_at DESC, id DESC",
+}
+
+// GetProfilesByStatusPage retrieves up to limit profiles with a specific status, skipping
+// the first offset in orderBy order. An empty orderBy sorts by creation, oldest first.
+func (r *Repository) GetProfilesByStatusPage(ctx context.Context, status core.ProfileStatus, offset, limit int, orderBy core.ProfileOrder) ([]*core.Profile, error) {
+	if orderBy == "" {
+		orderBy = core.ProfileOrderCreatedAsc // Default fallback
+	}
+	order, ok := profileOrderClauses[orderBy]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", core.ErrInvalidProfileOrder, orderBy)
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	var profiles []*core.Profile
+	result := r.db.WithContext(ctx).
+		Where("status = ?", status).
+		Order(order).
+		Offset(offset).
+		Limit(limit).
+		Find(&profiles)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return profiles, nil
+}
+
+// CountProfilesByStatus returns the number of profiles with a specific status
+func (r *Repository) CountProfilesByStatus(ctx context.Context, status core.ProfileStatus) (int64, error) {
+	var count int64
+	result := r.db.WithContext(ctx).Model(&core.Profile{}).Where("status = ?", status).Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return count, nil
+}
+
+// GetConnectedProfilesByName retrieves connected profiles whose stored name is one of names
+func (r *Repository) GetConnectedProfilesByName(ctx context.Context, names []string) ([]*core.Profile, error) {
+	var profiles []*core.Profile
+	if len(names) == 0 {
+		return profiles, nil
+	}
+
+	result := r.db.WithContext(ctx).
+		Where("name IN ? AND connected_at IS NOT NULL", names).
+		Order("connected_at ASC").
+		Find(&profiles)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return profiles, nil
+}
+
+// SearchProfiles retrieves profiles matching every non-zero field of the filter
+func (r *Repository) SearchProfiles(ctx context.Context, filter *core.ProfileFilter) ([]*core.Profile, error) {
+	var profiles []*core.Profile
+	if err := r.filterProfiles(ctx, filter).Find(&profiles).Error; err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
+
+// maxProfilePageSize caps the page size of SearchProfilesPage
+const maxProfilePageSize = 500
+
+// SearchProfilesPage returns one page of the profiles matching the filter, most recently
+// updated first, and how many match
+func (r *Repository) SearchProfilesPage(ctx context.Context, filter *core.ProfileFilter, page core.Pagination) ([]*core.Profile, int64, error) {
+	limit := page.Limit
+	if limit <= 0 {
+		limit = 50 // Default fallback
+	}
+	if limit > maxProfilePageSize {
+		limit = maxProfilePageSize
+	}
+	offset := page.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	var total int64
+	if err := r.filterProfiles(ctx, filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var profiles []*core.Profile
+	result := r.filterProfiles(ctx, filter).
+		Order("updated_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&profiles)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	return profiles, total, nil
+}
+
+// CountProfiles counts the profiles matching the filter
+func (r *Repository) CountProfiles(ctx context.Context, filter *core.ProfileFilter) (int64, error) {
+	var count int64
+	if err := r.filterProfiles(ctx, filter).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// filterProfiles returns a profiles query restricted to the filter
+func (r *Repository) filterProfiles(ctx context.Context, filter *core.ProfileFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&core.Profile{})
+
+	if filter != nil {
+		if len(filter.IDs) > 0 {
+			query = query.Where("id IN ?", filter.IDs)
+		}
+		if filter.Status != "" {
+			query = query.Where("status = ?", filter.Status)
+		}
+		if filter.Campaign != "" {
+			query = query.Where("campaign = ?", filter.Campaign)
+		}
+		if !filter.CreatedAfter.IsZero() {
+			query = query.Where("created_at >= ?", filter.CreatedAfter)
+		}
+		if !filter.CreatedBefore.IsZero() {
+			query = query.Where("created_at < ?", filter.CreatedBefore)
+		}
+		if !filter.ConnectedAfter.IsZero() {
+			query = query.Where("connected_at >= ?", filter.ConnectedAfter)
+		}
+		if !filter.ConnectedBefore.IsZero() {
+			query = query.Where("connected_at < ?", filter.ConnectedBefore)
+		}
+		if filter.Query != "" {
+			pattern := "%" + escapeLike(strings.ToLower(filter.Query)) + "%"
+			query = query.Where(
+				"(LOWER(linked_in_url) LIKE ? ESCAPE '\\' OR LOWER(name) LIKE ? ESCAPE '\\' OR LOWER(headline) LIKE ? ESCAPE '\\' OR LOWER(current_company) LIKE ? ESCAPE '\\')",
+				pattern, pattern, pattern, pattern,
+			)
+		}
+	}
+
+	return query
+}
+
+// AddProfileToGroup records that a profile was discovered in a group
+func (r *Repository) AddProfileToGroup(ctx context.Context, profileID uint, groupURL string) error {
+	membership := &core.ProfileGroup{
+		ProfileID: profileID,
+		GroupURL:  groupURL,
+		CreatedAt: time.Now(),
+	}
+
+	result := r.db.WithContext(ctx).
+		Where("profile_id = ? AND group_url = ?", profileID, groupURL).
+		FirstOrCreate(membership)
+
+	return result.Error
+}
+
+// GetProfilesByGroup retrieves all profiles discovered in a specific group
+func (r *Repository) GetProfilesByGroup(ctx context.Context, groupURL string) ([]*core.Profile, error) {
+	var profiles []*core.Profile
+	result := r.db.WithContext(ctx).
+		Joins("JOIN profile_groups ON profile_groups.profile_id = profiles.id").
+		Where("profile_groups.group_url = ?", groupURL).
+		Find(&profiles)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return profiles, nil
+}
+
+// GetProfilesByLabel retrieves the profiles carrying label. A profile's labels are its
+// campaign and the URLs of the groups it was discovered in, so a group URL selects that
+// group's members.
+func (r *Repository) GetProfilesByLabel(ctx context.Context, label string) ([]*core.Profile, error) {
+	var profiles []*core.Profile
+	result := r.db.WithContext(ctx).
+		Where("campaign = ? OR id IN (?)", label,
+			r.db.Table("profile_groups").Select("profile_id").Where("group_url = ?", label),
+		).
+		Order("id ASC").
+		Find(&profiles)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return profiles, nil
+}
+
+// GetGroupStats computes connection acceptance rates per source group
+func (r *Repository) GetGroupStats(ctx context.Context) ([]*core.GroupStats, error) {
+	var stats []*core.GroupStats
+	result := r.db.WithContext(ctx).
+		Table("profile_groups").
+		Select(`profile_groups.group_url AS group_url,
+			COUNT(*) AS total,
+			SUM(CASE WHEN profiles.status IN (?, ?, ?) THEN 1 ELSE 0 END) AS requests_sent,
+			SUM(CASE WHEN profiles.status IN (?, ?) THEN 1 ELSE 0 END) AS accepted`,
+			core.ProfileStatusRequestSent, core.ProfileStatusConnected, core.ProfileStatusMessageSent,
+			core.ProfileStatusConnected, core.ProfileStatusMessageSent,
+		).
+		Joins("JOIN profiles ON profiles.id = profile_groups.profile_id AND profiles.deleted_at IS NULL AND profiles.account = ?", r.account).
+		Group("profile_groups.group_url").
+		Scan(&stats)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, s := range stats {
+		if s.RequestsSent > 0 {
+			s.AcceptanceRate = float64(s.Accepted) / float64(s.RequestsSent)
+		}
+	}
+
+	return stats, nil
+}
+
+// GetPendingFollowups returns connected or messaged profiles whose next follow-up
+// sequence step is due, limited to the connections inside window and in its order. The
+// steps done are read from the profile's sequence messages and endorsement. A nil window
+// returns every profile due the first message, newest first.
+func (r *Repository) GetPendingFollowups(ctx context.Context, limit int, window *core.FollowUpWindow) ([]*core.Profile, error) {
+	if window == nil {
+		window = &core.FollowUpWindow{IncludeUnknownConnectedAt: true}
+	}
+
+	// Replied and MessageRestricted profiles are excluded by the status
+	var profiles []*core.Profile
+	due, args := r.dueSequenceStep(window.Sequence, time.Now())
+	if due == "" {
+		return profiles, nil
+	}
+	query := r.db.WithContext(ctx).
+		Where("status IN ?", []core.ProfileStatus{core.ProfileStatusConnected, core.ProfileStatusMessageSent}).
+		Where(due, args...)
+
+	known := r.db.Where("connected_at IS NOT NULL")
+	if window.MinDaysSinceConnected > 0 {
+		known = known.Where("connected_at <= ?", time.Now().AddDate(0, 0, -window.MinDaysSinceConnected))
+	}
+	if window.MaxDaysSinceConnected > 0 {
+		known = known.Where("connected_at >= ?", time.Now().AddDate(0, 0, -window.MaxDaysSinceConnected))
+	}
+	if window.IncludeUnknownConnectedAt {
+		query = query.Where(r.db.Where(known).Or("connected_at IS NULL"))
+	} else {
+		query = query.Where(known)
+	}
+
+	// Rows without connected_at count as the oldest connections
+	if window.Order == core.FollowUpOrderOldest {
+		query = query.Order("connected_at IS NOT NULL, connected_at ASC, id ASC")
+	} else {
+		query = query.Order("connected_at IS NULL, connected_at DESC, id ASC")
+	}
+
+	result := query.
+		Limit(limit).
+		Find(&profiles)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return profiles, nil
+}
+
+// dueSequenceStep returns the condition matching profiles whose first step of sequence not
+// yet done is due at now, or "" if the sequence has no steps. Message step k is done once
+// k sequence messages were sent; profiles messaged before the Message table have one. A
+// step is due `day` days after connected_at, or right away when that is unknown.
+func (r *Repository) dueSequenceStep(sequence []core.SequenceStep, now time.Time) (string, []interface{}) {
+	if len(sequence) == 0 {
+		sequence = []core.SequenceStep{{Type: core.SequenceStepMessage}}
+	}
+
+	sentCount := "(SELECT COUNT(*) FROM messages WHERE messages.profile_id = profiles.id AND messages.direction = ? AND messages.sequence_step > 0)"
+	sent := "(CASE WHEN " + sentCount + " = 0 AND profiles.last_message_sent_at IS NOT NULL THEN 1 ELSE " + sentCount + " END)"
+	sentArgs := []interface{}{core.MessageDirectionOut, core.MessageDirectionOut}
+	endorsed := "EXISTS (SELECT 1 FROM endorsements WHERE endorsements.profile_id = profiles.id)"
+
+	var (
+		clauses  []string
+		args     []interface{}
+		done     []string // Conditions for the steps before the current one being done
+		doneArgs []interface{}
+	)
+	messageStep := 0
+	for _, step := range sequence {
+		var notDone, isDone string
+		var stepArgs []interface{}
+		switch step.Type {
+		case core.SequenceStepEndorse:
+			notDone, isDone = "NOT "+endorsed, endorsed
+		case core.SequenceStepMessage:
+			messageStep++
+			notDone, isDone = sent+" < ?", sent+" >= ?"
+			stepArgs = append(append(stepArgs, sentArgs...), messageStep)
+		default:
+			continue // The sequence engine ignores unknown steps too
+		}
+
+		clause := append(append([]string{}, done...), notDone,
+			"(profiles.connected_at IS NULL OR "+r.epochSeconds("profiles.connected_at")+" <= ?)")
+		clauses = append(clauses, "("+strings.Join(clause, " AND ")+")")
+		args = append(append(append(args, doneArgs...), stepArgs...), now.Add(-time.Duration(step.Day)*24*time.Hour).Unix())
+
+		done = append(done, isDone)
+		doneArgs = append(doneArgs, stepArgs...)
+	}
+
+	if len(clauses) == 0 {
+		return "", nil
+	}
+	return "(" + strings.Join(clauses, " OR ") + ")", args
+}
+
+// MarkAsConnected updates a profile status to Connected
+func (r *Repository) MarkAsConnected(ctx context.Context, linkedinURL string) error {
+	return r.MarkAsConnectedAt(ctx, linkedinURL, time.Now())
+}
+
+// MarkAsConnectedAt updates a profile status to Connected with a known acceptance time.
+// Profiles already past Connected (messaged, replied) are rejected with
+// core.ErrInvalidStatusTransition.
+func (r *Repository) MarkAsConnectedAt(ctx context.Context, linkedinURL string, connectedAt time.Time) error {
+	linkedinURL = core.NormalizeProfileURL(linkedinURL)
+	if err := r.checkTransition(ctx, linkedinURL, core.ProfileStatusConnected); err != nil {
+		return err
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&core.Profile{}).
+		Where("linked_in_url = ?", linkedinURL).
+		Updates(map[string]interface{}{
+			"status":       core.ProfileStatusConnected,
+			"connected_at": &connectedAt,
+			"updated_at":   time.Now(),
+		})
+
+	return result.Error
+}
+
+// MarkInvitationPending records that a sent invitation is still awaiting a response
+func (r *Repository) MarkInvitationPending(ctx context.Context, linkedinURL string) error {
+	linkedinURL = core.NormalizeProfileURL(linkedinURL)
+	now := time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&core.Profile{}).
+		Where("linked_in_url = ?", linkedinURL).
+		Updates(map[string]interface{}{
+			"last_seen_pending_at": &now,
+			"updated_at":           now,
+		})
+
+	return result.Error
+}
+
+// LogMessageSent records an outgoing message, updates the profile status and logs the
+// send in history (used for rate limiting). Messages outside the follow-up sequence
+// (SequenceStep 0) leave the profile untouched.
+func (r *Repository) LogMessageSent(ctx context.Context, message *core.Message) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		
+		message.Direction = core.MessageDirectionOut
+		if message.SentAt.IsZero() {
+			message.SentAt = now
+		}
+		if err := tx.WithContext(ctx).Create(message).Error; err != nil {
+			return err
+		}
+
+		// Update profile
+		if message.SequenceStep > 0 {
+			if err := tx.WithContext(ctx).Model(&core.Profile{}).
+				Where("id = ?", message.ProfileID).
+				Updates(map[string]interface{}{
+					"status":               core.ProfileStatusMessageSent,
+					"last_message_sent_at": &now,
+					"updated_at":           now,
+				}).Error; err != nil {
+				return err
+			}
+		}
+
+		// Create history entry, counted against the profile's campaign budget
+		var profile core.Profile
+		if err := tx.WithContext(ctx).Select("linked_in_url", "campaign").
+			Where("id = ?", message.ProfileID).
+			Limit(1).Find(&profile).Error; err != nil {
+			return err
+		}
+		history := core.NewMessageHistory(profile.LinkedInURL, message.TemplateName)
+		history.Campaign = profile.Campaign
+		history.Timestamp = now
+		linkRun(ctx, history)
+		
+		if err := tx.WithContext(ctx).Create(history).Error; err != nil {
+			return err
+		}
+
+		return r.projections.Apply(tx, history)
+	})
+}
+
+// CreateMessage creates a new message record
+func (r *Repository) CreateMessage(ctx context.Context, message *core.Message) error {
+	if message.SentAt.IsZero() {
+		message.SentAt = time.Now()
+	}
+
+	return r.db.WithContext(ctx).Create(message).Error
+}
+
+// CreateMessageIfNotExists stores a message unless one with the same profile, direction,
+// timestamp and content hash already exists. It reports whether a new row was created.
+func (r *Repository) CreateMessageIfNotExists(ctx context.Context, message *core.Message) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("profile_id = ? AND direction = ? AND sent_at = ? AND content_hash = ?",
+			message.ProfileID, message.Direction, message.SentAt, message.ContentHash).
+		FirstOrCreate(message)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// GetMessagesForProfile returns a profile's conversation, oldest first
+func (r *Repository) GetMessagesForProfile(ctx context.Context, profileID uint) ([]*core.Message, error) {
+	var messages []*core.Message
+	result := r.db.WithContext(ctx).
+		Where("profile_id = ?", profileID).
+		Order("sent_at ASC").
+		Find(&messages)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return messages, nil
+}
+
+// GetLastMessageByTemplate returns the most recent outgoing message built from a template,
+// or nil if none was sent
+func (r *Repository) GetLastMessageByTemplate(ctx context.Context, profileID uint, templateName string) (*core.Message, error) {
+	var message core.Message
+	result := r.db.WithContext(ctx).
+		Where("profile_id = ? AND direction = ? AND template_name = ?", profileID, core.MessageDirectionOut, templateName).
+		Order("sent_at DESC").
+		First(&message)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &message, nil
+}
+
+// UpdateMessage saves changes to an existing message record
+func (r *Repository) UpdateMessage(ctx context.Context, message *core.Message) error {
+	return r.db.WithContext(ctx).Save(message).Error
+}
+
+// DeleteMessage deletes a message record
+func (r *Repository) DeleteMessage(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&core.Message{}, id).Error
+}
+
+// CreateEndorsement records that a profile's skills were endorsed
+func (r *Repository) CreateEndorsement(ctx context.Context, endorsement *core.Endorsement) error {
+	if endorsement.EndorsedAt.IsZero() {
+		endorsement.EndorsedAt = time.Now()
+	}
+
+	return r.db.WithContext(ctx).Create(endorsement).Error
+}
+
+// HasEndorsement reports whether a profile has already been endorsed
+func (r *Repository) HasEndorsement(ctx context.Context, profileID uint) (bool, error) {
+	var count int64
+	result := r.db.WithContext(ctx).
+		Model(&core.Endorsement{}).
+		Where("profile_id = ?", profileID).
+		Count(&count)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return count > 0, nil
+}
+
+// CreateDeclinedInvite records a declined inviter, ignoring inviters already recorded
+func (r *Repository) CreateDeclinedInvite(ctx context.Context, invite *core.DeclinedInvite) error {
+	invite.ProfileURL = core.NormalizeProfileURL(invite.ProfileURL)
+	if invite.DeclinedAt.IsZero() {
+		invite.DeclinedAt = time.Now()
+	}
+
+	return r.db.WithContext(ctx).
+		Where("profile_url = ?", invite.ProfileURL).
+		FirstOrCreate(invite).Error
+}
+
+// IsInviteDeclined reports whether an invitation from the profile was declined before
+func (r *Repository) IsInviteDeclined(ctx context.Context, profileURL string) (bool, error) {
+	profileURL = core.NormalizeProfileURL(profileURL)
+	var count int64
+	result := r.db.WithContext(ctx).
+		Model(&core.DeclinedInvite{}).
+		Where("profile_url = ?", profileURL).
+		Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return count > 0, nil
+}
+
+// CreateNotificationIfNotExists stores a notification unless one with the same content
+// hash was already stored. It reports whether a new row was created.
+func (r *Repository) CreateNotificationIfNotExists(ctx context.Context, notification *core.Notification) (bool, error) {
+	notification.ActorURL = core.NormalizeProfileURL(notification.ActorURL)
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = time.Now()
+	}
+
+	result := r.db.WithContext(ctx).
+		Where("content_hash = ?", notification.ContentHash).
+		FirstOrCreate(notification)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// GetNotificationsByDateRange returns notifications stored within a time range, oldest first
+func (r *Repository) GetNotificationsByDateRange(ctx context.Context, start, end time.Time) ([]*core.Notification, error) {
+	var notifications []*core.Notification
+	result := r.db.WithContext(ctx).
+		Where("created_at >= ? AND created_at < ?", start, end).
+		Order("created_at ASC").
+		Find(&notifications)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return notifications, nil
+}
+
+// CreatePageLoad records the load timings of a navigation
+func (r *Repository) CreatePageLoad(ctx context.Context, pageLoad *core.PageLoad) error {
+	if pageLoad.LoadedAt.IsZero() {
+		pageLoad.LoadedAt = time.Now()
+	}
+
+	return r.db.WithContext(ctx).Create(pageLoad).Error
+}
+
+// SaveGroup creates the group record or updates the existing one for the same URL
+func (r *Repository) SaveGroup(ctx context.Context, group *core.Group) error {
+	now := time.Now()
+	if group.RequestedAt.IsZero() {
+		group.RequestedAt = now
+	}
+	group.UpdatedAt = now
+
+	var existing core.Group
+	result := r.db.WithContext(ctx).Where("group_url = ?", group.GroupURL).First(&existing)
+	if result.Error != nil {
+		if result.Error != gorm.ErrRecordNotFound {
+			return result.Error
+		}
+		group.CreatedAt = now
+		return r.db.WithContext(ctx).Create(group).Error
+	}
+
+	group.ID = existing.ID
+	group.CreatedAt = existing.CreatedAt
+	return r.db.WithContext(ctx).Save(group).Error
+}
+
+// CreateCampaign creates a new campaign, active unless another status is set
+func (r *Repository) CreateCampaign(ctx context.Context, campaign *core.Campaign) error {
+	if campaign.Name == "" {
+		return fmt.Errorf("campaign name is required")
+	}
+	if campaign.Status == "" {
+		campaign.Status = core.CampaignStatusActive
+	}
+
+	existing, err := r.GetCampaignByName(ctx, campaign.Name)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("campaign %q already exists", campaign.Name)
+	}
+
+	now := time.Now()
+	campaign.CreatedAt = now
+	campaign.UpdatedAt = now
+	return r.db.WithContext(ctx).Create(campaign).Error
+}
+
+// GetCampaignByName retrieves a campaign by name
+func (r *Repository) GetCampaignByName(ctx context.Context, name string) (*core.Campaign, error) {
+	var campaign core.Campaign
+	result := r.db.WithContext(ctx).Where("name = ?", name).First(&campaign)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil // Campaign not found, not an error
+		}
+		return nil, result.Error
+	}
+
+	return &campaign, nil
+}
+
+// ListCampaigns returns all campaigns, oldest first
+func (r *Repository) ListCampaigns(ctx context.Context) ([]*core.Campaign, error) {
+	var campaigns []*core.Campaign
+	result := r.db.WithContext(ctx).Order("created_at ASC").Find(&campaigns)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return campaigns, nil
+}
+
+// UpdateCampaignStatus sets a campaign's status (active, paused or archived)
+func (r *Repository) UpdateCampaignStatus(ctx context.Context, name string, status string) error {
+	switch status {
+	case core.CampaignStatusActive, core.CampaignStatusPaused, core.CampaignStatusArchived:
+	default:
+		return fmt.Errorf("invalid campaign status %q", status)
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&core.Campaign{}).
+		Where("name = ?", name).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("campaign %q not found", name)
+	}
+
+	return nil
+}
+
+// AssignProfileToCampaign moves a profile into an active campaign. A profile already in a
+// different campaign that is still active is left alone and ErrProfileInActiveCampaign
+// is returned.
+func (r *Repository) AssignProfileToCampaign(ctx context.Context, profileURL string, campaignName string) error {
+	profileURL = core.NormalizeProfileURL(profileURL)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var campaign core.Campaign
+		if err := tx.Where("name = ?", campaignName).First(&campaign).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fmt.Errorf("campaign %q not found", campaignName)
+			}
+			return err
+		}
+		if campaign.Status != core.CampaignStatusActive {
+			return fmt.Errorf("campaign %q is %s", campaignName, campaign.Status)
+		}
+
+		var profile core.Profile
+		if err := tx.Where("linked_in_url = ?", profileURL).First(&profile).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fmt.Errorf("profile not found: %s", profileURL)
+			}
+			return err
+		}
+		if profile.Campaign == campaignName {
+			return nil
+		}
+
+		if profile.Campaign != "" {
+			var current core.Campaign
+			err := tx.Where("name = ?", profile.Campaign).First(&current).Error
+			if err == nil && current.Status == core.CampaignStatusActive {
+				return core.ErrProfileInActiveCampaign
+			}
+			if err != nil && err != gorm.ErrRecordNotFound {
+				return err
+			}
+		}
+
+		return tx.Model(&core.Profile{}).
+			Where("id = ?", profile.ID).
+			Updates(map[string]interface{}{
+				"campaign":   campaignName,
+				"updated_at": time.Now(),
+			}).Error
+	})
+}
+
+// GetGroupByURL retrieves a group record by URL
+func (r *Repository) GetGroupByURL(ctx context.Context, groupURL string) (*core.Group, error) {
+	var group core.Group
+	result := r.db.WithContext(ctx).Where("group_url = ?", groupURL).First(&group)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil // Group not found, not an error
+		}
+		return nil, result.Error
+	}
+
+	return &group, nil
+}
+
+// GetGroupsByState returns the groups in a membership state, oldest request first
+func (r *Repository) GetGroupsByState(ctx context.Context, state string) ([]*core.Group, error) {
+	var groups []*core.Group
+	result := r.db.WithContext(ctx).
+		Where("state = ?", state).
+		Order("requested_at ASC").
+		Find(&groups)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return groups, nil
+}
+
+// CreateFollowedCompany records a followed company, ignoring companies already recorded
+func (r *Repository) CreateFollowedCompany(ctx context.Context, company *core.FollowedCompany) error {
+	if company.FollowedAt.IsZero() {
+		company.FollowedAt = time.Now()
+	}
+
+	return r.db.WithContext(ctx).
+		Where("company_url = ?", company.CompanyURL).
+		FirstOrCreate(company).Error
+}
+
+// IsCompanyFollowed reports whether a company is recorded as followed
+func (r *Repository) IsCompanyFollowed(ctx context.Context, companyURL string) (bool, error) {
+	var count int64
+	result := r.db.WithContext(ctx).
+		Model(&core.FollowedCompany{}).
+		Where("company_url = ?", companyURL).
+		Count(&count)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return count > 0, nil
+}
+
+// GetCompaniesToFollow returns current company URLs of enriched profiles that are not
+// followed yet, most common first
+func (r *Repository) GetCompaniesToFollow(ctx context.Context, limit int) ([]string, error) {
+	var urls []string
+	result := r.db.WithContext(ctx).
+		Model(&core.Profile{}).
+		Select("current_company_url").
+		Where("current_company_url <> ''").
+		Where("current_company_url NOT IN (?)", r.db.Model(&core.FollowedCompany{}).Select("company_url")).
+		Group("current_company_url").
+		Order("COUNT(*) DESC").
+		Limit(limit).
+		Pluck("current_company_url", &urls)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return urls, nil
+}
+
+// CreatePostComment records a posted comment
+func (r *Repository) CreatePostComment(ctx context.Context, comment *core.PostComment) error {
+	comment.AuthorURL = core.NormalizeProfileURL(comment.AuthorURL)
+	if comment.CommentedAt.IsZero() {
+		comment.CommentedAt = time.Now()
+	}
+
+	return r.db.WithContext(ctx).Create(comment).Error
+}
+
+// GetLastCommentForAuthor returns the most recent comment on an author's posts, or nil if none
+func (r *Repository) GetLastCommentForAuthor(ctx context.Context, authorURL string) (*core.PostComment, error) {
+	authorURL = core.NormalizeProfileURL(authorURL)
+	var comment core.PostComment
+	result := r.db.WithContext(ctx).
+		Where("author_url = ?", authorURL).
+		Order("commented_at DESC").
+		First(&comment)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &comment, nil
+}
+
+// CreateHistory creates a new history record and counts it in the daily projection
+func (r *Repository) CreateHistory(ctx context.Context, history *core.History) error {
+	if history.Timestamp.IsZero() {
+		history.Timestamp = time.Now()
+	}
+	linkRun(ctx, history)
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(history).Error; err != nil {
+			return err
+		}
+		return r.projections.Apply(tx, history)
+	})
+}
+
+// GetTodayActionCount counts actions of a specific type performed today, which starts at
+// midnight in database.timezone
+func (r *Repository) GetTodayActionCount(ctx context.Context, actionType string) (int64, error) {
+	return r.GetActionCountSince(ctx, actionType, r.startOfDay(time.Now()))
+}
+
+// GetTodayCampaignActionCount counts actions of a type taken today for a campaign
+func (r *Repository) GetTodayCampaignActionCount(ctx context.Context, actionType string, campaign string) (int64, error) {
+	var count int64
+	result := r.db.WithContext(ctx).
+		Model(&core.History{}).
+		Where("action_type = ? AND campaign = ? AND "+r.epochSeconds("timestamp")+" >= ?", actionType, campaign, r.startOfDay(time.Now()).Unix()).
+		Count(&count)
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return count, nil
+}
+
+// GetActionCountSince counts actions of a type recorded at or after since, e.g. for
+// weekly or monthly limits. Times are compared as instants, so rows written with another
+// UTC offset (such as before a daylight saving change) are counted correctly.
+func (r *Repository) GetActionCountSince(ctx context.Context, actionType string, since time.Time) (int64, error) {
+	var count int64
+	result := r.db.WithContext(ctx).
+		Model(&core.History{}).
+		Where("action_type = ? AND "+r.epochSeconds("timestamp")+" >= ?", actionType, since.Unix()).
+		Count(&count)
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return count, nil
+}
+
+// GetActionCountsByType counts the actions recorded at or after since and before until,
+// per action type. Types without actions are omitted.
+func (r *Repository) GetActionCountsByType(ctx context.Context, since, until time.Time) (map[string]int64, error) {
+	var rows []struct {
+		ActionType string
+		Count      int64
+	}
+	epoch := r.epochSeconds("timestamp")
+	result := r.db.WithContext(ctx).
+		Model(&core.History{}).
+		Select("action_type, COUNT(*) AS count").
+		Where(epoch+" >= ? AND "+epoch+" < ?", since.Unix(), until.Unix()).
+		Group("action_type").
+		Scan(&rows)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.ActionType] = row.Count
+	}
+
+	return counts, nil
+}
+
+// startOfDay returns midnight of t's day in database.timezone. On days where a daylight
+// saving change skips midnight, the day starts at the first moment that exists.
+func (r *Repository) startOfDay(t time.Time) time.Time {
+	t = t.In(r.location)
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, r.location)
+	// time.Date resolves a skipped midnight to the previous day's offset, i.e. 23:00
+	if start.Day() != t.Day() {
+		_, start = start.ZoneBounds()
+	}
+	return start
+}
+
+// GetHistoryByDateRange retrieves history records within a date range
+func (r *Repository) GetHistoryByDateRange(ctx context.Context, start, end time.Time) ([]*core.History, error) {
+	var histories []*core.History
+	epoch := r.epochSeconds("timestamp")
+	result := r.db.WithContext(ctx).
+		Where(epoch+" >= ? AND "+epoch+" <= ?", start.Unix(), end.Unix()).
+		Order("timestamp DESC").
+		Find(&histories)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return histories, nil
+}
+
+// linkRun links history to the run in ctx, unless it already names a run
+func linkRun(ctx context.Context, history *core.History) {
+	if history.RunID != nil {
+		return
+	}
+	if runID, ok := core.RunIDFromContext(ctx); ok {
+		history.RunID = &runID
+	}
+}
+
+// CreateRunMetadata records the start of a run
+func (r *Repository) CreateRunMetadata(ctx context.Context, run *core.RunMetadata) error {
+	if run.StartedAt.IsZero() {
+		run.StartedAt = time.Now()
+	}
+	return r.db.WithContext(ctx).Create(run).Error
+}
+
+// UpdateRunMetadata saves a run's counters and completion time
+func (r *Repository) UpdateRunMetadata(ctx context.Context, run *core.RunMetadata) error {
+	run.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).Save(run).Error
+}
+
+// ListRunMetadata returns the most recent runs, newest first
+func (r *Repository) ListRunMetadata(ctx context.Context, limit int) ([]*core.RunMetadata, error) {
+	var runs []*core.RunMetadata
+	result := r.db.WithContext(ctx).
+		Order("started_at DESC").
+		Limit(limit).
+		Find(&runs)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return runs, nil
+}
+
+// GetRunMetadata looks up a run by its numeric ID or its UUID
+func (r *Repository) GetRunMetadata(ctx context.Context, id string) (*core.RunMetadata, error) {
+	query := r.db.WithContext(ctx).Where("run_id = ?", id)
+	if numericID, err := strconv.ParseUint(id, 10, 64); err == nil {
+		query = r.db.WithContext(ctx).Where("id = ?", numericID)
+	}
+
+	var run core.RunMetadata
+	result := query.First(&run)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &run, nil
+}
+
+// GetRunHistory returns the actions taken during a run, oldest first
+func (r *Repository) GetRunHistory(ctx context.Context, runID uint) ([]*core.History, error) {
+	var histories []*core.History
+	result := r.db.WithContext(ctx).
+		Where("run_id = ?", runID).
+		Order("timestamp ASC, id ASC").
+		Find(&histories)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return histories, nil
+}
+
+// HasIntroductionRequest reports whether an introduction to the target was already requested
+func (r *Repository) HasIntroductionRequest(ctx context.Context, targetURL string) (bool, error) {
+	var count int64
+	result := r.db.WithContext(ctx).
+		Model(&core.History{}).
+		Where("action_type = ? AND (details LIKE ? OR "+r.detailsField("details", "profile_url")+" = ?)",
+			"IntroductionRequest", targetURL+" via %", targetURL).
+		Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return count > 0, nil
+}
+
+// HasHistoryTarget reports whether an actionType entry was recorded with target as its
+// details target, e.g. a daily report email for "2026-03-14"
+func (r *Repository) HasHistoryTarget(ctx context.Context, actionType, target string) (bool, error) {
+	var count int64
+	result := r.db.WithContext(ctx).
+		Model(&core.History{}).
+		Where("action_type = ? AND "+r.detailsField("details", "target")+" = ?", actionType, target).
+		Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return count > 0, nil
+}
+
+// GetActionStats counts an action type and its errors in buckets of bucketSize between
+// start and end. Buckets are aligned to the Unix epoch (UTC) and empty buckets are omitted.
+// Errors are "Error" history entries whose error class is the action type.
+func (r *Repository) GetActionStats(ctx context.Context, actionType string, start, end time.Time, bucketSize time.Duration) ([]core.StatsBucket, error) {
+	bucketSeconds := int64(bucketSize / time.Second)
+	if bucketSeconds <= 0 {
+		bucketSeconds = 86400 // Default fallback: one day
+	}
+
+	var rows []struct {
+		Bucket       int64
+		SuccessCount int64
+		ErrorCount   int64
+	}
+	result := r.db.WithContext(ctx).Raw(`
+		SELECT (`+r.epochSeconds("timestamp")+` / ?) * ? AS bucket,
+			SUM(CASE WHEN action_type = ? THEN 1 ELSE 0 END) AS success_count,
+			SUM(CASE WHEN action_type = 'Error' THEN 1 ELSE 0 END) AS error_count
+		FROM histories
+		WHERE account = ? AND deleted_at IS NULL AND `+r.epochSeconds("timestamp")+` >= ? AND `+r.epochSeconds("timestamp")+` < ?
+			AND (action_type = ? OR (action_type = 'Error'
+				AND (`+r.detailsField("details", "error_class")+` = ? OR LOWER(details) LIKE ?)))
+		GROUP BY bucket
+		ORDER BY bucket`,
+		bucketSeconds, bucketSeconds, actionType, r.account, start.Unix(), end.Unix(),
+		actionType, strings.ToLower(actionType), strings.ToLower(actionType)+":%",
+	).Scan(&rows)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	buckets := make([]core.StatsBucket, 0, len(rows))
+	for _, row := range rows {
+		buckets = append(buckets, core.StatsBucket{
+			BucketStart:  time.Unix(row.Bucket, 0).UTC(),
+			Count:        row.SuccessCount + row.ErrorCount,
+			SuccessCount: row.SuccessCount,
+			ErrorCount:   row.ErrorCount,
+		})
+	}
+
+	return buckets, nil
+}
+
+// GetAcceptanceRateByDay groups connection requests sent between start and end by UTC
+// day and counts how many of the requested profiles have since been accepted
+func (r *Repository) GetAcceptanceRateByDay(ctx context.Context, start, end time.Time) ([]core.DailyAcceptanceRate, error) {
+	var rows []struct {
+		Day          string
+		RequestsSent int64
+		Accepted     int64
+	}
+	result := r.db.WithContext(ctx).Raw(`
+		SELECT `+r.utcDay("histories.timestamp")+` AS day,
+			COUNT(*) AS requests_sent,
+			SUM(CASE WHEN profiles.connected_at IS NOT NULL THEN 1 ELSE 0 END) AS accepted
+		FROM histories
+		LEFT JOIN profiles ON `+r.connectedProfileJoin()+`
+		WHERE histories.account = ? AND histories.action_type = 'Connect' AND histories.deleted_at IS NULL
+			AND `+r.epochSeconds("histories.timestamp")+` >= ? AND `+r.epochSeconds("histories.timestamp")+` < ?
+		GROUP BY day
+		ORDER BY day`,
+		r.account, start.Unix(), end.Unix(),
+	).Scan(&rows)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	rates := make([]core.DailyAcceptanceRate, 0, len(rows))
+	for _, row := range rows {
+		day, err := time.Parse("2006-01-02", row.Day)
+		if err != nil {
+			return nil, err
+		}
+		rate := core.DailyAcceptanceRate{
+			Date:         day,
+			RequestsSent: row.RequestsSent,
+			Accepted:     row.Accepted,
+		}
+		if rate.RequestsSent > 0 {
+			rate.AcceptanceRate = float64(rate.Accepted) / float64(rate.RequestsSent)
+		}
+		rates = append(rates, rate)
+	}
+
+	return rates, nil
+}
+
+// epochSeconds returns the SQL expression for column as whole Unix seconds
+func (r *Repository) epochSeconds(column string) string {
+	if r.db.Dialector.Name() == DriverPostgres {
+		return "CAST(EXTRACT(EPOCH FROM " + column + ") AS BIGINT)"
+	}
+	return "CAST(strftime('%s', " + column + ") AS INTEGER)"
+}
+
+// detailsField returns the SQL expression for key of the JSON history details in column,
+// NULL for legacy free-text details
+func (r *Repository) detailsField(column, key string) string {
+	if r.db.Dialector.Name() == DriverPostgres {
+		return "(CASE WHEN " + column + " LIKE '{%' THEN CAST(" + column + " AS jsonb) ->> '" + key + "' END)"
+	}
+	return "(CASE WHEN " + column + " LIKE '{%' THEN json_extract(" + column + ", '$." + key + "') END)"
+}
+
+// connectedProfileJoin returns the join condition of "Connect" histories to the profile
+// they were sent to, for JSON and legacy ("Connected to <url>") details
+func (r *Repository) connectedProfileJoin() string {
+	return "profiles.account = histories.account AND (" +
+		r.detailsField("histories.details", "profile_url") + " = profiles.linked_in_url" +
+		" OR histories.details = 'Connected to ' || profiles.linked_in_url)"
+}
+
+// utcDay returns the SQL expression for column's UTC day as YYYY-MM-DD text
+func (r *Repository) utcDay(column string) string {
+	if r.db.Dialector.Name() == DriverPostgres {
+		return "to_char(" + column + " AT TIME ZONE 'UTC', 'YYYY-MM-DD')"
+	}
+	return "date(" + column + ")"
+}
+
+// CanPerformAction checks if an action can be performed based on daily limits
+func (r *Repository) CanPerformAction(ctx context.Context, actionType string, dailyLimit int) (bool, error) {
+	count, err := r.GetTodayActionCount(ctx, actionType)
+	if err != nil {
+		return false, err
+	}
+
+	return count < int64(dailyLimit), nil
+}
+
+// Close closes the database connection
+func (r *Repository) Close() error {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.Close()
+}
+
+// GetDB returns the underlying GORM database instance (for advanced usage)
+func (r *Repository) GetDB() *gorm.DB {
+	return r.db
+}
+
+// escapeLike escapes the LIKE wildcards in s, for use with ESCAPE '\'
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"linkedin-automation/internal/core"
+)
+
+func TestConcurrentUpsertProfile(t *testing.T) {
+	// One connection serializes the upserts in the pool; several race on the unique index
+	for _, conns := range []int{1, 4} {
+		t.Run(fmt.Sprintf("%d connections", conns), func(t *testing.T) {
+			testConcurrentUpsertProfile(t, newTestRepository(t, core.DatabaseConfig{SQLiteMaxOpenConns: conns, SQLiteMaxIdleConns: conns}))
+		})
+	}
+}
+
+func testConcurrentUpsertProfile(t *testing.T, repo *Repository) {
+	ctx := context.Background()
+
+	const upserters = 16
+	url := "https://www.linkedin.com/in/popular-profile/"
+	ids := make([]uint, upserters)
+	created := make([]bool, upserters)
+	errs := make([]error, upserters)
+
+	var wg sync.WaitGroup
+	for i := 0; i < upserters; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Each caller spells the URL differently; all normalize to url
+			profile := &core.Profile{LinkedInURL: url + "?trk=" + string(rune('a'+i)), Status: core.ProfileStatusDiscovered}
+			result, err := repo.UpsertProfile(ctx, profile)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			ids[i] = profile.ID
+			created[i] = result.Created
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("UpsertProfile %d: %v", i, err)
+		}
+	}
+
+	var rows []core.Profile
+	if err := repo.GetDB().Where("linked_in_url = ?", url).Find(&rows).Error; err != nil {
+		t.Fatalf("load profiles: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("%d rows stored for %s, want 1", len(rows), url)
+	}
+
+	creators := 0
+	for i := range ids {
+		if ids[i] != rows[0].ID {
+			t.Errorf("UpsertProfile %d got ID %d, want the stored ID %d", i, ids[i], rows[0].ID)
+		}
+		if created[i] {
+			creators++
+		}
+	}
+	if creators != 1 {
+		t.Errorf("%d upserts reported Created, want 1", creators)
+	}
+}
+
+// BenchmarkBulkCreateProfiles compares saving a page of new profiles with a CreateProfile
+// loop and with one BulkCreateProfiles call, in the default (WAL) and rollback journal modes
+func BenchmarkBulkCreateProfiles(b *testing.B) {
+	ctx := context.Background()
+	newProfiles := func(run, n int) []*core.Profile {
+		profiles := make([]*core.Profile, n)
+		for i := range profiles {
+			profiles[i] = &core.Profile{
+				LinkedInURL: fmt.Sprintf("https://www.linkedin.com/in/run-%d-profile-%d/", run, i),
+				Status:      core.ProfileStatusDiscovered,
+			}
+		}
+		return profiles
+	}
+
+	for _, journal := range []string{"wal", "delete"} {
+		for _, n := range []int{100, 500} {
+			b.Run(fmt.Sprintf("%s/%d/loop", journal, n), func(b *testing.B) {
+				repo := newBenchmarkRepository(b, journal)
+				for i := 0; i < b.N; i++ {
+					for _, profile := range newProfiles(i, n) {
+						if err := repo.CreateProfile(ctx, profile); err != nil {
+							b.Fatalf("CreateProfile: %v", err)
+						}
+					}
+				}
+			})
+			b.Run(fmt.Sprintf("%s/%d/bulk", journal, n), func(b *testing.B) {
+				repo := newBenchmarkRepository(b, journal)
+				for i := 0; i < b.N; i++ {
+					if created, _, err := repo.BulkCreateProfiles(ctx, newProfiles(i, n)); err != nil || created != n {
+						b.Fatalf("BulkCreateProfiles = %d created, %v; want %d", created, err, n)
+					}
+				}
+			})
+		}
+	}
+}
+
+// newBenchmarkRepository opens a SQLite repository on disk with the journal mode
+func newBenchmarkRepository(b *testing.B, journal string) *Repository {
+	b.Helper()
+	repo, err := NewRepository(&core.DatabaseConfig{Path: filepath.Join(b.TempDir(), "bot.db"), SQLiteJournalMode: journal})
+	if err != nil {
+		b.Fatalf("NewRepository: %v", err)
+	}
+	b.Cleanup(func() { _ = repo.Close() })
+	return repo
+}
+
+func TestStartOfDayDaylightSaving(t *testing.T) {
+	tests := []struct {
+		name     string
+		zone     string
+		at       time.Time // Wall clock in zone
+		want     string
+		dayHours float64
+	}{
+		{"ordinary day", "America/New_York", time.Date(2024, time.March, 9, 15, 0, 0, 0, time.UTC), "2024-03-09T00:00:00-05:00", 24},
+		{"spring forward", "America/New_York", time.Date(2024, time.March, 10, 15, 0, 0, 0, time.UTC), "2024-03-10T00:00:00-05:00", 23},
+		{"spring forward, after the change", "America/New_York", time.Date(2024, time.March, 10, 3, 0, 0, 0, time.UTC), "2024-03-10T00:00:00-05:00", 23},
+		{"fall back", "America/New_York", time.Date(2024, time.November, 3, 15, 0, 0, 0, time.UTC), "2024-11-03T00:00:00-04:00", 25},
+		{"fall back, repeated hour", "America/New_York", time.Date(2024, time.November, 3, 1, 30, 0, 0, time.UTC), "2024-11-03T00:00:00-04:00", 25},
+		// Chile moves its clocks at midnight, so the day starts at 01:00
+		{"spring forward skips midnight", "America/Santiago", time.Date(2024, time.September, 8, 12, 0, 0, 0, time.UTC), "2024-09-08T01:00:00-03:00", 23},
+		{"fall back repeats the hour before midnight", "America/Santiago", time.Date(2024, time.April, 6, 12, 0, 0, 0, time.UTC), "2024-04-06T00:00:00-03:00", 25},
+		{"day after falling back at midnight", "America/Santiago", time.Date(2024, time.April, 7, 12, 0, 0, 0, time.UTC), "2024-04-07T00:00:00-04:00", 24},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location, err := time.LoadLocation(tt.zone)
+			if err != nil {
+				t.Skipf("time zone data not available: %v", err)
+			}
+			repo := &Repository{location: location}
+			at := time.Date(tt.at.Year(), tt.at.Month(), tt.at.Day(), tt.at.Hour(), tt.at.Minute(), 0, 0, location)
+
+			start := repo.startOfDay(at)
+			if got := start.Format(time.RFC3339); got != tt.want {
+				t.Errorf("startOfDay(%s) = %s, want %s", at, got, tt.want)
+			}
+			next := repo.startOfDay(start.Add(36 * time.Hour))
+			if hours := next.Sub(start).Hours(); hours != tt.dayHours {
+				t.Errorf("day of %s lasts %vh, want %vh", at, hours, tt.dayHours)
+			}
+		})
+	}
+}
+
+func TestActionCountsDaylightSaving(t *testing.T) {
+	ny := newYork(t)
+	repo := newTestRepository(t, core.DatabaseConfig{Timezone: "America/New_York"})
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		day    time.Time
+		inside []time.Time
+	}{
+		{
+			name: "spring forward",
+			day:  time.Date(2024, time.March, 10, 12, 0, 0, 0, ny),
+			inside: []time.Time{
+				time.Date(2024, time.March, 10, 0, 0, 0, 0, ny),
+				time.Date(2024, time.March, 10, 1, 59, 59, 0, ny),
+				time.Date(2024, time.March, 10, 3, 0, 0, 0, ny), // First moment after the skipped hour
+				time.Date(2024, time.March, 10, 23, 59, 59, 0, ny),
+			},
+		},
+		{
+			name: "fall back",
+			day:  time.Date(2024, time.November, 3, 12, 0, 0, 0, ny),
+			inside: []time.Time{
+				time.Date(2024, time.November, 3, 0, 30, 0, 0, ny),
+				time.Date(2024, time.November, 3, 5, 30, 0, 0, time.UTC), // 01:30 EDT
+				time.Date(2024, time.November, 3, 6, 30, 0, 0, time.UTC), // 01:30 EST, the repeated hour
+				time.Date(2024, time.November, 3, 23, 59, 59, 0, ny),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actionType := "Connect " + tt.name // Keeps the days' counts apart
+			start := repo.startOfDay(tt.day)
+			end := repo.startOfDay(start.Add(36 * time.Hour))
+
+			for _, at := range tt.inside {
+				createHistoryAt(t, repo, core.NewHistory(actionType, core.HistoryDetails{}), at)
+			}
+			// The last second of the day before and the first of the day after
+			createHistoryAt(t, repo, core.NewHistory(actionType, core.HistoryDetails{}), start.Add(-time.Second))
+			createHistoryAt(t, repo, core.NewHistory(actionType, core.HistoryDetails{}), end)
+
+			counts, err := repo.GetActionCountsByType(ctx, start, end)
+			if err != nil {
+				t.Fatalf("GetActionCountsByType: %v", err)
+			}
+			if counts[actionType] != int64(len(tt.inside)) {
+				t.Errorf("GetActionCountsByType(%s, %s) = %d, want %d", start, end, counts[actionType], len(tt.inside))
+			}
+
+			since, err := repo.GetActionCountSince(ctx, actionType, start)
+			if err != nil {
+				t.Fatalf("GetActionCountSince: %v", err)
+			}
+			if since != int64(len(tt.inside))+1 { // The day after is included
+				t.Errorf("GetActionCountSince(%s) = %d, want %d", start, since, len(tt.inside)+1)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+
+	"linkedin-automation/internal/core"
+
+	"gorm.io/gorm"
+)
+
+// CreateSearchQuery records a finished search and sets source_query_id on the profiles it
+// created. Queries made during a run are linked to it.
+func (r *Repository) CreateSearchQuery(ctx context.Context, query *core.SearchQuery, profileIDs []uint) error {
+	if query.RunID == nil {
+		if runID, ok := core.RunIDFromContext(ctx); ok {
+			query.RunID = &runID
+		}
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(query).Error; err != nil {
+			return err
+		}
+		if len(profileIDs) == 0 {
+			return nil
+		}
+		// UpdateColumn leaves updated_at alone: attributing a profile isn't a change to it
+		return tx.Model(&core.Profile{}).
+			Where("id IN ?", profileIDs).
+			UpdateColumn("source_query_id", query.ID).Error
+	})
+}
+
+// GetSearchQueryStats returns the limit most recent search queries with the connection
+// requests sent to the profiles each one found and how many of those were accepted
+func (r *Repository) GetSearchQueryStats(ctx context.Context, limit int) ([]*core.SearchQueryStats, error) {
+	if limit <= 0 {
+		limit = 20 // Default fallback
+	}
+
+	var stats []*core.SearchQueryStats
+	result := r.db.WithContext(ctx).
+		Table("search_queries").
+		Select(`search_queries.id AS query_id,
+			search_queries.keyword AS keyword,
+			search_queries.filters AS filters,
+			search_queries.created_at AS created_at,
+			search_queries.pages_crawled AS pages_crawled,
+			search_queries.results_found AS results_found,
+			search_queries.new_profiles AS new_profiles,
+			COALESCE(SUM(CASE WHEN profiles.status IN (?) THEN 1 ELSE 0 END), 0) AS requests_sent,
+			COALESCE(SUM(CASE WHEN profiles.status IN (?) THEN 1 ELSE 0 END), 0) AS accepted`,
+			requestedStatuses, acceptedStatuses,
+		).
+		Joins("LEFT JOIN profiles ON profiles.source_query_id = search_queries.id AND profiles.deleted_at IS NULL AND profiles.account = search_queries.account").
+		Where("search_queries.account = ?", r.account).
+		Group("search_queries.id").
+		Order("search_queries.id DESC").
+		Limit(limit).
+		Scan(&stats)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, s := range stats {
+		if s.RequestsSent > 0 {
+			s.AcceptanceRate = float64(s.Accepted) / float64(s.RequestsSent)
+		}
+	}
+
+	return stats, nil
+}
+
+// acceptedStatuses and requestedStatuses count acceptances in the stats queries
+var (
+	acceptedStatuses  = core.AcceptedStatuses
+	requestedStatuses = core.RequestedStatuses
+)
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"linkedin-automation/internal/core"
+)
+
+// The aggregates below compare times as epoch seconds rather than as stored values, since
+// SQLite stores times as text with the writer's UTC offset and those don't sort by instant.
+
+// GetDailyActionCounts counts the history entries between start and end per UTC day and
+// action type, ordered by day then action type
+func (r *Repository) GetDailyActionCounts(ctx context.Context, start, end time.Time) ([]core.DailyActionCount, error) {
+	var rows []struct {
+		Day        string
+		ActionType string
+		Count      int64
+	}
+	result := r.db.WithContext(ctx).Raw(`
+		SELECT `+r.utcDay("timestamp")+` AS day, action_type, COUNT(*) AS count
+		FROM histories
+		WHERE account = ? AND deleted_at IS NULL AND `+r.epochSeconds("timestamp")+` >= ? AND `+r.epochSeconds("timestamp")+` < ?
+		GROUP BY day, action_type
+		ORDER BY day, action_type`,
+		r.account, start.Unix(), end.Unix(),
+	).Scan(&rows)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	counts := make([]core.DailyActionCount, 0, len(rows))
+	for _, row := range rows {
+		day, err := time.Parse("2006-01-02", row.Day)
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, core.DailyActionCount{
+			Date:       day,
+			ActionType: row.ActionType,
+			Count:      row.Count,
+		})
+	}
+
+	return counts, nil
+}
+
+// GetStatusCounts counts the profiles in each status, ordered by status. Statuses
+// without profiles are omitted.
+func (r *Repository) GetStatusCounts(ctx context.Context) ([]core.StatusCount, error) {
+	counts := make([]core.StatusCount, 0)
+	result := r.db.WithContext(ctx).Model(&core.Profile{}).
+		Select("status, COUNT(*) AS count").
+		Group("status").
+		Order("status").
+		Scan(&counts)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return counts, nil
+}
+
+// GetAcceptanceStats counts the connection requests sent between start and end and how
+// many of the requested profiles connected before end
+func (r *Repository) GetAcceptanceStats(ctx context.Context, start, end time.Time) (*core.AcceptanceStats, error) {
+	var row struct {
+		RequestsSent       int64
+		Accepted           sql.NullInt64
+		AvgSecondsToAccept sql.NullFloat64
+	}
+	requested := r.epochSeconds("histories.timestamp")
+	connected := r.epochSeconds("profiles.connected_at")
+	accepted := "profiles.connected_at IS NOT NULL AND " + connected + " >= " + requested + " AND " + connected + " < ?"
+	result := r.db.WithContext(ctx).Raw(`
+		SELECT COUNT(*) AS requests_sent,
+			SUM(CASE WHEN `+accepted+` THEN 1 ELSE 0 END) AS accepted,
+			AVG(CASE WHEN `+accepted+`
+				THEN `+connected+` - `+requested+`
+			END) AS avg_seconds_to_accept
+		FROM histories
+		LEFT JOIN profiles ON `+r.connectedProfileJoin()+`
+		WHERE histories.account = ? AND histories.action_type = 'Connect' AND histories.deleted_at IS NULL
+			AND `+requested+` >= ? AND `+requested+` < ?`,
+		end.Unix(), end.Unix(), r.account, start.Unix(), end.Unix(),
+	).Scan(&row)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	stats := &core.AcceptanceStats{
+		RequestsSent: row.RequestsSent,
+		Accepted:     row.Accepted.Int64,
+	}
+	if stats.RequestsSent > 0 {
+		stats.AcceptanceRate = float64(stats.Accepted) / float64(stats.RequestsSent)
+	}
+	if row.AvgSecondsToAccept.Valid {
+		stats.AvgDaysToAccept = row.AvgSecondsToAccept.Float64 / 86400
+	}
+
+	return stats, nil
+}
+
+// GetTemplatePerformance counts the outgoing messages sent between start and end per
+// template, and how many of them were followed by an incoming message from the same
+// profile. Messages without a template name are omitted.
+func (r *Repository) GetTemplatePerformance(ctx context.Context, start, end time.Time) ([]core.TemplatePerformance, error) {
+	var rows []struct {
+		TemplateName string
+		Sent         int64
+		Replied      int64
+	}
+	sentAt := r.epochSeconds("sent.sent_at")
+	result := r.db.WithContext(ctx).Raw(`
+		SELECT sent.template_name AS template_name,
+			COUNT(*) AS sent,
+			SUM(CASE WHEN EXISTS (
+				SELECT 1 FROM messages replies
+				WHERE replies.profile_id = sent.profile_id
+					AND replies.direction = ?
+					AND `+r.epochSeconds("replies.sent_at")+` > `+sentAt+`
+			) THEN 1 ELSE 0 END) AS replied
+		FROM messages sent
+		WHERE sent.account = ? AND sent.direction = ? AND sent.template_name <> ''
+			AND `+sentAt+` >= ? AND `+sentAt+` < ?
+		GROUP BY sent.template_name
+		ORDER BY sent.template_name`,
+		core.MessageDirectionIn, r.account, core.MessageDirectionOut, start.Unix(), end.Unix(),
+	).Scan(&rows)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	performance := make([]core.TemplatePerformance, 0, len(rows))
+	for _, row := range rows {
+		template := core.TemplatePerformance{
+			TemplateName: row.TemplateName,
+			Sent:         row.Sent,
+			Replied:      row.Replied,
+		}
+		if template.Sent > 0 {
+			template.ReplyRate = float64(template.Replied) / float64(template.Sent)
+		}
+		performance = append(performance, template)
+	}
+
+	return performance, nil
+}
@@ -0,0 +1,293 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"linkedin-automation/internal/core"
+)
+
+// newYork is a zone whose midnight is not UTC midnight. Times written in it are stored
+// with their -05:00 offset.
+func newYork(t *testing.T) *time.Location {
+	t.Helper()
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data not available: %v", err)
+	}
+	return location
+}
+
+// createHistoryAt records history as taken at timestamp
+func createHistoryAt(t *testing.T, repo *Repository, history *core.History, timestamp time.Time) {
+	t.Helper()
+	history.Timestamp = timestamp
+	if err := repo.CreateHistory(context.Background(), history); err != nil {
+		t.Fatalf("CreateHistory: %v", err)
+	}
+}
+
+func TestGetDailyActionCounts(t *testing.T) {
+	repo := newTestRepository(t, core.DatabaseConfig{})
+	ny := newYork(t)
+	url := "https://www.linkedin.com/in/someone/"
+
+	// Written in New York time on both sides of local midnight; grouped by UTC day
+	createHistoryAt(t, repo, core.NewConnectHistory(url), time.Date(2024, time.March, 3, 18, 0, 0, 0, ny))  // 23:00 UTC, March 3
+	createHistoryAt(t, repo, core.NewConnectHistory(url), time.Date(2024, time.March, 3, 23, 30, 0, 0, ny)) // 04:30 UTC, March 4
+	createHistoryAt(t, repo, core.NewConnectHistory(url), time.Date(2024, time.March, 4, 0, 30, 0, 0, ny))  // 05:30 UTC, March 4
+	createHistoryAt(t, repo, core.NewMessageHistory(url, "intro"), time.Date(2024, time.March, 4, 0, 30, 0, 0, ny))
+	// Either side of UTC midnight
+	createHistoryAt(t, repo, core.NewMessageHistory(url, "intro"), time.Date(2024, time.March, 4, 23, 59, 59, 0, time.UTC))
+	createHistoryAt(t, repo, core.NewMessageHistory(url, "intro"), time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC))
+	// Outside the range
+	createHistoryAt(t, repo, core.NewConnectHistory(url), time.Date(2024, time.March, 2, 23, 59, 59, 0, time.UTC))
+	createHistoryAt(t, repo, core.NewConnectHistory(url), time.Date(2024, time.March, 6, 0, 0, 0, 0, time.UTC))
+
+	start := time.Date(2024, time.March, 3, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, time.March, 6, 0, 0, 0, 0, time.UTC)
+	counts, err := repo.GetDailyActionCounts(context.Background(), start, end)
+	if err != nil {
+		t.Fatalf("GetDailyActionCounts: %v", err)
+	}
+
+	day := func(d int) time.Time { return time.Date(2024, time.March, d, 0, 0, 0, 0, time.UTC) }
+	want := []core.DailyActionCount{
+		{Date: day(3), ActionType: "Connect", Count: 1},
+		{Date: day(4), ActionType: "Connect", Count: 2},
+		{Date: day(4), ActionType: "Message", Count: 2},
+		{Date: day(5), ActionType: "Message", Count: 1},
+	}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("GetDailyActionCounts = %v, want %v", counts, want)
+	}
+}
+
+func TestGetStatusCounts(t *testing.T) {
+	repo := newTestRepository(t, core.DatabaseConfig{})
+	ctx := context.Background()
+
+	for url, status := range map[string]core.ProfileStatus{
+		"https://www.linkedin.com/in/a/": core.ProfileStatusDiscovered,
+		"https://www.linkedin.com/in/b/": core.ProfileStatusRequestSent,
+		"https://www.linkedin.com/in/c/": core.ProfileStatusRequestSent,
+		"https://www.linkedin.com/in/d/": core.ProfileStatusConnected,
+	} {
+		if err := repo.CreateProfile(ctx, &core.Profile{LinkedInURL: url, Status: status}); err != nil {
+			t.Fatalf("CreateProfile: %v", err)
+		}
+	}
+
+	counts, err := repo.GetStatusCounts(ctx)
+	if err != nil {
+		t.Fatalf("GetStatusCounts: %v", err)
+	}
+	want := []core.StatusCount{
+		{Status: core.ProfileStatusConnected, Count: 1},
+		{Status: core.ProfileStatusDiscovered, Count: 1},
+		{Status: core.ProfileStatusRequestSent, Count: 2},
+	}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("GetStatusCounts = %v, want %v", counts, want)
+	}
+}
+
+func TestGetAcceptanceStats(t *testing.T) {
+	repo := newTestRepository(t, core.DatabaseConfig{})
+	ny := newYork(t)
+	ctx := context.Background()
+
+	// The range is one New York day; its midnights are 05:00 UTC
+	start := time.Date(2024, time.March, 4, 0, 0, 0, 0, ny)
+	end := start.AddDate(0, 0, 1)
+
+	requests := []struct {
+		slug        string
+		requestedAt time.Time
+		connectedAt time.Time // Zero when not accepted
+	}{
+		{"accepted-same-day", start.Add(time.Hour), start.Add(7 * time.Hour)},
+		{"accepted-before-midnight", end.Add(-2 * time.Hour), end.Add(-time.Second)},
+		{"accepted-after-range", start.Add(2 * time.Hour), end.Add(time.Hour)},
+		{"pending", start.Add(3 * time.Hour), time.Time{}},
+		{"before-range", start.Add(-time.Minute), start.Add(time.Hour)},
+		{"after-range", end, end.Add(time.Hour)},
+	}
+	for _, request := range requests {
+		url := "https://www.linkedin.com/in/" + request.slug + "/"
+		if err := repo.CreateProfile(ctx, &core.Profile{LinkedInURL: url, Status: core.ProfileStatusRequestSent}); err != nil {
+			t.Fatalf("CreateProfile: %v", err)
+		}
+		createHistoryAt(t, repo, core.NewConnectHistory(url), request.requestedAt)
+		if !request.connectedAt.IsZero() {
+			if err := repo.MarkAsConnectedAt(ctx, url, request.connectedAt); err != nil {
+				t.Fatalf("MarkAsConnectedAt: %v", err)
+			}
+		}
+	}
+
+	stats, err := repo.GetAcceptanceStats(ctx, start, end)
+	if err != nil {
+		t.Fatalf("GetAcceptanceStats: %v", err)
+	}
+	if stats.RequestsSent != 4 || stats.Accepted != 2 {
+		t.Fatalf("GetAcceptanceStats = %+v, want 4 sent and 2 accepted", stats)
+	}
+	if stats.AcceptanceRate != 0.5 {
+		t.Errorf("AcceptanceRate = %v, want 0.5", stats.AcceptanceRate)
+	}
+	// Six hours and two hours less a second
+	wantDays := (6*3600 + 2*3600 - 1) / 2.0 / 86400
+	if math.Abs(stats.AvgDaysToAccept-wantDays) > 1e-9 {
+		t.Errorf("AvgDaysToAccept = %v, want %v", stats.AvgDaysToAccept, wantDays)
+	}
+
+	empty, err := repo.GetAcceptanceStats(ctx, end.AddDate(0, 0, 1), end.AddDate(0, 0, 2))
+	if err != nil {
+		t.Fatalf("GetAcceptanceStats: %v", err)
+	}
+	if *empty != (core.AcceptanceStats{}) {
+		t.Errorf("GetAcceptanceStats of an empty range = %+v, want zeros", empty)
+	}
+}
+
+func TestGetTemplatePerformance(t *testing.T) {
+	repo := newTestRepository(t, core.DatabaseConfig{})
+	ny := newYork(t)
+	ctx := context.Background()
+
+	start := time.Date(2024, time.March, 4, 0, 0, 0, 0, ny)
+	end := start.AddDate(0, 0, 1)
+
+	profileIDs := make(map[string]uint)
+	for _, slug := range []string{"a", "b", "c", "d"} {
+		profile := &core.Profile{LinkedInURL: "https://www.linkedin.com/in/" + slug + "/", Status: core.ProfileStatusConnected}
+		if err := repo.CreateProfile(ctx, profile); err != nil {
+			t.Fatalf("CreateProfile: %v", err)
+		}
+		profileIDs[slug] = profile.ID
+	}
+
+	messages := []core.Message{
+		{ProfileID: profileIDs["a"], Direction: core.MessageDirectionOut, TemplateName: "intro", SentAt: start.Add(time.Hour)},
+		{ProfileID: profileIDs["a"], Direction: core.MessageDirectionIn, SentAt: end.Add(time.Hour)}, // Replies after the range count
+		{ProfileID: profileIDs["b"], Direction: core.MessageDirectionOut, TemplateName: "intro", SentAt: end.Add(-time.Second)},
+		{ProfileID: profileIDs["c"], Direction: core.MessageDirectionIn, SentAt: start.Add(time.Hour)}, // Before the message, not a reply
+		{ProfileID: profileIDs["c"], Direction: core.MessageDirectionOut, TemplateName: "question", SentAt: start.Add(2 * time.Hour)},
+		{ProfileID: profileIDs["d"], Direction: core.MessageDirectionOut, TemplateName: "intro", SentAt: start.Add(-time.Second)}, // Before the range
+		{ProfileID: profileIDs["d"], Direction: core.MessageDirectionOut, SentAt: start.Add(time.Hour)},                           // No template
+	}
+	for i := range messages {
+		messages[i].Body = "message"
+		if err := repo.CreateMessage(ctx, &messages[i]); err != nil {
+			t.Fatalf("CreateMessage: %v", err)
+		}
+	}
+
+	performance, err := repo.GetTemplatePerformance(ctx, start, end)
+	if err != nil {
+		t.Fatalf("GetTemplatePerformance: %v", err)
+	}
+	want := []core.TemplatePerformance{
+		{TemplateName: "intro", Sent: 2, Replied: 1, ReplyRate: 0.5},
+		{TemplateName: "question", Sent: 1, Replied: 0, ReplyRate: 0},
+	}
+	if !reflect.DeepEqual(performance, want) {
+		t.Errorf("GetTemplatePerformance = %+v, want %+v", performance, want)
+	}
+}
+
+func TestRangeFiltersAcrossOffsets(t *testing.T) {
+	repo := newTestRepository(t, core.DatabaseConfig{})
+	ny := newYork(t)
+	ctx := context.Background()
+	url := "https://www.linkedin.com/in/someone/"
+
+	// A range given in UTC over rows stored with New York's -05:00 offset
+	start := time.Date(2024, time.March, 4, 5, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+	// Compared as text, the two inside rows sort before start and the one at end before end
+	for _, at := range []time.Time{start.Add(-time.Second), start, start.Add(time.Hour), end} {
+		createHistoryAt(t, repo, core.NewConnectHistory(url), at.In(ny))
+	}
+	const want = 2
+
+	counts, err := repo.GetActionCountsByType(ctx, start, end)
+	if err != nil {
+		t.Fatalf("GetActionCountsByType: %v", err)
+	}
+	if counts["Connect"] != want {
+		t.Errorf("GetActionCountsByType = %d, want %d", counts["Connect"], want)
+	}
+
+	buckets, err := repo.GetActionStats(ctx, "Connect", start, end, time.Hour)
+	if err != nil {
+		t.Fatalf("GetActionStats: %v", err)
+	}
+	var bucketed int64
+	for _, bucket := range buckets {
+		bucketed += bucket.SuccessCount
+	}
+	if bucketed != want {
+		t.Errorf("GetActionStats counted %d, want %d", bucketed, want)
+	}
+
+	rates, err := repo.GetAcceptanceRateByDay(ctx, start, end)
+	if err != nil {
+		t.Fatalf("GetAcceptanceRateByDay: %v", err)
+	}
+	var sent int64
+	for _, rate := range rates {
+		sent += rate.RequestsSent
+	}
+	if sent != want {
+		t.Errorf("GetAcceptanceRateByDay counted %d requests, want %d", sent, want)
+	}
+
+	histories, total, err := repo.QueryHistory(ctx, core.HistoryQuery{Start: &start, End: &end})
+	if err != nil {
+		t.Fatalf("QueryHistory: %v", err)
+	}
+	if len(histories) != want || total != want {
+		t.Errorf("QueryHistory = %d rows of %d, want %d", len(histories), total, want)
+	}
+
+	// GetHistoryByDateRange includes its end
+	histories, err = repo.GetHistoryByDateRange(ctx, start, end.Add(-time.Second))
+	if err != nil {
+		t.Fatalf("GetHistoryByDateRange: %v", err)
+	}
+	if len(histories) != want {
+		t.Errorf("GetHistoryByDateRange = %d rows, want %d", len(histories), want)
+	}
+}
+
+func TestGetOpenToWorkRate(t *testing.T) {
+	repo := newTestRepository(t, core.DatabaseConfig{})
+	ctx := context.Background()
+
+	checked := map[string]bool{"open": true, "closed-1": false, "closed-2": false, "open-2": true}
+	for _, slug := range []string{"open", "closed-1", "closed-2", "open-2", "never-checked-1", "never-checked-2"} {
+		url := "https://www.linkedin.com/in/" + slug + "/"
+		if err := repo.CreateProfile(ctx, &core.Profile{LinkedInURL: url, Status: core.ProfileStatusDiscovered}); err != nil {
+			t.Fatalf("CreateProfile: %v", err)
+		}
+		if openToWork, ok := checked[slug]; ok {
+			if err := repo.UpdateProfileOpenToWork(ctx, url, openToWork); err != nil {
+				t.Fatalf("UpdateProfileOpenToWork: %v", err)
+			}
+		}
+	}
+
+	rate, err := repo.GetOpenToWorkRate(ctx)
+	if err != nil {
+		t.Fatalf("GetOpenToWorkRate: %v", err)
+	}
+	want := core.OpenToWorkRate{Checked: 4, OpenToWork: 2, Rate: 0.5}
+	if *rate != want {
+		t.Errorf("GetOpenToWorkRate = %+v, want %+v", *rate, want)
+	}
+}
@@ -0,0 +1,165 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// arithmeticPattern matches simple two-operand challenges such as "What is 4+7?"
+var arithmeticPattern = regexp.MustCompile(`(\d+)\s*([\+\-\*\/])\s*(\d+)`)
+
+// questionPattern guards against reading dates or phone numbers in a dialog as arithmetic
+var questionPattern = regexp.MustCompile(`(?i)(what is|what's|solve|calculate|=|\?)`)
+
+// codePattern matches challenges that show the code to type, such as "Enter the code: 4821".
+// The colon is required so prose like "Enter the code we sent" isn't read as a code.
+var codePattern = regexp.MustCompile(`(?i)enter the code:\s*([A-Za-z0-9]{4,8})\b`)
+
+// challengeSelectors are the containers a text challenge is shown in
+var challengeSelectors = []string{
+	"#captcha-internal",
+	".challenge-dialog",
+	"form#email-pin-challenge",
+	"[role='dialog']",
+}
+
+// answerSelectors are the inputs a challenge answer is typed into
+var answerSelectors = []string{
+	"#captcha-internal input[type='text']",
+	".challenge-dialog input[type='text']",
+	"input[name='pin']",
+	"[role='dialog'] input[type='text']",
+}
+
+// submitSelectors are the buttons that submit a challenge answer
+var submitSelectors = []string{
+	"#captcha-internal button[type='submit']",
+	".challenge-dialog button[type='submit']",
+	"#email-pin-submit-button",
+	"[role='dialog'] button[type='submit']",
+}
+
+// TextCaptchaSolver answers security challenges that only need text, never visual ones
+type TextCaptchaSolver struct {
+	config *core.SecurityConfig
+	logger *zap.Logger
+}
+
+// NewTextCaptchaSolver creates a new text challenge solver
+func NewTextCaptchaSolver(config *core.SecurityConfig, logger *zap.Logger) *TextCaptchaSolver {
+	return &TextCaptchaSolver{
+		config: config,
+		logger: logger,
+	}
+}
+
+// TrySolve reads the visible challenge and, if it is a simple arithmetic question or
+// shows the code to enter, types the answer and submits it. It returns (true, nil) when
+// a challenge was answered, (false, nil) when there is no text challenge (or solving is
+// disabled), and (false, err) when answering failed.
+func (s *TextCaptchaSolver) TrySolve(ctx context.Context, browser core.BrowserPort) (bool, error) {
+	if !s.config.SolveTextChallenges {
+		return false, nil
+	}
+
+	text := ""
+	for _, selector := range challengeSelectors {
+		if visible, _ := browser.IsElementVisible(ctx, selector); !visible {
+			continue
+		}
+		content, err := browser.GetText(ctx, selector)
+		if err == nil && strings.TrimSpace(content) != "" {
+			text = content
+			break
+		}
+	}
+	if text == "" {
+		return false, nil
+	}
+
+	answer, err := solveText(text)
+	if err != nil {
+		return false, err
+	}
+	if answer == "" {
+		s.logger.Debug("Challenge is not a text challenge", zap.String("text", text))
+		return false, nil
+	}
+
+	inputSelector := ""
+	for _, selector := range answerSelectors {
+		if visible, _ := browser.IsElementVisible(ctx, selector); visible {
+			inputSelector = selector
+			break
+		}
+	}
+	if inputSelector == "" {
+		return false, fmt.Errorf("challenge answer field not found")
+	}
+
+	if err := browser.HumanClick(ctx, inputSelector); err != nil {
+		return false, fmt.Errorf("failed to focus challenge answer field: %w", err)
+	}
+	if err := browser.HumanType(ctx, inputSelector, answer); err != nil {
+		return false, fmt.Errorf("failed to type challenge answer: %w", err)
+	}
+	browser.RandomSleep(ctx, 0.8, 1.6)
+
+	for _, selector := range submitSelectors {
+		if visible, _ := browser.IsElementVisible(ctx, selector); visible {
+			if err := browser.HumanClick(ctx, selector); err != nil {
+				return false, fmt.Errorf("failed to submit challenge answer: %w", err)
+			}
+			break
+		}
+	}
+
+	s.logger.Info("Answered text challenge")
+	return true, nil
+}
+
+// solveText returns the answer to a text challenge, or "" if the text is not one.
+// "Enter the code" challenges take precedence, since codes are made of digits too.
+func solveText(text string) (string, error) {
+	if match := codePattern.FindStringSubmatch(text); match != nil {
+		return match[1], nil
+	}
+
+	match := arithmeticPattern.FindStringSubmatch(text)
+	if match == nil || !questionPattern.MatchString(text) {
+		return "", nil
+	}
+
+	a, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid challenge operand %q: %w", match[1], err)
+	}
+	b, err := strconv.ParseInt(match[3], 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid challenge operand %q: %w", match[3], err)
+	}
+
+	var result int64
+	switch match[2] {
+	case "+":
+		result = a + b
+	case "-":
+		result = a - b
+	case "*":
+		result = a * b
+	case "/":
+		if b == 0 || a%b != 0 {
+			return "", fmt.Errorf("challenge %s has no whole-number answer", match[0])
+		}
+		result = a / b
+	}
+
+	return strconv.FormatInt(result, 10), nil
+}
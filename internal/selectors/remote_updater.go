@@ -0,0 +1,149 @@
+package selectors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// maxRemoteSize caps the downloaded selectors document
+const maxRemoteSize = 1 << 20
+
+// updaterSettings are SelectorsConfig fields that configure the updater itself and are
+// never taken from the remote document
+var updaterSettings = map[string]bool{
+	"version":         true,
+	"remote_url":      true,
+	"auto_update":     true,
+	"default_timeout": true,
+	"quick_timeout":   true,
+	"timeouts":        true,
+}
+
+// remoteSelectors is the community-maintained selectors document. Selector keys match
+// the keys of the selectors section in config.yaml.
+type remoteSelectors struct {
+	Version   int                        `json:"version"`
+	Selectors map[string]json.RawMessage `json:"selectors"`
+}
+
+// RemoteUpdater merges newer selectors from a community-maintained JSON file so that
+// LinkedIn DOM changes don't require every user to edit their config by hand
+type RemoteUpdater struct {
+	url      string
+	defaults *core.SelectorsConfig
+	client   *http.Client
+	logger   *zap.Logger
+}
+
+// NewRemoteUpdater creates an updater for the document at url. Selectors that differ
+// from defaults are treated as user overrides and never replaced.
+func NewRemoteUpdater(url string, defaults *core.SelectorsConfig, logger *zap.Logger) *RemoteUpdater {
+	return &RemoteUpdater{
+		url:      url,
+		defaults: defaults,
+		client:   &http.Client{Timeout: 15 * time.Second},
+		logger:   logger,
+	}
+}
+
+// CheckAndUpdate downloads the remote selectors and, if their version is newer than
+// currentConfig.Version, returns a copy of currentConfig with the remote selectors merged
+// in field by field. The bool reports whether anything changed.
+func (u *RemoteUpdater) CheckAndUpdate(ctx context.Context, currentConfig *core.SelectorsConfig) (*core.SelectorsConfig, bool, error) {
+	remote, err := u.fetch(ctx)
+	if err != nil {
+		return currentConfig, false, err
+	}
+
+	if remote.Version <= currentConfig.Version {
+		u.logger.Info("Selectors are up to date",
+			zap.Int("version", currentConfig.Version),
+			zap.Int("remote_version", remote.Version),
+		)
+		return currentConfig, false, nil
+	}
+
+	updated := *currentConfig
+	current := reflect.ValueOf(&updated).Elem()
+	defaults := reflect.ValueOf(u.defaults).Elem()
+	fields := current.Type()
+
+	changed := make([]string, 0)
+	for i := 0; i < fields.NumField(); i++ {
+		key := fields.Field(i).Tag.Get("mapstructure")
+		if updaterSettings[key] {
+			continue
+		}
+		raw, ok := remote.Selectors[key]
+		if !ok {
+			continue
+		}
+
+		field := current.Field(i)
+		if !reflect.DeepEqual(field.Interface(), defaults.Field(i).Interface()) {
+			u.logger.Debug("Keeping user-overridden selector", zap.String("selector", key))
+			continue
+		}
+
+		value := reflect.New(field.Type())
+		if err := json.Unmarshal(raw, value.Interface()); err != nil {
+			u.logger.Warn("Ignoring invalid remote selector", zap.String("selector", key), zap.Error(err))
+			continue
+		}
+		if reflect.DeepEqual(field.Interface(), value.Elem().Interface()) {
+			continue
+		}
+
+		field.Set(value.Elem())
+		changed = append(changed, key)
+	}
+
+	updated.Version = remote.Version
+
+	u.logger.Info("Selectors updated from remote",
+		zap.Int("from_version", currentConfig.Version),
+		zap.Int("to_version", remote.Version),
+		zap.Strings("updated", changed),
+	)
+
+	return &updated, len(changed) > 0, nil
+}
+
+// fetch downloads and parses the remote selectors document
+func (u *RemoteUpdater) fetch(ctx context.Context) (*remoteSelectors, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create selectors request: %w", err)
+	}
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download selectors: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download selectors: unexpected status %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read selectors: %w", err)
+	}
+
+	var remote remoteSelectors
+	if err := json.Unmarshal(data, &remote); err != nil {
+		return nil, fmt.Errorf("failed to parse selectors: %w", err)
+	}
+
+	return &remote, nil
+}
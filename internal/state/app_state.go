@@ -0,0 +1,104 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// AppState holds the in-progress run state persisted between crashes
+type AppState struct {
+	RunID                string    `json:"run_id"`
+	CurrentCampaignID    uint      `json:"current_campaign_id"`
+	ProfilesProcessed    int       `json:"profiles_processed"`
+	LastProfileURL       string    `json:"last_profile_url"`
+	ProfileURLs          []string  `json:"profile_urls"`                     // Search results being worked through
+	RemainingProfileURLs []string  `json:"remaining_profile_urls,omitempty"` // Not processed when the session hit its maximum duration
+	StartedAt            time.Time `json:"started_at"`
+}
+
+// StateManager persists AppState to a JSON file
+type StateManager struct {
+	path string
+}
+
+// NewStateManager creates a new state manager writing to the given path
+func NewStateManager(path string) *StateManager {
+	return &StateManager{path: path}
+}
+
+// Save writes the state atomically (temp file + rename) so a crash mid-write
+// never leaves a truncated state file behind
+func (m *StateManager) Save(ctx context.Context, state *AppState) error {
+	if state == nil {
+		return fmt.Errorf("state cannot be nil")
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	dir := filepath.Dir(m.path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(m.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp state file: %w", err)
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write temp state file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to close temp state file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, m.path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace state file: %w", err)
+	}
+
+	return nil
+}
+
+// Load reads the saved state. It returns nil (without error) if no state file exists.
+func (m *StateManager) Load() (*AppState, error) {
+	data, err := os.ReadFile(m.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	var state AppState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal state: %w", err)
+	}
+
+	return &state, nil
+}
+
+// Clear removes the saved state, called after a clean exit
+func (m *StateManager) Clear() error {
+	if err := os.Remove(m.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove state file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package stealth
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// IdleDrifter moves the mouse slightly while a page is being read, the way a resting
+// hand does, instead of leaving it perfectly still between actions
+type IdleDrifter struct {
+	config *core.IdleMouseDriftConfig
+	rng    *rand.Rand
+}
+
+// NewIdleDrifter creates a new idle drifter with the given configuration. A non-zero
+// seed makes the drift reproducible.
+func NewIdleDrifter(config *core.IdleMouseDriftConfig, seed int64) *IdleDrifter {
+	return &IdleDrifter{
+		config: config,
+		rng:    newRand(seed),
+	}
+}
+
+// Drift moves the mouse in a Brownian walk: each step adds a small Gaussian displacement
+// to the current position, kept within DriftRadius pixels of the starting point. Steps
+// are 50-100ms apart. It returns the final position, which the caller should track.
+func (d *IdleDrifter) Drift(ctx context.Context, page *rod.Page, currentX, currentY float64) (newX, newY float64, err error) {
+	if !d.config.Enabled || page == nil {
+		return currentX, currentY, nil
+	}
+
+	radius := d.config.DriftRadius
+	if radius <= 0 {
+		radius = 15 // Default fallback
+	}
+	steps := d.config.DriftSteps
+	if steps <= 0 {
+		steps = 8 // Default fallback
+	}
+
+	// Scale the step size so a full walk tends to cover about half the radius
+	sigma := radius / 2 / math.Sqrt(float64(steps))
+
+	x, y := currentX, currentY
+	for i := 0; i < steps; i++ {
+		select {
+		case <-ctx.Done():
+			return x, y, ctx.Err()
+		case <-time.After(time.Duration(50+d.rng.Intn(51)) * time.Millisecond):
+		}
+
+		nextX := x + d.rng.NormFloat64()*sigma
+		nextY := y + d.rng.NormFloat64()*sigma
+
+		// Pull the step back onto the circle if it would wander past the radius
+		dx, dy := nextX-currentX, nextY-currentY
+		if dist := math.Hypot(dx, dy); dist > radius {
+			nextX = currentX + dx/dist*radius
+			nextY = currentY + dy/dist*radius
+		}
+		nextX = math.Max(0, nextX)
+		nextY = math.Max(0, nextY)
+
+		err := proto.InputDispatchMouseEvent{
+			Type: proto.InputDispatchMouseEventTypeMouseMoved,
+			X:    nextX,
+			Y:    nextY,
+		}.Call(page)
+		if err != nil {
+			return x, y, err
+		}
+		x, y = nextX, nextY
+	}
+
+	return x, y, nil
+}
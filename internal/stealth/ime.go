@@ -0,0 +1,43 @@
+package stealth
+
+import (
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// deadKeys maps combining marks to the spacing accent a dead key shows while it waits
+// for the letter, e.g. the acute accent of "é"
+var deadKeys = map[rune]rune{
+	'\u0300': '`', // grave: à, è
+	'\u0301': '´', // acute: é, á
+	'\u0302': '^', // circumflex: ê, î
+	'\u0303': '~', // tilde: ñ, ã
+	'\u0308': '¨', // diaeresis: ü, ö
+	'\u030a': '˚', // ring: å
+	'\u030c': 'ˇ', // caron: č, š
+	'\u0327': '¸', // cedilla: ç
+}
+
+// IsASCII reports whether text has only ASCII characters, which need no IME composition
+func IsASCII(text string) bool {
+	for _, r := range text {
+		if r > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
+
+// CompositionSteps returns the composition texts an input method shows while r is
+// typed, before it is committed. A letter with one accent from a dead key, like "é",
+// shows the accent and then the letter; other characters show only themselves.
+func CompositionSteps(r rune) []string {
+	decomposed := []rune(norm.NFD.String(string(r)))
+	if len(decomposed) == 2 {
+		if accent, ok := deadKeys[decomposed[1]]; ok {
+			return []string{string(accent), string(r)}
+		}
+	}
+	return []string{string(r)}
+}
@@ -12,13 +12,22 @@ type Jitter struct {
 	rng *rand.Rand
 }
 
-// NewJitter creates a new Jitter instance
-func NewJitter() *Jitter {
+// NewJitter creates a new Jitter instance. A non-zero seed makes its timings
+// reproducible (see StealthConfig.RandomSeed).
+func NewJitter(seed int64) *Jitter {
 	return &Jitter{
-		rng: rand.New(rand.NewSource(time.Now().UnixNano())),
+		rng: newRand(seed),
 	}
 }
 
+// newRand returns a random source seeded with seed, or from the clock when seed is 0
+func newRand(seed int64) *rand.Rand {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return rand.New(rand.NewSource(seed))
+}
+
 // RandomSleep sleeps for a randomized duration
 // baseSeconds: base delay in seconds
 // varianceSeconds: maximum variance to add/subtract (±varianceSeconds)
@@ -60,6 +69,19 @@ func (j *Jitter) RandomSleep(ctx context.Context, baseSeconds, varianceSeconds f
 
 // RandomSleepRange sleeps for a random duration between min and max seconds
 func (j *Jitter) RandomSleepRange(ctx context.Context, minSeconds, maxSeconds float64) {
+	duration := j.RandomDuration(minSeconds, maxSeconds)
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(duration):
+		return
+	}
+}
+
+// RandomDuration samples the duration RandomSleepRange would sleep for, for callers that
+// wait themselves (e.g. to log the delay or stop early on cancellation)
+func (j *Jitter) RandomDuration(minSeconds, maxSeconds float64) time.Duration {
 	if minSeconds < 0 {
 		minSeconds = 0
 	}
@@ -74,14 +96,7 @@ func (j *Jitter) RandomSleepRange(ctx context.Context, minSeconds, maxSeconds fl
 	fractionalJitter := j.rng.Float64() * 0.0001
 	randomSeconds += fractionalJitter
 	
-	duration := time.Duration(randomSeconds * float64(time.Second))
-
-	select {
-	case <-ctx.Done():
-		return
-	case <-time.After(duration):
-		return
-	}
+	return time.Duration(randomSeconds * float64(time.Second))
 }
 
 // RandomInt returns a random integer between min and max (inclusive)
@@ -107,6 +122,19 @@ func (j *Jitter) RandomFloat(min, max float64) float64 {
 // GaussianDelay returns a delay sampled from a Gaussian (normal) distribution
 // Useful for more natural timing patterns
 func (j *Jitter) GaussianDelay(ctx context.Context, meanSeconds, stdDevSeconds float64) {
+	duration := j.GaussianDuration(meanSeconds, stdDevSeconds)
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(duration):
+		return
+	}
+}
+
+// GaussianDuration samples the duration GaussianDelay would sleep for, for callers that
+// schedule the delay themselves (e.g. as a typing action)
+func (j *Jitter) GaussianDuration(meanSeconds, stdDevSeconds float64) time.Duration {
 	if meanSeconds < 0 {
 		meanSeconds = 0
 	}
@@ -132,13 +160,5 @@ func (j *Jitter) GaussianDelay(ctx context.Context, meanSeconds, stdDevSeconds f
 	fractionalJitter := j.rng.Float64() * 0.0001
 	delaySeconds += fractionalJitter
 	
-	duration := time.Duration(delaySeconds * float64(time.Second))
-
-	select {
-	case <-ctx.Done():
-		return
-	case <-time.After(duration):
-		return
-	}
+	return time.Duration(delaySeconds * float64(time.Second))
 }
-
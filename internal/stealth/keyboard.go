@@ -4,25 +4,37 @@ import (
 	"context"
 	"math/rand"
 	"time"
+
+	"linkedin-automation/internal/core"
 )
 
 // Keyboard implements human-like typing with variable speed and typos
 type Keyboard struct {
-	rng *rand.Rand
+	rng           *rand.Rand
+	jitter        *Jitter
+	pausePatterns []core.PausePattern
 }
 
-// NewKeyboard creates a new Keyboard instance
-func NewKeyboard() *Keyboard {
+// NewKeyboard creates a new Keyboard instance. A non-zero seed makes its typing
+// actions reproducible.
+func NewKeyboard(seed int64) *Keyboard {
 	return &Keyboard{
-		rng: rand.New(rand.NewSource(time.Now().UnixNano())),
+		rng:    newRand(seed),
+		jitter: NewJitter(seed),
 	}
 }
 
+// SetPausePatterns sets the thought pauses inserted between words while typing
+func (k *Keyboard) SetPausePatterns(patterns []core.PausePattern) {
+	k.pausePatterns = patterns
+}
+
 // HumanType simulates human typing with:
 // - Variable WPM (words per minute)
 // - Occasional typos (with probability typoProb)
 // - Backspace and correction after typos
 // - Natural delays between keystrokes
+// - Thought pauses every few words (see SetPausePatterns)
 func (k *Keyboard) HumanType(ctx context.Context, text string, wpmMin, wpmMax int, typoProb float64) ([]KeyAction, error) {
 	if wpmMin < 1 {
 		wpmMin = 1
@@ -47,6 +59,13 @@ func (k *Keyboard) HumanType(ctx context.Context, text string, wpmMin, wpmMax in
 	wpm := wpmMin + k.rng.Intn(wpmMax-wpmMin+1)
 	baseDelayPerChar := (60.0 / float64(wpm)) / 6.0 // seconds per character
 
+	// Words typed so far, and the word count at which each pause pattern fires next
+	wordsTyped := 0
+	nextPause := make([]int, len(k.pausePatterns))
+	for p, pattern := range k.pausePatterns {
+		nextPause[p] = k.pauseInterval(pattern)
+	}
+
 	i := 0
 	for i < len(textRunes) {
 		// Check context cancellation
@@ -100,12 +119,59 @@ func (k *Keyboard) HumanType(ctx context.Context, text string, wpmMin, wpmMax in
 			})
 		}
 		
+		// A word ends at the first space after a non-space character
+		if char == ' ' && i > 0 && textRunes[i-1] != ' ' {
+			wordsTyped++
+			for p, pattern := range k.pausePatterns {
+				if nextPause[p] <= 0 || wordsTyped < nextPause[p] {
+					continue
+				}
+				actions = append(actions, KeyAction{
+					Type:  ActionTypeDelay,
+					Delay: k.pauseDuration(pattern),
+				})
+				nextPause[p] = wordsTyped + k.pauseInterval(pattern)
+			}
+		}
+
 		i++
 	}
 
 	return actions, nil
 }
 
+// pauseInterval returns the number of words until the pattern's next pause: AfterWord
+// varied by up to 25% either way, so pauses don't land on an exact word count.
+// It returns 0 for disabled patterns.
+func (k *Keyboard) pauseInterval(pattern core.PausePattern) int {
+	if pattern.AfterWord <= 0 {
+		return 0
+	}
+	spread := pattern.AfterWord / 4
+	if spread == 0 {
+		return pattern.AfterWord
+	}
+	return pattern.AfterWord - spread + k.rng.Intn(2*spread+1)
+}
+
+// pauseDuration samples a pause from a Gaussian centred in the pattern's range,
+// clamped to the range
+func (k *Keyboard) pauseDuration(pattern core.PausePattern) time.Duration {
+	minSeconds, maxSeconds := pattern.Duration[0], pattern.Duration[1]
+	if maxSeconds < minSeconds {
+		maxSeconds = minSeconds
+	}
+
+	delay := k.jitter.GaussianDuration((minSeconds+maxSeconds)/2, (maxSeconds-minSeconds)/4)
+	if lower := time.Duration(minSeconds * float64(time.Second)); delay < lower {
+		delay = lower
+	}
+	if upper := time.Duration(maxSeconds * float64(time.Second)); delay > upper {
+		delay = upper
+	}
+	return delay
+}
+
 // KeyAction represents a single keyboard action
 type KeyAction struct {
 	Type  ActionType      // Type of action
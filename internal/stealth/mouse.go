@@ -4,7 +4,6 @@ import (
 	"context"
 	"math"
 	"math/rand"
-	"time"
 )
 
 // Constants for mouse movement physics
@@ -34,13 +33,14 @@ type MouseConfig struct {
 	ControlPointOffsetMax float64 // Max control point offset
 	ControlPointSpreadMin float64 // Min control point spread
 	ControlPointSpreadMax float64 // Max control point spread
+	Seed                  int64   // Random seed for reproducible paths (0 = seed from the clock)
 }
 
 // NewMouse creates a new Mouse instance
 func NewMouse(config *MouseConfig) *Mouse {
 	return &Mouse{
 		config: config,
-		rng:    rand.New(rand.NewSource(time.Now().UnixNano())),
+		rng:    newRand(config.Seed),
 	}
 }
 
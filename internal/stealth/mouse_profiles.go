@@ -0,0 +1,80 @@
+package stealth
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// MouseProfileRandom picks one of the presets at random once per session
+const MouseProfileRandom = "random"
+
+// MouseProfiles holds tuned mouse parameters for each preset.
+//
+//   - "cautious": slow, shallow curves and few overshoots. Safest, but every click
+//     takes longer, so fewer profiles are processed per hour.
+//   - "normal": the defaults shipped in config.yaml. A balance of speed and risk.
+//   - "confident": fast movement with frequent, larger overshoots. Processes the most
+//     profiles per hour but the quicker, more uniform paths carry a higher detection risk.
+var MouseProfiles = map[string]MouseConfig{
+	"cautious": {
+		SpeedMin:              0.3,
+		SpeedMax:              0.8,
+		OvershootChance:       0.1,
+		OvershootDistMin:      0.05,
+		OvershootDistMax:      0.15,
+		ControlPointOffsetMin: 0.15,
+		ControlPointOffsetMax: 0.35,
+		ControlPointSpreadMin: 0.3,
+		ControlPointSpreadMax: 0.6,
+	},
+	"normal": {
+		SpeedMin:              0.5,
+		SpeedMax:              1.5,
+		OvershootChance:       0.3,
+		OvershootDistMin:      0.1,
+		OvershootDistMax:      0.3,
+		ControlPointOffsetMin: 0.2,
+		ControlPointOffsetMax: 0.5,
+		ControlPointSpreadMin: 0.3,
+		ControlPointSpreadMax: 0.7,
+	},
+	"confident": {
+		SpeedMin:              1.0,
+		SpeedMax:              2.0,
+		OvershootChance:       0.5,
+		OvershootDistMin:      0.15,
+		OvershootDistMax:      0.4,
+		ControlPointOffsetMin: 0.25,
+		ControlPointOffsetMax: 0.6,
+		ControlPointSpreadMin: 0.2,
+		ControlPointSpreadMax: 0.8,
+	},
+}
+
+// ResolveMouseProfile returns the preset for name along with the resolved profile name.
+// "random" picks one of the presets using crypto/rand.
+func ResolveMouseProfile(name string) (string, MouseConfig, error) {
+	if name == MouseProfileRandom {
+		names := make([]string, 0, len(MouseProfiles))
+		for n := range MouseProfiles {
+			names = append(names, n)
+		}
+		// Map iteration order is random; sort so only crypto/rand decides
+		sort.Strings(names)
+
+		idx, err := rand.Int(rand.Reader, big.NewInt(int64(len(names))))
+		if err != nil {
+			return "", MouseConfig{}, fmt.Errorf("failed to pick random mouse profile: %w", err)
+		}
+		name = names[idx.Int64()]
+	}
+
+	profile, ok := MouseProfiles[name]
+	if !ok {
+		return "", MouseConfig{}, fmt.Errorf("unknown mouse profile: %s", name)
+	}
+
+	return name, profile, nil
+}
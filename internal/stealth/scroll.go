@@ -12,10 +12,11 @@ type Scroll struct {
 	rng *rand.Rand
 }
 
-// NewScroll creates a new Scroll instance
-func NewScroll() *Scroll {
+// NewScroll creates a new Scroll instance. A non-zero seed makes its scroll actions
+// reproducible.
+func NewScroll(seed int64) *Scroll {
 	return &Scroll{
-		rng: rand.New(rand.NewSource(time.Now().UnixNano())),
+		rng: newRand(seed),
 	}
 }
 
@@ -29,14 +29,22 @@ func NewStealth(config *core.StealthConfig) *Stealth {
 			ControlPointOffsetMax: config.ControlPointOffsetMax,
 			ControlPointSpreadMin: config.ControlPointSpreadMin,
 			ControlPointSpreadMax: config.ControlPointSpreadMax,
+			Seed:                  config.RandomSeed,
 		}),
-		keyboard: NewKeyboard(),
-		jitter:   NewJitter(),
-		scroll:   NewScroll(),
+		keyboard: newConfiguredKeyboard(config),
+		jitter:   NewJitter(config.RandomSeed),
+		scroll:   NewScroll(config.RandomSeed),
 		config:   config,
 	}
 }
 
+// newConfiguredKeyboard creates a keyboard using the configured typing pauses
+func newConfiguredKeyboard(config *core.StealthConfig) *Keyboard {
+	keyboard := NewKeyboard(config.RandomSeed)
+	keyboard.SetPausePatterns(config.TypingPausePatterns)
+	return keyboard
+}
+
 // MoveMouse moves the mouse using Bézier curves with optional overshoot
 // Note: This requires a rod.Page instance, which will be provided by the browser layer
 // For now, we return the path points that the browser layer can execute
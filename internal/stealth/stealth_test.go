@@ -0,0 +1,58 @@
+package stealth
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"linkedin-automation/internal/core"
+)
+
+func TestDeterministicTypingActions(t *testing.T) {
+	text := "Hi Jane, thanks for connecting! Looking forward to staying in touch."
+	typingActions := func(seed int64) []KeyAction {
+		t.Helper()
+		s := NewStealth(&core.StealthConfig{
+			TypingSpeedMin:  40,
+			TypingSpeedMax:  80,
+			TypoProbability: 0.1,
+			RandomSeed:      seed,
+		})
+		actions, err := s.GetTypingActions(context.Background(), text)
+		if err != nil {
+			t.Fatalf("GetTypingActions(seed %d): %v", seed, err)
+		}
+		return actions
+	}
+
+	first := typingActions(42)
+	if !reflect.DeepEqual(first, typingActions(42)) {
+		t.Errorf("GetTypingActions with seed 42 differs between runs")
+	}
+	if reflect.DeepEqual(first, typingActions(43)) {
+		t.Errorf("GetTypingActions with seeds 42 and 43 gave the same actions")
+	}
+}
+
+func TestDeterministicRandomDuration(t *testing.T) {
+	durations := func(seed int64) []time.Duration {
+		j := NewJitter(seed)
+		out := make([]time.Duration, 5)
+		for i := range out {
+			out[i] = j.RandomDuration(30, 90)
+			if out[i] < 30*time.Second || out[i] > 90*time.Second+time.Millisecond {
+				t.Fatalf("RandomDuration(30, 90) = %s, want between 30s and 90s", out[i])
+			}
+		}
+		return out
+	}
+
+	first := durations(42)
+	if !reflect.DeepEqual(first, durations(42)) {
+		t.Errorf("RandomDuration with seed 42 differs between runs")
+	}
+	if reflect.DeepEqual(first, durations(43)) {
+		t.Errorf("RandomDuration with seeds 42 and 43 gave the same durations")
+	}
+}
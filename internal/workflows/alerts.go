@@ -0,0 +1,49 @@
+package workflows
+
+import (
+	"context"
+	"errors"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// ErrInviteLimitReached is returned when LinkedIn refuses further connection requests
+// for the week
+var ErrInviteLimitReached = errors.New("weekly invitation limit reached")
+
+// inviteLimitSelectors match the modal LinkedIn shows instead of sending an invitation
+// once the weekly limit is used up
+var inviteLimitSelectors = []string{
+	".ip-fuse-limit-alert",
+	"[data-test-modal-id='fuse-limit-alert']",
+	"//*[contains(text(), 'weekly invitation limit')]",
+}
+
+// notify delivers alert through notifier, which may be nil when alerts are off
+func notify(ctx context.Context, notifier core.NotifierPort, alert core.Alert) {
+	if notifier == nil {
+		return
+	}
+	notifier.Notify(ctx, alert)
+}
+
+// inviteLimitReached reports whether the weekly invitation limit modal is showing, and
+// dismisses it
+func inviteLimitReached(ctx context.Context, browser core.BrowserPort, logger *zap.Logger) bool {
+	for _, selector := range inviteLimitSelectors {
+		if visible, _ := browser.IsElementVisible(ctx, selector); !visible {
+			continue
+		}
+
+		logger.Warn("Weekly invitation limit reached", zap.String("selector", selector))
+		if exists, _ := browser.ElementExists(ctx, "button[aria-label='Got it']"); exists {
+			if err := browser.HumanClick(ctx, "button[aria-label='Got it']"); err != nil {
+				logger.Warn("Failed to dismiss invitation limit modal", zap.Error(err))
+			}
+		}
+		return true
+	}
+	return false
+}
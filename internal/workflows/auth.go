@@ -7,28 +7,55 @@ import (
 	"time"
 
 	"linkedin-automation/internal/core"
+	"linkedin-automation/internal/security"
 
 	"go.uber.org/zap"
 )
 
 // AuthWorkflow implements the authentication workflow
 type AuthWorkflow struct {
-	browser   core.BrowserPort
-	config    *core.Config
-	logger    *zap.Logger
+	browser    core.BrowserPort
+	config     *core.Config
+	logger     *zap.Logger
+	textSolver *security.TextCaptchaSolver
+	salesNav   *SalesNavWorkflow
+	notifier   core.NotifierPort
 }
 
 // NewAuthWorkflow creates a new authentication workflow
 func NewAuthWorkflow(browser core.BrowserPort, config *core.Config, logger *zap.Logger) *AuthWorkflow {
 	return &AuthWorkflow{
-		browser: browser,
-		config:  config,
-		logger:  logger,
+		browser:    browser,
+		config:     config,
+		logger:     logger,
+		textSolver: security.NewTextCaptchaSolver(&config.Security, logger),
+		salesNav:   NewSalesNavWorkflow(browser, config, logger),
 	}
 }
 
-// Authenticate performs login or loads existing session
+// SetNotifier makes security challenges that need solving by hand send an alert
+func (a *AuthWorkflow) SetNotifier(notifier core.NotifierPort) {
+	a.notifier = notifier
+}
+
+// Authenticate performs login or loads existing session, then signs in to Sales
+// Navigator when sales_navigator.enabled is set
 func (a *AuthWorkflow) Authenticate(ctx context.Context) error {
+	if err := a.authenticateLinkedIn(ctx); err != nil {
+		return err
+	}
+	if !a.config.SalesNavigator.Enabled {
+		return nil
+	}
+
+	if err := a.salesNav.EnsureAuthenticated(ctx); err != nil {
+		return fmt.Errorf("Sales Navigator authentication failed: %w", err)
+	}
+	return nil
+}
+
+// authenticateLinkedIn performs the LinkedIn login or loads the existing session
+func (a *AuthWorkflow) authenticateLinkedIn(ctx context.Context) error {
 	// Try to load existing cookies first
 	if err := a.browser.LoadCookies(ctx, a.config.Session.CookiesPath); err != nil {
 		a.logger.Warn("Failed to load cookies, will perform fresh login", zap.Error(err))
@@ -54,7 +81,7 @@ func (a *AuthWorkflow) Authenticate(ctx context.Context) error {
 	}
 
 	// Wait for login form to appear
-	if err := a.browser.WaitForElement(ctx, a.config.Selectors.LoginEmailInput, 10*time.Second); err != nil {
+	if err := a.browser.WaitForElement(ctx, a.config.Selectors.LoginEmailInput, elementTimeout(&a.config.Selectors)); err != nil {
 		return fmt.Errorf("login form not found: %w", err)
 	}
 
@@ -213,9 +240,23 @@ func (a *AuthWorkflow) handleSecurityChallenge(ctx context.Context) error {
 
 	if challengeReason != "" {
 		a.logger.Warn("⚠️ SECURITY CHALLENGE DETECTED! ⚠️", zap.String("reason", challengeReason))
-		a.logger.Warn("The bot has been presented with a security check (CAPTCHA/Arkose).")
-		a.logger.Warn("Please switch to the browser window and solve the challenge MANUALLY.")
-		a.logger.Warn("Waiting for up to 5 minutes...")
+
+		// Simple text challenges can be answered directly; the loop below confirms they cleared
+		solved, err := a.textSolver.TrySolve(ctx, a.browser)
+		if err != nil {
+			a.logger.Warn("Failed to answer text challenge", zap.Error(err))
+		}
+		if solved {
+			a.logger.Info("Text challenge answered, waiting for it to clear...")
+		} else {
+			notify(ctx, a.notifier, core.Alert{
+				Event: core.AlertEventCaptcha,
+				Text:  "Security check during login needs solving in the browser within 5 minutes (" + challengeReason + ")",
+			})
+			a.logger.Warn("The bot has been presented with a security check (CAPTCHA/Arkose).")
+			a.logger.Warn("Please switch to the browser window and solve the challenge MANUALLY.")
+			a.logger.Warn("Waiting for up to 5 minutes...")
+		}
 
 		ticker := time.NewTicker(5 * time.Second)
 		defer ticker.Stop()
@@ -315,3 +356,22 @@ func (a *AuthWorkflow) Handle2FA(ctx context.Context) error {
 	}
 }
 
+
+// elementTimeout returns how long to wait for an element, selectors.default_timeout
+func elementTimeout(config *core.SelectorsConfig) time.Duration {
+	timeout := config.DefaultTimeout
+	if timeout <= 0 {
+		timeout = 10 // Default fallback
+	}
+	return time.Duration(timeout) * time.Second
+}
+
+// quickTimeout returns how long to probe for an optional element or a fallback
+// selector, selectors.quick_timeout
+func quickTimeout(config *core.SelectorsConfig) time.Duration {
+	timeout := config.QuickTimeout
+	if timeout <= 0 {
+		timeout = 2 // Default fallback
+	}
+	return time.Duration(timeout) * time.Second
+}
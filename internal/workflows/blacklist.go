@@ -0,0 +1,41 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// matchBlacklist reports whether a profile matches a blacklist entry, and records the
+// entry's reason on the stored profile when it does. Lookup errors are logged and
+// treated as no match.
+func matchBlacklist(ctx context.Context, repo core.RepositoryPort, logger *zap.Logger, profile *core.Profile) bool {
+	entry, err := repo.MatchBlacklist(ctx, profile)
+	if err != nil {
+		logger.Warn("Failed to check blacklist", zap.String(core.LogFieldProfileURL, profile.LinkedInURL), zap.Error(err))
+		return false
+	}
+	if entry == nil {
+		return false
+	}
+
+	reason := entry.Reason
+	if reason == "" {
+		reason = fmt.Sprintf("%s %s", entry.PatternType, entry.Value)
+	}
+	logger.Info("Profile is blacklisted",
+		zap.String(core.LogFieldProfileURL, profile.LinkedInURL),
+		zap.String("reason", reason),
+	)
+
+	if profile.BlacklistReason != reason {
+		if err := repo.MarkProfileBlacklisted(ctx, profile.LinkedInURL, reason); err != nil {
+			logger.Warn("Failed to record blacklist match", zap.String(core.LogFieldProfileURL, profile.LinkedInURL), zap.Error(err))
+		}
+	}
+
+	return true
+}
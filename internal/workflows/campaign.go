@@ -0,0 +1,57 @@
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"linkedin-automation/internal/core"
+)
+
+// ErrCampaignBudgetReached is returned when a campaign has used its share of today's
+// connection requests
+var ErrCampaignBudgetReached = errors.New("campaign daily budget reached")
+
+// ResolveCampaign returns the campaign record for a campaign name. Names without a record
+// are plain tags from before campaigns were records, and return (nil, nil). Paused and
+// archived campaigns return an error, so nothing is sent on their behalf.
+func ResolveCampaign(ctx context.Context, repo core.RepositoryPort, name string) (*core.Campaign, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	campaign, err := repo.GetCampaignByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load campaign %q: %w", name, err)
+	}
+	if campaign != nil && campaign.Status != core.CampaignStatusActive {
+		return nil, fmt.Errorf("campaign %q is %s", name, campaign.Status)
+	}
+
+	return campaign, nil
+}
+
+// CampaignDailyLimit returns the campaign's share of a daily limit, at least 1. Campaigns
+// without a share are only held to the limit itself.
+func CampaignDailyLimit(campaign *core.Campaign, limit int) int {
+	if campaign == nil || campaign.DailyBudgetShare <= 0 || campaign.DailyBudgetShare >= 1 {
+		return limit
+	}
+	return int(math.Max(1, math.Floor(campaign.DailyBudgetShare*float64(limit))))
+}
+
+// CampaignSequenceTemplates returns the template names of a campaign's message sequence
+func CampaignSequenceTemplates(campaign *core.Campaign) ([]string, error) {
+	if campaign == nil || strings.TrimSpace(campaign.MessageSequence) == "" {
+		return nil, nil
+	}
+
+	var names []string
+	if err := json.Unmarshal([]byte(campaign.MessageSequence), &names); err != nil {
+		return nil, fmt.Errorf("invalid message sequence for campaign %q: %w", campaign.Name, err)
+	}
+	return names, nil
+}
@@ -0,0 +1,208 @@
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// companyPage is the follow state read from a company page's top card
+type companyPage struct {
+	Name      string `json:"name"`
+	Found     bool   `json:"found"`     // A Follow/Following button was found
+	Following bool   `json:"following"` // The account already follows the company
+}
+
+// FollowCompanies follows up to limit companies, taken first from engagement.follow_companies
+// and then from the current companies of enriched profiles. Companies already followed
+// are skipped. It returns the number of companies followed.
+func (e *EngagementWorkflow) FollowCompanies(ctx context.Context, limit int) (int, error) {
+	if limit <= 0 {
+		return 0, nil
+	}
+
+	candidates := make([]string, 0, limit)
+	for _, companyURL := range e.config.Engagement.FollowCompanies {
+		followed, err := e.repository.IsCompanyFollowed(ctx, companyURL)
+		if err != nil {
+			return 0, fmt.Errorf("failed to check followed companies: %w", err)
+		}
+		if !followed {
+			candidates = append(candidates, companyURL)
+		}
+	}
+
+	if len(candidates) < limit {
+		fromProfiles, err := e.repository.GetCompaniesToFollow(ctx, limit-len(candidates))
+		if err != nil {
+			return 0, fmt.Errorf("failed to load companies to follow: %w", err)
+		}
+		candidates = append(candidates, fromProfiles...)
+	}
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	if len(candidates) == 0 {
+		e.logger.Info("No companies to follow")
+		return 0, nil
+	}
+
+	e.logger.Info("Following companies", zap.Int("count", len(candidates)))
+
+	followedCount := 0
+	for _, companyURL := range candidates {
+		select {
+		case <-ctx.Done():
+			return followedCount, ctx.Err()
+		default:
+		}
+
+		followed, err := e.FollowCompany(ctx, companyURL)
+		if err != nil {
+			if errors.Is(err, errFollowLimitReached) {
+				break
+			}
+			e.logger.Warn("Failed to follow company", zap.String("url", companyURL), zap.Error(err))
+			continue
+		}
+		if followed {
+			followedCount++
+		}
+
+		e.browser.RandomSleep(ctx, 4.0, 9.0)
+	}
+
+	e.logger.Info("Company following complete",
+		zap.Int("candidates", len(candidates)),
+		zap.Int("followed", followedCount),
+	)
+
+	return followedCount, nil
+}
+
+// errFollowLimitReached is returned by FollowCompany once the daily follow cap is used up
+var errFollowLimitReached = errors.New("daily follow limit reached")
+
+// FollowCompany opens a company page and clicks Follow. Companies the account already
+// follows are recorded without clicking. It returns true only if a new follow was placed.
+func (e *EngagementWorkflow) FollowCompany(ctx context.Context, companyURL string) (bool, error) {
+	if companyURL == "" {
+		return false, fmt.Errorf("company URL is required")
+	}
+
+	maxFollows := e.config.Engagement.MaxFollowsPerDay
+	if maxFollows <= 0 {
+		maxFollows = 10 // Default fallback
+	}
+
+	canFollow, err := e.repository.CanPerformAction(ctx, "Follow", maxFollows)
+	if err != nil {
+		e.logger.Warn("Failed to check follow rate limit", zap.Error(err))
+	} else if !canFollow {
+		e.logger.Warn("Daily follow limit reached", zap.Int("limit", maxFollows))
+		return false, errFollowLimitReached
+	}
+
+	pageURL := strings.TrimRight(strings.Split(companyURL, "?")[0], "/") + "/"
+	if err := e.browser.Navigate(ctx, pageURL); err != nil {
+		return false, fmt.Errorf("failed to navigate to company page: %w", err)
+	}
+	e.browser.RandomSleep(ctx, 2.0, 4.0)
+
+	page, err := e.readCompanyPage(ctx)
+	if err != nil {
+		return false, err
+	}
+	if !page.Found {
+		if html, errHtml := e.browser.GetPageHTML(ctx); errHtml == nil {
+			dumpPath := fmt.Sprintf("data/debug_follow_fail_%d.html", time.Now().Unix())
+			_ = os.WriteFile(dumpPath, []byte(html), 0644)
+		}
+		return false, fmt.Errorf("follow button not found")
+	}
+
+	company := &core.FollowedCompany{
+		CompanyURL: companyURL,
+		Name:       page.Name,
+		FollowedAt: time.Now(),
+	}
+
+	if page.Following {
+		e.logger.Info("Already following company", zap.String("url", companyURL))
+		if err := e.repository.CreateFollowedCompany(ctx, company); err != nil {
+			e.logger.Warn("Failed to save followed company", zap.Error(err))
+		}
+		return false, nil
+	}
+
+	if err := e.browser.HumanClick(ctx, "button[data-bot-follow='1']"); err != nil {
+		return false, fmt.Errorf("failed to click follow button: %w", err)
+	}
+	e.browser.RandomSleep(ctx, 1.5, 2.5)
+
+	page, err = e.readCompanyPage(ctx)
+	if err != nil || !page.Following {
+		e.logger.Warn("Follow was not registered", zap.String("url", companyURL))
+		return false, nil
+	}
+
+	if err := e.repository.CreateFollowedCompany(ctx, company); err != nil {
+		e.logger.Warn("Failed to save followed company", zap.Error(err))
+	}
+
+	history := core.NewHistory("Follow", core.HistoryDetails{Target: companyURL})
+	if err := e.repository.CreateHistory(ctx, history); err != nil {
+		e.logger.Warn("Failed to save history", zap.Error(err))
+	}
+
+	e.logger.Info("Followed company", zap.String("url", companyURL), zap.String("name", company.Name))
+	return true, nil
+}
+
+// readCompanyPage reads the company name and follow state from the top card, tagging the
+// Follow button with data-bot-follow so it can be clicked by selector
+func (e *EngagementWorkflow) readCompanyPage(ctx context.Context) (*companyPage, error) {
+	res, err := e.browser.ExecuteScript(ctx, `() => {
+const card = document.querySelector(".org-top-card") || document;
+const title = card.querySelector("h1");
+const result = {name: title ? title.innerText.trim() : "", found: false, following: false};
+for (const button of card.querySelectorAll("button")) {
+const text = button.innerText.trim().replace(/^\+\s*/, "");
+if (text === "Following") {
+result.found = true;
+result.following = true;
+break;
+}
+if (text === "Follow") {
+button.setAttribute("data-bot-follow", "1");
+result.found = true;
+break;
+}
+}
+return result;
+}`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read company page: %w", err)
+	}
+
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read company page: %w", err)
+	}
+
+	var page companyPage
+	if err := json.Unmarshal(raw, &page); err != nil {
+		return nil, fmt.Errorf("failed to parse company page: %w", err)
+	}
+
+	return &page, nil
+}
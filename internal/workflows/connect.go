@@ -2,22 +2,43 @@ package workflows
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 	"time"
 
 	"linkedin-automation/internal/core"
+	"linkedin-automation/internal/linkedin"
+	"linkedin-automation/pkg/template"
 
 	"go.uber.org/zap"
 )
 
+// clickVerifyTimeout is how long the Connect and Send clicks wait for the modal to open
+// or close under browser.click_verification
+const clickVerifyTimeout = 5 * time.Second
+
+// ErrIntroductionRequested is returned by SendConnectionRequest when a mutual connection
+// was asked for an introduction instead; the direct request goes out on a later run
+var ErrIntroductionRequested = errors.New("introduction requested instead of connecting")
+
 // ConnectWorkflow implements the connection workflow
 type ConnectWorkflow struct {
-	browser   core.BrowserPort
+	browser    core.BrowserPort
 	repository core.RepositoryPort
-	config    *core.Config
-	logger    *zap.Logger
+	config     *core.Config
+	logger     *zap.Logger
+	extractor  *ProfileExtractor
+	liker      *PostLikerWorkflow
+	enricher   *EnrichmentWorkflow
+	variator   *template.LengthVariator
+	introducer *MutualConnectionWorkflow
+	prefetcher *ProfilePrefetcher
+	voyager    *linkedin.VoyagerClient
+	templates  *TemplateEngine
+	enrichers  []TemplateEnricher
+	notifier   core.NotifierPort
 }
 
 // NewConnectWorkflow creates a new connection workflow
@@ -32,10 +53,76 @@ func NewConnectWorkflow(
 		repository: repository,
 		config:     config,
 		logger:     logger,
+		extractor:  NewProfileExtractor(browser, logger),
+		liker:      NewPostLikerWorkflow(browser, repository, config, logger),
+		enricher:   NewEnrichmentWorkflow(browser, repository, config, logger),
+		variator:   template.NewLengthVariator(config.Connection.ClosingPhrases),
+		introducer: NewMutualConnectionWorkflow(browser, repository, config, logger),
+		voyager:    linkedin.NewVoyagerClient(browser, logger),
+		templates:  NewTemplateEngine(),
+		enrichers:  NewTemplateEnrichers(repository, config, logger),
+	}
+}
+
+// SetNotifier makes SendConnectionRequest send an alert when the weekly invitation limit is hit
+func (c *ConnectWorkflow) SetNotifier(notifier core.NotifierPort) {
+	c.notifier = notifier
+}
+
+// SetPrefetcher makes SendConnectionRequest use prefetched profile data when available
+func (c *ConnectWorkflow) SetPrefetcher(prefetcher *ProfilePrefetcher) {
+	c.prefetcher = prefetcher
+}
+
+// renderNote fills in the note template for the profile. {{Name}} comes from params; other
+// variables are asked of the enrichers. A note that still has placeholders after that
+// is dropped (the request is sent without a note) rather than sent half-rendered.
+func (c *ConnectWorkflow) renderNote(ctx context.Context, params *core.ConnectParams) string {
+	if params.Note == "" {
+		return ""
+	}
+
+	data := &TemplateContext{
+		Variables: map[string]string{"Name": params.Name},
+		Enrichers: c.enrichers,
+	}
+	note, missing := c.templates.Render(params.Note, data)
+	if len(missing) == 0 {
+		return note
+	}
+
+	if len(c.enrichers) > 0 {
+		profile, err := c.repository.GetProfileByURL(ctx, params.ProfileURL)
+		if err != nil {
+			c.logger.Warn("Failed to load profile for note enrichment", zap.Error(err))
+		}
+		data.Profile = profile
+		note, missing = c.templates.Render(params.Note, data.Enrich(ctx, c.logger))
+		if len(missing) == 0 {
+			return note
+		}
+	}
+
+	c.logger.Warn("Note template variables unavailable, sending without note",
+		zap.String(core.LogFieldProfileURL, params.ProfileURL),
+		zap.Strings("missing", missing),
+	)
+	return ""
+}
+
+// GetDailyConnectionSummary reports today's connection requests, acceptances and the
+// quota left, with an estimate of how long the rest of the quota will take
+func (c *ConnectWorkflow) GetDailyConnectionSummary(ctx context.Context) (*core.ConnectionSummary, error) {
+	now := time.Now()
+	if loc, err := c.config.Database.Location(); err == nil {
+		now = now.In(loc)
 	}
+	return core.GetDailyConnectionSummary(ctx, c.repository, &c.config.Limits, now)
 }
 
-// SendConnectionRequest sends a connection request with a personalized note
+// SendConnectionRequest sends a connection request with a personalized note. It returns
+// ErrIntroductionRequested when an introduction was requested instead, and
+// core.ErrProfileInActiveCampaign when the profile was skipped for another campaign.
 func (c *ConnectWorkflow) SendConnectionRequest(ctx context.Context, params *core.ConnectParams) error {
 	if params == nil {
 		return fmt.Errorf("connect params cannot be nil")
@@ -44,16 +131,66 @@ func (c *ConnectWorkflow) SendConnectionRequest(ctx context.Context, params *cor
 	if params.ProfileURL == "" {
 		return fmt.Errorf("profile URL is required")
 	}
+	logger := ProfileLogger(c.logger, params.ProfileURL)
 
 	// 1. Enforce Daily Limits
 	dailyCount, err := c.repository.GetTodayActionCount(ctx, "Connect")
 	if err != nil {
-		c.logger.Warn("Failed to check daily limits", zap.Error(err))
+		logger.Warn("Failed to check daily limits", zap.Error(err))
 	} else if dailyCount >= int64(c.config.Limits.MaxActionsPerDay) {
 		return fmt.Errorf("daily connection limit reached (%d/%d)", dailyCount, c.config.Limits.MaxActionsPerDay)
 	}
 
-	c.logger.Info("Sending connection request", zap.String("profile_url", params.ProfileURL))
+	// Campaigns get their own share of the daily limit, and a profile is only ever
+	// contacted for one active campaign
+	campaign, err := ResolveCampaign(ctx, c.repository, params.Campaign)
+	if err != nil {
+		return err
+	}
+	if campaign != nil {
+		if err := c.checkCampaign(ctx, campaign, params.ProfileURL); err != nil {
+			return err
+		}
+	}
+
+	// Checked before navigating, since opening the profile would count as a fresh visit
+	if c.config.Visits.DaysBeforeConnect > 0 {
+		profile, err := c.repository.GetProfileByURL(ctx, params.ProfileURL)
+		if err != nil {
+			logger.Warn("Failed to check profile visit", zap.Error(err))
+		} else if c.awaitingVisit(profile) {
+			logger.Info("Profile not visited long enough ago, skipping for now")
+			return nil
+		}
+	}
+
+	// Prefetched data lets already-connected profiles be skipped without a visit
+	if c.prefetcher != nil {
+		if cached, ok := c.prefetcher.Get(params.ProfileURL); ok {
+			if cached.ConnectionDegree == "1st" {
+				logger.Info("Profile already connected (prefetched), skipping")
+				if err := c.repository.MarkAsConnected(ctx, params.ProfileURL); err != nil {
+					logger.Warn("Failed to mark profile as connected", zap.Error(err))
+				}
+				return nil
+			}
+			if params.Name == "" {
+				if parts := strings.Fields(cached.Name); len(parts) > 0 {
+					params.Name = parts[0]
+				}
+			}
+		}
+	}
+
+	logger.Info("Sending connection request")
+
+	// Warm up by liking a recent post first
+	if c.config.Engagement.LikeBeforeConnect {
+		if _, err := c.liker.LikeRecentPost(ctx, params.ProfileURL); err != nil {
+			logger.Warn("Failed to like recent post", zap.Error(err))
+		}
+		c.browser.RandomSleep(ctx, 2.0, 4.0)
+	}
 
 	// Navigate to profile page
 	if err := c.browser.Navigate(ctx, params.ProfileURL); err != nil {
@@ -67,7 +204,7 @@ func (c *ConnectWorkflow) SendConnectionRequest(ctx context.Context, params *cor
 	if params.Name == "" {
 		name, err := c.ExtractProfileName(ctx)
 		if err != nil {
-			c.logger.Warn("Failed to extract profile name", zap.Error(err))
+			logger.Warn("Failed to extract profile name", zap.Error(err))
 			params.Name = "there" // Fallback
 		} else {
 			params.Name = name
@@ -77,19 +214,25 @@ func (c *ConnectWorkflow) SendConnectionRequest(ctx context.Context, params *cor
 	// Check if we should skip this profile
 	shouldSkip, err := c.ShouldSkipProfile(ctx, params.ProfileURL)
 	if err != nil {
-		c.logger.Warn("Failed to check if should skip profile", zap.Error(err))
+		logger.Warn("Failed to check if should skip profile", zap.Error(err))
 		// Continue anyway
 	}
 
 	if shouldSkip {
-		c.logger.Info("Skipping profile", zap.String("reason", "already connected or not available"))
+		logger.Info("Skipping profile", actionField("Skip"), zap.String("reason", "already connected or not available"))
 		return nil
 	}
 
+	// Prefer a warm introduction; the direct request goes out on a later run
+	if c.config.Connection.UseIntroductionRequests && c.requestIntroduction(ctx, params) {
+		logger.Info("Introduction requested instead of connecting", actionField("IntroductionRequest"))
+		return ErrIntroductionRequested
+	}
+
 	// Scroll down slightly to ensure content is loaded, but not too much to hide the top card
 	// Reduced from 300 to 20 to avoid hiding the 'More' button behind the sticky header
 	if err := c.browser.HumanScroll(ctx, "down", 20); err != nil {
-		c.logger.Warn("Failed to scroll", zap.Error(err))
+		logger.Warn("Failed to scroll", zap.Error(err))
 	}
 
 	// Try to find Connect button directly
@@ -97,9 +240,9 @@ func (c *ConnectWorkflow) SendConnectionRequest(ctx context.Context, params *cor
 	
 	// Try the configured selector first
 	if c.config.Selectors.ProfileConnectBtn != "" {
-		if err := c.browser.WaitForElement(ctx, c.config.Selectors.ProfileConnectBtn, 3*time.Second); err == nil {
+		if err := c.browser.WaitForElement(ctx, c.config.Selectors.ProfileConnectBtn, quickTimeout(&c.config.Selectors)); err == nil {
 			connectBtnFound = true
-			c.logger.Info("Found Connect button directly", zap.String("selector", c.config.Selectors.ProfileConnectBtn))
+			logger.Info("Found Connect button directly", zap.String("selector", c.config.Selectors.ProfileConnectBtn))
 		}
 	}
 
@@ -109,10 +252,10 @@ func (c *ConnectWorkflow) SendConnectionRequest(ctx context.Context, params *cor
 		fallbackSelectors := c.config.Selectors.ProfileConnectButtonFallbacks
 
 		for _, selector := range fallbackSelectors {
-			if err := c.browser.WaitForElement(ctx, selector, 2*time.Second); err == nil {
+			if err := c.browser.WaitForElement(ctx, selector, quickTimeout(&c.config.Selectors)); err == nil {
 				c.config.Selectors.ProfileConnectBtn = selector
 				connectBtnFound = true
-				c.logger.Info("Found Connect button using fallback", zap.String("selector", selector))
+				logger.Info("Found Connect button using fallback", zap.String("selector", selector))
 				break
 			}
 		}
@@ -120,7 +263,7 @@ func (c *ConnectWorkflow) SendConnectionRequest(ctx context.Context, params *cor
 
 	if !connectBtnFound {
 		// If not found, check if it's hidden under "More" actions
-		c.logger.Info("Connect button not found directly, checking 'More' menu...")
+		logger.Info("Connect button not found directly, checking 'More' menu...")
 
 		// Define fallback selectors for "More" button
 		// We strictly scope this to the top card (.pv-top-card) to avoid clicking "More" buttons
@@ -151,13 +294,13 @@ func (c *ConnectWorkflow) SendConnectionRequest(ctx context.Context, params *cor
 		}
 
 		if foundMoreSelector != "" {
-			c.logger.Info("Found 'More' button", zap.String("selector", foundMoreSelector))
+			logger.Info("Found 'More' button", zap.String("selector", foundMoreSelector))
 			
 			// Try human click first
 			if err := c.browser.HumanClick(ctx, foundMoreSelector); err != nil {
-				c.logger.Warn("Human click failed, trying JS click", zap.Error(err))
+				logger.Warn("Human click failed, trying JS click", zap.Error(err))
 				if err := c.browser.JSClick(ctx, foundMoreSelector); err != nil {
-					c.logger.Error("JS click also failed", zap.Error(err))
+					logger.Error("JS click also failed", zap.Error(err))
 				}
 			}
 			
@@ -167,22 +310,22 @@ func (c *ConnectWorkflow) SendConnectionRequest(ctx context.Context, params *cor
 			// This confirms the menu actually opened
 			dropdownVisible, _ := c.browser.IsElementVisible(ctx, ".artdeco-dropdown__content")
 			if !dropdownVisible {
-				c.logger.Warn("Dropdown content not visible after clicking 'More', trying JS click...")
+				logger.Warn("Dropdown content not visible after clicking 'More', trying JS click...")
 				// Retry with JS click
 				if err := c.browser.JSClick(ctx, foundMoreSelector); err != nil {
-					c.logger.Error("Retry JS click failed", zap.Error(err))
+					logger.Error("Retry JS click failed", zap.Error(err))
 				}
 				c.browser.RandomSleep(ctx, 1.0, 2.0)
 				
 				// Check again
 				dropdownVisible, _ = c.browser.IsElementVisible(ctx, ".artdeco-dropdown__content")
 				if !dropdownVisible {
-					c.logger.Error("Dropdown still not visible after retry")
+					logger.Error("Dropdown still not visible after retry")
 					// Dump HTML here to see why it's not opening
 					if html, errHtml := c.browser.GetPageHTML(ctx); errHtml == nil {
 						dumpPath := fmt.Sprintf("data/debug_more_click_fail_%d.html", time.Now().Unix())
 						if errWrite := os.WriteFile(dumpPath, []byte(html), 0644); errWrite == nil {
-							c.logger.Info("Dumped HTML after failed 'More' click", zap.String("path", dumpPath))
+							logger.Info("Dumped HTML after failed 'More' click", zap.String("path", dumpPath))
 						}
 					}
 				}
@@ -207,7 +350,7 @@ func (c *ConnectWorkflow) SendConnectionRequest(ctx context.Context, params *cor
 					// Update selector to use the one we found for the click
 					c.config.Selectors.ProfileConnectBtn = selector
 					connectBtnFound = true
-					c.logger.Info("Found Connect button in 'More' menu", zap.String("selector", selector))
+					logger.Info("Found Connect button in 'More' menu", zap.String("selector", selector))
 					break
 				}
 			}
@@ -219,14 +362,18 @@ func (c *ConnectWorkflow) SendConnectionRequest(ctx context.Context, params *cor
 		if html, errHtml := c.browser.GetPageHTML(ctx); errHtml == nil {
 			dumpPath := fmt.Sprintf("data/debug_connect_fail_%d.html", time.Now().Unix())
 			if errWrite := os.WriteFile(dumpPath, []byte(html), 0644); errWrite == nil {
-				c.logger.Info("Dumped profile page HTML for debugging", zap.String("path", dumpPath))
+				logger.Info("Dumped profile page HTML for debugging", zap.String("path", dumpPath))
 			}
 		}
 		return fmt.Errorf("connect button not found (even after checking 'More' menu)")
 	}
 
-	// Click Connect button with human-like mouse movement
-	if err := c.browser.HumanClick(ctx, c.config.Selectors.ProfileConnectBtn); err != nil {
+	// Rendered before the modal opens, since enrichers may call external APIs
+	note := c.renderNote(ctx, params)
+
+	// Click Connect button with human-like mouse movement; with click verification the
+	// invitation modal (browser.click_verification.selector_after_click) must open
+	if err := c.browser.HumanClickVerified(ctx, c.config.Selectors.ProfileConnectBtn, "", clickVerifyTimeout); err != nil {
 		return fmt.Errorf("failed to click connect button: %w", err)
 	}
 
@@ -234,7 +381,7 @@ func (c *ConnectWorkflow) SendConnectionRequest(ctx context.Context, params *cor
 	c.browser.RandomSleep(ctx, 2.0, 3.0)
 
 	// Handle Note
-	if params.Note != "" {
+	if note != "" {
 		// Check for "Add a note" button
 		addNoteSelector := c.config.Selectors.ConnectModalAddNoteButton
 		if addNoteSelector == "" {
@@ -242,9 +389,9 @@ func (c *ConnectWorkflow) SendConnectionRequest(ctx context.Context, params *cor
 		}
 
 		// Wait for the "Add a note" button to be visible
-		if err := c.browser.WaitForElement(ctx, addNoteSelector, 5*time.Second); err == nil {
+		if err := c.browser.WaitForElement(ctx, addNoteSelector, elementTimeout(&c.config.Selectors)); err == nil {
 			if err := c.browser.HumanClick(ctx, addNoteSelector); err != nil {
-				c.logger.Warn("Failed to click 'Add a note'", zap.Error(err))
+				logger.Warn("Failed to click 'Add a note'", zap.Error(err))
 			} else {
 				c.browser.RandomSleep(ctx, 1.0, 2.0)
 				
@@ -256,11 +403,11 @@ func (c *ConnectWorkflow) SendConnectionRequest(ctx context.Context, params *cor
 
 				textareaExists, err := c.browser.ElementExists(ctx, textareaSelector)
 				if err != nil {
-					c.logger.Warn("Failed to check for note textarea", zap.Error(err))
+					logger.Warn("Failed to check for note textarea", zap.Error(err))
 				}
 
 				if !textareaExists {
-					c.logger.Warn("Note textarea not found after clicking 'Add a note'. Monthly limit for personalized invites might be reached. Sending without note.")
+					logger.Warn("Note textarea not found after clicking 'Add a note'. Monthly limit for personalized invites might be reached. Sending without note.")
 					
 					// Check for potential "Got it" or dismissal button if a limit modal appeared
 					dismissSelectors := []string{
@@ -271,9 +418,9 @@ func (c *ConnectWorkflow) SendConnectionRequest(ctx context.Context, params *cor
 					
 					for _, sel := range dismissSelectors {
 						if exists, _ := c.browser.ElementExists(ctx, sel); exists {
-							c.logger.Info("Found dismissal button, clicking it to proceed", zap.String("selector", sel))
+							logger.Info("Found dismissal button, clicking it to proceed", zap.String("selector", sel))
 							if err := c.browser.HumanClick(ctx, sel); err != nil {
-								c.logger.Warn("Failed to click dismissal button", zap.Error(err))
+								logger.Warn("Failed to click dismissal button", zap.Error(err))
 							}
 							c.browser.RandomSleep(ctx, 0.5, 1.0)
 							break
@@ -281,24 +428,23 @@ func (c *ConnectWorkflow) SendConnectionRequest(ctx context.Context, params *cor
 					}
 
 					// Retry clicking Connect to open the modal again (without adding note this time)
-					c.logger.Info("Retrying connection without note...")
+					logger.Info("Retrying connection without note...")
 					if err := c.browser.HumanClick(ctx, c.config.Selectors.ProfileConnectBtn); err != nil {
-						c.logger.Warn("Failed to click connect button on retry", zap.Error(err))
+						logger.Warn("Failed to click connect button on retry", zap.Error(err))
 					}
 					c.browser.RandomSleep(ctx, 2.0, 3.0)
 				} else {
-					// Personalize note with name
-					personalizedNote := strings.ReplaceAll(params.Note, "{{Name}}", params.Name)
+					personalizedNote := c.variator.Vary(note, c.config.Connection.NoteLength.Min, c.config.Connection.NoteLength.Max)
 					
 					// Enforce character limit (300 chars)
 					if len(personalizedNote) > 300 {
-						c.logger.Warn("Note exceeds 300 characters, truncating", zap.Int("length", len(personalizedNote)))
+						logger.Warn("Note exceeds 300 characters, truncating", zap.Int("length", len(personalizedNote)))
 						personalizedNote = personalizedNote[:297] + "..."
 					}
 
 					// Type note with human-like behavior
 					if err := c.browser.HumanType(ctx, textareaSelector, personalizedNote); err != nil {
-						c.logger.Warn("Failed to type note", zap.Error(err))
+						logger.Warn("Failed to type note", zap.Error(err))
 					}
 					
 					// Small delay before sending
@@ -306,14 +452,18 @@ func (c *ConnectWorkflow) SendConnectionRequest(ctx context.Context, params *cor
 				}
 			}
 		} else {
-			c.logger.Info("Add a note button not found, sending without note")
+			logger.Info("Add a note button not found, sending without note")
 		}
 	}
 
-	// Click Send button
+	// Click Send button; with click verification the modal must close
+	modalClosed := ""
+	if modal := c.config.Browser.ClickVerification.SelectorAfterClick; modal != "" {
+		modalClosed = "body:not(:has(" + modal + "))"
+	}
 	sendExists, err := c.browser.ElementExists(ctx, c.config.Selectors.ConnectSendButton)
 	if err == nil && sendExists {
-		if err := c.browser.HumanClick(ctx, c.config.Selectors.ConnectSendButton); err != nil {
+		if err := c.browser.HumanClickVerified(ctx, c.config.Selectors.ConnectSendButton, modalClosed, clickVerifyTimeout); err != nil {
 			return fmt.Errorf("failed to click send button: %w", err)
 		}
 	} else {
@@ -328,7 +478,7 @@ func (c *ConnectWorkflow) SendConnectionRequest(ctx context.Context, params *cor
 		clicked := false
 		for _, selector := range altSelectors {
 			if exists, _ := c.browser.ElementExists(ctx, selector); exists {
-				if err := c.browser.HumanClick(ctx, selector); err == nil {
+				if err := c.browser.HumanClickVerified(ctx, selector, modalClosed, clickVerifyTimeout); err == nil {
 					clicked = true
 					break
 				}
@@ -336,41 +486,133 @@ func (c *ConnectWorkflow) SendConnectionRequest(ctx context.Context, params *cor
 		}
 		
 		if !clicked {
-			c.logger.Warn("Could not find send button, connection may have been sent automatically")
+			logger.Warn("Could not find send button, connection may have been sent automatically")
 		}
 	}
 
 	// Wait a moment for the request to process
 	c.browser.RandomSleep(ctx, 2.0, 4.0)
 
+	// Nothing was sent when LinkedIn shows the weekly limit instead
+	if inviteLimitReached(ctx, c.browser, c.logger) {
+		notify(ctx, c.notifier, core.Alert{
+			Event: core.AlertEventInviteLimit,
+			Text:  "Weekly invitation limit reached, connection requests stop until it resets",
+		})
+		return ErrInviteLimitReached
+	}
+
 	// Record in database
 	existing, err := c.repository.GetProfileByURL(ctx, params.ProfileURL)
 	if err == nil && existing != nil {
 		if err := c.repository.UpdateProfileStatus(ctx, params.ProfileURL, core.ProfileStatusRequestSent); err != nil {
-			c.logger.Warn("Failed to update profile status", zap.Error(err))
+			logger.Warn("Failed to update profile status", zap.Error(err))
 		}
 	} else {
 		profile := &core.Profile{
 			LinkedInURL: params.ProfileURL,
-			Status:      core.ProfileStatusRequestSent,
+			Status:       core.ProfileStatusRequestSent,
+			Campaign:     params.Campaign,
+			Source:       core.ProfileSourceConnect,
+			SourceDetail: params.Keyword,
 		}
 		if err := c.repository.CreateProfile(ctx, profile); err != nil {
-			c.logger.Warn("Failed to save profile to database", zap.Error(err))
+			logger.Warn("Failed to save profile to database", zap.Error(err))
 		}
 	}
 
 	// Record in history
-	history := &core.History{
-		ActionType: "Connect",
-		Details:    fmt.Sprintf("Connected to %s", params.ProfileURL),
-		Timestamp:  time.Now(),
-	}
+	history := core.NewConnectHistory(params.ProfileURL)
+	history.Campaign = params.Campaign
+	history.Keyword = params.Keyword
 
 	if err := c.repository.CreateHistory(ctx, history); err != nil {
-		c.logger.Warn("Failed to save history", zap.Error(err))
+		logger.Warn("Failed to save history", zap.Error(err))
 	}
 
-	c.logger.Info("Connection request sent successfully", zap.String("profile_url", params.ProfileURL))
+	logger.Info("Connection request sent successfully", actionField("Connect"))
+
+	// Capture role and education while we're still on the profile
+	if c.config.Enrichment.AfterConnect {
+		if err := c.enricher.EnrichProfile(ctx, params.ProfileURL); err != nil {
+			logger.Warn("Failed to enrich profile", zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// requestIntroduction asks a mutual connection to introduce us to the profile currently
+// open, unless an introduction was already requested. It reports whether one was sent.
+func (c *ConnectWorkflow) requestIntroduction(ctx context.Context, params *core.ConnectParams) bool {
+	requested, err := c.repository.HasIntroductionRequest(ctx, params.ProfileURL)
+	if err != nil {
+		c.logger.Warn("Failed to check introduction requests", zap.Error(err))
+		return false
+	}
+	if requested {
+		return false
+	}
+
+	mutuals, err := c.introducer.mutualConnectionsOnPage(ctx)
+	if err != nil {
+		c.logger.Warn("Failed to find mutual connections", zap.Error(err))
+		return false
+	}
+	if len(mutuals) == 0 {
+		return false
+	}
+
+	if err := c.repository.UpdateProfileIdentity(ctx, params.ProfileURL, params.Name, ""); err != nil {
+		c.logger.Debug("Failed to store profile name", zap.Error(err))
+	}
+
+	if err := c.introducer.RequestIntroduction(ctx, mutuals[0], params.ProfileURL, ""); err != nil {
+		c.logger.Warn("Failed to request introduction", zap.Error(err))
+
+		// The attempt may have left the profile, so go back before connecting directly
+		if err := c.browser.Navigate(ctx, params.ProfileURL); err != nil {
+			c.logger.Warn("Failed to return to profile", zap.Error(err))
+		}
+		c.browser.RandomSleep(ctx, 2.0, 4.0)
+		return false
+	}
+
+	return true
+}
+
+// checkCampaign enforces the campaign's daily budget and assigns a known profile to it.
+// It returns core.ErrProfileInActiveCampaign when the profile belongs to another active
+// campaign and should be skipped.
+func (c *ConnectWorkflow) checkCampaign(ctx context.Context, campaign *core.Campaign, profileURL string) error {
+	limit := CampaignDailyLimit(campaign, c.config.Limits.MaxActionsPerDay)
+	count, err := c.repository.GetTodayCampaignActionCount(ctx, "Connect", campaign.Name)
+	if err != nil {
+		c.logger.Warn("Failed to check campaign budget", zap.String("campaign", campaign.Name), zap.Error(err))
+	} else if count >= int64(limit) {
+		return fmt.Errorf("%w: %s (%d/%d)", ErrCampaignBudgetReached, campaign.Name, count, limit)
+	}
+
+	profile, err := c.repository.GetProfileByURL(ctx, profileURL)
+	if err != nil {
+		c.logger.Warn("Failed to look up profile campaign", zap.Error(err))
+		return nil
+	}
+	if profile == nil {
+		return nil // Assigned when the profile is saved after the request
+	}
+
+	err = c.repository.AssignProfileToCampaign(ctx, profileURL, campaign.Name)
+	if errors.Is(err, core.ErrProfileInActiveCampaign) {
+		c.logger.Info("Profile belongs to another active campaign, skipping",
+			zap.String(core.LogFieldProfileURL, profileURL),
+			zap.String("campaign", profile.Campaign),
+		)
+		return fmt.Errorf("%w: %s", core.ErrProfileInActiveCampaign, profile.Campaign)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to assign profile to campaign: %w", err)
+	}
 
 	return nil
 }
@@ -386,24 +628,17 @@ func (c *ConnectWorkflow) ExtractProfileName(ctx context.Context) (string, error
 		"h1.inline",
 	}
 
+	texts, err := c.browser.GetAllText(ctx, selectors)
+	if err != nil {
+		return "", fmt.Errorf("could not extract profile name: %w", err)
+	}
+
+	// Respect selector priority order
 	for _, selector := range selectors {
-		exists, err := c.browser.ElementExists(ctx, selector)
-		if err != nil {
-			continue
-		}
-
-		if exists {
-			name, err := c.browser.GetText(ctx, selector)
-			if err == nil && name != "" {
-				// Clean up the name (remove extra whitespace)
-				name = strings.TrimSpace(name)
-				// Extract first name if full name
-				parts := strings.Fields(name)
-				if len(parts) > 0 {
-					return parts[0], nil
-				}
-				return name, nil
-			}
+		// Extract first name if full name
+		parts := strings.Fields(texts[selector])
+		if len(parts) > 0 {
+			return parts[0], nil
 		}
 	}
 
@@ -412,33 +647,121 @@ func (c *ConnectWorkflow) ExtractProfileName(ctx context.Context) (string, error
 
 // ShouldSkipProfile checks if a profile should be skipped
 func (c *ConnectWorkflow) ShouldSkipProfile(ctx context.Context, profileURL string) (bool, error) {
+	logger := ProfileLogger(c.logger, profileURL)
+
 	// Check database first
 	existingProfile, err := c.repository.GetProfileByURL(ctx, profileURL)
 	if err != nil {
 		return false, fmt.Errorf("failed to check database: %w", err)
 	}
 
+	if isBlacklisted(c.config, profileURL) {
+		logger.Info("Profile is blacklisted")
+		return true, nil
+	}
+
+	// Archived profiles are hidden from GetProfileByURL, so would otherwise look new
+	if existingProfile == nil {
+		archived, err := c.repository.IsProfileArchived(ctx, profileURL)
+		if err != nil {
+			return false, fmt.Errorf("failed to check database: %w", err)
+		}
+		if archived {
+			logger.Info("Profile is archived")
+			return true, nil
+		}
+	}
+
+	blacklistProfile := existingProfile
+	if blacklistProfile == nil {
+		blacklistProfile = &core.Profile{LinkedInURL: profileURL}
+	}
+	if matchBlacklist(ctx, c.repository, c.logger, blacklistProfile) {
+		return true, nil
+	}
+
+	if c.config.Visits.DaysBeforeConnect > 0 && c.awaitingVisit(existingProfile) {
+		return true, nil
+	}
+
 	if existingProfile != nil {
 		// Already processed
 		if existingProfile.Status == core.ProfileStatusConnected || 
 		   existingProfile.Status == core.ProfileStatusIgnored || 
 		   existingProfile.Status == core.ProfileStatusRequestSent {
-			c.logger.Info("Profile already processed", 
-				zap.String("url", profileURL),
-				zap.String("status", existingProfile.Status),
+			logger.Info("Profile already processed",
+				zap.String("status", string(existingProfile.Status)),
 			)
 			return true, nil
 		}
 	}
 
-	// Check if Connect button exists and is enabled
-	// If button says "Connected" or "Pending", skip
-	connectBtnText, err := c.browser.GetText(ctx, c.config.Selectors.ProfileConnectBtn)
-	if err == nil {
-		btnTextLower := strings.ToLower(connectBtnText)
-		if strings.Contains(btnTextLower, "connected") ||
-		   strings.Contains(btnTextLower, "pending") {
-			c.logger.Info("Profile already connected or pending", zap.String("button_text", connectBtnText))
+	// Skip connected, pending and unavailable profiles. Follow-only (creator) profiles
+	// go on, since Connect may still be under the "More" menu.
+	status, err := c.GetConnectionStatus(ctx)
+	if err != nil {
+		logger.Warn("Failed to read connection status", zap.Error(err))
+	} else {
+		switch status {
+		case StatusConnected, StatusPending, StatusUnavailable:
+			logger.Info("Profile not connectable, skipping",
+				zap.Stringer("status", status),
+			)
+			return true, nil
+		}
+	}
+
+	// Record the "Open to Work" flag and apply targeting filters
+	openToWork, err := c.extractor.IsOpenToWork(ctx)
+	if err != nil {
+		logger.Warn("Failed to detect open to work status", zap.Error(err))
+		return false, nil
+	}
+
+	if err := c.repository.UpdateProfileOpenToWork(ctx, profileURL, openToWork); err != nil {
+		logger.Warn("Failed to store open to work status", zap.Error(err))
+	}
+
+	if c.config.Targeting.RequireOpenToWork && !openToWork {
+		logger.Info("Profile is not open to work")
+		return true, nil
+	}
+	if c.config.Targeting.ExcludeOpenToWork && openToWork {
+		logger.Info("Profile is open to work")
+		return true, nil
+	}
+
+	if required := c.config.Targeting.RequiredProfileSkills; len(required) > 0 {
+		skills, err := c.extractor.ExtractSkills(ctx)
+		if err != nil {
+			logger.Warn("Failed to extract skills", zap.Error(err))
+			return false, nil
+		}
+
+		if err := c.repository.UpdateProfileSkills(ctx, profileURL, skills); err != nil {
+			logger.Warn("Failed to store skills", zap.Error(err))
+		}
+
+		if !hasAnySkill(skills, required) {
+			logger.Info("Profile has none of the required skills",
+				zap.Strings("skills", skills),
+			)
+			return true, nil
+		}
+	}
+
+	if c.config.Targeting.MinFollowerCount > 0 || c.config.Targeting.MaxFollowerCount > 0 {
+		followers, ok := c.followerCount(ctx, profileURL, existingProfile)
+		if !ok {
+			return false, nil
+		}
+
+		minFollowers := int64(c.config.Targeting.MinFollowerCount)
+		maxFollowers := int64(c.config.Targeting.MaxFollowerCount)
+		if (minFollowers > 0 && followers < minFollowers) || (maxFollowers > 0 && followers > maxFollowers) {
+			logger.Info("Profile follower count outside target range",
+				zap.Int64("followers", followers),
+			)
 			return true, nil
 		}
 	}
@@ -446,6 +769,41 @@ func (c *ConnectWorkflow) ShouldSkipProfile(ctx context.Context, profileURL stri
 	return false, nil
 }
 
+// followerCount returns the stored follower count, fetching and storing it through the
+// Voyager API when it hasn't been fetched yet
+func (c *ConnectWorkflow) followerCount(ctx context.Context, profileURL string, profile *core.Profile) (int64, bool) {
+	if profile != nil && profile.FollowerCount > 0 {
+		return profile.FollowerCount, true
+	}
+
+	vanityName := linkedin.VanityName(profileURL)
+	if vanityName == "" {
+		return 0, false
+	}
+
+	details, err := c.voyager.GetProfileDetails(ctx, vanityName)
+	if err != nil {
+		c.logger.Warn("Failed to fetch follower count", zap.String(core.LogFieldProfileURL, profileURL), zap.Error(err))
+		return 0, false
+	}
+
+	if err := c.repository.UpdateProfileFollowerCount(ctx, profileURL, details.FollowerCount); err != nil {
+		c.logger.Warn("Failed to store follower count", zap.String(core.LogFieldProfileURL, profileURL), zap.Error(err))
+	}
+
+	return details.FollowerCount, true
+}
+
+// awaitingVisit reports whether visits.days_before_connect holds a profile back because it
+// has not been visited yet, or was visited too recently
+func (c *ConnectWorkflow) awaitingVisit(profile *core.Profile) bool {
+	if profile == nil || profile.VisitedAt == nil {
+		return true
+	}
+	wait := time.Duration(c.config.Visits.DaysBeforeConnect) * 24 * time.Hour
+	return time.Since(*profile.VisitedAt) < wait
+}
+
 // GetRepository returns the repository instance (for rate limiting checks)
 func (c *ConnectWorkflow) GetRepository() core.RepositoryPort {
 	return c.repository
@@ -0,0 +1,97 @@
+package workflows
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// cdpRoundTrip stands in for the latency of one CDP call to a local browser
+const cdpRoundTrip = 200 * time.Microsecond
+
+// profilePageBrowser serves a profile page whose name is only under the last fallback
+// selector, counting each call that would be a CDP round trip
+type profilePageBrowser struct {
+	core.BrowserPort
+	texts      map[string]string
+	roundTrips int
+}
+
+func (b *profilePageBrowser) roundTrip() {
+	b.roundTrips++
+	time.Sleep(cdpRoundTrip)
+}
+
+func (b *profilePageBrowser) GetAllText(ctx context.Context, selectors []string) (map[string]string, error) {
+	b.roundTrip()
+	texts := make(map[string]string, len(selectors))
+	for _, selector := range selectors {
+		texts[selector] = b.texts[selector]
+	}
+	return texts, nil
+}
+
+func (b *profilePageBrowser) ElementExists(ctx context.Context, selector string) (bool, error) {
+	b.roundTrip()
+	_, ok := b.texts[selector]
+	return ok, nil
+}
+
+func (b *profilePageBrowser) GetText(ctx context.Context, selector string) (string, error) {
+	b.roundTrip()
+	return b.texts[selector], nil
+}
+
+// extractProfileNameSequentially is the lookup ExtractProfileName did before GetAllText:
+// an ElementExists and a GetText call per selector
+func extractProfileNameSequentially(ctx context.Context, browser core.BrowserPort) string {
+	selectors := []string{
+		"h1.text-heading-xlarge",
+		"h1[data-anonymize='person-name']",
+		".pv-text-details__left-panel h1",
+		"h1.inline",
+	}
+	for _, selector := range selectors {
+		if exists, _ := browser.ElementExists(ctx, selector); !exists {
+			continue
+		}
+		text, _ := browser.GetText(ctx, selector)
+		if parts := strings.Fields(text); len(parts) > 0 {
+			return parts[0]
+		}
+	}
+	return ""
+}
+
+func BenchmarkExtractProfileName(b *testing.B) {
+	ctx := context.Background()
+	newBrowser := func() *profilePageBrowser {
+		return &profilePageBrowser{texts: map[string]string{"h1.inline": "Jane Doe"}}
+	}
+
+	b.Run("GetAllText", func(b *testing.B) {
+		browser := newBrowser()
+		connect := NewConnectWorkflow(browser, nil, &core.Config{}, zap.NewNop())
+		for i := 0; i < b.N; i++ {
+			if name, err := connect.ExtractProfileName(ctx); err != nil || name != "Jane" {
+				b.Fatalf("ExtractProfileName = %q, %v", name, err)
+			}
+		}
+		b.ReportMetric(float64(browser.roundTrips)/float64(b.N), "round_trips/op")
+	})
+
+	b.Run("Sequential", func(b *testing.B) {
+		browser := newBrowser()
+		for i := 0; i < b.N; i++ {
+			if name := extractProfileNameSequentially(ctx, browser); name != "Jane" {
+				b.Fatalf("extractProfileNameSequentially = %q", name)
+			}
+		}
+		b.ReportMetric(float64(browser.roundTrips)/float64(b.N), "round_trips/op")
+	})
+}
@@ -0,0 +1,92 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ConnectionStatus is the relationship with a profile as shown by its top card buttons
+type ConnectionStatus int
+
+const (
+	StatusNotConnected ConnectionStatus = iota // Connect button shown (or possibly under "More")
+	StatusConnected                            // 1st degree connection
+	StatusPending                              // Invitation sent, awaiting a response
+	StatusFollowOnly                           // Creator account: Follow instead of Connect
+	StatusUnavailable                          // Member no longer available
+)
+
+// String returns the status name for logging
+func (s ConnectionStatus) String() string {
+	switch s {
+	case StatusConnected:
+		return "connected"
+	case StatusPending:
+		return "pending"
+	case StatusFollowOnly:
+		return "follow_only"
+	case StatusUnavailable:
+		return "unavailable"
+	}
+	return "not_connected"
+}
+
+// topCardButtonSelectors find the action buttons of the profile's top card, in priority
+// order. Buttons elsewhere on the page (People also viewed, activity) are left out.
+var topCardButtonSelectors = []string{
+	".pv-top-card button[aria-label]",
+	"main section.artdeco-card:first-of-type button[aria-label]",
+}
+
+// unavailableScript reports whether the page says the member is no longer available
+const unavailableScript = `() => {
+const text = (document.querySelector("main") || document.body).innerText.toLowerCase();
+return text.includes("this member is no longer available") || text.includes("this profile is not available");
+}`
+
+// GetConnectionStatus reads the open profile page's top card buttons and returns the
+// connection status. Connected wins over Connect, and Connect over Follow, since
+// creator profiles can show both Connect and Follow.
+func (c *ConnectWorkflow) GetConnectionStatus(ctx context.Context) (ConnectionStatus, error) {
+	res, err := c.browser.ExecuteScript(ctx, unavailableScript)
+	if err != nil {
+		return StatusNotConnected, fmt.Errorf("failed to read profile page: %w", err)
+	}
+	if fmt.Sprint(res) == "true" {
+		return StatusUnavailable, nil
+	}
+
+	var labels []string
+	for _, selector := range topCardButtonSelectors {
+		labels, err = c.browser.GetAttributes(ctx, selector, "aria-label")
+		if err == nil && len(labels) > 0 {
+			break
+		}
+	}
+
+	hasConnect, hasFollow := false, false
+	for _, label := range labels {
+		label = strings.ToLower(strings.TrimSpace(label))
+		switch {
+		case strings.HasPrefix(label, "pending") || strings.Contains(label, "withdraw invitation"):
+			return StatusPending, nil
+		case strings.HasPrefix(label, "remove connection") || strings.HasPrefix(label, "remove your connection"):
+			return StatusConnected, nil
+		case strings.HasPrefix(label, "invite") && strings.Contains(label, "connect"):
+			hasConnect = true
+		case strings.HasPrefix(label, "follow "):
+			hasFollow = true
+		}
+	}
+
+	// The Remove connection item is not always rendered, so check the degree badge too
+	if degree, err := c.browser.GetText(ctx, ".pv-top-card .dist-value"); err == nil && strings.TrimSpace(degree) == "1st" {
+		return StatusConnected, nil
+	}
+
+	if !hasConnect && hasFollow {
+		return StatusFollowOnly, nil
+	}
+	return StatusNotConnected, nil
+}
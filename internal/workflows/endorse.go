@@ -0,0 +1,159 @@
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// EndorsementWorkflow endorses a connection's top skills as a lightweight touch before messaging
+type EndorsementWorkflow struct {
+	browser    core.BrowserPort
+	repository core.RepositoryPort
+	config     *core.Config
+	logger     *zap.Logger
+}
+
+// NewEndorsementWorkflow creates a new endorsement workflow
+func NewEndorsementWorkflow(browser core.BrowserPort, repo core.RepositoryPort, config *core.Config, logger *zap.Logger) *EndorsementWorkflow {
+	return &EndorsementWorkflow{
+		browser:    browser,
+		repository: repo,
+		config:     config,
+		logger:     logger,
+	}
+}
+
+// endorsableSkill is a skill whose Endorse button has not been pressed yet
+type endorsableSkill struct {
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+}
+
+// EndorseSkills endorses up to maxSkills of a connection's listed skills, top skills first.
+// Each profile is endorsed at most once: profiles already recorded in the repository are
+// skipped. It returns the number of skills endorsed.
+func (e *EndorsementWorkflow) EndorseSkills(ctx context.Context, profileURL string, maxSkills int) (int, error) {
+	if maxSkills <= 0 {
+		maxSkills = 3 // Default fallback
+	}
+
+	profile, err := e.repository.GetProfileByURL(ctx, profileURL)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load profile: %w", err)
+	}
+	if profile == nil {
+		return 0, fmt.Errorf("profile is not in the database")
+	}
+
+	endorsed, err := e.repository.HasEndorsement(ctx, profile.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check endorsement: %w", err)
+	}
+	if endorsed {
+		e.logger.Info("Profile already endorsed, skipping", zap.String("url", profileURL))
+		return 0, nil
+	}
+
+	skillsURL := strings.TrimRight(profileURL, "/") + "/details/skills/"
+	e.logger.Info("Opening profile skills", zap.String("url", skillsURL))
+
+	if err := e.browser.Navigate(ctx, skillsURL); err != nil {
+		return 0, fmt.Errorf("failed to navigate to skills: %w", err)
+	}
+	e.browser.RandomSleep(ctx, 2.0, 4.0)
+
+	skills, err := e.findEndorsableSkills(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(skills) > maxSkills {
+		skills = skills[:maxSkills]
+	}
+
+	names := make([]string, 0, len(skills))
+	for _, skill := range skills {
+		select {
+		case <-ctx.Done():
+			return len(names), ctx.Err()
+		default:
+		}
+
+		selector := fmt.Sprintf("button[data-bot-endorse='%d']", skill.Index)
+		if err := e.browser.HumanClick(ctx, selector); err != nil {
+			e.logger.Warn("Failed to click endorse button", zap.String("skill", skill.Name), zap.Error(err))
+			continue
+		}
+		e.browser.RandomSleep(ctx, 1.5, 3.5)
+
+		names = append(names, skill.Name)
+		e.logger.Info("Endorsed skill", zap.String("url", profileURL), zap.String("skill", skill.Name))
+	}
+
+	// Record the attempt even when nothing was endorsable so the sequence moves on
+	data, err := json.Marshal(names)
+	if err != nil {
+		return len(names), fmt.Errorf("failed to encode endorsed skills: %w", err)
+	}
+	endorsement := &core.Endorsement{
+		ProfileID:  profile.ID,
+		Skills:     string(data),
+		EndorsedAt: time.Now(),
+	}
+	if err := e.repository.CreateEndorsement(ctx, endorsement); err != nil {
+		return len(names), fmt.Errorf("failed to save endorsement: %w", err)
+	}
+
+	if len(names) > 0 {
+		history := core.NewHistory("Endorse", core.HistoryDetails{ProfileURL: profileURL})
+		if err := e.repository.CreateHistory(ctx, history); err != nil {
+			e.logger.Warn("Failed to save history", zap.Error(err))
+		}
+	}
+
+	e.logger.Info("Endorsement complete",
+		zap.String("url", profileURL),
+		zap.Int("endorsed", len(names)),
+	)
+
+	return len(names), nil
+}
+
+// findEndorsableSkills tags each unpressed Endorse button with data-bot-endorse so it
+// can be clicked by selector, and returns the skills in page order
+func (e *EndorsementWorkflow) findEndorsableSkills(ctx context.Context) ([]endorsableSkill, error) {
+	res, err := e.browser.ExecuteScript(ctx, `() => {
+const result = [];
+let index = 0;
+for (const item of document.querySelectorAll("li.pvs-list__paged-list-item, li.artdeco-list__item")) {
+const button = Array.from(item.querySelectorAll("button")).find((b) => b.innerText.trim() === "Endorse");
+if (!button || button.getAttribute("aria-pressed") === "true") continue;
+const label = item.querySelector("span[aria-hidden='true']");
+button.setAttribute("data-bot-endorse", String(index));
+result.push({index: index, name: label ? label.innerText.trim() : ""});
+index++;
+}
+return result;
+}`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find endorsable skills: %w", err)
+	}
+
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read endorsable skills: %w", err)
+	}
+
+	var skills []endorsableSkill
+	if err := json.Unmarshal(raw, &skills); err != nil {
+		return nil, fmt.Errorf("failed to parse endorsable skills: %w", err)
+	}
+
+	return skills, nil
+}
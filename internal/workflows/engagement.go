@@ -0,0 +1,455 @@
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// EngagementWorkflow keeps the account looking active by liking posts from the feed
+// or a hashtag page
+type EngagementWorkflow struct {
+	browser    core.BrowserPort
+	repository core.RepositoryPort
+	config     *core.Config
+	logger     *zap.Logger
+}
+
+// NewEngagementWorkflow creates a new engagement workflow
+func NewEngagementWorkflow(browser core.BrowserPort, repo core.RepositoryPort, config *core.Config, logger *zap.Logger) *EngagementWorkflow {
+	return &EngagementWorkflow{
+		browser:    browser,
+		repository: repo,
+		config:     config,
+		logger:     logger,
+	}
+}
+
+// feedPost is a post card read from the feed
+type feedPost struct {
+	URN      string `json:"urn"`
+	Promoted bool   `json:"promoted"`
+	Liked    bool   `json:"liked"`
+	ActorURL string `json:"actorURL"`
+	Text     string `json:"text"`
+}
+
+// LikePosts likes up to count posts from sourceURL (the home feed if empty). Sponsored
+// posts, posts already liked, posts by blacklisted profiles and posts not matching the
+// keyword filter are skipped. It returns the number of posts liked.
+func (e *EngagementWorkflow) LikePosts(ctx context.Context, count int, sourceURL string) (int, error) {
+	if count <= 0 {
+		return 0, nil
+	}
+
+	if sourceURL == "" {
+		sourceURL = e.config.LinkedIn.BaseURL + "/feed/"
+	}
+
+	maxLikes := e.config.Engagement.MaxLikesPerDay
+	if maxLikes <= 0 {
+		maxLikes = 10 // Default fallback
+	}
+
+	maxScrolls := e.config.Engagement.MaxFeedScrolls
+	if maxScrolls <= 0 {
+		maxScrolls = 15 // Default fallback
+	}
+
+	e.logger.Info("Liking posts", zap.String("source", sourceURL), zap.Int("count", count))
+
+	if err := e.browser.Navigate(ctx, sourceURL); err != nil {
+		return 0, fmt.Errorf("failed to navigate to feed: %w", err)
+	}
+	e.browser.RandomSleep(ctx, 3.0, 5.0)
+
+	seen := make(map[string]bool)
+	likedCount := 0
+	skippedCount := 0
+
+	for scrolls := 0; likedCount < count && scrolls <= maxScrolls; scrolls++ {
+		select {
+		case <-ctx.Done():
+			return likedCount, ctx.Err()
+		default:
+		}
+
+		posts, err := e.extractPosts(ctx)
+		if err != nil {
+			return likedCount, err
+		}
+
+		for _, post := range posts {
+			if likedCount >= count {
+				break
+			}
+			if post.URN == "" || seen[post.URN] {
+				continue
+			}
+			seen[post.URN] = true
+
+			if reason := e.skipReason(post); reason != "" {
+				e.logger.Debug("Skipping post", zap.String("urn", post.URN), zap.String("reason", reason))
+				skippedCount++
+				continue
+			}
+
+			canLike, err := e.repository.CanPerformAction(ctx, "Like", maxLikes)
+			if err != nil {
+				e.logger.Warn("Failed to check like rate limit", zap.Error(err))
+			} else if !canLike {
+				e.logger.Warn("Daily like limit reached, stopping", zap.Int("limit", maxLikes))
+				return likedCount, nil
+			}
+
+			likeSelector := fmt.Sprintf("div[data-urn='%s'] button[aria-label*='React Like']", post.URN)
+			if err := e.browser.HumanClick(ctx, likeSelector); err != nil {
+				e.logger.Warn("Failed to click like button", zap.String("urn", post.URN), zap.Error(err))
+				continue
+			}
+
+			// Let the reaction animation finish
+			e.browser.RandomSleep(ctx, 1.5, 2.5)
+
+			likedCount++
+			history := core.NewHistory("Like", core.HistoryDetails{Target: post.URN})
+			if err := e.repository.CreateHistory(ctx, history); err != nil {
+				e.logger.Warn("Failed to save history", zap.Error(err))
+			}
+
+			e.logger.Info("Liked post", zap.String("urn", post.URN), zap.Int("liked", likedCount))
+
+			// Read a little before moving on
+			e.browser.RandomSleep(ctx, 4.0, 9.0)
+		}
+
+		if likedCount >= count {
+			break
+		}
+
+		if err := e.browser.HumanScroll(ctx, "down", 1200); err != nil {
+			e.logger.Warn("Failed to scroll feed", zap.Error(err))
+		}
+		e.browser.RandomSleep(ctx, 2.0, 4.0)
+	}
+
+	e.logger.Info("Post liking complete",
+		zap.Int("liked", likedCount),
+		zap.Int("skipped", skippedCount),
+	)
+
+	return likedCount, nil
+}
+
+// skipReason returns why a post should not be liked, or an empty string if it may be liked
+func (e *EngagementWorkflow) skipReason(post feedPost) string {
+	if post.Promoted {
+		return "promoted"
+	}
+	if post.Liked {
+		return "already liked"
+	}
+	if isBlacklisted(e.config, post.ActorURL) {
+		return "blacklisted author"
+	}
+
+	keywords := e.config.Engagement.Keywords
+	if len(keywords) > 0 {
+		text := strings.ToLower(post.Text)
+		for _, keyword := range keywords {
+			if strings.Contains(text, strings.ToLower(keyword)) {
+				return ""
+			}
+		}
+		return "no keyword match"
+	}
+
+	return ""
+}
+
+// extractPosts reads every loaded post card in a single script call
+func (e *EngagementWorkflow) extractPosts(ctx context.Context) ([]feedPost, error) {
+	res, err := e.browser.ExecuteScript(ctx, `() => {
+const result = [];
+for (const post of document.querySelectorAll("div[data-urn^='urn:li:activity']")) {
+const actor = post.querySelector(".update-components-actor");
+const actorText = actor ? actor.innerText : "";
+const like = post.querySelector("button[aria-label*='React Like']");
+const link = actor ? actor.querySelector("a[href*='/in/']") : null;
+const body = post.querySelector(".update-components-text, .feed-shared-update-v2__description");
+result.push({
+urn: post.getAttribute("data-urn") || "",
+promoted: /\bPromoted\b/.test(actorText),
+liked: like ? like.getAttribute("aria-pressed") === "true" : true,
+actorURL: link ? link.href.split("?")[0] : "",
+text: body ? body.innerText : ""
+});
+}
+return result;
+}`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract feed posts: %w", err)
+	}
+
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feed posts: %w", err)
+	}
+
+	var posts []feedPost
+	if err := json.Unmarshal(raw, &posts); err != nil {
+		return nil, fmt.Errorf("failed to parse feed posts: %w", err)
+	}
+
+	return posts, nil
+}
+
+// isBlacklisted reports whether a profile URL is in targeting.blacklist
+func isBlacklisted(config *core.Config, profileURL string) bool {
+	if profileURL == "" {
+		return false
+	}
+
+	normalized := strings.ToLower(strings.TrimRight(profileURL, "/"))
+	for _, entry := range config.Targeting.Blacklist {
+		if strings.ToLower(strings.TrimRight(entry, "/")) == normalized {
+			return true
+		}
+	}
+
+	return false
+}
+
+// postAuthor is the author of an open post page
+type postAuthor struct {
+	URL  string `json:"url"`
+	Name string `json:"name"`
+}
+
+// CommentOnPost opens a post and comments on it with the rendered template. The author
+// must be allowlisted (by profile URL or campaign) and must not have been commented on
+// within the cooldown. In dry-run mode the rendered comment is only logged. It returns
+// true only if a comment was posted and seen in the comment list; a submitted comment that
+// was not seen is still recorded, with the outcome "unconfirmed".
+func (e *EngagementWorkflow) CommentOnPost(ctx context.Context, postURL, template string) (bool, error) {
+	if postURL == "" {
+		return false, fmt.Errorf("post URL is required")
+	}
+	if template == "" {
+		template = e.config.Engagement.CommentTemplate
+	}
+	if template == "" {
+		return false, fmt.Errorf("comment template is required")
+	}
+
+	maxComments := e.config.Engagement.MaxCommentsPerDay
+	if maxComments <= 0 {
+		maxComments = 3 // Default fallback
+	}
+
+	cooldownDays := e.config.Engagement.CommentCooldownDays
+	if cooldownDays <= 0 {
+		cooldownDays = 30 // Default fallback
+	}
+
+	canComment, err := e.repository.CanPerformAction(ctx, "Comment", maxComments)
+	if err != nil {
+		e.logger.Warn("Failed to check comment rate limit", zap.Error(err))
+	} else if !canComment {
+		e.logger.Info("Daily comment limit reached, skipping", zap.Int("limit", maxComments))
+		return false, nil
+	}
+
+	e.logger.Info("Opening post", zap.String("url", postURL))
+	if err := e.browser.Navigate(ctx, postURL); err != nil {
+		return false, fmt.Errorf("failed to navigate to post: %w", err)
+	}
+	e.browser.RandomSleep(ctx, 2.0, 4.0)
+
+	author, err := e.extractPostAuthor(ctx)
+	if err != nil {
+		return false, err
+	}
+	if author.URL == "" {
+		e.logger.Info("Post author not found, skipping", zap.String("url", postURL))
+		return false, nil
+	}
+
+	allowed, err := e.isCommentAllowed(ctx, author.URL)
+	if err != nil {
+		return false, err
+	}
+	if !allowed {
+		e.logger.Info("Author not in comment allowlist, skipping", zap.String("author", author.URL))
+		return false, nil
+	}
+
+	last, err := e.repository.GetLastCommentForAuthor(ctx, author.URL)
+	if err != nil {
+		return false, fmt.Errorf("failed to check comment cooldown: %w", err)
+	}
+	if last != nil && time.Since(last.CommentedAt) < time.Duration(cooldownDays)*24*time.Hour {
+		e.logger.Info("Commented on this author recently, skipping",
+			zap.String("author", author.URL),
+			zap.Time("last_commented_at", last.CommentedAt),
+		)
+		return false, nil
+	}
+
+	firstName := ""
+	if parts := strings.Fields(author.Name); len(parts) > 0 {
+		firstName = parts[0]
+	}
+	body := strings.ReplaceAll(template, "{{FirstName}}", firstName)
+	body = strings.ReplaceAll(body, "{{Snippet}}", e.config.Engagement.CommentSnippet)
+	body = strings.TrimSpace(body)
+
+	if e.config.Engagement.CommentDryRun {
+		e.logger.Info("Dry run: comment not posted",
+			zap.String("url", postURL),
+			zap.String("author", author.URL),
+			zap.String("comment", body),
+		)
+		return false, nil
+	}
+
+	if err := e.browser.HumanClick(ctx, "button[aria-label='Comment']"); err != nil {
+		return false, fmt.Errorf("failed to open comment box: %w", err)
+	}
+
+	editorSelector := ".comments-comment-box .ql-editor"
+	if err := e.browser.WaitForElement(ctx, editorSelector, elementTimeout(&e.config.Selectors)); err != nil {
+		return false, fmt.Errorf("comment box not found: %w", err)
+	}
+	e.browser.RandomSleep(ctx, 1.0, 2.0)
+
+	if err := e.browser.HumanType(ctx, editorSelector, body); err != nil {
+		return false, fmt.Errorf("failed to type comment: %w", err)
+	}
+	e.browser.RandomSleep(ctx, 1.0, 2.5)
+
+	if err := e.browser.HumanClick(ctx, "button.comments-comment-box__submit-button"); err != nil {
+		return false, fmt.Errorf("failed to submit comment: %w", err)
+	}
+	e.browser.RandomSleep(ctx, 2.0, 3.5)
+
+	// A comment that was submitted but not found may still have been posted, so it is
+	// recorded either way and counts toward the author cooldown and the daily limit
+	confirmed := e.commentVisible(ctx, body)
+	outcome := "posted"
+	if !confirmed {
+		e.logger.Warn("Comment was not found after submitting", zap.String("url", postURL))
+		outcome = "unconfirmed"
+	}
+
+	comment := &core.PostComment{
+		AuthorURL:   author.URL,
+		PostURL:     postURL,
+		Body:        body,
+		CommentedAt: time.Now(),
+	}
+	if err := e.repository.CreatePostComment(ctx, comment); err != nil {
+		e.logger.Warn("Failed to save comment", zap.Error(err))
+	}
+
+	history := core.NewHistory("Comment", core.HistoryDetails{Target: postURL, Outcome: outcome})
+	if err := e.repository.CreateHistory(ctx, history); err != nil {
+		e.logger.Warn("Failed to save history", zap.Error(err))
+	}
+
+	if !confirmed {
+		return false, nil
+	}
+
+	e.logger.Info("Commented on post", zap.String("url", postURL), zap.String("author", author.URL))
+	return true, nil
+}
+
+// isCommentAllowed reports whether the author's profile URL, or the campaign the
+// profile was discovered for, is in engagement.comment_allowlist
+func (e *EngagementWorkflow) isCommentAllowed(ctx context.Context, authorURL string) (bool, error) {
+	allowlist := e.config.Engagement.CommentAllowlist
+	if len(allowlist) == 0 {
+		return false, nil
+	}
+
+	normalized := strings.ToLower(strings.TrimRight(authorURL, "/"))
+	for _, entry := range allowlist {
+		if strings.ToLower(strings.TrimRight(entry, "/")) == normalized {
+			return true, nil
+		}
+	}
+
+	profile, err := e.repository.GetProfileByURL(ctx, authorURL)
+	if err != nil {
+		return false, fmt.Errorf("failed to load author profile: %w", err)
+	}
+	if profile == nil || profile.Campaign == "" {
+		return false, nil
+	}
+
+	for _, entry := range allowlist {
+		if strings.EqualFold(entry, profile.Campaign) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// extractPostAuthor reads the author's profile URL and name from an open post page
+func (e *EngagementWorkflow) extractPostAuthor(ctx context.Context) (*postAuthor, error) {
+	res, err := e.browser.ExecuteScript(ctx, `() => {
+const actor = document.querySelector(".update-components-actor");
+if (!actor) return {url: "", name: ""};
+const link = actor.querySelector("a[href*='/in/']");
+const name = actor.querySelector(".update-components-actor__title span[aria-hidden='true']");
+return {
+url: link ? link.href.split("?")[0].replace(/\/$/, "") : "",
+name: name ? name.innerText.trim() : ""
+};
+}`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract post author: %w", err)
+	}
+
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read post author: %w", err)
+	}
+
+	var author postAuthor
+	if err := json.Unmarshal(raw, &author); err != nil {
+		return nil, fmt.Errorf("failed to parse post author: %w", err)
+	}
+
+	return &author, nil
+}
+
+// commentVisible reports whether a comment with the given body is shown under the post
+func (e *EngagementWorkflow) commentVisible(ctx context.Context, body string) bool {
+	quoted, err := json.Marshal(body)
+	if err != nil {
+		return false
+	}
+
+	res, err := e.browser.ExecuteScript(ctx, fmt.Sprintf(`() => {
+const body = %s;
+for (const item of document.querySelectorAll(".comments-comment-item__main-content, .comments-comment-entity")) {
+if (item.innerText.trim().includes(body)) return true;
+}
+return false;
+}`, quoted))
+	if err != nil {
+		e.logger.Debug("Failed to check for posted comment", zap.Error(err))
+		return false
+	}
+
+	return res != nil && fmt.Sprint(res) == "true"
+}
@@ -0,0 +1,234 @@
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// EnrichmentWorkflow captures current role and education from profile pages for lead scoring
+type EnrichmentWorkflow struct {
+	browser    core.BrowserPort
+	repository core.RepositoryPort
+	config     *core.Config
+	logger     *zap.Logger
+}
+
+// NewEnrichmentWorkflow creates a new enrichment workflow
+func NewEnrichmentWorkflow(browser core.BrowserPort, repo core.RepositoryPort, config *core.Config, logger *zap.Logger) *EnrichmentWorkflow {
+	return &EnrichmentWorkflow{
+		browser:    browser,
+		repository: repo,
+		config:     config,
+		logger:     logger,
+	}
+}
+
+// profileSections is the raw Experience/Education data read from a profile page
+type profileSections struct {
+	HasExperience bool   `json:"hasExperience"`
+	Title         string `json:"title"`
+	Company       string `json:"company"`
+	CompanyURL    string `json:"companyURL"`
+	Dates         string `json:"dates"`
+	HasEducation  bool   `json:"hasEducation"`
+	School        string `json:"school"`
+}
+
+// EnrichPending enriches profiles that have not been enriched yet, up to the configured batch limit
+func (e *EnrichmentWorkflow) EnrichPending(ctx context.Context) error {
+	limit := e.config.Enrichment.BatchLimit
+	if limit <= 0 {
+		limit = 10 // Default fallback
+	}
+
+	profiles, err := e.repository.GetProfilesForEnrichment(ctx, limit)
+	if err != nil {
+		return fmt.Errorf("failed to load profiles for enrichment: %w", err)
+	}
+
+	if len(profiles) == 0 {
+		e.logger.Info("No profiles pending enrichment")
+		return nil
+	}
+
+	e.logger.Info("Starting enrichment", zap.Int("count", len(profiles)))
+
+	enrichedCount := 0
+	for _, profile := range profiles {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if err := e.EnrichProfile(ctx, profile.LinkedInURL); err != nil {
+			e.logger.Warn("Failed to enrich profile", zap.String(core.LogFieldProfileURL, profile.LinkedInURL), zap.Error(err))
+			continue
+		}
+		enrichedCount++
+
+		e.browser.RandomSleep(ctx, 3.0, 6.0)
+	}
+
+	e.logger.Info("Enrichment complete",
+		zap.Int("pending", len(profiles)),
+		zap.Int("enriched", enrichedCount),
+	)
+
+	return nil
+}
+
+// EnrichProfile reads the first Experience entry and most recent Education entry of a
+// profile and stores them. It reuses the current page if it is already on the profile.
+func (e *EnrichmentWorkflow) EnrichProfile(ctx context.Context, profileURL string) error {
+	if profileURL == "" {
+		return fmt.Errorf("profile URL is required")
+	}
+	logger := ProfileLogger(e.logger, profileURL)
+
+	currentURL, err := e.browser.GetCurrentURL(ctx)
+	if err != nil || core.NormalizeProfileURL(currentURL) != core.NormalizeProfileURL(profileURL) {
+		if err := e.browser.Navigate(ctx, profileURL); err != nil {
+			return fmt.Errorf("failed to navigate to profile: %w", err)
+		}
+		e.browser.RandomSleep(ctx, 2.0, 4.0)
+	}
+
+	// Experience and Education are lazy-loaded below the top card
+	if err := e.browser.HumanScroll(ctx, "down", 1200); err != nil {
+		logger.Warn("Failed to scroll profile", zap.Error(err))
+	}
+	e.browser.RandomSleep(ctx, 1.0, 2.0)
+
+	sections, err := e.extractSections(ctx)
+	if err != nil {
+		return err
+	}
+
+	enrichment := &core.ProfileEnrichment{
+		CurrentTitle:      sections.Title,
+		CurrentCompany:    sections.Company,
+		CurrentCompanyURL: sections.CompanyURL,
+		School:            sections.School,
+	}
+	if startedAt, ok := parseRoleStartDate(sections.Dates); ok {
+		enrichment.CurrentRoleStartedAt = &startedAt
+	}
+
+	if !sections.HasExperience {
+		logger.Info("Profile has no experience section")
+	}
+
+	if err := e.repository.UpdateProfileEnrichment(ctx, profileURL, enrichment); err != nil {
+		return fmt.Errorf("failed to store enrichment: %w", err)
+	}
+
+	logger.Info("Profile enriched",
+		actionField("Enrich"),
+		zap.String("title", enrichment.CurrentTitle),
+		zap.String("company", enrichment.CurrentCompany),
+		zap.String("school", enrichment.School),
+	)
+
+	return nil
+}
+
+// extractSections expands truncated entries and reads the Experience and Education
+// sections with two script calls instead of one element query per field
+func (e *EnrichmentWorkflow) extractSections(ctx context.Context) (*profileSections, error) {
+	// Expand "see more" truncation inside the two sections
+	if _, err := e.browser.ExecuteScript(ctx, `() => {
+for (const id of ["experience", "education"]) {
+const anchor = document.getElementById(id);
+const section = anchor ? anchor.closest("section") : null;
+if (!section) continue;
+for (const btn of section.querySelectorAll(".inline-show-more-text__button, button[aria-expanded='false']")) {
+btn.click();
+}
+}
+}`); err != nil {
+		e.logger.Debug("Failed to expand profile sections", zap.Error(err))
+	} else {
+		e.browser.RandomSleep(ctx, 0.5, 1.0)
+	}
+
+	res, err := e.browser.ExecuteScript(ctx, `() => {
+const sectionFor = (id) => {
+const anchor = document.getElementById(id);
+return anchor ? anchor.closest("section") : null;
+};
+const texts = (el) => Array.from(el.querySelectorAll("span[aria-hidden='true']"))
+.map((s) => s.innerText.trim()).filter((t) => t);
+const result = {hasExperience: false, title: "", company: "", companyURL: "", dates: "", hasEducation: false, school: ""};
+
+const experience = sectionFor("experience");
+const entry = experience ? experience.querySelector("li.artdeco-list__item") : null;
+if (entry) {
+result.hasExperience = true;
+const link = entry.querySelector("a[href*='/company/']");
+result.companyURL = link ? link.href.split("?")[0] : "";
+const role = entry.querySelector("ul li");
+const lines = texts(entry);
+if (role && texts(role).length > 0) {
+// Several roles at one company: the entry header is the company
+const roleLines = texts(role);
+result.company = lines[0] || "";
+result.title = roleLines[0] || "";
+result.dates = roleLines.find((t) => /\d{4}/.test(t)) || "";
+} else {
+result.title = lines[0] || "";
+result.company = (lines[1] || "").split(" · ")[0];
+result.dates = lines.slice(2).find((t) => /\d{4}/.test(t)) || "";
+}
+}
+
+const education = sectionFor("education");
+const school = education ? education.querySelector("li.artdeco-list__item") : null;
+if (school) {
+result.hasEducation = true;
+result.school = texts(school)[0] || "";
+}
+return result;
+}`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract profile sections: %w", err)
+	}
+
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile sections: %w", err)
+	}
+
+	var sections profileSections
+	if err := json.Unmarshal(raw, &sections); err != nil {
+		return nil, fmt.Errorf("failed to parse profile sections: %w", err)
+	}
+
+	return &sections, nil
+}
+
+// roleDatePattern captures the start of an experience date range ("Jan 2020 - Present")
+var roleDatePattern = regexp.MustCompile(`^\s*((?:[A-Za-z]+\s+)?\d{4})\s*[-–]`)
+
+// parseRoleStartDate extracts the start date from an experience date range
+func parseRoleStartDate(dates string) (time.Time, bool) {
+	match := roleDatePattern.FindStringSubmatch(dates)
+	if match == nil {
+		return time.Time{}, false
+	}
+
+	for _, layout := range []string{"Jan 2006", "January 2006", "2006"} {
+		if t, err := time.Parse(layout, match[1]); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
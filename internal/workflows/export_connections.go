@@ -0,0 +1,335 @@
+package workflows
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"linkedin-automation/internal/core"
+	"linkedin-automation/pkg/utils"
+
+	"go.uber.org/zap"
+)
+
+const (
+	exportMaxScrolls  = 2000 // Safety cap, enough for roughly 20k connections
+	exportIdleScrolls = 4    // Consecutive scrolls without new cards before giving up
+	exportFlushEvery  = 100  // Rows written between CSV flushes
+)
+
+// connectionsTotalPattern matches the "1,234 connections" header above the list
+var connectionsTotalPattern = regexp.MustCompile(`(?i)([\d,.]+)\s+connections?`)
+
+// ConnectionExport summarizes an export run
+type ConnectionExport struct {
+	Exported      int   // Rows written to the CSV
+	LinkedInTotal int   // Count shown in the connections page header (0 if not found)
+	DBBefore      int64 // Connected profiles in the database before the export
+	DBAfter       int64 // Connected profiles in the database after reconciling
+	Added         int   // Connections that were not in the database
+	Updated       int   // Known profiles newly marked connected
+}
+
+// ExportConnectionsWorkflow dumps the full connections list to CSV and reconciles it
+// into the database, without waiting for LinkedIn's data archive
+type ExportConnectionsWorkflow struct {
+	browser    core.BrowserPort
+	repository core.RepositoryPort
+	config     *core.Config
+	logger     *zap.Logger
+	messenger  *MessagingWorkflow
+}
+
+// NewExportConnectionsWorkflow creates a new export workflow
+func NewExportConnectionsWorkflow(browser core.BrowserPort, repo core.RepositoryPort, config *core.Config, logger *zap.Logger) *ExportConnectionsWorkflow {
+	return &ExportConnectionsWorkflow{
+		browser:    browser,
+		repository: repo,
+		config:     config,
+		logger:     logger,
+		messenger:  NewMessagingWorkflow(browser, repo, config, logger),
+	}
+}
+
+// connectionCard is a card read from the connections list
+type connectionCard struct {
+	Name      string `json:"name"`
+	Href      string `json:"href"`
+	Headline  string `json:"headline"`
+	Connected string `json:"connected"`
+}
+
+// Export paginates through the whole connections list and writes one CSV row per
+// connection to outPath. Cards are read in batches as they load and written straight
+// to the file, so memory stays bounded by the set of URLs seen.
+func (e *ExportConnectionsWorkflow) Export(ctx context.Context, outPath string) (*ConnectionExport, error) {
+	result := &ConnectionExport{}
+
+	before, err := e.repository.CountProfilesByStatus(ctx, core.ProfileStatusConnected)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count connections in database: %w", err)
+	}
+	result.DBBefore = before
+
+	connectionsURL := e.config.LinkedIn.BaseURL + "/mynetwork/invite-connect/connections/?sortType=RECENTLY_ADDED"
+	if err := e.browser.Navigate(ctx, connectionsURL); err != nil {
+		return nil, fmt.Errorf("failed to navigate to connections page: %w", err)
+	}
+
+	listSelector := "div[data-view-name='connections-list']"
+	if err := e.browser.WaitForElement(ctx, listSelector, elementTimeout(&e.config.Selectors)); err != nil {
+		if html, errHtml := e.browser.GetPageHTML(ctx); errHtml == nil {
+			dumpPath := fmt.Sprintf("data/debug_export_fail_%d.html", time.Now().Unix())
+			_ = os.WriteFile(dumpPath, []byte(html), 0644)
+		}
+		return nil, fmt.Errorf("connections list not found: %w", err)
+	}
+
+	result.LinkedInTotal = e.readTotal(ctx)
+
+	if dir := filepath.Dir(outPath); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create export directory: %w", err)
+		}
+	}
+	file, err := os.Create(outPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write([]string{"name", "profile_url", "headline", "connected_at", "connected_text"}); err != nil {
+		return nil, fmt.Errorf("failed to write export header: %w", err)
+	}
+
+	e.logger.Info("Exporting connections", zap.Int("linkedin_total", result.LinkedInTotal), zap.String("out", outPath))
+
+	seen := make(map[string]bool)
+	idleScrolls := 0
+	for scrolls := 0; scrolls < exportMaxScrolls; scrolls++ {
+		select {
+		case <-ctx.Done():
+			writer.Flush()
+			return result, ctx.Err()
+		default:
+		}
+
+		cards, err := e.nextCards(ctx)
+		if err != nil {
+			return result, err
+		}
+
+		newCount := 0
+		now := time.Now()
+		for _, card := range cards {
+			profileURL := e.messenger.cleanProfileURL(card.Href)
+			if profileURL == "" || seen[profileURL] {
+				continue
+			}
+			seen[profileURL] = true
+			newCount++
+
+			connectedAt, hasDate := utils.ParseRelativeTime(card.Connected, now)
+			connectedAtText := ""
+			if hasDate {
+				connectedAtText = connectedAt.Format("2006-01-02")
+			}
+
+			if err := writer.Write([]string{card.Name, profileURL, card.Headline, connectedAtText, card.Connected}); err != nil {
+				return result, fmt.Errorf("failed to write export row: %w", err)
+			}
+			result.Exported++
+			if result.Exported%exportFlushEvery == 0 {
+				writer.Flush()
+				e.logger.Info("Export progress", zap.Int("exported", result.Exported))
+			}
+
+			var datePtr *time.Time
+			if hasDate {
+				datePtr = &connectedAt
+			}
+			e.reconcile(ctx, profileURL, card, datePtr, result)
+		}
+
+		if newCount == 0 {
+			idleScrolls++
+			if idleScrolls >= exportIdleScrolls {
+				break
+			}
+			e.clickShowMore(ctx)
+		} else {
+			idleScrolls = 0
+		}
+
+		if err := e.browser.HumanScroll(ctx, "down", 1200); err != nil {
+			e.logger.Warn("Failed to scroll connections list", zap.Error(err))
+		}
+		e.browser.RandomSleep(ctx, 1.5, 3.0)
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return result, fmt.Errorf("failed to write export file: %w", err)
+	}
+
+	after, err := e.repository.CountProfilesByStatus(ctx, core.ProfileStatusConnected)
+	if err != nil {
+		e.logger.Warn("Failed to count connections in database", zap.Error(err))
+	}
+	result.DBAfter = after
+
+	history := core.NewHistory("Export", core.HistoryDetails{
+		Target: outPath,
+		Counts: map[string]int{
+			"exported":       result.Exported,
+			"linkedin_total": result.LinkedInTotal,
+			"db_before":      int(result.DBBefore),
+			"db_after":       int(result.DBAfter),
+		},
+	})
+	if err := e.repository.CreateHistory(ctx, history); err != nil {
+		e.logger.Warn("Failed to save history", zap.Error(err))
+	}
+
+	return result, nil
+}
+
+// reconcile adds unknown connections to the database and marks known profiles connected
+func (e *ExportConnectionsWorkflow) reconcile(ctx context.Context, profileURL string, card connectionCard, connectedAt *time.Time, result *ConnectionExport) {
+	profile, err := e.repository.GetProfileByURL(ctx, profileURL)
+	if err != nil {
+		e.logger.Warn("Failed to query profile", zap.String("url", profileURL), zap.Error(err))
+		return
+	}
+
+	if profile == nil {
+		if connectedAt == nil {
+			now := time.Now()
+			connectedAt = &now
+		}
+		newProfile := &core.Profile{
+			LinkedInURL: profileURL,
+			Status:      core.ProfileStatusConnected,
+			ConnectedAt: connectedAt,
+			Name:         card.Name,
+			Headline:     card.Headline,
+			Source:       core.ProfileSourceScan,
+			SourceDetail: "export",
+		}
+		if err := e.repository.CreateProfile(ctx, newProfile); err != nil {
+			e.logger.Warn("Failed to add connection", zap.String("url", profileURL), zap.Error(err))
+			return
+		}
+		result.Added++
+		return
+	}
+
+	// Messaged and replied profiles are already past Connected
+	if profile.Status != core.ProfileStatusConnected && core.IsValidTransition(profile.Status, core.ProfileStatusConnected) {
+		if connectedAt != nil {
+			err = e.repository.MarkAsConnectedAt(ctx, profileURL, *connectedAt)
+		} else {
+			err = e.repository.MarkAsConnected(ctx, profileURL)
+		}
+		if err != nil {
+			e.logger.Warn("Failed to mark profile as connected", zap.String("url", profileURL), zap.Error(err))
+		} else {
+			result.Updated++
+		}
+	}
+
+	if err := e.repository.UpdateProfileIdentity(ctx, profileURL, card.Name, card.Headline); err != nil {
+		e.logger.Warn("Failed to update profile", zap.String("url", profileURL), zap.Error(err))
+	}
+}
+
+// nextCards reads the connection cards that haven't been read yet, tagging each with
+// data-bot-exported so the next call only returns newly loaded cards
+func (e *ExportConnectionsWorkflow) nextCards(ctx context.Context) ([]connectionCard, error) {
+	res, err := e.browser.ExecuteScript(ctx, `() => {
+const result = [];
+for (const a of document.querySelectorAll("a[data-view-name='connections-profile']:not([data-bot-exported])")) {
+a.setAttribute("data-bot-exported", "1");
+const card = a.closest("li") || a.parentElement;
+const lines = (card ? card.innerText : a.innerText).split("\n").map(l => l.trim()).filter(l => l);
+const connected = lines.find(l => /^Connected/i.test(l)) || "";
+const time = card ? card.querySelector("time") : null;
+const rest = lines.filter(l => l !== connected && !/^(Message|Remove connection)$/i.test(l));
+result.push({
+href: a.getAttribute("href") || "",
+name: rest[0] || "",
+headline: rest[1] || "",
+connected: time ? time.innerText.trim() : connected
+});
+}
+return result;
+}`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract connection cards: %w", err)
+	}
+
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read connection cards: %w", err)
+	}
+
+	var cards []connectionCard
+	if err := json.Unmarshal(raw, &cards); err != nil {
+		return nil, fmt.Errorf("failed to parse connection cards: %w", err)
+	}
+
+	return cards, nil
+}
+
+// readTotal reads the connection count from the page header
+func (e *ExportConnectionsWorkflow) readTotal(ctx context.Context) int {
+	res, err := e.browser.ExecuteScript(ctx, `() => {
+const header = document.querySelector("main h1, main h2, header h1");
+return header ? header.innerText : "";
+}`)
+	if err != nil {
+		e.logger.Warn("Failed to read connection count", zap.Error(err))
+		return 0
+	}
+
+	match := connectionsTotalPattern.FindStringSubmatch(fmt.Sprint(res))
+	if match == nil {
+		return 0
+	}
+	total, err := strconv.Atoi(strings.NewReplacer(",", "", ".", "").Replace(match[1]))
+	if err != nil {
+		return 0
+	}
+	return total
+}
+
+// clickShowMore clicks the "Show more results" button LinkedIn shows after a few
+// hundred connections instead of loading more on scroll
+func (e *ExportConnectionsWorkflow) clickShowMore(ctx context.Context) {
+	res, err := e.browser.ExecuteScript(ctx, `() => {
+for (const button of document.querySelectorAll("main button")) {
+if (/show more results/i.test(button.innerText)) {
+button.setAttribute("data-bot-show-more", "1");
+return true;
+}
+}
+return false;
+}`)
+	if err != nil || fmt.Sprint(res) != "true" {
+		return
+	}
+
+	if err := e.browser.HumanClick(ctx, "button[data-bot-show-more='1']"); err != nil {
+		e.logger.Debug("Failed to click show more", zap.Error(err))
+		return
+	}
+	e.browser.RandomSleep(ctx, 2.0, 3.5)
+}
@@ -0,0 +1,240 @@
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"linkedin-automation/internal/core"
+	"linkedin-automation/pkg/linkedin"
+
+	"go.uber.org/zap"
+)
+
+// FeedScraperWorkflow discovers target profiles from the home feed: the authors of posts
+// about the configured topics and the people commenting on them
+type FeedScraperWorkflow struct {
+	browser    core.BrowserPort
+	repository core.RepositoryPort
+	config     *core.Config
+	logger     *zap.Logger
+}
+
+// NewFeedScraperWorkflow creates a new feed scraper workflow
+func NewFeedScraperWorkflow(browser core.BrowserPort, repo core.RepositoryPort, config *core.Config, logger *zap.Logger) *FeedScraperWorkflow {
+	return &FeedScraperWorkflow{
+		browser:    browser,
+		repository: repo,
+		config:     config,
+		logger:     logger,
+	}
+}
+
+// scrapedPost is a feed post card with the people shown on it
+type scrapedPost struct {
+	URN        string   `json:"urn"`
+	Promoted   bool     `json:"promoted"`
+	AuthorURL  string   `json:"authorURL"`
+	Text       string   `json:"text"`
+	Commenters []string `json:"commenters"`
+}
+
+// DiscoverFromFeed scrolls the home feed and returns up to maxProfiles new profile URLs:
+// the authors of posts whose text contains one of keywords (case-insensitive) and the
+// commenters visible under those posts. Profiles are saved as Discovered with the post
+// they were found on; profiles already in the database and blacklisted ones are left out.
+func (f *FeedScraperWorkflow) DiscoverFromFeed(ctx context.Context, keywords []string, maxProfiles int) ([]string, error) {
+	if len(keywords) == 0 {
+		return nil, fmt.Errorf("at least one feed keyword is required")
+	}
+	if maxProfiles <= 0 {
+		return nil, fmt.Errorf("max profiles must be positive")
+	}
+
+	maxScrolls := f.config.Search.FeedScraping.MaxScrolls
+	if maxScrolls <= 0 {
+		maxScrolls = 15 // Default fallback
+	}
+
+	f.logger.Info("Discovering profiles from feed",
+		zap.Strings("keywords", keywords),
+		zap.Int("max_profiles", maxProfiles),
+	)
+
+	if err := f.browser.Navigate(ctx, f.config.LinkedIn.BaseURL+"/feed/"); err != nil {
+		return nil, fmt.Errorf("failed to navigate to feed: %w", err)
+	}
+	f.browser.RandomSleep(ctx, 3.0, 5.0)
+
+	seenPosts := make(map[string]bool)
+	seenProfiles := make(map[string]bool)
+	discovered := make([]string, 0)
+	matchedPosts := 0
+
+	for scrolls := 0; len(discovered) < maxProfiles && scrolls <= maxScrolls; scrolls++ {
+		select {
+		case <-ctx.Done():
+			return discovered, ctx.Err()
+		default:
+		}
+
+		posts, err := f.extractPosts(ctx)
+		if err != nil {
+			return discovered, err
+		}
+
+		for _, post := range posts {
+			if post.URN == "" || seenPosts[post.URN] {
+				continue
+			}
+			seenPosts[post.URN] = true
+
+			if post.Promoted || !containsKeyword(post.Text, keywords) {
+				continue
+			}
+			matchedPosts++
+
+			postURL := f.config.LinkedIn.BaseURL + "/feed/update/" + post.URN + "/"
+			candidates := make([]*core.Profile, 0, 1+len(post.Commenters))
+			for _, rawURL := range append([]string{post.AuthorURL}, post.Commenters...) {
+				profileURL := f.cleanProfileURL(rawURL)
+				if profileURL == "" || seenProfiles[profileURL] {
+					continue
+				}
+				seenProfiles[profileURL] = true
+				candidates = append(candidates, &core.Profile{
+					LinkedInURL:       profileURL,
+					Status:            core.ProfileStatusDiscovered,
+					SourceFeedPostURL: postURL,
+					Source:            core.ProfileSourcePost,
+					SourceDetail:      post.URN,
+				})
+			}
+			if len(candidates) == 0 {
+				continue
+			}
+
+			// Profiles already in the DB are skipped by the insert and keep their source
+			created, skipped, err := f.repository.BulkCreateProfiles(ctx, candidates)
+			if err != nil {
+				f.logger.Warn("Failed to save feed profiles to DB", zap.String("post", postURL), zap.Error(err))
+				continue
+			}
+			f.logger.Debug("Saved feed profiles to DB",
+				zap.String("post", postURL),
+				zap.Int("created", created),
+				zap.Int("skipped", skipped),
+			)
+
+			for _, profile := range candidates {
+				if len(discovered) >= maxProfiles {
+					break
+				}
+				if profile.ID == 0 {
+					continue
+				}
+				if matchBlacklist(ctx, f.repository, f.logger, profile) {
+					continue
+				}
+				discovered = append(discovered, profile.LinkedInURL)
+			}
+		}
+
+		if len(discovered) >= maxProfiles {
+			break
+		}
+
+		if err := f.browser.HumanScroll(ctx, "down", 1200); err != nil {
+			f.logger.Warn("Failed to scroll feed", zap.Error(err))
+		}
+		f.browser.RandomSleep(ctx, 2.0, 4.0)
+	}
+
+	f.logger.Info("Feed discovery completed",
+		zap.Int("posts_seen", len(seenPosts)),
+		zap.Int("posts_matched", matchedPosts),
+		zap.Int("profiles_found", len(discovered)),
+	)
+
+	history := core.NewHistory("FeedScrape", core.HistoryDetails{
+		Keyword: strings.Join(keywords, ","),
+		Found:   len(discovered),
+		Counts:  map[string]int{"posts": len(seenPosts), "matched": matchedPosts},
+	})
+	if err := f.repository.CreateHistory(ctx, history); err != nil {
+		f.logger.Warn("Failed to save history", zap.Error(err))
+	}
+
+	return discovered, nil
+}
+
+// cleanProfileURL returns the normalized profile URL of a feed link, or an empty string
+// for company pages, placeholders and anything else that is not a person's profile
+func (f *FeedScraperWorkflow) cleanProfileURL(rawURL string) string {
+	if rawURL == "" {
+		return ""
+	}
+	if !strings.HasPrefix(rawURL, "http") {
+		rawURL = f.config.LinkedIn.BaseURL + rawURL
+	}
+
+	profileURL := core.NormalizeProfileURL(rawURL)
+	if err := linkedin.ValidateProfileURL(profileURL); err != nil {
+		f.logger.Debug("Skipping feed URL", zap.Error(err))
+		return ""
+	}
+	return profileURL
+}
+
+// extractPosts reads every loaded post card, with its author and visible commenters,
+// in a single script call
+func (f *FeedScraperWorkflow) extractPosts(ctx context.Context) ([]scrapedPost, error) {
+	res, err := f.browser.ExecuteScript(ctx, `() => {
+const result = [];
+for (const post of document.querySelectorAll("div[data-urn^='urn:li:activity']")) {
+const actor = post.querySelector(".update-components-actor");
+const actorText = actor ? actor.innerText : "";
+const author = post.querySelector(".update-components-actor__name a") || (actor ? actor.querySelector("a[href*='/in/']") : null);
+const body = post.querySelector(".update-components-text, .feed-shared-update-v2__description");
+const commenters = [];
+for (const link of post.querySelectorAll(".comments-comment-item a[href*='/in/'], .comments-comment-entity a[href*='/in/']")) {
+commenters.push(link.href);
+}
+result.push({
+urn: post.getAttribute("data-urn") || "",
+promoted: /\bPromoted\b/.test(actorText),
+authorURL: author ? author.href : "",
+text: body ? body.innerText : "",
+commenters: commenters
+});
+}
+return result;
+}`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract feed posts: %w", err)
+	}
+
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feed posts: %w", err)
+	}
+
+	var posts []scrapedPost
+	if err := json.Unmarshal(raw, &posts); err != nil {
+		return nil, fmt.Errorf("failed to parse feed posts: %w", err)
+	}
+
+	return posts, nil
+}
+
+// containsKeyword reports whether text contains any of keywords, ignoring case
+func containsKeyword(text string, keywords []string) bool {
+	text = strings.ToLower(text)
+	for _, keyword := range keywords {
+		if keyword != "" && strings.Contains(text, strings.ToLower(keyword)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,228 @@
+package workflows
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// GroupSearchWorkflow implements profile discovery from LinkedIn group member lists
+type GroupSearchWorkflow struct {
+	browser    core.BrowserPort
+	repository core.RepositoryPort
+	config     *core.Config
+	logger     *zap.Logger
+}
+
+// NewGroupSearchWorkflow creates a new group search workflow
+func NewGroupSearchWorkflow(browser core.BrowserPort, repo core.RepositoryPort, config *core.Config, logger *zap.Logger) *GroupSearchWorkflow {
+	return &GroupSearchWorkflow{
+		browser:    browser,
+		repository: repo,
+		config:     config,
+		logger:     logger,
+	}
+}
+
+// Search extracts members from every group in params.GroupURLs and returns up to MaxResults new profile URLs
+func (g *GroupSearchWorkflow) Search(ctx context.Context, params *core.SearchParams) ([]string, error) {
+	if params == nil {
+		return nil, fmt.Errorf("search params cannot be nil")
+	}
+
+	if len(params.GroupURLs) == 0 {
+		return nil, fmt.Errorf("at least one group URL is required")
+	}
+
+	// Profiles are not discovered for paused or archived campaigns
+	if _, err := ResolveCampaign(ctx, g.repository, params.Campaign); err != nil {
+		return nil, err
+	}
+
+	allProfileURLs := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, groupURL := range params.GroupURLs {
+		select {
+		case <-ctx.Done():
+			return allProfileURLs, ctx.Err()
+		default:
+		}
+
+		memberURLs, err := g.ExtractGroupMembers(ctx, groupURL)
+		if err != nil {
+			g.logger.Warn("Failed to extract group members", zap.String("group_url", groupURL), zap.Error(err))
+			continue
+		}
+
+		for _, url := range memberURLs {
+			// Record membership even for profiles we already know about
+			existingProfile, err := g.repository.GetProfileByURL(ctx, url)
+			if err != nil {
+				g.logger.Warn("Failed to query profile", zap.String("url", url), zap.Error(err))
+				continue
+			}
+
+			if existingProfile == nil {
+				existingProfile = &core.Profile{
+					LinkedInURL: url,
+					Status:      core.ProfileStatusDiscovered,
+					Campaign:     params.Campaign,
+					Source:       core.ProfileSourceGroup,
+					SourceDetail: groupIDFromURL(groupURL),
+					CreatedAt:    time.Now(),
+					UpdatedAt:    time.Now(),
+				}
+				if err := g.repository.CreateProfile(ctx, existingProfile); errors.Is(err, core.ErrProfileArchived) {
+					g.logger.Debug("Skipping archived profile", zap.String("url", url))
+					continue
+				} else if err != nil {
+					g.logger.Warn("Failed to save profile to DB", zap.String("url", url), zap.Error(err))
+					continue
+				}
+			} else if existingProfile.Status != core.ProfileStatusDiscovered {
+				// Already processed in an earlier run
+				if err := g.repository.AddProfileToGroup(ctx, existingProfile.ID, groupURL); err != nil {
+					g.logger.Warn("Failed to record group membership", zap.String("url", url), zap.Error(err))
+				}
+				continue
+			}
+
+			if err := g.repository.AddProfileToGroup(ctx, existingProfile.ID, groupURL); err != nil {
+				g.logger.Warn("Failed to record group membership", zap.String("url", url), zap.Error(err))
+			}
+
+			if seen[url] {
+				continue
+			}
+			seen[url] = true
+			allProfileURLs = append(allProfileURLs, url)
+		}
+
+		if params.MaxResults > 0 && len(allProfileURLs) >= params.MaxResults {
+			break
+		}
+	}
+
+	// Limit results if needed
+	if params.MaxResults > 0 && len(allProfileURLs) > params.MaxResults {
+		allProfileURLs = allProfileURLs[:params.MaxResults]
+	}
+
+	g.logger.Info("Group search completed",
+		zap.Int("groups", len(params.GroupURLs)),
+		zap.Int("profiles_found", len(allProfileURLs)),
+	)
+
+	return allProfileURLs, nil
+}
+
+// ExtractGroupMembers navigates to a group's member list and extracts member profile URLs
+func (g *GroupSearchWorkflow) ExtractGroupMembers(ctx context.Context, groupURL string) ([]string, error) {
+	if groupURL == "" {
+		return nil, fmt.Errorf("group URL is required")
+	}
+
+	// Joined through -join-group but not approved yet: the member list is still hidden
+	group, err := g.repository.GetGroupByURL(ctx, strings.TrimRight(groupURL, "/")+"/")
+	if err != nil {
+		g.logger.Warn("Failed to check group membership", zap.String("group_url", groupURL), zap.Error(err))
+	} else if group != nil && group.State == core.GroupStatePending {
+		g.logger.Info("Group join awaiting approval, skipping", zap.String("group_url", groupURL))
+		return nil, nil
+	}
+
+	membersURL := strings.TrimRight(groupURL, "/") + "/members/"
+	g.logger.Info("Extracting group members", zap.String("url", membersURL))
+
+	if err := g.browser.Navigate(ctx, membersURL); err != nil {
+		return nil, fmt.Errorf("failed to navigate to group members page: %w", err)
+	}
+
+	g.browser.RandomSleep(ctx, 2.0, 4.0)
+
+	// Members are hidden behind a "Join" wall for groups we are not part of
+	if exists, _ := g.browser.ElementExists(ctx, ".groups-join-button"); exists {
+		g.logger.Warn("Group requires membership to view members, skipping", zap.String("group_url", groupURL))
+		return nil, nil
+	}
+
+	memberSelector := "a[href*='/in/']"
+	if err := g.browser.WaitForElement(ctx, memberSelector, elementTimeout(&g.config.Selectors)); err != nil {
+		return nil, fmt.Errorf("member list not found: %w", err)
+	}
+
+	// Scroll until the list stops growing (the member list is lazy-loaded)
+	previousCount := 0
+	var rawURLs []string
+	for i := 0; i < 10; i++ {
+		if err := g.browser.HumanScroll(ctx, "down", 800); err != nil {
+			g.logger.Warn("Failed to scroll member list", zap.Error(err))
+		}
+		g.browser.RandomSleep(ctx, 1.0, 2.0)
+
+		urls, err := g.browser.GetAttributes(ctx, memberSelector, "href")
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract member URLs: %w", err)
+		}
+		rawURLs = urls
+
+		if len(rawURLs) == previousCount {
+			break
+		}
+		previousCount = len(rawURLs)
+	}
+
+	// Filter and clean URLs
+	memberURLs := make([]string, 0, len(rawURLs))
+	seen := make(map[string]bool)
+
+	for _, urlStr := range rawURLs {
+		if !strings.Contains(urlStr, "/in/") {
+			continue
+		}
+
+		// Ensure full URL
+		if !strings.HasPrefix(urlStr, "http") {
+			urlStr = g.config.LinkedIn.BaseURL + urlStr
+		}
+
+		// Remove query parameters
+		urlStr = strings.Split(urlStr, "?")[0]
+		urlStr = strings.Split(urlStr, "#")[0]
+
+		if seen[urlStr] {
+			continue
+		}
+		seen[urlStr] = true
+
+		memberURLs = append(memberURLs, urlStr)
+	}
+
+	g.logger.Info("Extracted group members",
+		zap.String("group_url", groupURL),
+		zap.Int("count", len(memberURLs)),
+	)
+
+	return memberURLs, nil
+}
+
+// groupIDFromURL returns the id segment of a /groups/<id>/ URL, or the URL itself when it
+// has none
+func groupIDFromURL(groupURL string) string {
+	_, rest, found := strings.Cut(groupURL, "/groups/")
+	if !found {
+		return groupURL
+	}
+	id, _, _ := strings.Cut(rest, "/")
+	if id == "" {
+		return groupURL
+	}
+	return id
+}
@@ -0,0 +1,226 @@
+package workflows
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// ErrGroupJoinLimitReached is returned when groups.max_joins_per_week join requests were
+// already sent in the last 7 days
+var ErrGroupJoinLimitReached = errors.New("weekly group join limit reached")
+
+// GroupMembershipWorkflow joins LinkedIn groups and tracks pending approvals, so member
+// harvesting only runs on groups whose members are visible
+type GroupMembershipWorkflow struct {
+	browser    core.BrowserPort
+	repository core.RepositoryPort
+	config     *core.Config
+	logger     *zap.Logger
+}
+
+// NewGroupMembershipWorkflow creates a new group membership workflow
+func NewGroupMembershipWorkflow(browser core.BrowserPort, repo core.RepositoryPort, config *core.Config, logger *zap.Logger) *GroupMembershipWorkflow {
+	return &GroupMembershipWorkflow{
+		browser:    browser,
+		repository: repo,
+		config:     config,
+		logger:     logger,
+	}
+}
+
+// JoinGroup opens the group and clicks Join (or Request to join), then records whether
+// membership is immediate or awaits approval. Groups already recorded are not joined
+// again, and join requests are capped by groups.max_joins_per_week.
+func (g *GroupMembershipWorkflow) JoinGroup(ctx context.Context, groupURL string) (string, error) {
+	groupURL = strings.Split(strings.TrimRight(groupURL, "/"), "?")[0] + "/"
+
+	existing, err := g.repository.GetGroupByURL(ctx, groupURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to check group: %w", err)
+	}
+	if existing != nil {
+		g.logger.Info("Group already recorded, not joining again",
+			zap.String("group_url", groupURL),
+			zap.String("state", existing.State),
+		)
+		return existing.State, nil
+	}
+
+	maxJoins := g.config.Groups.MaxJoinsPerWeek
+	if maxJoins <= 0 {
+		maxJoins = 5 // Default fallback
+	}
+	joins, err := g.repository.GetActionCountSince(ctx, "JoinGroup", time.Now().AddDate(0, 0, -7))
+	if err != nil {
+		return "", fmt.Errorf("failed to count group joins: %w", err)
+	}
+	if joins >= int64(maxJoins) {
+		return "", ErrGroupJoinLimitReached
+	}
+
+	if err := g.browser.Navigate(ctx, groupURL); err != nil {
+		return "", fmt.Errorf("failed to navigate to group: %w", err)
+	}
+	g.browser.RandomSleep(ctx, 2.0, 4.0)
+
+	name := g.groupName(ctx)
+	state := g.membershipState(ctx)
+
+	if state == "" {
+		if !g.tagJoinButton(ctx) {
+			g.dumpPage(ctx)
+			return "", fmt.Errorf("join button not found")
+		}
+		if err := g.browser.HumanClick(ctx, "button[data-bot-group-join='1']"); err != nil {
+			return "", fmt.Errorf("failed to click join button: %w", err)
+		}
+		g.browser.RandomSleep(ctx, 2.0, 3.5)
+
+		state = g.membershipState(ctx)
+		if state == "" {
+			g.dumpPage(ctx)
+			return "", fmt.Errorf("membership state unknown after clicking join")
+		}
+
+		history := core.NewHistory("JoinGroup", core.HistoryDetails{Target: groupURL, Outcome: state})
+		if err := g.repository.CreateHistory(ctx, history); err != nil {
+			g.logger.Warn("Failed to save history", zap.Error(err))
+		}
+	}
+
+	group := &core.Group{
+		GroupURL: groupURL,
+		Name:     name,
+		State:    state,
+	}
+	if state == core.GroupStateJoined {
+		now := time.Now()
+		group.JoinedAt = &now
+	}
+	if err := g.repository.SaveGroup(ctx, group); err != nil {
+		return state, fmt.Errorf("failed to save group: %w", err)
+	}
+
+	g.logger.Info("Group joined",
+		zap.String("group_url", groupURL),
+		zap.String("name", name),
+		zap.String("state", state),
+	)
+
+	return state, nil
+}
+
+// CheckPendingGroups revisits groups awaiting approval and marks the approved ones joined.
+// It returns the number of groups newly approved.
+func (g *GroupMembershipWorkflow) CheckPendingGroups(ctx context.Context) (int, error) {
+	pending, err := g.repository.GetGroupsByState(ctx, core.GroupStatePending)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load pending groups: %w", err)
+	}
+
+	approved := 0
+	for _, group := range pending {
+		select {
+		case <-ctx.Done():
+			return approved, ctx.Err()
+		default:
+		}
+
+		if err := g.browser.Navigate(ctx, group.GroupURL); err != nil {
+			g.logger.Warn("Failed to open pending group", zap.String("group_url", group.GroupURL), zap.Error(err))
+			continue
+		}
+		g.browser.RandomSleep(ctx, 2.0, 4.0)
+
+		now := time.Now()
+		group.CheckedAt = &now
+		state := g.membershipState(ctx)
+		switch state {
+		case core.GroupStateJoined:
+			group.State = core.GroupStateJoined
+			group.JoinedAt = &now
+			approved++
+			g.logger.Info("Group join approved", zap.String("group_url", group.GroupURL))
+		case "":
+			// The request was declined or withdrawn; it stays pending so it isn't requested again
+			g.logger.Info("Group shows no membership or pending request", zap.String("group_url", group.GroupURL))
+		default:
+			g.logger.Info("Group join still pending", zap.String("group_url", group.GroupURL))
+		}
+
+		if err := g.repository.SaveGroup(ctx, group); err != nil {
+			g.logger.Warn("Failed to update group", zap.String("group_url", group.GroupURL), zap.Error(err))
+		}
+	}
+
+	g.logger.Info("Pending groups checked", zap.Int("pending", len(pending)), zap.Int("approved", approved))
+	return approved, nil
+}
+
+// membershipState reads the group header's action buttons: a Join button means no
+// membership, Pending/Requested means awaiting approval, and member-only controls mean
+// joined. It returns "" when the account is not a member.
+func (g *GroupMembershipWorkflow) membershipState(ctx context.Context) string {
+	res, err := g.browser.ExecuteScript(ctx, `() => {
+const labels = Array.from(document.querySelectorAll("main button, main a")).map(el => el.innerText.trim().toLowerCase());
+if (labels.some(l => l === "pending" || l === "requested" || l.includes("withdraw request"))) return "pending";
+if (labels.some(l => l === "join" || l === "request to join")) return "";
+if (document.querySelector(".groups-entity-header__post-button, .share-box-feed-entry__trigger") ||
+labels.some(l => l.includes("start a post") || l === "joined" || l.includes("leave this group"))) return "joined";
+return "";
+}`)
+	if err != nil {
+		g.logger.Warn("Failed to read group membership", zap.Error(err))
+		return ""
+	}
+
+	switch fmt.Sprint(res) {
+	case core.GroupStateJoined:
+		return core.GroupStateJoined
+	case core.GroupStatePending:
+		return core.GroupStatePending
+	}
+	return ""
+}
+
+// tagJoinButton marks the Join / Request to join button so it can be clicked by selector
+func (g *GroupMembershipWorkflow) tagJoinButton(ctx context.Context) bool {
+	res, err := g.browser.ExecuteScript(ctx, `() => {
+for (const button of document.querySelectorAll("main button")) {
+const label = button.innerText.trim().toLowerCase();
+if (label === "join" || label === "request to join") {
+button.setAttribute("data-bot-group-join", "1");
+return true;
+}
+}
+return false;
+}`)
+	return err == nil && fmt.Sprint(res) == "true"
+}
+
+// groupName reads the group's name from the page header
+func (g *GroupMembershipWorkflow) groupName(ctx context.Context) string {
+	name, err := g.browser.GetText(ctx, "main h1")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(name)
+}
+
+// dumpPage writes the current page HTML for debugging
+func (g *GroupMembershipWorkflow) dumpPage(ctx context.Context) {
+	if html, errHtml := g.browser.GetPageHTML(ctx); errHtml == nil {
+		dumpPath := fmt.Sprintf("data/debug_group_join_fail_%d.html", time.Now().Unix())
+		if errWrite := os.WriteFile(dumpPath, []byte(html), 0644); errWrite == nil {
+			g.logger.Info("Dumped page HTML for debugging", zap.String("path", dumpPath))
+		}
+	}
+}
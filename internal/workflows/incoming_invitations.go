@@ -0,0 +1,401 @@
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// Invitation decisions
+const (
+	invitationAccept = "accept"
+	invitationIgnore = "ignore"
+	invitationHold   = "hold" // Left pending: no include rule matched, or excluded without ignore_excluded
+)
+
+// incomingInvitation is an invitation card read from the invitation manager
+type incomingInvitation struct {
+	Index      int    `json:"index"` // Value of the data-bot-invite attribute on the card's buttons
+	Name       string `json:"name"`
+	Headline   string `json:"headline"`
+	ProfileURL string `json:"profileURL"`
+	Mutuals    int    `json:"mutuals"`  // Mutual connections shown on the card
+	HasPhoto   bool   `json:"hasPhoto"` // The card shows a real photo rather than the placeholder avatar
+}
+
+// invitationRule is a compiled InvitationRule
+type invitationRule struct {
+	core.InvitationRule
+	pattern *regexp.Regexp
+}
+
+// matches reports whether the rule's pattern matches the configured field
+func (r *invitationRule) matches(inv *incomingInvitation) bool {
+	switch r.Field {
+	case "name":
+		return r.pattern.MatchString(inv.Name)
+	case "headline":
+		return r.pattern.MatchString(inv.Headline)
+	case "url":
+		return r.pattern.MatchString(inv.ProfileURL)
+	default:
+		return r.pattern.MatchString(inv.Name) || r.pattern.MatchString(inv.Headline)
+	}
+}
+
+// IncomingInvitationsWorkflow accepts or ignores received invitations based on
+// include/exclude rules
+type IncomingInvitationsWorkflow struct {
+	browser    core.BrowserPort
+	repository core.RepositoryPort
+	config     *core.Config
+	logger     *zap.Logger
+	messenger  *MessagingWorkflow
+}
+
+// NewIncomingInvitationsWorkflow creates a new incoming invitations workflow
+func NewIncomingInvitationsWorkflow(browser core.BrowserPort, repo core.RepositoryPort, config *core.Config, logger *zap.Logger) *IncomingInvitationsWorkflow {
+	return &IncomingInvitationsWorkflow{
+		browser:    browser,
+		repository: repo,
+		config:     config,
+		logger:     logger,
+		messenger:  NewMessagingWorkflow(browser, repo, config, logger),
+	}
+}
+
+// invitationRuleSet holds the compiled invitation rules
+type invitationRuleSet struct {
+	decline, exclude, include []*invitationRule
+	declineNoMutuals          bool
+	declineNoPhoto            bool
+	ignoreExcluded            bool
+}
+
+// ProcessInvitations reads the invitation manager and decides on each invitation from a
+// person. Spam rules are checked first, then excludes, then includes; the rule that
+// decided is recorded in history. Accepted inviters are stored as Connected so follow-ups
+// pick them up. With reportOnly, decisions are only logged and nothing is clicked.
+func (w *IncomingInvitationsWorkflow) ProcessInvitations(ctx context.Context, reportOnly bool) error {
+	rules := &invitationRuleSet{
+		declineNoMutuals: w.config.Invitations.DeclineNoMutuals,
+		declineNoPhoto:   w.config.Invitations.DeclineNoPhoto,
+		ignoreExcluded:   w.config.Invitations.IgnoreExcluded,
+	}
+	var err error
+	if rules.decline, err = compileInvitationRules(w.config.Invitations.Decline); err != nil {
+		return err
+	}
+	if rules.exclude, err = compileInvitationRules(w.config.Invitations.Exclude); err != nil {
+		return err
+	}
+	if rules.include, err = compileInvitationRules(w.config.Invitations.Include); err != nil {
+		return err
+	}
+
+	maxAccepts := w.config.Invitations.MaxAcceptsPerDay
+	if maxAccepts <= 0 {
+		maxAccepts = 20 // Default fallback
+	}
+
+	w.logger.Info("Checking incoming invitations...")
+
+	if err := w.browser.Navigate(ctx, w.config.LinkedIn.BaseURL+"/mynetwork/invitation-manager/"); err != nil {
+		return fmt.Errorf("failed to navigate to invitation manager: %w", err)
+	}
+	w.browser.RandomSleep(ctx, 3.0, 5.0)
+
+	invitations, err := w.extractInvitations(ctx)
+	if err != nil {
+		return err
+	}
+	if len(invitations) == 0 {
+		w.logger.Info("No incoming invitations from people")
+		if html, errHtml := w.browser.GetPageHTML(ctx); errHtml == nil {
+			dumpPath := fmt.Sprintf("data/debug_invitations_empty_%d.html", time.Now().Unix())
+			_ = os.WriteFile(dumpPath, []byte(html), 0644)
+		}
+		return nil
+	}
+
+	w.logger.Info("Found incoming invitations", zap.Int("count", len(invitations)))
+
+	counts := map[string]int{}
+	for i := range invitations {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		inv := &invitations[i]
+		inv.ProfileURL = w.messenger.cleanProfileURL(inv.ProfileURL)
+
+		declined, err := w.repository.IsInviteDeclined(ctx, inv.ProfileURL)
+		if err != nil {
+			w.logger.Warn("Failed to check declined invitations", zap.Error(err))
+		}
+
+		decision, rule := invitationIgnore, "previously declined"
+		if !declined {
+			decision, rule = decideInvitation(inv, rules)
+		}
+
+		if reportOnly {
+			w.logger.Info("Invitation decision (report only)",
+				zap.String("url", inv.ProfileURL),
+				zap.String("name", inv.Name),
+				zap.String("headline", inv.Headline),
+				zap.String("decision", decision),
+				zap.String("rule", rule),
+			)
+			counts[decision]++
+			continue
+		}
+
+		if decision == invitationHold {
+			w.logger.Info("Leaving invitation pending",
+				zap.String("url", inv.ProfileURL),
+				zap.String("headline", inv.Headline),
+				zap.String("rule", rule),
+			)
+			counts[decision]++
+
+			details := core.HistoryDetails{ProfileURL: inv.ProfileURL, Outcome: "held", Rule: rule}
+			if rule == "" {
+				details.Note = "no include rule matched"
+			} else {
+				details.Note = "excluded, ignore_excluded is off"
+			}
+			if err := w.repository.CreateHistory(ctx, core.NewHistory("HoldInvitation", details)); err != nil {
+				w.logger.Warn("Failed to save history", zap.Error(err))
+			}
+			continue
+		}
+
+		if decision == invitationAccept {
+			canAccept, err := w.repository.CanPerformAction(ctx, "AcceptInvitation", maxAccepts)
+			if err != nil {
+				w.logger.Warn("Failed to check accept rate limit", zap.Error(err))
+			} else if !canAccept {
+				w.logger.Info("Daily accept limit reached", zap.Int("limit", maxAccepts))
+				break
+			}
+		}
+
+		if err := w.clickDecision(ctx, inv, decision); err != nil {
+			w.logger.Warn("Failed to handle invitation", zap.String("url", inv.ProfileURL), zap.String("decision", decision), zap.Error(err))
+			continue
+		}
+		counts[decision]++
+
+		switch decision {
+		case invitationAccept:
+			w.storeConnection(ctx, inv)
+		case invitationIgnore:
+			invite := &core.DeclinedInvite{
+				ProfileURL: inv.ProfileURL,
+				Name:       inv.Name,
+				Headline:   inv.Headline,
+				Rule:       rule,
+				DeclinedAt: time.Now(),
+			}
+			if err := w.repository.CreateDeclinedInvite(ctx, invite); err != nil {
+				w.logger.Warn("Failed to save declined invitation", zap.Error(err))
+			}
+		}
+
+		actionType, outcome := "AcceptInvitation", "accepted"
+		if decision == invitationIgnore {
+			actionType, outcome = "IgnoreInvitation", "ignored"
+		}
+		history := core.NewHistory(actionType, core.HistoryDetails{ProfileURL: inv.ProfileURL, Outcome: outcome, Rule: rule})
+		if err := w.repository.CreateHistory(ctx, history); err != nil {
+			w.logger.Warn("Failed to save history", zap.Error(err))
+		}
+
+		w.logger.Info("Handled invitation",
+			zap.String("url", inv.ProfileURL),
+			zap.String("decision", decision),
+			zap.String("rule", rule),
+		)
+
+		w.browser.RandomSleep(ctx, 3.0, 7.0)
+	}
+
+	w.logger.Info("Incoming invitations processed",
+		zap.Int("accepted", counts[invitationAccept]),
+		zap.Int("ignored", counts[invitationIgnore]),
+		zap.Int("pending", counts[invitationHold]),
+		zap.Bool("report_only", reportOnly),
+	)
+
+	return nil
+}
+
+// decideInvitation applies spam rules, then exclude rules, then include rules, and returns
+// the decision with the name of the rule that made it
+func decideInvitation(inv *incomingInvitation, rules *invitationRuleSet) (string, string) {
+	for _, rule := range rules.decline {
+		if rule.matches(inv) {
+			return invitationIgnore, rule.Name
+		}
+	}
+	if rules.declineNoMutuals && inv.Mutuals == 0 {
+		return invitationIgnore, "no mutual connections"
+	}
+	if rules.declineNoPhoto && !inv.HasPhoto {
+		return invitationIgnore, "no profile photo"
+	}
+
+	for _, rule := range rules.exclude {
+		if rule.matches(inv) {
+			if rules.ignoreExcluded {
+				return invitationIgnore, rule.Name
+			}
+			return invitationHold, rule.Name
+		}
+	}
+	for _, rule := range rules.include {
+		if rule.matches(inv) {
+			return invitationAccept, rule.Name
+		}
+	}
+	return invitationHold, ""
+}
+
+// compileInvitationRules compiles configured rules, naming unnamed ones after their pattern
+func compileInvitationRules(rules []core.InvitationRule) ([]*invitationRule, error) {
+	compiled := make([]*invitationRule, 0, len(rules))
+	for _, rule := range rules {
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid invitation rule %q: %w", rule.Name, err)
+		}
+		if rule.Name == "" {
+			rule.Name = rule.Pattern
+		}
+		compiled = append(compiled, &invitationRule{InvitationRule: rule, pattern: pattern})
+	}
+	return compiled, nil
+}
+
+// storeConnection creates or updates the inviter's profile as Connected
+func (w *IncomingInvitationsWorkflow) storeConnection(ctx context.Context, inv *incomingInvitation) {
+	profile, err := w.repository.GetProfileByURL(ctx, inv.ProfileURL)
+	if err != nil {
+		w.logger.Warn("Failed to query profile", zap.String("url", inv.ProfileURL), zap.Error(err))
+		return
+	}
+
+	if profile == nil {
+		now := time.Now()
+		newProfile := &core.Profile{
+			LinkedInURL: inv.ProfileURL,
+			Status:      core.ProfileStatusConnected,
+			ConnectedAt: &now,
+			Name:        inv.Name,
+			Headline:    inv.Headline,
+			Source:      core.ProfileSourceInvitation,
+		}
+		if err := w.repository.CreateProfile(ctx, newProfile); err != nil {
+			w.logger.Warn("Failed to add connection", zap.String("url", inv.ProfileURL), zap.Error(err))
+		}
+		return
+	}
+
+	if err := w.repository.MarkAsConnected(ctx, inv.ProfileURL); err != nil {
+		w.logger.Warn("Failed to mark profile as connected", zap.String("url", inv.ProfileURL), zap.Error(err))
+	}
+	if err := w.repository.UpdateProfileIdentity(ctx, inv.ProfileURL, inv.Name, inv.Headline); err != nil {
+		w.logger.Warn("Failed to update profile", zap.String("url", inv.ProfileURL), zap.Error(err))
+	}
+}
+
+// clickDecision clicks the Accept or Ignore button of an invitation card
+func (w *IncomingInvitationsWorkflow) clickDecision(ctx context.Context, inv *incomingInvitation, decision string) error {
+	selector := fmt.Sprintf("button[data-bot-invite='%d'][data-bot-invite-action='%s']", inv.Index, decision)
+	if err := w.browser.HumanClick(ctx, selector); err != nil {
+		return fmt.Errorf("failed to click %s: %w", decision, err)
+	}
+	w.browser.RandomSleep(ctx, 1.5, 2.5)
+	return nil
+}
+
+// extractInvitations reads the invitation cards that come from people, tagging their
+// Accept and Ignore buttons with data-bot-invite so they can be clicked by selector
+func (w *IncomingInvitationsWorkflow) extractInvitations(ctx context.Context) ([]incomingInvitation, error) {
+	res, err := w.browser.ExecuteScript(ctx, `() => {
+const result = [];
+const seen = new Set();
+let index = 0;
+for (const accept of document.querySelectorAll("main button")) {
+if (!/^Accept$/i.test(accept.innerText.trim()) && !/^Accept /i.test(accept.getAttribute("aria-label") || "")) continue;
+const card = accept.closest("li, div[role='listitem']");
+if (!card || seen.has(card)) continue;
+seen.add(card);
+const link = card.querySelector("a[href*='/in/']");
+if (!link) continue; // Page, event and newsletter invitations
+let ignore = null;
+for (const button of card.querySelectorAll("button")) {
+if (/^Ignore$/i.test(button.innerText.trim()) || /^Ignore /i.test(button.getAttribute("aria-label") || "")) {
+ignore = button;
+break;
+}
+}
+accept.setAttribute("data-bot-invite", String(index));
+accept.setAttribute("data-bot-invite-action", "accept");
+if (ignore) {
+ignore.setAttribute("data-bot-invite", String(index));
+ignore.setAttribute("data-bot-invite-action", "ignore");
+}
+const lines = card.innerText.split("\n").map(l => l.trim()).filter(l => l && !/^(Accept|Ignore|Message)$/i.test(l));
+const name = link.innerText.trim().split("\n")[0] || lines[0] || "";
+const mutualLine = lines.find(l => /mutual connection/i.test(l)) || "";
+let mutuals = 0;
+const others = mutualLine.match(/and\s+(\d+)\s+other/i);
+const count = mutualLine.match(/(\d+)\s+mutual connection/i);
+if (others) {
+mutuals = parseInt(others[1], 10) + mutualLine.split(/,\s*|\s+and\s+/).length - 1;
+} else if (count) {
+mutuals = parseInt(count[1], 10);
+} else if (mutualLine) {
+mutuals = mutualLine.split(/,\s*|\s+and\s+/).length;
+}
+const img = card.querySelector("img");
+const hasPhoto = !!img && !!img.getAttribute("src") && !/ghost|data:image/i.test(img.className + " " + img.getAttribute("src")) && !card.querySelector("[class*='ghost-person']");
+result.push({
+index: index,
+name: name,
+headline: lines.find(l => l !== name && !/mutual connection|invited you/i.test(l)) || "",
+profileURL: link.href,
+mutuals: mutuals,
+hasPhoto: hasPhoto
+});
+index++;
+}
+return result;
+}`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract invitations: %w", err)
+	}
+
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read invitations: %w", err)
+	}
+
+	var invitations []incomingInvitation
+	if err := json.Unmarshal(raw, &invitations); err != nil {
+		return nil, fmt.Errorf("failed to parse invitations: %w", err)
+	}
+
+	return invitations, nil
+}
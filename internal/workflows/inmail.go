@@ -0,0 +1,240 @@
+package workflows
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// ErrNoPremium is returned when the InMail composer is not available, which means the
+// account has no premium subscription (or no InMail access to this profile)
+var ErrNoPremium = errors.New("InMail composer not available: the account needs LinkedIn Premium to send InMail")
+
+// ErrInMailBudgetExhausted is returned when the monthly InMail budget or the account's
+// remaining credits are used up
+var ErrInMailBudgetExhausted = errors.New("no InMail credits left this month")
+
+// InMailWorkflow sends InMails to profiles outside the network, such as 3rd-degree
+// prospects, instead of connecting first
+type InMailWorkflow struct {
+	browser    core.BrowserPort
+	repository core.RepositoryPort
+	config     *core.Config
+	logger     *zap.Logger
+	messenger  *MessagingWorkflow
+}
+
+// NewInMailWorkflow creates a new InMail workflow
+func NewInMailWorkflow(browser core.BrowserPort, repo core.RepositoryPort, config *core.Config, logger *zap.Logger) *InMailWorkflow {
+	return &InMailWorkflow{
+		browser:    browser,
+		repository: repo,
+		config:     config,
+		logger:     logger,
+		messenger:  NewMessagingWorkflow(browser, repo, config, logger),
+	}
+}
+
+// SendInMail opens the InMail composer from the profile's Message button and sends subject
+// and body ({{FirstName}} is filled in for both; inmail.subject and inmail.template are
+// used when empty). It is limited by inmail.monthly_credits and by the remaining credits
+// the composer shows, and returns ErrNoPremium if the composer doesn't open.
+func (w *InMailWorkflow) SendInMail(ctx context.Context, profileURL, subject, body string) error {
+	if profileURL == "" {
+		return fmt.Errorf("profile URL is required")
+	}
+	if subject == "" {
+		subject = w.config.InMail.Subject
+	}
+	if body == "" {
+		body = w.config.InMail.Template
+	}
+	if subject == "" || body == "" {
+		return fmt.Errorf("InMail subject and template are required")
+	}
+
+	remaining, err := w.remainingBudget(ctx)
+	if err != nil {
+		return err
+	}
+	if remaining <= 0 {
+		return ErrInMailBudgetExhausted
+	}
+
+	if err := w.browser.Navigate(ctx, profileURL); err != nil {
+		return fmt.Errorf("failed to navigate to profile: %w", err)
+	}
+	w.browser.RandomSleep(ctx, 3.0, 5.0)
+
+	firstName := w.messenger.extractFirstName(ctx)
+	if firstName == "" {
+		firstName = "there" // Fallback
+	}
+
+	if err := w.messenger.clickMessageButton(ctx); err != nil {
+		w.dumpPage(ctx)
+		return fmt.Errorf("%w: %v", ErrNoPremium, err)
+	}
+	w.browser.RandomSleep(ctx, 1.0, 2.0)
+
+	state, credits := w.messenger.detectComposerState(ctx)
+	switch state {
+	case composerRestricted:
+		return fmt.Errorf("profile does not accept InMail")
+	case composerChat:
+		if upsell, _ := w.showsPremiumUpsell(ctx); upsell {
+			return ErrNoPremium
+		}
+		return fmt.Errorf("profile opened a regular chat; it can be messaged without InMail")
+	}
+
+	// Reconcile the configured budget with what LinkedIn says is left
+	if credits >= 0 {
+		w.logger.Info("InMail credits", zap.Int("remaining", credits), zap.Int("budget_left", remaining))
+		if credits == 0 {
+			return ErrInMailBudgetExhausted
+		}
+	}
+
+	replacer := strings.NewReplacer("{{FirstName}}", firstName)
+	subject = replacer.Replace(subject)
+	body = replacer.Replace(body)
+
+	subjectSelector := ""
+	for _, sel := range []string{".msg-form__subject", "input[name='subject']"} {
+		if exists, _ := w.browser.ElementExists(ctx, sel); exists {
+			subjectSelector = sel
+			break
+		}
+	}
+	if subjectSelector == "" {
+		w.dumpPage(ctx)
+		return fmt.Errorf("InMail subject field not found")
+	}
+	if err := w.browser.HumanType(ctx, subjectSelector, subject); err != nil {
+		return fmt.Errorf("failed to type InMail subject: %w", err)
+	}
+	w.browser.RandomSleep(ctx, 0.5, 1.5)
+
+	bodySelector := w.messenger.findChatInput(ctx)
+	if bodySelector == "" {
+		w.dumpPage(ctx)
+		return fmt.Errorf("InMail body field not found")
+	}
+	if err := w.browser.HumanClick(ctx, bodySelector); err != nil {
+		return fmt.Errorf("failed to focus InMail body: %w", err)
+	}
+	if err := w.browser.HumanType(ctx, bodySelector, body); err != nil {
+		return fmt.Errorf("failed to type InMail body: %w", err)
+	}
+	w.browser.RandomSleep(ctx, 1.0, 2.0)
+
+	sendBtnSelector := "button.msg-form__send-button"
+	if err := w.browser.WaitForElement(ctx, sendBtnSelector, quickTimeout(&w.config.Selectors)); err != nil {
+		return fmt.Errorf("InMail send button not found: %w", err)
+	}
+	if err := w.browser.HumanClick(ctx, sendBtnSelector); err != nil {
+		return fmt.Errorf("failed to click send button: %w", err)
+	}
+	w.browser.RandomSleep(ctx, 2.0, 3.0)
+
+	if !w.verifySent(ctx, body) {
+		w.dumpPage(ctx)
+		return fmt.Errorf("InMail was not confirmed as sent")
+	}
+
+	w.record(ctx, profileURL, body)
+
+	w.logger.Info("InMail sent", zap.String("url", profileURL), zap.Int("budget_left", remaining-1))
+	return nil
+}
+
+// remainingBudget returns how many InMails may still be sent this calendar month
+func (w *InMailWorkflow) remainingBudget(ctx context.Context) (int, error) {
+	monthlyCredits := w.config.InMail.MonthlyCredits
+	if monthlyCredits <= 0 {
+		monthlyCredits = 5 // Default fallback
+	}
+
+	now := time.Now()
+	startOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	used, err := w.repository.GetActionCountSince(ctx, "InMail", startOfMonth)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count InMails this month: %w", err)
+	}
+
+	return monthlyCredits - int(used), nil
+}
+
+// record stores the sent InMail in history and, for known profiles, in the message log
+func (w *InMailWorkflow) record(ctx context.Context, profileURL, body string) {
+	history := core.NewHistory("InMail", core.HistoryDetails{ProfileURL: profileURL})
+	if err := w.repository.CreateHistory(ctx, history); err != nil {
+		w.logger.Warn("Failed to save history", zap.Error(err))
+	}
+
+	profile, err := w.repository.GetProfileByURL(ctx, profileURL)
+	if err != nil || profile == nil {
+		return
+	}
+	message := &core.Message{
+		ProfileID:    profile.ID,
+		Body:         body,
+		TemplateName: "inmail",
+	}
+	if err := w.repository.LogMessageSent(ctx, message); err != nil {
+		w.logger.Warn("Failed to log InMail", zap.Error(err))
+	}
+}
+
+// verifySent checks that the body shows up in the conversation or the composer was cleared
+func (w *InMailWorkflow) verifySent(ctx context.Context, body string) bool {
+	snippet := body
+	if len([]rune(snippet)) > 40 {
+		snippet = string([]rune(snippet)[:40])
+	}
+
+	res, err := w.browser.ExecuteScript(ctx, fmt.Sprintf(`() => {
+const snippet = %q;
+for (const el of document.querySelectorAll(".msg-s-event-listitem__body, .msg-s-message-list-content")) {
+if (el.innerText.includes(snippet)) return true;
+}
+const input = document.querySelector(".msg-form__contenteditable[role='textbox']");
+return !!input && input.innerText.trim() === "";
+}`, snippet))
+	if err != nil {
+		w.logger.Warn("Failed to verify InMail", zap.Error(err))
+		return false
+	}
+
+	return fmt.Sprint(res) == "true"
+}
+
+// showsPremiumUpsell reports whether a "Try Premium" prompt opened instead of a composer
+func (w *InMailWorkflow) showsPremiumUpsell(ctx context.Context) (bool, error) {
+	res, err := w.browser.ExecuteScript(ctx, `() => {
+const el = document.querySelector(".artdeco-modal, .msg-overlay-conversation-bubble, .premium-upsell-link");
+return !!el && /premium/i.test(el.innerText);
+}`)
+	if err != nil {
+		return false, err
+	}
+	return fmt.Sprint(res) == "true", nil
+}
+
+// dumpPage writes the current page HTML for debugging
+func (w *InMailWorkflow) dumpPage(ctx context.Context) {
+	if html, errHtml := w.browser.GetPageHTML(ctx); errHtml == nil {
+		dumpPath := fmt.Sprintf("data/debug_inmail_fail_%d.html", time.Now().Unix())
+		if errWrite := os.WriteFile(dumpPath, []byte(html), 0644); errWrite == nil {
+			w.logger.Info("Dumped page HTML for debugging", zap.String("path", dumpPath))
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// invitationScanBatchSize is how many sent requests ScanSentInvitations loads at a time
+const invitationScanBatchSize = 200
+
+// ScanSentInvitations reconciles RequestSent profiles against the sent-invitations page.
+// Invitations still listed get their last_seen_pending_at refreshed; invitations that are
+// neither pending nor accepted (ignored or expired by LinkedIn) are marked Expired, unless
+// the connections scan stopped at its cap: those may be older connections it didn't reach.
+// The page shows a "Withdraw" button per invitation; this scan only reads links and
+// pagination controls and never clicks anything inside an invitation card.
+func (m *MessagingWorkflow) ScanSentInvitations(ctx context.Context) error {
+	m.logger.Info("Scanning sent invitations...")
+
+	pendingURLs, err := m.collectPendingInvitationURLs(ctx)
+	if err != nil {
+		return err
+	}
+	if pendingURLs == nil {
+		// Without a reliable pending list we can't tell expired invitations apart
+		m.logger.Warn("Sent invitations list unavailable, skipping reconciliation")
+		return nil
+	}
+
+	pending := make(map[string]bool, len(pendingURLs))
+	for _, u := range pendingURLs {
+		pending[u] = true
+	}
+
+	m.logger.Info("Found pending invitations", zap.Int("count", len(pendingURLs)))
+
+	// Only fetch the connections list if some requests are no longer pending
+	var connected map[string]bool
+	var scan *connectionScan
+	stillPendingCount := 0
+	expiredCount := 0
+	acceptedCount := 0
+	unknownCount := 0
+
+	// Accepted and expired requests leave RequestSent as we go, which the pages allow for
+	err = core.ForEachProfilePage(ctx, m.repository, core.ProfileStatusRequestSent, core.ProfileOrderCreatedAsc, invitationScanBatchSize, func(profiles []*core.Profile) error {
+		for _, profile := range profiles {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+
+			if pending[profile.LinkedInURL] {
+				if err := m.repository.MarkInvitationPending(ctx, profile.LinkedInURL); err != nil {
+					m.logger.Error("Failed to update pending invitation", zap.String("url", profile.LinkedInURL), zap.Error(err))
+				}
+				stillPendingCount++
+				continue
+			}
+
+			if connected == nil {
+				connectionScan, err := m.collectConnectionURLs(ctx)
+				if err != nil {
+					return err
+				}
+				if connectionScan == nil {
+					m.logger.Warn("Connections list unavailable, skipping expiry detection")
+					return core.ErrStopIteration
+				}
+				scan = connectionScan
+				if scan.Truncated {
+					m.logger.Warn("Connections scan stopped at its cap, not expiring invitations missing from it",
+						zap.Int("connections_scanned", len(scan.URLs)),
+					)
+				}
+				connected = make(map[string]bool, len(scan.URLs))
+				for _, u := range scan.URLs {
+					connected[u] = true
+				}
+			}
+
+			if connected[profile.LinkedInURL] {
+				m.logger.Info("Sent invitation was accepted", zap.String("url", profile.LinkedInURL))
+				if err := m.markConnected(ctx, profile.LinkedInURL, scan); err != nil {
+					m.logger.Error("Failed to mark profile as connected", zap.Error(err))
+				} else {
+					acceptedCount++
+				}
+				continue
+			}
+
+			if scan.Truncated {
+				unknownCount++
+				continue
+			}
+
+			m.logger.Info("Sent invitation no longer pending, marking as expired", zap.String("url", profile.LinkedInURL))
+			if err := m.repository.UpdateProfileStatus(ctx, profile.LinkedInURL, core.ProfileStatusExpired); err != nil {
+				m.logger.Error("Failed to mark invitation as expired", zap.Error(err))
+			} else {
+				expiredCount++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	m.logger.Info("Sent invitations scan complete",
+		zap.Int("still_pending", stillPendingCount),
+		zap.Int("accepted", acceptedCount),
+		zap.Int("expired", expiredCount),
+		zap.Int("unknown", unknownCount),
+	)
+
+	return nil
+}
+
+// collectPendingInvitationURLs paginates the sent-invitations page and returns the invitee
+// profile URLs. It returns nil (without error) if the invitation list could not be found.
+func (m *MessagingWorkflow) collectPendingInvitationURLs(ctx context.Context) ([]string, error) {
+	sentURL := m.config.LinkedIn.BaseURL + "/mynetwork/invitation-manager/sent/"
+	if err := m.browser.Navigate(ctx, sentURL); err != nil {
+		return nil, fmt.Errorf("failed to navigate to sent invitations page: %w", err)
+	}
+
+	listSelector := ".invitation-card, .mn-invitation-list, div[data-view-name='pending-invitation']"
+	emptyStateSelector := ".artdeco-empty-state"
+	if err := m.browser.WaitForElement(ctx, listSelector, elementTimeout(&m.config.Selectors)); err != nil {
+		// No outstanding invitations is a valid (empty) result
+		if exists, _ := m.browser.ElementExists(ctx, emptyStateSelector); exists {
+			return []string{}, nil
+		}
+
+		m.logger.Warn("Could not find sent invitations list", zap.Error(err))
+		if html, errHtml := m.browser.GetPageHTML(ctx); errHtml == nil {
+			dumpPath := fmt.Sprintf("data/debug_sent_invitations_fail_%d.html", time.Now().Unix())
+			if errWrite := os.WriteFile(dumpPath, []byte(html), 0644); errWrite == nil {
+				m.logger.Info("Dumped sent invitations page HTML for debugging", zap.String("path", dumpPath))
+			}
+		}
+		return nil, nil
+	}
+
+	linkSelector := ".invitation-card a[href*='/in/'], div[data-view-name='pending-invitation'] a[href*='/in/']"
+
+	seen := make(map[string]bool)
+	pendingURLs := make([]string, 0)
+	page := 1
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		if err := m.browser.HumanScroll(ctx, "down", 1500); err != nil {
+			m.logger.Warn("Failed to scroll sent invitations", zap.Error(err))
+		}
+		m.browser.RandomSleep(ctx, 1.5, 2.5)
+
+		urls, err := m.browser.GetAttributes(ctx, linkSelector, "href")
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract invitation URLs: %w", err)
+		}
+
+		for _, rawURL := range urls {
+			clean := m.cleanProfileURL(rawURL)
+			if clean != "" && !seen[clean] {
+				seen[clean] = true
+				pendingURLs = append(pendingURLs, clean)
+			}
+		}
+
+		m.logger.Debug("Extracted pending invitations", zap.Int("page", page), zap.Int("total", len(pendingURLs)))
+
+		// Go to next page (pagination buttons only, never invitation card actions)
+		page++
+		nextPageButton := fmt.Sprintf("button[aria-label='Page %d']", page)
+		exists, err := m.browser.ElementExists(ctx, nextPageButton)
+		if err != nil || !exists {
+			break
+		}
+
+		if err := m.browser.HumanClick(ctx, nextPageButton); err != nil {
+			m.logger.Warn("Failed to click next page", zap.Error(err))
+			// A partial list would mark real pending invitations as expired
+			return nil, nil
+		}
+		m.browser.RandomSleep(ctx, 2.0, 3.0)
+	}
+
+	return pendingURLs, nil
+}
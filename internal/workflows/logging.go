@@ -0,0 +1,24 @@
+package workflows
+
+import (
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// WorkflowLogger returns the logger a workflow is constructed with: logger with the
+// workflow's name on every line. Workflows a workflow builds for itself share its logger.
+func WorkflowLogger(logger *zap.Logger, name string) *zap.Logger {
+	return logger.With(zap.String(core.LogFieldWorkflow, name))
+}
+
+// ProfileLogger returns the child logger for processing one profile, so every line about
+// it carries its URL
+func ProfileLogger(logger *zap.Logger, profileURL string) *zap.Logger {
+	return logger.With(zap.String(core.LogFieldProfileURL, profileURL))
+}
+
+// actionField marks a line as recording a history action
+func actionField(action string) zap.Field {
+	return zap.String(core.LogFieldAction, action)
+}
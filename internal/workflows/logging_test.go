@@ -0,0 +1,100 @@
+package workflows
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// loggingRepository serves a profile that was already sent a request and accepts writes
+type loggingRepository struct {
+	core.RepositoryPort
+}
+
+func (loggingRepository) GetTodayActionCount(ctx context.Context, actionType string) (int64, error) {
+	return 0, nil
+}
+
+func (loggingRepository) GetProfileByURL(ctx context.Context, url string) (*core.Profile, error) {
+	return &core.Profile{LinkedInURL: url, Status: core.ProfileStatusRequestSent}, nil
+}
+
+func (loggingRepository) MatchBlacklist(ctx context.Context, profile *core.Profile) (*core.BlacklistEntry, error) {
+	return nil, nil
+}
+
+func (loggingRepository) MarkProfileVisited(ctx context.Context, url string, visitedAt time.Time) error {
+	return nil
+}
+
+func (loggingRepository) CreateHistory(ctx context.Context, history *core.History) error {
+	return nil
+}
+
+// loggingBrowser loads every page instantly
+type loggingBrowser struct {
+	core.BrowserPort
+}
+
+func (loggingBrowser) Navigate(ctx context.Context, url string) error { return nil }
+
+func (loggingBrowser) RandomSleep(ctx context.Context, minSeconds, maxSeconds float64) {}
+
+func (loggingBrowser) HumanScroll(ctx context.Context, direction string, distance int) error {
+	return nil
+}
+
+func TestLogCorrelationFields(t *testing.T) {
+	ctx := context.Background()
+	cfg := &core.Config{}
+	cfg.Limits.MaxActionsPerDay = 10
+	profileURL := "https://www.linkedin.com/in/jane-doe/"
+
+	observed, logs := observer.New(zapcore.InfoLevel)
+	// The run's logger, as cmd/bot builds it
+	runLogger := zap.New(observed).With(zap.String(core.LogFieldAccount, "default"), zap.String(core.LogFieldRunID, "run-1"))
+
+	connect := NewConnectWorkflow(loggingBrowser{}, loggingRepository{}, cfg, WorkflowLogger(runLogger, "connect"))
+	if err := connect.SendConnectionRequest(ctx, &core.ConnectParams{ProfileURL: profileURL, Name: "Jane"}); err != nil {
+		t.Fatalf("SendConnectionRequest: %v", err)
+	}
+	visit := NewVisitWorkflow(loggingBrowser{}, loggingRepository{}, cfg, WorkflowLogger(runLogger, "visit"))
+	if err := visit.VisitProfile(ctx, profileURL); err != nil {
+		t.Fatalf("VisitProfile: %v", err)
+	}
+
+	tests := []struct {
+		message  string
+		workflow string
+		action   string
+	}{
+		{"Skipping profile", "connect", "Skip"},
+		{"Visited profile", "visit", "Visit"},
+	}
+	for _, tt := range tests {
+		entries := logs.FilterMessage(tt.message).All()
+		if len(entries) != 1 {
+			t.Errorf("%d %q lines logged, want 1", len(entries), tt.message)
+			continue
+		}
+		fields := entries[0].ContextMap()
+		want := map[string]string{
+			core.LogFieldRunID:      "run-1",
+			core.LogFieldAccount:    "default",
+			core.LogFieldWorkflow:   tt.workflow,
+			core.LogFieldProfileURL: profileURL,
+			core.LogFieldAction:     tt.action,
+		}
+		for field, value := range want {
+			if fields[field] != value {
+				t.Errorf("%q line has %s = %v, want %q", tt.message, field, fields[field], value)
+			}
+		}
+	}
+}
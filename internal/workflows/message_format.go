@@ -0,0 +1,90 @@
+package workflows
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Markup accepted in follow-up templates when messaging.allow_html_formatting is set
+var (
+	boldMarkup   = regexp.MustCompile(`(?is)<b>(.*?)</b>`)
+	italicMarkup = regexp.MustCompile(`(?is)<i>(.*?)</i>`)
+	linkMarkup   = regexp.MustCompile(`(?is)<a\s+href\s*=\s*["']([^"']*)["']\s*>(.*?)</a>`)
+)
+
+// hasFormattingMarkup reports whether body contains <b>, <i> or <a href> markup
+func hasFormattingMarkup(body string) bool {
+	return boldMarkup.MatchString(body) || italicMarkup.MatchString(body) || linkMarkup.MatchString(body)
+}
+
+// plainMessage strips the formatting markup from body, leaving what gets typed into the
+// composer. Web links keep their URL after the text.
+func plainMessage(body string) string {
+	body = linkMarkup.ReplaceAllStringFunc(body, func(match string) string {
+		parts := linkMarkup.FindStringSubmatch(match)
+		href, text := strings.TrimSpace(parts[1]), parts[2]
+		if !strings.HasPrefix(href, "https://") && !strings.HasPrefix(href, "http://") {
+			return text
+		}
+		if text == "" || text == href {
+			return href
+		}
+		return text + " (" + href + ")"
+	})
+	body = boldMarkup.ReplaceAllString(body, "$1")
+	return italicMarkup.ReplaceAllString(body, "$1")
+}
+
+// formattedMessageHTML converts body to the composer's HTML: bold and italics become
+// LinkedIn markdown (**text** and _text_), http(s) links become anchors and every line a
+// paragraph. Everything else is escaped.
+func formattedMessageHTML(body string) string {
+	var links []string
+	body = linkMarkup.ReplaceAllStringFunc(body, func(match string) string {
+		parts := linkMarkup.FindStringSubmatch(match)
+		href := strings.TrimSpace(parts[1])
+		text := parts[2]
+		if text == "" {
+			text = href
+		}
+		// Only web links are kept; anything else (javascript:, mailto:) leaves its text
+		if !strings.HasPrefix(href, "https://") && !strings.HasPrefix(href, "http://") {
+			return text
+		}
+		links = append(links, `<a href="`+html.EscapeString(href)+`">`+html.EscapeString(plainMessage(text))+`</a>`)
+		// Placeholder survives escaping and is swapped back below
+		return "\x00" + strconv.Itoa(len(links)-1) + "\x00"
+	})
+	body = boldMarkup.ReplaceAllString(body, "**$1**")
+	body = italicMarkup.ReplaceAllString(body, "_${1}_")
+	body = html.EscapeString(body)
+	for i, link := range links {
+		body = strings.Replace(body, "\x00"+strconv.Itoa(i)+"\x00", link, 1)
+	}
+
+	lines := strings.Split(body, "\n")
+	var b strings.Builder
+	for _, line := range lines {
+		if line == "" {
+			line = "<br>"
+		}
+		b.WriteString("<p>" + line + "</p>")
+	}
+	return b.String()
+}
+
+// PreviewFormattedMessage renders template with vars ({{Key}} placeholders) and returns
+// the message as it would be sent: the composer HTML when the template uses formatting
+// markup, otherwise the plain text.
+func PreviewFormattedMessage(template string, vars map[string]string) string {
+	rendered := template
+	for key, value := range vars {
+		rendered = strings.ReplaceAll(rendered, "{{"+key+"}}", value)
+	}
+	if !hasFormattingMarkup(rendered) {
+		return rendered
+	}
+	return formattedMessageHTML(rendered)
+}
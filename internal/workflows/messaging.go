@@ -2,14 +2,21 @@ package workflows
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math/rand"
 	"net/url"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"linkedin-automation/internal/browser"
 	"linkedin-automation/internal/core"
+	"linkedin-automation/internal/integrations"
+	"linkedin-automation/internal/stealth"
+	"linkedin-automation/pkg/utils"
 
 	"go.uber.org/zap"
 )
@@ -20,6 +27,13 @@ type MessagingWorkflow struct {
 	repository core.RepositoryPort
 	config     *core.Config
 	logger     *zap.Logger
+	endorser   *EndorsementWorkflow
+	sheets     *integrations.SheetsSync
+	notifier   core.NotifierPort
+	jitter     *stealth.Jitter
+
+	// sessionSent counts follow-ups sent during this bot run
+	sessionSent int
 }
 
 // NewMessagingWorkflow creates a new messaging workflow
@@ -34,23 +48,187 @@ func NewMessagingWorkflow(
 		repository: repository,
 		config:     config,
 		logger:     logger,
+		endorser:   NewEndorsementWorkflow(browser, repository, config, logger),
+		jitter:     stealth.NewJitter(config.Stealth.RandomSeed),
 	}
 }
 
+// SetSheetsSync makes ScanNewConnections append new connections to Google Sheets
+func (m *MessagingWorkflow) SetSheetsSync(sheets *integrations.SheetsSync) {
+	m.sheets = sheets
+}
+
+// SetNotifier makes ScanNewConnections send an alert for each accepted connection request
+func (m *MessagingWorkflow) SetNotifier(notifier core.NotifierPort) {
+	m.notifier = notifier
+}
+
 // ScanNewConnections checks for new connections and updates their status in the DB
 func (m *MessagingWorkflow) ScanNewConnections(ctx context.Context) error {
 	m.logger.Info("Scanning for new connections...")
 
-	connectionsURL := "https://www.linkedin.com/mynetwork/invite-connect/connections/"
+	scan, err := m.collectConnectionURLs(ctx)
+	if err != nil {
+		return err
+	}
+	if scan == nil {
+		return nil
+	}
+	cleanURLs, scrolls := scan.URLs, scan.Scrolls
+
+	m.logger.Info("Found connections on page", zap.Int("count", len(cleanURLs)), zap.Int("scrolls", scrolls))
+
+	newConnectionsCount := 0
+	accepted := make([]*core.Profile, 0)
+	
+	for _, profileURL := range cleanURLs {
+		// Profiles not in our DB are added as Connected so we can message them later.
+		// Known profiles move to Connected when their status allows it.
+		connectedAt := time.Now()
+		if t, ok := scan.ConnectedAt[profileURL]; ok {
+			connectedAt = t
+		}
+		profile := &core.Profile{
+			LinkedInURL: profileURL,
+			Status:       core.ProfileStatusConnected,
+			ConnectedAt:  &connectedAt,
+			Source:       core.ProfileSourceScan,
+			SourceDetail: "scan",
+		}
+		upsert, err := m.repository.UpsertProfile(ctx, profile)
+		if err != nil {
+			m.logger.Error("Failed to save connection", zap.String(core.LogFieldProfileURL, profileURL), zap.Error(err))
+			continue
+		}
+
+		switch {
+		case upsert.Created:
+			newConnectionsCount++
+			accepted = append(accepted, profile)
+			m.logger.Info("Added new connection not in DB", zap.String(core.LogFieldProfileURL, profileURL))
+		case upsert.StatusChanged:
+			// If we sent a request and now they appear here, they accepted!
+			m.logger.Info("Detected new connection acceptance",
+				zap.String(core.LogFieldProfileURL, profileURL),
+				zap.String("previous_status", string(upsert.PreviousStatus)),
+			)
+			newConnectionsCount++
+			accepted = append(accepted, profile)
+			notify(ctx, m.notifier, core.Alert{
+				Event: core.AlertEventConnectionAccepted,
+				Text:  "Connection request accepted:",
+				URL:   profileURL,
+			})
+		case upsert.PreviousStatus == core.ProfileStatusConnected:
+			// Already marked, likely from a previous run
+			m.logger.Debug("Profile already marked as connected", zap.String(core.LogFieldProfileURL, profileURL))
+		}
+	}
+
+	m.logger.Info("Scan complete", zap.Int("newly_marked_connected", newConnectionsCount))
+
+	if m.sheets != nil {
+		for _, profile := range accepted {
+			m.sheets.AppendProfileAsync(profile)
+		}
+	}
+
+	// Record scan coverage so missed acceptances can be diagnosed later
+	history := core.NewHistory("Scan", core.HistoryDetails{Counts: map[string]int{
+		"cards":     len(cleanURLs),
+		"scrolls":   scrolls,
+		"connected": newConnectionsCount,
+	}})
+	if err := m.repository.CreateHistory(ctx, history); err != nil {
+		m.logger.Warn("Failed to save history", zap.Error(err))
+	}
+
+	if m.config.Messaging.SendOnAccept && len(accepted) > 0 {
+		return m.sendOnAccept(ctx, accepted)
+	}
+
+	return nil
+}
+
+// sendOnAccept sends the first follow-up to connections accepted during this scan,
+// bounded by max_on_accept and the usual message limits. Sent messages are logged
+// like any other follow-up, so these profiles drop out of the next pending batch.
+func (m *MessagingWorkflow) sendOnAccept(ctx context.Context, accepted []*core.Profile) error {
+	maxOnAccept := m.config.Messaging.MaxOnAccept
+	if maxOnAccept <= 0 {
+		maxOnAccept = 3 // Default fallback
+	}
+	if len(accepted) > maxOnAccept {
+		accepted = accepted[:maxOnAccept]
+	}
+
+	m.logger.Info("Sending follow-ups to new acceptances", zap.Int("count", len(accepted)))
+
+	sentCount := 0
+	for i, profile := range accepted {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if profile.LastMessageSentAt != nil {
+			continue
+		}
+
+		if !m.hasMessageBudget(ctx) {
+			break
+		}
+
+		result := m.runSequenceStep(ctx, profile)
+		if result == followUpSent {
+			sentCount++
+		}
+
+		if result != followUpSkipped && i < len(accepted)-1 {
+			if err := m.followUpCooldown(ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	m.logger.Info("Sent follow-ups on accept", zap.Int("sent", sentCount))
+
+	return nil
+}
+
+// markConnected marks a profile as connected, using the date shown on its connection
+// card when available instead of the scan time
+func (m *MessagingWorkflow) markConnected(ctx context.Context, profileURL string, scan *connectionScan) error {
+	if t, ok := scan.ConnectedAt[profileURL]; ok {
+		return m.repository.MarkAsConnectedAt(ctx, profileURL, t)
+	}
+	return m.repository.MarkAsConnected(ctx, profileURL)
+}
+
+// connectionScan is the result of walking the connections list
+type connectionScan struct {
+	URLs        []string             // Cleaned profile URLs, most recently added first
+	ConnectedAt map[string]time.Time // Parsed "Connected X ago" per URL, where available
+	Scrolls     int
+	Truncated   bool // Stopped at scan_max_connections or scan_max_scrolls, so older connections are missing
+}
+
+// collectConnectionURLs opens the connections page and returns the cleaned profile URLs
+// listed along with their connection dates. It returns nil (without error) if the list
+// could not be found.
+func (m *MessagingWorkflow) collectConnectionURLs(ctx context.Context) (*connectionScan, error) {
+	// Sort by recently added so new acceptances are seen first
+	connectionsURL := m.config.LinkedIn.BaseURL + "/mynetwork/invite-connect/connections/?sortType=RECENTLY_ADDED"
 	if err := m.browser.Navigate(ctx, connectionsURL); err != nil {
-		return fmt.Errorf("failed to navigate to connections page: %w", err)
+		return nil, fmt.Errorf("failed to navigate to connections page: %w", err)
 	}
 
 	// Wait for the list to load
 	// The list container usually has a class like 'scaffold-finite-scroll__content' or specific connection cards
 	// Updated based on debug dump: using data-view-name="connections-list"
 	listSelector := "div[data-view-name='connections-list']"
-	if err := m.browser.WaitForElement(ctx, listSelector, 10*time.Second); err != nil {
+	if err := m.browser.WaitForElement(ctx, listSelector, elementTimeout(&m.config.Selectors)); err != nil {
 		m.logger.Warn("Could not find connection list container", zap.Error(err))
 		
 		// Dump HTML for debugging
@@ -61,26 +239,75 @@ func (m *MessagingWorkflow) ScanNewConnections(ctx context.Context) error {
 			}
 		}
 
-		return nil
+		return nil, nil
 	}
 
-	// Scroll down a bit to ensure we get the most recent ones
-	// We don't need to scroll infinitely, just enough to catch recent accepts
-	if err := m.browser.HumanScroll(ctx, "down", 500); err != nil {
-		m.logger.Warn("Failed to scroll connections list", zap.Error(err))
+	maxConnections := m.config.Messaging.ScanMaxConnections
+	if maxConnections <= 0 {
+		maxConnections = 200 // Default fallback
+	}
+	maxScrolls := m.config.Messaging.ScanMaxScrolls
+	if maxScrolls <= 0 {
+		maxScrolls = 30 // Default fallback
 	}
-	m.browser.RandomSleep(ctx, 2.0, 3.0)
 
-	// Extract all profile URLs from the visible list
+	// Extract all profile URLs from the list, scrolling until it stops growing
 	// Selector targets the main link in the connection card
 	// Updated based on debug dump: using data-view-name="connections-profile"
 	linkSelector := "a[data-view-name='connections-profile']"
-	urls, err := m.browser.GetAttributes(ctx, linkSelector, "href")
-	if err != nil {
-		return fmt.Errorf("failed to extract connection URLs: %w", err)
+	uniqueURLs := make(map[string]bool)
+	cleanURLs := make([]string, 0)
+	scrolls := 0
+	idleScrolls := 0
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		urls, err := m.browser.GetAttributes(ctx, linkSelector, "href")
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract connection URLs: %w", err)
+		}
+
+		// Deduplicate URLs
+		newCount := 0
+		for _, rawURL := range urls {
+			clean := m.cleanProfileURL(rawURL)
+			if clean != "" && !uniqueURLs[clean] {
+				uniqueURLs[clean] = true
+				cleanURLs = append(cleanURLs, clean)
+				newCount++
+			}
+		}
+
+		if newCount == 0 {
+			idleScrolls++
+		} else {
+			idleScrolls = 0
+		}
+
+		// Stop once two consecutive scrolls yield nothing new or we hit the configured caps
+		if idleScrolls >= 2 || len(cleanURLs) >= maxConnections || scrolls >= maxScrolls {
+			break
+		}
+
+		if err := m.browser.HumanScroll(ctx, "down", 1000); err != nil {
+			m.logger.Warn("Failed to scroll connections list", zap.Error(err))
+		}
+		m.browser.RandomSleep(ctx, 1.5, 2.5)
+		scrolls++
 	}
 
-	if len(urls) == 0 {
+	// Two idle scrolls mean the end of the list was reached; anything else hit a cap
+	truncated := idleScrolls < 2
+	if len(cleanURLs) > maxConnections {
+		cleanURLs = cleanURLs[:maxConnections]
+	}
+
+	if len(cleanURLs) == 0 {
 		m.logger.Warn("No connection URLs found despite finding list container")
 		// Dump HTML for debugging
 		if html, errHtml := m.browser.GetPageHTML(ctx); errHtml == nil {
@@ -91,72 +318,70 @@ func (m *MessagingWorkflow) ScanNewConnections(ctx context.Context) error {
 		}
 	}
 
-	// Deduplicate URLs
-	uniqueURLs := make(map[string]bool)
-	var cleanURLs []string
-	for _, rawURL := range urls {
-		clean := m.cleanProfileURL(rawURL)
-		if clean != "" && !uniqueURLs[clean] {
-			uniqueURLs[clean] = true
-			cleanURLs = append(cleanURLs, clean)
-		}
+	return &connectionScan{
+		URLs:        cleanURLs,
+		ConnectedAt: m.extractConnectedTimes(ctx),
+		Scrolls:     scrolls,
+		Truncated:   truncated,
+	}, nil
+}
+
+// extractConnectedTimes reads the "Connected X ago" text from every loaded connection
+// card in a single script call and parses it into approximate timestamps
+func (m *MessagingWorkflow) extractConnectedTimes(ctx context.Context) map[string]time.Time {
+	connectedAt := make(map[string]time.Time)
+
+	res, err := m.browser.ExecuteScript(ctx, `() => {
+const links = document.querySelectorAll("a[data-view-name='connections-profile']");
+const result = [];
+for (const a of links) {
+const card = a.closest("li") || a.parentElement;
+const time = card ? card.querySelector("time") : null;
+let text = time ? time.innerText : "";
+if (!text && card) {
+const match = card.innerText.match(/Connected[^\n]*/);
+text = match ? match[0] : "";
+}
+result.push({href: a.getAttribute("href") || "", text: text});
+}
+return result;
+}`)
+	if err != nil {
+		m.logger.Warn("Failed to extract connection dates", zap.Error(err))
+		return connectedAt
 	}
 
-	m.logger.Info("Found connections on page", zap.Int("count", len(cleanURLs)))
+	raw, err := json.Marshal(res)
+	if err != nil {
+		m.logger.Warn("Failed to read connection dates", zap.Error(err))
+		return connectedAt
+	}
 
-	newConnectionsCount := 0
-	
-	for _, profileURL := range cleanURLs {
-		// Check if we know this profile
-		profile, err := m.repository.GetProfileByURL(ctx, profileURL)
-		if err != nil {
-			m.logger.Error("Failed to query profile", zap.String("url", profileURL), zap.Error(err))
+	var cards []struct {
+		Href string `json:"href"`
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(raw, &cards); err != nil {
+		m.logger.Warn("Failed to parse connection dates", zap.Error(err))
+		return connectedAt
+	}
+
+	now := time.Now()
+	for _, card := range cards {
+		clean := m.cleanProfileURL(card.Href)
+		if clean == "" {
 			continue
 		}
-
-		if profile != nil {
-			// If we sent a request and now they appear here, they accepted!
-			if profile.Status == core.ProfileStatusRequestSent || 
-			   profile.Status == core.ProfileStatusScanned || 
-			   profile.Status == core.ProfileStatusDiscovered {
-				
-				m.logger.Info("Detected new connection acceptance", 
-					zap.String("url", profileURL),
-					zap.String("previous_status", profile.Status),
-				)
-
-				if err := m.repository.MarkAsConnected(ctx, profileURL); err != nil {
-					m.logger.Error("Failed to mark profile as connected", zap.Error(err))
-				} else {
-					newConnectionsCount++
-				}
-			} else if profile.Status == core.ProfileStatusConnected {
-				// Already marked, likely from a previous run
-				m.logger.Debug("Profile already marked as connected", zap.String("url", profileURL))
-			}
-		} else {
-			// Profile not in our DB. 
-			// Add them as 'Connected' so we can message them later
-			m.logger.Info("Found new connection not in DB, adding to database", zap.String("url", profileURL))
-			
-			newProfile := &core.Profile{
-				LinkedInURL: profileURL,
-				Status:      core.ProfileStatusConnected,
-			}
-			if err := m.repository.CreateProfile(ctx, newProfile); err == nil {
-				newConnectionsCount++
-				m.logger.Info("Successfully added new connection", zap.String("url", profileURL))
-			} else {
-				m.logger.Error("Failed to add new connection", zap.String("url", profileURL), zap.Error(err))
-			}
+		if t, ok := utils.ParseRelativeTime(card.Text, now); ok {
+			connectedAt[clean] = t
 		}
 	}
 
-	m.logger.Info("Scan complete", zap.Int("newly_marked_connected", newConnectionsCount))
-	return nil
+	return connectedAt
 }
 
-// cleanProfileURL removes query parameters and ensures standard format
+// cleanProfileURL removes query parameters and ensures standard format (see
+// core.NormalizeProfileURL)
 func (m *MessagingWorkflow) cleanProfileURL(rawURL string) string {
 	if rawURL == "" {
 		return ""
@@ -164,7 +389,7 @@ func (m *MessagingWorkflow) cleanProfileURL(rawURL string) string {
 	
 	// Handle relative URLs
 	if strings.HasPrefix(rawURL, "/") {
-		rawURL = "https://www.linkedin.com" + rawURL
+		rawURL = m.config.LinkedIn.BaseURL + rawURL
 	}
 
 	parsed, err := url.Parse(rawURL)
@@ -174,7 +399,7 @@ func (m *MessagingWorkflow) cleanProfileURL(rawURL string) string {
 
 	// Keep only scheme, host, and path
 	// Example: https://www.linkedin.com/in/username/
-	return fmt.Sprintf("%s://%s%s", parsed.Scheme, parsed.Host, parsed.Path)
+	return core.NormalizeProfileURL(fmt.Sprintf("%s://%s%s", parsed.Scheme, parsed.Host, parsed.Path))
 }
 
 // SendFollowUpMessages sends personalized follow-up messages to new connections
@@ -185,7 +410,13 @@ func (m *MessagingWorkflow) SendFollowUpMessages(ctx context.Context) error {
 	if limit <= 0 {
 		limit = 5 // Default fallback
 	}
-	profiles, err := m.repository.GetPendingFollowups(ctx, limit)
+	profiles, err := m.repository.GetPendingFollowups(ctx, limit, &core.FollowUpWindow{
+		MinDaysSinceConnected:     m.config.Messaging.MinDaysSinceConnected,
+		MaxDaysSinceConnected:     m.config.Messaging.MaxDaysSinceConnected,
+		Order:                     m.config.Messaging.FollowUpOrder,
+		IncludeUnknownConnectedAt: m.config.Messaging.IncludeUnknownConnectedAt,
+		Sequence:                  m.followUpSequence(),
+	})
 	if err != nil {
 		return fmt.Errorf("failed to get pending follow-ups: %w", err)
 	}
@@ -195,8 +426,21 @@ func (m *MessagingWorkflow) SendFollowUpMessages(ctx context.Context) error {
 		return nil
 	}
 
+	if m.config.Messaging.RandomizeOrder {
+		rand.Shuffle(len(profiles), func(i, j int) {
+			profiles[i], profiles[j] = profiles[j], profiles[i]
+		})
+	}
+
 	m.logger.Info("Starting follow-up sequence", zap.Int("count", len(profiles)))
 
+	processedCount := 0
+	sentCount := 0
+	restrictedCount := 0
+	skippedCount := 0
+	endorsedCount := 0
+	budgetExhausted := false
+
 	for i, profile := range profiles {
 		// Check context
 		select {
@@ -205,143 +449,567 @@ func (m *MessagingWorkflow) SendFollowUpMessages(ctx context.Context) error {
 		default:
 		}
 
-		m.logger.Info("Processing follow-up", 
-			zap.Int("index", i+1), 
-			zap.String("url", profile.LinkedInURL),
+		if !m.hasMessageBudget(ctx) {
+			budgetExhausted = true
+			break
+		}
+
+		processedCount++
+
+		ProfileLogger(m.logger, profile.LinkedInURL).Info("Processing follow-up",
+			zap.Int("index", i+1),
 		)
 
-		// 2. Navigate to profile
-		if err := m.browser.Navigate(ctx, profile.LinkedInURL); err != nil {
-			m.logger.Error("Failed to navigate to profile", zap.String("url", profile.LinkedInURL), zap.Error(err))
-			continue
+		// 2. Run the next sequence step
+		result := m.runSequenceStep(ctx, profile)
+		switch result {
+		case followUpSent:
+			sentCount++
+		case followUpRestricted:
+			restrictedCount++
+		case followUpSkipped:
+			skippedCount++
+		case followUpEndorsed:
+			endorsedCount++
 		}
-		
-		// Wait for load
-		m.browser.RandomSleep(ctx, 3.0, 5.0)
-
-		// 3. Extract Name for personalization
-		firstName := m.extractFirstName(ctx)
-		if firstName == "" {
-			firstName = "there" // Fallback
-		}
-
-		// 4. Find and Click Message Button
-		if err := m.clickMessageButton(ctx); err != nil {
-			m.logger.Warn("Failed to click message button", zap.Error(err))
-			// Dump HTML for debugging
-			if html, errHtml := m.browser.GetPageHTML(ctx); errHtml == nil {
-				dumpPath := fmt.Sprintf("data/debug_msg_fail_%d.html", time.Now().Unix())
-				_ = os.WriteFile(dumpPath, []byte(html), 0644)
+
+		// 3. Cooldown (nothing was done on the page for skipped profiles)
+		if result != followUpSkipped && i < len(profiles)-1 {
+			if err := m.followUpCooldown(ctx); err != nil {
+				return err
 			}
-			continue
 		}
+	}
+
+	// Summary
+	m.logger.Info("Follow-up summary",
+		zap.Int("pending", len(profiles)),
+		zap.Int("sent", sentCount),
+		zap.Int("restricted", restrictedCount),
+		zap.Int("skipped", skippedCount),
+		zap.Int("endorsed", endorsedCount),
+		zap.Int("failed", processedCount-sentCount-restrictedCount-skippedCount-endorsedCount),
+		zap.Bool("limit_reached", budgetExhausted),
+	)
+
+	return nil
+}
+
+// hasMessageBudget reports whether another follow-up may be sent without exceeding
+// the per-session cap or the daily message limit
+func (m *MessagingWorkflow) hasMessageBudget(ctx context.Context) bool {
+	// Enforce per-session cap regardless of batch limit
+	sessionLimit := m.config.Messaging.MaxMessagesPerSession
+	if sessionLimit > 0 && m.sessionSent >= sessionLimit {
+		m.logger.Warn("Session message limit reached, stopping follow-ups",
+			zap.Int("limit", sessionLimit),
+		)
+		return false
+	}
+
+	dailyLimit := m.config.Messaging.DailyLimit
+	if dailyLimit <= 0 {
+		dailyLimit = 20 // Default fallback
+	}
+
+	// Enforce daily message limit before each send
+	canMessage, err := m.repository.CanPerformAction(ctx, "Message", dailyLimit)
+	if err != nil {
+		m.logger.Warn("Failed to check message rate limit", zap.Error(err))
+		return true
+	}
+	if !canMessage {
+		m.logger.Warn("Daily message limit reached, stopping follow-ups",
+			zap.Int("limit", dailyLimit),
+			zap.Int("sent_this_session", m.sessionSent),
+		)
+		return false
+	}
+
+	return true
+}
+
+// followUpCooldown sleeps for a random interval between two follow-up messages:
+// follow_up_cooldown_min/max seconds when set, otherwise cooldown_min/max minutes
+func (m *MessagingWorkflow) followUpCooldown(ctx context.Context) error {
+	minSeconds, maxSeconds := m.config.Messaging.FollowUpCooldownMin, m.config.Messaging.FollowUpCooldownMax
+	if maxSeconds <= 0 {
+		minSeconds, maxSeconds = m.config.Messaging.CooldownMin*60, m.config.Messaging.CooldownMax*60
+	}
+	delay := m.jitter.RandomDuration(float64(minSeconds), float64(maxSeconds))
+	m.logger.Info("Sleeping before next message", zap.Duration("duration", delay))
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(delay):
+	}
+	return nil
+}
+
+// followUpResult is the outcome of a single follow-up attempt
+type followUpResult int
+
+const (
+	followUpFailed followUpResult = iota
+	followUpSent
+	followUpRestricted
+	followUpSkipped
+	followUpEndorsed
+)
+
+// runSequenceStep runs the next step of the follow-up sequence for a profile if it is
+// due. Rate limits and cooldowns are left to the caller.
+func (m *MessagingWorkflow) runSequenceStep(ctx context.Context, profile *core.Profile) followUpResult {
+	logger := ProfileLogger(m.logger, profile.LinkedInURL)
+
+	// Profiles of paused or archived campaigns get no follow-ups
+	campaign, err := ResolveCampaign(ctx, m.repository, profile.Campaign)
+	if err != nil {
+		logger.Info("Skipping follow-up", zap.Error(err))
+		return followUpSkipped
+	}
+
+	// Never repeat a step that was already done
+	step, messageStep, err := m.nextSequenceStep(ctx, profile)
+	if err != nil {
+		logger.Error("Failed to load sequence progress", zap.Error(err))
+		return followUpFailed
+	}
+	if step == nil {
+		logger.Info("Follow-up sequence already complete, skipping")
+		return followUpSkipped
+	}
+
+	if profile.ConnectedAt != nil && time.Since(*profile.ConnectedAt) < time.Duration(step.Day)*24*time.Hour {
+		logger.Debug("Sequence step not due yet",
+			zap.String("step", step.Type),
+			zap.Int("day", step.Day),
+		)
+		return followUpSkipped
+	}
+
+	if step.Type == core.SequenceStepEndorse {
+		if _, err := m.endorser.EndorseSkills(ctx, profile.LinkedInURL, step.MaxSkills); err != nil {
+			logger.Error("Failed to endorse skills", zap.Error(err))
+			return followUpFailed
+		}
+		return followUpEndorsed
+	}
+
+	if campaign != nil && !m.hasCampaignBudget(ctx, campaign) {
+		return followUpSkipped
+	}
+
+	return m.sendFollowUp(ctx, profile, campaign, messageStep)
+}
+
+// hasCampaignBudget reports whether the campaign has messages left in its share of
+// messaging.daily_limit today
+func (m *MessagingWorkflow) hasCampaignBudget(ctx context.Context, campaign *core.Campaign) bool {
+	dailyLimit := m.config.Messaging.DailyLimit
+	if dailyLimit <= 0 {
+		dailyLimit = 20 // Default fallback
+	}
+	limit := CampaignDailyLimit(campaign, dailyLimit)
+
+	count, err := m.repository.GetTodayCampaignActionCount(ctx, "Message", campaign.Name)
+	if err != nil {
+		m.logger.Warn("Failed to check campaign message budget", zap.String("campaign", campaign.Name), zap.Error(err))
+		return true
+	}
+	if count >= int64(limit) {
+		m.logger.Info("Campaign message budget reached, skipping",
+			zap.String("campaign", campaign.Name),
+			zap.Int("limit", limit),
+		)
+		return false
+	}
+	return true
+}
+
+// sendFollowUp opens a profile, composes the follow-up message for the given 1-based
+// message step and sends it. Rate limits and cooldowns are left to the caller.
+func (m *MessagingWorkflow) sendFollowUp(ctx context.Context, profile *core.Profile, campaign *core.Campaign, step int) followUpResult {
+	templateName, template := m.resolveTemplate(profile, campaign, step)
+	return m.sendMessage(ctx, profile, templateName, template, step)
+}
+
+// sendMessage opens a profile, renders template ({{FirstName}}) and sends it. step is the
+// follow-up sequence step, or 0 for messages outside the sequence.
+func (m *MessagingWorkflow) sendMessage(ctx context.Context, profile *core.Profile, templateName, template string, step int) followUpResult {
+	logger := ProfileLogger(m.logger, profile.LinkedInURL)
+
+	// 1. Navigate to profile
+	if err := m.browser.Navigate(ctx, profile.LinkedInURL); err != nil {
+		logger.Error("Failed to navigate to profile", zap.Error(err))
+		return followUpFailed
+	}
+	
+	// Wait for load
+	m.browser.RandomSleep(ctx, 3.0, 5.0)
+
+	// 2. Extract Name for personalization
+	firstName := m.extractFirstName(ctx)
+	if firstName == "" {
+		firstName = "there" // Fallback
+	}
 
-		// 5. Wait for chat overlay/window
-		// The chat input usually has role='textbox' and is contenteditable
-		chatInputSelectors := []string{
-			"div.msg-form__contenteditable[role='textbox']",
-			"div[role='textbox'][aria-label*='Write a message']",
-			"div[role='textbox'][aria-label*='Message']",
-			".msg-form__message-texteditor",
+	// 3. Find and Click Message Button
+	if err := m.clickMessageButton(ctx); err != nil {
+		logger.Warn("Failed to click message button", zap.Error(err))
+		// Dump HTML for debugging
+		if html, errHtml := m.browser.GetPageHTML(ctx); errHtml == nil {
+			dumpPath := fmt.Sprintf("data/debug_msg_fail_%d.html", time.Now().Unix())
+			_ = os.WriteFile(dumpPath, []byte(html), 0644)
 		}
-		
-		var chatInputSelector string
-		
-		// Wait for the chat window to appear (check primary selector first)
-		// Increased timeout to 10s
-		if err := m.browser.WaitForElement(ctx, chatInputSelectors[0], 10*time.Second); err == nil {
-			chatInputSelector = chatInputSelectors[0]
-		} else {
-			// If primary failed, check others quickly
-			for _, sel := range chatInputSelectors[1:] {
-				if exists, _ := m.browser.ElementExists(ctx, sel); exists {
-					chatInputSelector = sel
-					break
-				}
-			}
+		return followUpFailed
+	}
+
+	// 4. Detect InMail-only and restricted profiles before typing anything
+	m.browser.RandomSleep(ctx, 1.0, 2.0)
+	isInMail := false
+	state, credits := m.detectComposerState(ctx)
+	switch state {
+	case composerRestricted:
+		logger.Warn("Profile cannot be messaged, skipping")
+		m.markMessageRestricted(ctx, profile.LinkedInURL)
+		return followUpRestricted
+	case composerInMail:
+		if !m.config.Messaging.AllowInMail || credits <= 0 {
+			logger.Warn("Profile only accepts InMail, skipping",
+				zap.Bool("allow_inmail", m.config.Messaging.AllowInMail),
+				zap.Int("credits", credits),
+			)
+			m.markMessageRestricted(ctx, profile.LinkedInURL)
+			return followUpRestricted
 		}
+		logger.Info("Sending via InMail", zap.Int("credits_remaining", credits))
+		isInMail = true
+	}
 
-		if chatInputSelector == "" {
-			m.logger.Warn("Chat input not found")
-			// Dump HTML for debugging
-			if html, errHtml := m.browser.GetPageHTML(ctx); errHtml == nil {
-				dumpPath := fmt.Sprintf("data/debug_chat_input_fail_%d.html", time.Now().Unix())
-				if errWrite := os.WriteFile(dumpPath, []byte(html), 0644); errWrite == nil {
-					m.logger.Info("Dumped page HTML for debugging", zap.String("path", dumpPath))
-				}
+	// 5. Wait for chat overlay/window
+	chatInputSelector := m.findChatInput(ctx)
+	if chatInputSelector == "" {
+		logger.Warn("Chat input not found")
+		// Dump HTML for debugging
+		if html, errHtml := m.browser.GetPageHTML(ctx); errHtml == nil {
+			dumpPath := fmt.Sprintf("data/debug_chat_input_fail_%d.html", time.Now().Unix())
+			if errWrite := os.WriteFile(dumpPath, []byte(html), 0644); errWrite == nil {
+				logger.Info("Dumped page HTML for debugging", zap.String("path", dumpPath))
 			}
-			continue
 		}
+		return followUpFailed
+	}
+
+	// 6. Prepare Message
+	messageBody := strings.ReplaceAll(template, "{{FirstName}}", firstName)
 
-		// 6. Prepare Message
-		template := m.config.Messaging.FollowUpTemplate
-		if template == "" {
-			template = "Hi {{FirstName}}, thanks for connecting! I'd love to keep in touch."
+	// 7. Type Message (InMail needs a subject first)
+	if isInMail {
+		if err := m.typeInMailSubject(ctx); err != nil {
+			logger.Error("Failed to type InMail subject", zap.Error(err))
+			return followUpFailed
 		}
-		
-		messageBody := strings.ReplaceAll(template, "{{FirstName}}", firstName)
+	}
 
-		// 7. Type Message
-		if err := m.browser.HumanClick(ctx, chatInputSelector); err != nil {
-			m.logger.Warn("Failed to focus chat input", zap.Error(err))
-			continue
+	if err := m.browser.HumanClick(ctx, chatInputSelector); err != nil {
+		logger.Warn("Failed to focus chat input", zap.Error(err))
+		return followUpFailed
+	}
+	
+	formatted := m.config.Messaging.AllowHTMLFormatting && hasFormattingMarkup(messageBody)
+	typedBody := messageBody
+	if formatted {
+		typedBody = plainMessage(messageBody)
+	}
+	if err := m.browser.HumanType(ctx, chatInputSelector, typedBody); err != nil {
+		logger.Error("Failed to type message", zap.Error(err))
+		return followUpFailed
+	}
+
+	// The typed plain text stays in place if the formatting can't be applied
+	if formatted {
+		if err := m.applyFormatting(ctx, chatInputSelector, messageBody); err != nil {
+			logger.Warn("Failed to apply message formatting, sending plain text", zap.Error(err))
 		}
-		
-		if err := m.browser.HumanType(ctx, chatInputSelector, messageBody); err != nil {
-			m.logger.Error("Failed to type message", zap.Error(err))
-			continue
+	}
+
+	// 8. Click Send
+	sendBtnSelector := "button.msg-form__send-button"
+	if err := m.browser.WaitForElement(ctx, sendBtnSelector, quickTimeout(&m.config.Selectors)); err != nil {
+		logger.Warn("Send button not found", zap.Error(err))
+		return followUpFailed
+	}
+
+	if err := m.browser.HumanClick(ctx, sendBtnSelector); err != nil {
+		logger.Error("Failed to click send button", zap.Error(err))
+		return followUpFailed
+	}
+
+	// 9. Log Success
+	m.sessionSent++
+	message := &core.Message{
+		ProfileID:    profile.ID,
+		Body:         messageBody,
+		TemplateName: templateName,
+		SequenceStep: step,
+	}
+	if err := m.repository.LogMessageSent(ctx, message); err != nil {
+		logger.Error("Failed to log message sent", zap.Error(err))
+	} else {
+		logger.Info("Message sent successfully", actionField("Message"), zap.String("template", templateName))
+	}
+
+	return followUpSent
+}
+
+// applyFormatting replaces the typed text in the composer with the formatted version of
+// body and fires an input event so the composer picks up the change
+func (m *MessagingWorkflow) applyFormatting(ctx context.Context, inputSelector, body string) error {
+	res, err := m.browser.ExecuteScript(ctx, fmt.Sprintf(`() => {
+const input = document.querySelector(%q);
+if (!input || !input.isContentEditable) return false;
+input.innerHTML = %q;
+input.dispatchEvent(new InputEvent("input", {bubbles: true}));
+return true;
+}`, inputSelector, formattedMessageHTML(body)))
+	if err != nil {
+		return err
+	}
+	if fmt.Sprint(res) != "true" {
+		return fmt.Errorf("composer input is not contenteditable")
+	}
+	return nil
+}
+
+// findChatInput waits for the chat or InMail composer and returns the selector of its
+// message input, or "" if none appeared
+func (m *MessagingWorkflow) findChatInput(ctx context.Context) string {
+	// The chat input usually has role='textbox' and is contenteditable
+	chatInputSelectors := []string{
+		"div.msg-form__contenteditable[role='textbox']",
+		"div[role='textbox'][aria-label*='Write a message']",
+		"div[role='textbox'][aria-label*='Message']",
+		".msg-form__message-texteditor",
+	}
+
+	// Wait for the chat window to appear (check primary selector first)
+	// Increased timeout to 10s
+	if err := m.browser.WaitForElement(ctx, chatInputSelectors[0], elementTimeout(&m.config.Selectors)); err == nil {
+		return chatInputSelectors[0]
+	}
+
+	// If primary failed, check others quickly
+	for _, sel := range chatInputSelectors[1:] {
+		if exists, _ := m.browser.ElementExists(ctx, sel); exists {
+			return sel
 		}
+	}
 
-		// 8. Click Send
-		sendBtnSelector := "button.msg-form__send-button"
-		if err := m.browser.WaitForElement(ctx, sendBtnSelector, 2*time.Second); err != nil {
-			m.logger.Warn("Send button not found", zap.Error(err))
-			continue
+	return ""
+}
+
+// resolveTemplate picks the follow-up template for a profile's campaign: the campaign's
+// message sequence entry for the 1-based step (the last entry repeats), then the
+// messaging.campaign_templates mapping, then the default template. It returns the
+// template name along with its body.
+func (m *MessagingWorkflow) resolveTemplate(profile *core.Profile, campaign *core.Campaign, step int) (string, string) {
+	names, err := CampaignSequenceTemplates(campaign)
+	if err != nil {
+		m.logger.Warn("Ignoring campaign message sequence", zap.Error(err))
+	}
+	if len(names) > 0 {
+		name := names[len(names)-1]
+		if step > 0 && step <= len(names) {
+			name = names[step-1]
+		}
+		if template, ok := m.config.Messaging.Templates[strings.ToLower(name)]; ok && template != "" {
+			return name, template
+		}
+		m.logger.Warn("Campaign sequence template not found",
+			zap.String("campaign", campaign.Name),
+			zap.String("template", name),
+		)
+	}
+
+	if profile.Campaign != "" {
+		// Viper lower-cases map keys, so campaign lookups are case-insensitive
+		campaign := strings.ToLower(profile.Campaign)
+		if name, ok := m.config.Messaging.CampaignTemplates[campaign]; ok {
+			if template, ok := m.config.Messaging.Templates[strings.ToLower(name)]; ok && template != "" {
+				return name, template
+			}
+			m.logger.Warn("Campaign template not found, using default",
+				zap.String("campaign", profile.Campaign),
+				zap.String("template", name),
+			)
 		}
+	}
 
-		if err := m.browser.HumanClick(ctx, sendBtnSelector); err != nil {
-			m.logger.Error("Failed to click send button", zap.Error(err))
-			continue
+	template := m.config.Messaging.FollowUpTemplate
+	if template == "" {
+		template = "Hi {{FirstName}}, thanks for connecting! I'd love to keep in touch."
+	}
+	return defaultTemplateName, template
+}
+
+// defaultTemplateName is recorded on messages built from messaging.follow_up_template
+const defaultTemplateName = "default"
+
+// followUpSequence returns the configured follow-up steps, or a single message step
+func (m *MessagingWorkflow) followUpSequence() []core.SequenceStep {
+	if len(m.config.Messaging.Sequence) > 0 {
+		return m.config.Messaging.Sequence
+	}
+	return []core.SequenceStep{{Type: core.SequenceStepMessage}}
+}
+
+// nextSequenceStep returns the first sequence step not yet done for a profile, based on
+// the outgoing messages and endorsement recorded for it, or nil if the sequence is
+// complete. For message steps it also returns the 1-based message number.
+func (m *MessagingWorkflow) nextSequenceStep(ctx context.Context, profile *core.Profile) (*core.SequenceStep, int, error) {
+	messages, err := m.repository.GetMessagesForProfile(ctx, profile.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sent := 0
+	for _, msg := range messages {
+		if msg.Direction == core.MessageDirectionOut && msg.SequenceStep > 0 {
+			sent++
 		}
+	}
+	if sent == 0 && profile.LastMessageSentAt != nil {
+		sent = 1 // Messaged before the Message table existed
+	}
 
-		// 9. Log Success
-		if err := m.repository.LogMessageSent(ctx, profile.ID, messageBody); err != nil {
-			m.logger.Error("Failed to log message sent", zap.Error(err))
-		} else {
-			m.logger.Info("Follow-up message sent successfully")
-		}
-
-		// 10. Cooldown
-		if i < len(profiles)-1 {
-			// Random delay 2-5 minutes
-			delay := time.Duration(120 + time.Now().Unix()%180) * time.Second
-			m.logger.Info("Sleeping before next message", zap.Duration("duration", delay))
-			
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case <-time.After(delay):
+	messageStep := 0
+	for _, step := range m.followUpSequence() {
+		switch step.Type {
+		case core.SequenceStepEndorse:
+			endorsed, err := m.repository.HasEndorsement(ctx, profile.ID)
+			if err != nil {
+				return nil, 0, err
 			}
+			if !endorsed {
+				return &step, 0, nil
+			}
+		case core.SequenceStepMessage:
+			messageStep++
+			if messageStep > sent {
+				return &step, messageStep, nil
+			}
+		default:
+			m.logger.Warn("Unknown sequence step type, ignoring", zap.String("type", step.Type))
 		}
 	}
 
-	return nil
+	return nil, 0, nil
+}
+
+// composerState describes what opened after clicking the Message button
+type composerState int
+
+const (
+	composerChat composerState = iota
+	composerInMail
+	composerRestricted
+)
+
+// inMailCreditsPattern matches the remaining-credit indicator in the InMail composer
+var inMailCreditsPattern = regexp.MustCompile(`(?i)(\d+)\s+(?:InMail\s+)?credits?`)
+
+// detectComposerState tells a normal chat apart from an InMail composer or an
+// "unable to message" notice. For InMail it also returns the remaining credits,
+// or -1 if the indicator could not be parsed.
+func (m *MessagingWorkflow) detectComposerState(ctx context.Context) (composerState, int) {
+	overlayText := ""
+	res, err := m.browser.ExecuteScript(ctx, `() => {
+const el = document.querySelector('.msg-overlay-conversation-bubble, .artdeco-modal, .msg-form');
+return el ? el.innerText : '';
+}`)
+	if err == nil && res != nil {
+		overlayText = strings.ToLower(fmt.Sprint(res))
+	}
+
+	restrictedPhrases := []string{
+		"can't message",
+		"cannot message",
+		"unable to message",
+		"not accepting messages",
+		"isn't accepting messages",
+	}
+	for _, phrase := range restrictedPhrases {
+		if strings.Contains(overlayText, phrase) {
+			return composerRestricted, 0
+		}
+	}
+
+	inMailSelectors := []string{
+		".msg-form__subject",
+		"input[name='subject']",
+		".msg-inmail-credits-display",
+	}
+	for _, sel := range inMailSelectors {
+		if exists, _ := m.browser.ElementExists(ctx, sel); exists {
+			credits := -1
+			if match := inMailCreditsPattern.FindStringSubmatch(overlayText); match != nil {
+				if n, err := strconv.Atoi(match[1]); err == nil {
+					credits = n
+				}
+			}
+			return composerInMail, credits
+		}
+	}
+
+	return composerChat, 0
+}
+
+// typeInMailSubject fills in the subject line of the InMail composer
+func (m *MessagingWorkflow) typeInMailSubject(ctx context.Context) error {
+	subject := m.config.Messaging.InMailSubject
+	if subject == "" {
+		subject = "Great to connect"
+	}
+
+	for _, sel := range []string{".msg-form__subject", "input[name='subject']"} {
+		if exists, _ := m.browser.ElementExists(ctx, sel); exists {
+			return m.browser.HumanType(ctx, sel, subject)
+		}
+	}
+
+	return fmt.Errorf("InMail subject field not found")
+}
+
+// markMessageRestricted flags a profile so it is no longer picked up for follow-ups
+func (m *MessagingWorkflow) markMessageRestricted(ctx context.Context, profileURL string) {
+	if err := m.repository.UpdateProfileStatus(ctx, profileURL, core.ProfileStatusMessageRestricted); err != nil {
+		m.logger.Error("Failed to mark profile as message restricted", zap.Error(err))
+	}
 }
 
 // extractFirstName extracts the first name from the profile page
 func (m *MessagingWorkflow) extractFirstName(ctx context.Context) string {
-	// Try standard profile name selector
-	// Usually h1.text-heading-xlarge
-	nameSelector := "h1.text-heading-xlarge"
-	name, err := m.browser.GetText(ctx, nameSelector)
-	if err != nil || name == "" {
+	// Usually h1.text-heading-xlarge, with fallbacks for other profile layouts
+	selectors := []string{
+		"h1.text-heading-xlarge",
+		"h1[data-anonymize='person-name']",
+		".pv-text-details__left-panel h1",
+		"h1.inline",
+	}
+
+	texts, err := m.browser.GetAllText(ctx, selectors)
+	if err != nil {
 		return ""
 	}
 
-	// Split by space and take first part
-	parts := strings.Fields(name)
-	if len(parts) > 0 {
-		return parts[0]
+	for _, selector := range selectors {
+		// Split by space and take first part
+		parts := strings.Fields(texts[selector])
+		if len(parts) > 0 {
+			return parts[0]
+		}
 	}
 	return ""
 }
@@ -436,7 +1104,7 @@ func (m *MessagingWorkflow) clickMessageButton(ctx context.Context) error {
 		}
 
 		for _, opt := range msgOptions {
-			if err := m.browser.WaitForElement(ctx, opt, 2*time.Second); err == nil {
+			if err := m.browser.WaitForElement(ctx, opt, quickTimeout(&m.config.Selectors)); err == nil {
 				m.logger.Info("Found Message option in dropdown", zap.String("selector", opt))
 				return m.browser.HumanClick(ctx, opt)
 			}
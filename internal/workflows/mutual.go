@@ -0,0 +1,160 @@
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// MutualConnectionWorkflow asks existing connections to introduce the account to a target,
+// which is far more likely to be accepted than a cold connection request
+type MutualConnectionWorkflow struct {
+	browser    core.BrowserPort
+	repository core.RepositoryPort
+	config     *core.Config
+	logger     *zap.Logger
+	messenger  *MessagingWorkflow
+}
+
+// NewMutualConnectionWorkflow creates a new mutual connection workflow
+func NewMutualConnectionWorkflow(browser core.BrowserPort, repo core.RepositoryPort, config *core.Config, logger *zap.Logger) *MutualConnectionWorkflow {
+	return &MutualConnectionWorkflow{
+		browser:    browser,
+		repository: repo,
+		config:     config,
+		logger:     logger,
+		messenger:  NewMessagingWorkflow(browser, repo, config, logger),
+	}
+}
+
+// FindMutualConnections opens the target profile and returns the profile URLs of our
+// connections among the mutual connections it lists. Names are matched against the
+// profiles table, so only connections whose name has been stored can be found.
+func (w *MutualConnectionWorkflow) FindMutualConnections(ctx context.Context, targetURL string) ([]string, error) {
+	if err := w.browser.Navigate(ctx, targetURL); err != nil {
+		return nil, fmt.Errorf("failed to navigate to profile: %w", err)
+	}
+	w.browser.RandomSleep(ctx, 2.0, 4.0)
+
+	return w.mutualConnectionsOnPage(ctx)
+}
+
+// mutualConnectionsOnPage matches the mutual connection names on the current profile
+// page against our connections
+func (w *MutualConnectionWorkflow) mutualConnectionsOnPage(ctx context.Context) ([]string, error) {
+	names, err := w.extractMutualNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return []string{}, nil
+	}
+
+	profiles, err := w.repository.GetConnectedProfilesByName(ctx, names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to match mutual connections: %w", err)
+	}
+
+	urls := make([]string, 0, len(profiles))
+	for _, profile := range profiles {
+		urls = append(urls, profile.LinkedInURL)
+	}
+
+	w.logger.Debug("Mutual connections found",
+		zap.Strings("names", names),
+		zap.Int("known", len(urls)),
+	)
+
+	return urls, nil
+}
+
+// RequestIntroduction messages the mutual connection asking for an introduction to the
+// target. introMessage supports {{FirstName}} (the mutual connection), {{TargetName}}
+// and {{TargetURL}}; connection.introduction_template is used when it is empty.
+func (w *MutualConnectionWorkflow) RequestIntroduction(ctx context.Context, mutualURL, targetURL string, introMessage string) error {
+	if introMessage == "" {
+		introMessage = w.config.Connection.IntroductionTemplate
+	}
+	if introMessage == "" {
+		return fmt.Errorf("introduction template is empty")
+	}
+
+	mutual, err := w.repository.GetProfileByURL(ctx, mutualURL)
+	if err != nil {
+		return fmt.Errorf("failed to load mutual connection: %w", err)
+	}
+	if mutual == nil {
+		return fmt.Errorf("mutual connection %s is not in the database", mutualURL)
+	}
+
+	if !w.messenger.hasMessageBudget(ctx) {
+		return fmt.Errorf("message limit reached")
+	}
+
+	targetName := "them"
+	target, err := w.repository.GetProfileByURL(ctx, targetURL)
+	if err != nil {
+		w.logger.Warn("Failed to load target profile", zap.Error(err))
+	} else if target != nil && target.Name != "" {
+		targetName = target.Name
+	}
+
+	body := strings.NewReplacer("{{TargetName}}", targetName, "{{TargetURL}}", targetURL).Replace(introMessage)
+
+	if result := w.messenger.sendMessage(ctx, mutual, "introduction", body, 0); result != followUpSent {
+		return fmt.Errorf("failed to send introduction request to %s", mutualURL)
+	}
+
+	history := core.NewHistory("IntroductionRequest", core.HistoryDetails{ProfileURL: targetURL, Target: mutualURL})
+	if err := w.repository.CreateHistory(ctx, history); err != nil {
+		w.logger.Warn("Failed to save history", zap.Error(err))
+	}
+
+	w.logger.Info("Requested introduction",
+		zap.String("target", targetURL),
+		zap.String("via", mutualURL),
+	)
+
+	return nil
+}
+
+// extractMutualNames reads mutual connection names from the top card's "X, Y and N other
+// mutual connections" insight and from the .pv-browsemap-section lists
+func (w *MutualConnectionWorkflow) extractMutualNames(ctx context.Context) ([]string, error) {
+	res, err := w.browser.ExecuteScript(ctx, `() => {
+const names = new Set();
+const insight = document.querySelector("a[href*='facetConnectionOf'], a[href*='mutual']");
+if (insight) {
+const text = insight.innerText.replace(/\s+/g, " ").trim();
+const head = text.split(/,?\s+and\s+\d+\s+other/i)[0].replace(/\s+(are|is)\s+mutual connections?.*$/i, "").replace(/\s+mutual connections?.*$/i, "");
+for (const part of head.split(/,\s*|\s+and\s+/)) {
+if (part.trim()) names.add(part.trim());
+}
+}
+for (const el of document.querySelectorAll(".pv-browsemap-section a[href*='/in/'] span[aria-hidden='true']")) {
+const name = el.innerText.trim();
+if (name) names.add(name);
+}
+return Array.from(names);
+}`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract mutual connections: %w", err)
+	}
+
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mutual connections: %w", err)
+	}
+
+	var names []string
+	if err := json.Unmarshal(raw, &names); err != nil {
+		return nil, fmt.Errorf("failed to parse mutual connections: %w", err)
+	}
+
+	return names, nil
+}
@@ -0,0 +1,256 @@
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// Celebration kinds, also used as the template name of the messages sent for them
+const (
+	celebrationBirthday    = "birthday"
+	celebrationAnniversary = "anniversary"
+)
+
+// celebrationRepeatWindow is how long to wait before congratulating the same person
+// for the same kind of celebration again
+const celebrationRepeatWindow = 365 * 24 * time.Hour
+
+// NotificationsWorkflow congratulates existing connections on birthdays and work
+// anniversaries surfaced on the notifications page
+type NotificationsWorkflow struct {
+	browser    core.BrowserPort
+	repository core.RepositoryPort
+	config     *core.Config
+	logger     *zap.Logger
+	messenger  *MessagingWorkflow
+}
+
+// NewNotificationsWorkflow creates a new notifications workflow
+func NewNotificationsWorkflow(browser core.BrowserPort, repo core.RepositoryPort, config *core.Config, logger *zap.Logger) *NotificationsWorkflow {
+	return &NotificationsWorkflow{
+		browser:    browser,
+		repository: repo,
+		config:     config,
+		logger:     logger,
+		messenger:  NewMessagingWorkflow(browser, repo, config, logger),
+	}
+}
+
+// notificationCard is a notification read from the notifications list
+type notificationCard struct {
+	Text       string `json:"text"`
+	ProfileURL string `json:"profileURL"`
+	ActorName  string `json:"actorName"`
+	Age        string `json:"age"` // Relative age shown on the card, e.g. "2h" or "3d"
+}
+
+// celebration is a birthday or work anniversary of a known connection
+type celebration struct {
+	Kind    string
+	Years   string
+	Profile *core.Profile
+}
+
+// anniversaryPattern matches "celebrating 5 years at ..." and "5 year work anniversary"
+var anniversaryPattern = regexp.MustCompile(`(?i)(?:celebrating\s+(\d+)\s+years?|(\d+)\s+years?\s+work anniversary|work anniversary)`)
+
+// SendCelebrationMessages reads the notifications page and messages connections with a
+// birthday or work anniversary. People not in the database are ignored, and nobody is
+// congratulated for the same kind of celebration twice within a year.
+func (n *NotificationsWorkflow) SendCelebrationMessages(ctx context.Context) error {
+	maxPerDay := n.config.Celebrations.MaxPerDay
+	if maxPerDay <= 0 {
+		maxPerDay = 5 // Default fallback
+	}
+
+	n.logger.Info("Checking notifications for celebrations...")
+
+	if err := n.browser.Navigate(ctx, n.config.LinkedIn.BaseURL+"/notifications/"); err != nil {
+		return fmt.Errorf("failed to navigate to notifications: %w", err)
+	}
+	n.browser.RandomSleep(ctx, 3.0, 5.0)
+
+	if err := n.browser.HumanScroll(ctx, "down", 1500); err != nil {
+		n.logger.Warn("Failed to scroll notifications", zap.Error(err))
+	}
+	n.browser.RandomSleep(ctx, 1.5, 3.0)
+
+	cards, err := n.extractNotifications(ctx)
+	if err != nil {
+		return err
+	}
+
+	celebrations, err := n.findCelebrations(ctx, cards)
+	if err != nil {
+		return err
+	}
+
+	n.logger.Info("Celebrations found",
+		zap.Int("notifications", len(cards)),
+		zap.Int("celebrations", len(celebrations)),
+	)
+
+	sentCount := 0
+	for i, c := range celebrations {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		canSend, err := n.repository.CanPerformAction(ctx, "Celebration", maxPerDay)
+		if err != nil {
+			n.logger.Warn("Failed to check celebration rate limit", zap.Error(err))
+		} else if !canSend {
+			n.logger.Info("Daily celebration limit reached", zap.Int("limit", maxPerDay))
+			break
+		}
+		if !n.messenger.hasMessageBudget(ctx) {
+			break
+		}
+
+		template := n.config.Celebrations.BirthdayTemplate
+		if c.Kind == celebrationAnniversary {
+			template = n.config.Celebrations.AnniversaryTemplate
+		}
+		template = strings.ReplaceAll(template, "{{Years}}", c.Years)
+
+		if n.messenger.sendMessage(ctx, c.Profile, c.Kind, template, 0) != followUpSent {
+			continue
+		}
+		sentCount++
+
+		history := core.NewHistory("Celebration", core.HistoryDetails{ProfileURL: c.Profile.LinkedInURL, Template: c.Kind})
+		if err := n.repository.CreateHistory(ctx, history); err != nil {
+			n.logger.Warn("Failed to save history", zap.Error(err))
+		}
+
+		if i < len(celebrations)-1 {
+			if err := n.messenger.followUpCooldown(ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	n.logger.Info("Celebration messages complete", zap.Int("sent", sentCount))
+
+	return nil
+}
+
+// findCelebrations keeps birthday and anniversary notifications about connections in
+// the database that have not been congratulated for it within the last year
+func (n *NotificationsWorkflow) findCelebrations(ctx context.Context, cards []notificationCard) ([]celebration, error) {
+	result := make([]celebration, 0)
+	seen := make(map[string]bool)
+
+	for _, card := range cards {
+		kind, years := classifyCelebration(card.Text)
+		if kind == "" || card.ProfileURL == "" {
+			continue
+		}
+
+		profileURL := n.messenger.cleanProfileURL(card.ProfileURL)
+		if seen[kind+profileURL] {
+			continue
+		}
+		seen[kind+profileURL] = true
+
+		profile, err := n.findProfile(ctx, profileURL)
+		if err != nil {
+			return nil, err
+		}
+		if profile == nil || profile.ConnectedAt == nil {
+			continue
+		}
+
+		last, err := n.repository.GetLastMessageByTemplate(ctx, profile.ID, kind)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check previous %s messages: %w", kind, err)
+		}
+		if last != nil && time.Since(last.SentAt) < celebrationRepeatWindow {
+			n.logger.Debug("Already congratulated this year", zap.String("url", profileURL), zap.String("kind", kind))
+			continue
+		}
+
+		result = append(result, celebration{Kind: kind, Years: years, Profile: profile})
+	}
+
+	return result, nil
+}
+
+// findProfile looks a profile up with and without a trailing slash
+func (n *NotificationsWorkflow) findProfile(ctx context.Context, profileURL string) (*core.Profile, error) {
+	trimmed := strings.TrimRight(profileURL, "/")
+	for _, candidate := range []string{trimmed + "/", trimmed} {
+		profile, err := n.repository.GetProfileByURL(ctx, candidate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up profile: %w", err)
+		}
+		if profile != nil {
+			return profile, nil
+		}
+	}
+	return nil, nil
+}
+
+// classifyCelebration returns the celebration kind of a notification, or "" if it is
+// not a celebration. For anniversaries it also returns the number of years when shown.
+func classifyCelebration(text string) (string, string) {
+	lower := strings.ToLower(text)
+	if strings.Contains(lower, "birthday") {
+		return celebrationBirthday, ""
+	}
+
+	match := anniversaryPattern.FindStringSubmatch(text)
+	if match == nil {
+		return "", ""
+	}
+	years := match[1]
+	if years == "" {
+		years = match[2]
+	}
+	return celebrationAnniversary, years
+}
+
+// extractNotifications reads every loaded notification card in a single script call
+func (n *NotificationsWorkflow) extractNotifications(ctx context.Context) ([]notificationCard, error) {
+	res, err := n.browser.ExecuteScript(ctx, `() => {
+const result = [];
+for (const card of document.querySelectorAll("article.nt-card, .nt-card-list article")) {
+const link = card.querySelector("a[href*='/in/']");
+const text = card.querySelector(".nt-card__text, .nt-card__headline");
+const actor = card.querySelector(".nt-card__headline strong, .nt-card__text strong");
+const age = card.querySelector(".nt-card__time-ago, time");
+result.push({
+text: (text || card).innerText.trim(),
+profileURL: link ? link.href : "",
+actorName: actor ? actor.innerText.trim() : "",
+age: age ? age.innerText.trim() : ""
+});
+}
+return result;
+}`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract notifications: %w", err)
+	}
+
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notifications: %w", err)
+	}
+
+	var cards []notificationCard
+	if err := json.Unmarshal(raw, &cards); err != nil {
+		return nil, fmt.Errorf("failed to parse notifications: %w", err)
+	}
+
+	return cards, nil
+}
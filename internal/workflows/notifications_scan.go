@@ -0,0 +1,239 @@
+package workflows
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"linkedin-automation/internal/core"
+	"linkedin-automation/pkg/utils"
+
+	"go.uber.org/zap"
+)
+
+// NotificationScan summarizes a notifications scan
+type NotificationScan struct {
+	Read      int            // Notification cards read from the page
+	Stored    int            // New events stored (already stored ones are skipped)
+	ByType    map[string]int // New events per type
+	Connected int            // Profiles marked connected from invite_accepted events
+}
+
+// notificationAgePattern matches the short ages on notification cards ("5m", "2h", "3d", "1w", "2mo", "1y")
+var notificationAgePattern = regexp.MustCompile(`^(\d+)\s*(mo|m|h|d|w|y)\b`)
+
+// reactionPattern matches notifications about reactions to our posts and comments
+var reactionPattern = regexp.MustCompile(`(?i)\b(reacted to|likes? your|liked your|loves? your|celebrates? your|supports? your|finds? your)\b`)
+
+// ScanNotifications reads the notifications page and stores profile views, reactions,
+// accepted invitations and mentions. It never interacts with the page beyond scrolling.
+// Accepted invitations mark known profiles connected right away, without waiting for
+// the next connections scan.
+func (n *NotificationsWorkflow) ScanNotifications(ctx context.Context) (*NotificationScan, error) {
+	n.logger.Info("Scanning notifications...")
+
+	if err := n.browser.Navigate(ctx, n.config.LinkedIn.BaseURL+"/notifications/"); err != nil {
+		return nil, fmt.Errorf("failed to navigate to notifications: %w", err)
+	}
+	n.browser.RandomSleep(ctx, 3.0, 5.0)
+
+	if err := n.browser.HumanScroll(ctx, "down", 1500); err != nil {
+		n.logger.Warn("Failed to scroll notifications", zap.Error(err))
+	}
+	n.browser.RandomSleep(ctx, 1.5, 3.0)
+
+	cards, err := n.extractNotifications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	scan := &NotificationScan{
+		Read:   len(cards),
+		ByType: make(map[string]int),
+	}
+
+	now := time.Now()
+	for _, card := range cards {
+		eventType := classifyNotification(card.Text)
+		if eventType == "" {
+			continue
+		}
+
+		actorURL := ""
+		if card.ProfileURL != "" {
+			actorURL = n.messenger.cleanProfileURL(card.ProfileURL)
+		}
+
+		hash := sha256.Sum256([]byte(eventType + "\n" + actorURL + "\n" + card.Text))
+		notification := &core.Notification{
+			Type:        eventType,
+			ActorURL:    actorURL,
+			ActorName:   card.ActorName,
+			Text:        card.Text,
+			ContentHash: hex.EncodeToString(hash[:]),
+		}
+		if occurredAt, ok := parseNotificationAge(card.Age, now); ok {
+			notification.OccurredAt = &occurredAt
+		}
+
+		created, err := n.repository.CreateNotificationIfNotExists(ctx, notification)
+		if err != nil {
+			return scan, fmt.Errorf("failed to store notification: %w", err)
+		}
+		if !created {
+			continue
+		}
+		scan.Stored++
+		scan.ByType[eventType]++
+
+		if eventType == core.NotificationInviteAccepted && actorURL != "" {
+			if n.markAccepted(ctx, actorURL, notification.OccurredAt) {
+				scan.Connected++
+			}
+		}
+	}
+
+	history := core.NewHistory("NotificationsScan", core.HistoryDetails{Counts: map[string]int{
+		"read":      scan.Read,
+		"stored":    scan.Stored,
+		"connected": scan.Connected,
+	}})
+	if err := n.repository.CreateHistory(ctx, history); err != nil {
+		n.logger.Warn("Failed to save history", zap.Error(err))
+	}
+
+	n.logger.Info("Notifications scan complete",
+		zap.Int("read", scan.Read),
+		zap.Int("stored", scan.Stored),
+		zap.Int("connected", scan.Connected),
+	)
+
+	return scan, nil
+}
+
+// ExportNotifications writes every stored notification to a CSV file and returns the row count
+func (n *NotificationsWorkflow) ExportNotifications(ctx context.Context, outPath string) (int, error) {
+	notifications, err := n.repository.GetNotificationsByDateRange(ctx, time.Time{}, time.Now().Add(time.Minute))
+	if err != nil {
+		return 0, fmt.Errorf("failed to load notifications: %w", err)
+	}
+
+	if dir := filepath.Dir(outPath); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return 0, fmt.Errorf("failed to create export directory: %w", err)
+		}
+	}
+	file, err := os.Create(outPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write([]string{"type", "actor_name", "actor_url", "occurred_at", "scanned_at", "text"}); err != nil {
+		return 0, fmt.Errorf("failed to write export header: %w", err)
+	}
+	for _, notification := range notifications {
+		occurredAt := ""
+		if notification.OccurredAt != nil {
+			occurredAt = notification.OccurredAt.Format(time.RFC3339)
+		}
+		row := []string{
+			notification.Type,
+			notification.ActorName,
+			notification.ActorURL,
+			occurredAt,
+			notification.CreatedAt.Format(time.RFC3339),
+			notification.Text,
+		}
+		if err := writer.Write(row); err != nil {
+			return 0, fmt.Errorf("failed to write export row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return 0, fmt.Errorf("failed to write export file: %w", err)
+	}
+
+	return len(notifications), nil
+}
+
+// markAccepted marks a known profile connected from an "accepted your invitation" event.
+// It reports whether the profile's status changed.
+func (n *NotificationsWorkflow) markAccepted(ctx context.Context, profileURL string, occurredAt *time.Time) bool {
+	profile, err := n.findProfile(ctx, profileURL)
+	if err != nil {
+		n.logger.Warn("Failed to look up accepted invitation", zap.String("url", profileURL), zap.Error(err))
+		return false
+	}
+	if profile == nil || profile.Status == core.ProfileStatusConnected || !core.IsValidTransition(profile.Status, core.ProfileStatusConnected) {
+		return false
+	}
+
+	connectedAt := time.Now()
+	if occurredAt != nil {
+		connectedAt = *occurredAt
+	}
+	if err := n.repository.MarkAsConnectedAt(ctx, profile.LinkedInURL, connectedAt); err != nil {
+		n.logger.Warn("Failed to mark profile as connected", zap.String("url", profile.LinkedInURL), zap.Error(err))
+		return false
+	}
+
+	n.logger.Info("Invitation accepted (from notifications)", zap.String("url", profile.LinkedInURL))
+	return true
+}
+
+// classifyNotification returns the event type of a notification, or "" for notifications
+// that are not stored
+func classifyNotification(text string) string {
+	lower := strings.ToLower(text)
+	switch {
+	case strings.Contains(lower, "accepted your invitation"):
+		return core.NotificationInviteAccepted
+	case strings.Contains(lower, "viewed your profile"):
+		return core.NotificationProfileView
+	case strings.Contains(lower, "mentioned you"):
+		return core.NotificationMention
+	case reactionPattern.MatchString(text):
+		return core.NotificationReaction
+	}
+	return ""
+}
+
+// parseNotificationAge converts a card age such as "2h" or "3d" into an approximate time
+func parseNotificationAge(age string, now time.Time) (time.Time, bool) {
+	match := notificationAgePattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(age)))
+	if match == nil {
+		return utils.ParseRelativeTime(age, now)
+	}
+
+	value, err := strconv.Atoi(match[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	switch match[2] {
+	case "m":
+		return now.Add(-time.Duration(value) * time.Minute), true
+	case "h":
+		return now.Add(-time.Duration(value) * time.Hour), true
+	case "d":
+		return now.AddDate(0, 0, -value), true
+	case "w":
+		return now.AddDate(0, 0, -7*value), true
+	case "mo":
+		return now.AddDate(0, -value, 0), true
+	case "y":
+		return now.AddDate(-value, 0, 0), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,209 @@
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// PostLikerWorkflow likes a profile's recent post as a warm-up before connecting
+type PostLikerWorkflow struct {
+	browser    core.BrowserPort
+	repository core.RepositoryPort
+	config     *core.Config
+	logger     *zap.Logger
+}
+
+// NewPostLikerWorkflow creates a new post liker workflow
+func NewPostLikerWorkflow(browser core.BrowserPort, repo core.RepositoryPort, config *core.Config, logger *zap.Logger) *PostLikerWorkflow {
+	return &PostLikerWorkflow{
+		browser:    browser,
+		repository: repo,
+		config:     config,
+		logger:     logger,
+	}
+}
+
+// postTypeScanLimit is how many of a profile's recent posts are checked for a preferred type
+const postTypeScanLimit = 3
+
+// postTypeMarkers map the class names of a post's content block to its type. Checked in
+// order, since e.g. an article share can also carry an image.
+var postTypeMarkers = []struct {
+	postType string
+	markers  []string
+}{
+	{core.PostTypeEvent, []string{"feed-shared-event", "update-components-event"}},
+	{core.PostTypeVideo, []string{"feed-shared-linkedin-video", "feed-shared-video", "update-components-linkedin-video"}},
+	{core.PostTypeArticle, []string{"feed-shared-article", "update-components-article"}},
+	{core.PostTypeImage, []string{"feed-shared-image", "update-components-image"}},
+}
+
+// activityPost is a post card read from a profile's activity page
+type activityPost struct {
+	URN  string `json:"urn"`
+	HTML string `json:"html"`
+}
+
+// LikeRecentPost likes the most recent post in the profile's Activity section, or with
+// engagement.preferred_post_types the most recent of the first 3 posts with a preferred
+// type. When none has one, the most recent post is liked unless
+// engagement.require_preferred_type is set. It returns true only if a new like was
+// placed; profiles without posts and posts that were already liked return false
+// without error.
+func (p *PostLikerWorkflow) LikeRecentPost(ctx context.Context, profileURL string) (bool, error) {
+	if profileURL == "" {
+		return false, fmt.Errorf("profile URL is required")
+	}
+
+	maxLikes := p.config.Engagement.MaxLikesPerDay
+	if maxLikes <= 0 {
+		maxLikes = 10 // Default fallback
+	}
+
+	canLike, err := p.repository.CanPerformAction(ctx, "Like", maxLikes)
+	if err != nil {
+		p.logger.Warn("Failed to check like rate limit", zap.Error(err))
+	} else if !canLike {
+		p.logger.Info("Daily like limit reached, skipping", zap.Int("limit", maxLikes))
+		return false, nil
+	}
+
+	activityURL := strings.TrimRight(profileURL, "/") + "/recent-activity/all/"
+	p.logger.Info("Opening profile activity", zap.String("url", activityURL))
+
+	if err := p.browser.Navigate(ctx, activityURL); err != nil {
+		return false, fmt.Errorf("failed to navigate to profile activity: %w", err)
+	}
+	p.browser.RandomSleep(ctx, 2.0, 4.0)
+
+	// The first Like button on the activity page belongs to the most recent post
+	likeSelector := "button[aria-label*='React Like']"
+	if err := p.browser.WaitForElement(ctx, likeSelector, elementTimeout(&p.config.Selectors)); err != nil {
+		p.logger.Info("No public posts found", zap.String("url", profileURL))
+		return false, nil
+	}
+
+	post, postType, err := p.choosePost(ctx)
+	if err != nil {
+		p.logger.Warn("Failed to read post types, liking the most recent post", zap.Error(err))
+	} else if post == nil {
+		p.logger.Info("No recent post of a preferred type, skipping like",
+			zap.String("url", profileURL), zap.Strings("preferred", p.config.Engagement.PreferredPostTypes))
+		return false, nil
+	} else if post.URN != "" {
+		likeSelector = fmt.Sprintf("div[data-urn='%s'] button[aria-label*='React Like']", post.URN)
+	}
+
+	pressed, err := p.browser.GetAttribute(ctx, likeSelector, "aria-pressed")
+	if err == nil && pressed == "true" {
+		p.logger.Info("Most recent post already liked", zap.String("url", profileURL))
+		return false, nil
+	}
+
+	if err := p.browser.HumanClick(ctx, likeSelector); err != nil {
+		return false, fmt.Errorf("failed to click like button: %w", err)
+	}
+
+	// Let the reaction animation finish before moving on
+	p.browser.RandomSleep(ctx, 1.5, 2.5)
+
+	pressed, err = p.browser.GetAttribute(ctx, likeSelector, "aria-pressed")
+	if err != nil || pressed != "true" {
+		p.logger.Warn("Like was not registered", zap.String("url", profileURL))
+		return false, nil
+	}
+
+	details := core.HistoryDetails{ProfileURL: profileURL, PostType: postType}
+	if post != nil {
+		details.Target = post.URN
+	}
+	history := core.NewHistory("Like", details)
+	if err := p.repository.CreateHistory(ctx, history); err != nil {
+		p.logger.Warn("Failed to save history", zap.Error(err))
+	}
+
+	p.logger.Info("Liked post", zap.String("url", profileURL), zap.String("post_type", postType))
+	return true, nil
+}
+
+// choosePost picks the post to like among the first postTypeScanLimit posts and returns
+// it with its type. Without preferred types, or when none matches and a preferred type
+// isn't required, that is the most recent post. It returns nil when nothing may be liked.
+func (p *PostLikerWorkflow) choosePost(ctx context.Context) (*activityPost, string, error) {
+	posts, err := p.extractActivityPosts(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(posts) == 0 {
+		// Cards without a data-urn: fall back to the first Like button
+		return &activityPost{}, "", nil
+	}
+	if len(posts) > postTypeScanLimit {
+		posts = posts[:postTypeScanLimit]
+	}
+
+	preferred := p.config.Engagement.PreferredPostTypes
+	if len(preferred) == 0 {
+		return &posts[0], detectPostType(posts[0].HTML), nil
+	}
+
+	for i := range posts {
+		postType := detectPostType(posts[i].HTML)
+		for _, want := range preferred {
+			if postType == want {
+				return &posts[i], postType, nil
+			}
+		}
+	}
+
+	if p.config.Engagement.RequirePreferredType {
+		return nil, "", nil
+	}
+	return &posts[0], detectPostType(posts[0].HTML), nil
+}
+
+// extractActivityPosts reads the post cards of the activity page, most recent first
+func (p *PostLikerWorkflow) extractActivityPosts(ctx context.Context) ([]activityPost, error) {
+	res, err := p.browser.ExecuteScript(ctx, `() => {
+const result = [];
+for (const post of document.querySelectorAll("div[data-urn^='urn:li:activity']")) {
+result.push({urn: post.getAttribute("data-urn") || "", html: post.outerHTML});
+if (result.length >= `+fmt.Sprint(postTypeScanLimit)+`) break;
+}
+return result;
+}`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract activity posts: %w", err)
+	}
+
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read activity posts: %w", err)
+	}
+
+	var posts []activityPost
+	if err := json.Unmarshal(raw, &posts); err != nil {
+		return nil, fmt.Errorf("failed to parse activity posts: %w", err)
+	}
+
+	return posts, nil
+}
+
+// detectPostType returns the type of a post from its HTML: the first postTypeMarkers
+// entry whose class name it contains, otherwise text
+func detectPostType(html string) string {
+	for _, entry := range postTypeMarkers {
+		for _, marker := range entry.markers {
+			if strings.Contains(html, marker) {
+				return entry.postType
+			}
+		}
+	}
+	return core.PostTypeText
+}
@@ -0,0 +1,161 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// BrowserFactory opens a new, authenticated browser for a prefetch worker
+type BrowserFactory func(ctx context.Context) (core.BrowserPort, error)
+
+// ProfilePrefetcher loads profile metadata for a batch of URLs in parallel browsers, so
+// later workflows can decide on a profile without visiting it first
+type ProfilePrefetcher struct {
+	newBrowser BrowserFactory
+	config     *core.Config
+	logger     *zap.Logger
+	profiles   sync.Map // profile URL -> *core.Profile
+}
+
+// NewProfilePrefetcher creates a new prefetcher. Each worker gets its own browser from newBrowser.
+func NewProfilePrefetcher(newBrowser BrowserFactory, config *core.Config, logger *zap.Logger) *ProfilePrefetcher {
+	return &ProfilePrefetcher{
+		newBrowser: newBrowser,
+		config:     config,
+		logger:     logger,
+	}
+}
+
+// Prefetch fetches every URL with up to prefetch.concurrency browsers at a time and
+// returns when all are done or ctx is cancelled. Profiles that fail to load are logged
+// and left out of the cache; an error is returned only if no browser could be opened.
+func (p *ProfilePrefetcher) Prefetch(ctx context.Context, urls []string) error {
+	concurrency := p.config.Prefetch.Concurrency
+	if concurrency <= 0 {
+		concurrency = 3 // Default fallback
+	}
+	if concurrency > len(urls) {
+		concurrency = len(urls)
+	}
+	if concurrency == 0 {
+		return nil
+	}
+
+	p.logger.Info("Prefetching profiles", zap.Int("profiles", len(urls)), zap.Int("concurrency", concurrency))
+
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	started := 0
+	var lastErr error
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+
+			browser, err := p.newBrowser(ctx)
+			if err != nil {
+				p.logger.Warn("Failed to open prefetch browser", zap.Int("worker", worker), zap.Error(err))
+				mu.Lock()
+				lastErr = err
+				mu.Unlock()
+				return
+			}
+			defer func() {
+				if err := browser.Close(ctx); err != nil {
+					p.logger.Warn("Failed to close prefetch browser", zap.Int("worker", worker), zap.Error(err))
+				}
+			}()
+
+			mu.Lock()
+			started++
+			mu.Unlock()
+
+			extractor := NewProfileExtractor(browser, p.logger)
+			for url := range jobs {
+				p.fetch(ctx, browser, extractor, url)
+			}
+		}(i)
+	}
+
+	// Hand out URLs until all are taken, the run is cancelled or every worker has
+	// stopped (e.g. none could open a browser)
+	done := make(chan struct{})
+	go func() {
+		defer close(jobs)
+		for _, url := range urls {
+			select {
+			case jobs <- url:
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+	close(done)
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if started == 0 && lastErr != nil {
+		return fmt.Errorf("no prefetch browser could be opened: %w", lastErr)
+	}
+
+	p.logger.Info("Prefetch completed", zap.Int("profiles", p.Len()))
+	return nil
+}
+
+// fetch loads one profile and stores what was extracted
+func (p *ProfilePrefetcher) fetch(ctx context.Context, browser core.BrowserPort, extractor *ProfileExtractor, url string) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	if err := browser.Navigate(ctx, url); err != nil {
+		p.logger.Warn("Failed to prefetch profile", zap.String("url", url), zap.Error(err))
+		return
+	}
+	browser.RandomSleep(ctx, 1.5, 3.0)
+
+	profile, err := extractor.Extract(ctx)
+	if err != nil {
+		p.logger.Warn("Failed to extract prefetched profile", zap.String("url", url), zap.Error(err))
+		return
+	}
+	profile.LinkedInURL = url
+
+	p.profiles.Store(url, profile)
+	p.logger.Debug("Prefetched profile",
+		zap.String("url", url),
+		zap.String("name", profile.Name),
+		zap.String("degree", profile.ConnectionDegree),
+	)
+}
+
+// Get returns the prefetched profile for url, if any
+func (p *ProfilePrefetcher) Get(url string) (*core.Profile, bool) {
+	value, ok := p.profiles.Load(url)
+	if !ok {
+		return nil, false
+	}
+	return value.(*core.Profile), true
+}
+
+// Len returns the number of prefetched profiles
+func (p *ProfilePrefetcher) Len() int {
+	count := 0
+	p.profiles.Range(func(_, _ any) bool {
+		count++
+		return true
+	})
+	return count
+}
@@ -0,0 +1,212 @@
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"linkedin-automation/internal/core"
+	"linkedin-automation/pkg/linkedin"
+	"linkedin-automation/pkg/utils"
+
+	"go.uber.org/zap"
+)
+
+// ProfileExtractor reads structured data from the currently open profile page
+type ProfileExtractor struct {
+	browser core.BrowserPort
+	logger  *zap.Logger
+}
+
+// NewProfileExtractor creates a new profile extractor
+func NewProfileExtractor(browser core.BrowserPort, logger *zap.Logger) *ProfileExtractor {
+	return &ProfileExtractor{
+		browser: browser,
+		logger:  logger,
+	}
+}
+
+// Extract reads the name, headline and connection degree from the profile top card
+func (p *ProfileExtractor) Extract(ctx context.Context) (*core.Profile, error) {
+	res, err := p.browser.ExecuteScript(ctx, `() => {
+const text = (selectors) => {
+for (const sel of selectors) {
+const el = document.querySelector(sel);
+if (el && el.innerText.trim()) return el.innerText.trim();
+}
+return "";
+};
+const degree = text([".pv-top-card .dist-value", ".distance-badge .dist-value", "span.dist-value"]);
+return {
+name: text(["h1.text-heading-xlarge", "h1[data-anonymize='person-name']", ".pv-text-details__left-panel h1", "h1.inline"]),
+headline: text([".pv-text-details__left-panel .text-body-medium", "div.text-body-medium.break-words"]),
+degree: (degree.match(/\d+(st|nd|rd|th)/) || [""])[0]
+};
+}`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract profile: %w", err)
+	}
+
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile: %w", err)
+	}
+
+	var card struct {
+		Name     string `json:"name"`
+		Headline string `json:"headline"`
+		Degree   string `json:"degree"`
+	}
+	if err := json.Unmarshal(raw, &card); err != nil {
+		return nil, fmt.Errorf("failed to parse profile: %w", err)
+	}
+	if card.Name == "" {
+		return nil, fmt.Errorf("profile name not found")
+	}
+
+	url, err := p.browser.GetCurrentURL(ctx)
+	if err != nil {
+		p.logger.Debug("Failed to read profile URL", zap.Error(err))
+	}
+
+	return &core.Profile{
+		LinkedInURL:      url,
+		Name:             card.Name,
+		Headline:         card.Headline,
+		ConnectionDegree: card.Degree,
+	}, nil
+}
+
+// ExtractLastActive reads the "Active X ago" indicator from the profile page.
+// It returns nil (without error) if the profile does not show an activity indicator.
+func (p *ProfileExtractor) ExtractLastActive(ctx context.Context) (*time.Time, error) {
+	selectors := []string{
+		".pv-member-badge .a11y-text",
+		".pv-member-badge",
+		".presence-entity__indicator + .visually-hidden",
+		".presence-indicator .a11y-text",
+	}
+
+	for _, selector := range selectors {
+		exists, err := p.browser.ElementExists(ctx, selector)
+		if err != nil || !exists {
+			continue
+		}
+
+		text, err := p.browser.GetText(ctx, selector)
+		if err != nil || text == "" {
+			continue
+		}
+
+		lastActive, ok := parseLastActive(text, time.Now())
+		if ok {
+			return lastActive, nil
+		}
+		p.logger.Debug("Unrecognized activity indicator", zap.String("text", text))
+	}
+
+	return nil, nil
+}
+
+// IsOpenToWork reports whether the profile shows the "Open to Work" banner or
+// the green frame around the profile photo
+func (p *ProfileExtractor) IsOpenToWork(ctx context.Context) (bool, error) {
+	selectors := []string{
+		".pv-open-to-work-banner",
+		".pv-top-card-profile-picture__image--open-to-work",
+		"img.pv-top-card-profile-picture__image[alt*='#OPEN_TO_WORK']",
+		".pv-top-card__photo-wrapper img[alt*='#OPEN_TO_WORK']",
+	}
+
+	var lastErr error
+	for _, selector := range selectors {
+		exists, err := p.browser.ElementExists(ctx, selector)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if exists {
+			return true, nil
+		}
+	}
+
+	if lastErr != nil {
+		return false, fmt.Errorf("failed to check open to work indicator: %w", lastErr)
+	}
+
+	return false, nil
+}
+
+// ExtractSkills reads the skill names listed on the profile page. It returns an
+// empty slice (without error) if the profile lists no skills.
+func (p *ProfileExtractor) ExtractSkills(ctx context.Context) ([]string, error) {
+	res, err := p.browser.ExecuteScript(ctx, `() => {
+const names = Array.from(document.querySelectorAll(".pv-skill-category-entity__name"))
+.map((el) => el.innerText.trim());
+if (names.length === 0) {
+const anchor = document.getElementById("skills");
+const section = anchor ? anchor.closest("section") : null;
+if (section) {
+for (const item of section.querySelectorAll("li.artdeco-list__item")) {
+const label = item.querySelector("span[aria-hidden='true']");
+if (label) names.push(label.innerText.trim());
+}
+}
+}
+return names.filter((n) => n);
+}`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract skills: %w", err)
+	}
+
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read skills: %w", err)
+	}
+
+	skills := make([]string, 0)
+	if err := json.Unmarshal(raw, &skills); err != nil {
+		return nil, fmt.Errorf("failed to parse skills: %w", err)
+	}
+
+	return skills, nil
+}
+
+// hasAnySkill reports whether skills contains at least one of required (case-insensitive)
+func hasAnySkill(skills, required []string) bool {
+	for _, want := range linkedin.NormalizeSkills(required) {
+		for _, have := range skills {
+			if strings.EqualFold(strings.TrimSpace(have), want) || strings.EqualFold(linkedin.NormalizeSkill(have), want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseLastActive converts LinkedIn's relative activity text into an absolute time
+func parseLastActive(text string, now time.Time) (*time.Time, bool) {
+	lower := strings.ToLower(strings.TrimSpace(text))
+
+	switch {
+	case strings.Contains(lower, "active this week"):
+		t := now.AddDate(0, 0, -7)
+		return &t, true
+	case strings.Contains(lower, "active this month"):
+		t := now.AddDate(0, -1, 0)
+		return &t, true
+	}
+
+	if !strings.Contains(lower, "active") {
+		return nil, false
+	}
+
+	t, ok := utils.ParseRelativeTime(lower, now)
+	if !ok {
+		return nil, false
+	}
+
+	return &t, true
+}
@@ -0,0 +1,204 @@
+package workflows
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"linkedin-automation/internal/core"
+	"linkedin-automation/pkg/utils"
+
+	"go.uber.org/zap"
+)
+
+// threadMessage is a message bubble read from an open conversation thread
+type threadMessage struct {
+	Sender   string `json:"sender"`
+	Heading  string `json:"heading"` // Date heading the bubble falls under ("Today", "Jan 5")
+	Clock    string `json:"clock"`   // Time shown on the message group ("10:32 AM")
+	Text     string `json:"text"`
+	Incoming bool   `json:"incoming"`
+}
+
+// ScanReplies opens the conversation of every profile we messaged and stores the other
+// party's messages. Profiles with at least one new incoming message are marked Replied.
+func (m *MessagingWorkflow) ScanReplies(ctx context.Context) error {
+	m.logger.Info("Scanning conversations for replies...")
+
+	limit := m.config.Messaging.ReplyScanLimit
+	if limit <= 0 {
+		limit = 20 // Default fallback
+	}
+
+	profiles, err := m.repository.GetProfilesByStatusPage(ctx, core.ProfileStatusMessageSent, 0, limit, core.ProfileOrderCreatedAsc)
+	if err != nil {
+		return fmt.Errorf("failed to load messaged profiles: %w", err)
+	}
+
+	repliedCount := 0
+	storedCount := 0
+
+	for _, profile := range profiles {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if err := m.browser.Navigate(ctx, profile.LinkedInURL); err != nil {
+			m.logger.Error("Failed to navigate to profile", zap.String("url", profile.LinkedInURL), zap.Error(err))
+			continue
+		}
+		m.browser.RandomSleep(ctx, 2.0, 4.0)
+
+		if err := m.clickMessageButton(ctx); err != nil {
+			m.logger.Warn("Failed to open conversation", zap.String("url", profile.LinkedInURL), zap.Error(err))
+			continue
+		}
+
+		if err := m.browser.WaitForElement(ctx, ".msg-s-message-list", elementTimeout(&m.config.Selectors)); err != nil {
+			m.logger.Warn("Conversation thread not found", zap.String("url", profile.LinkedInURL), zap.Error(err))
+			continue
+		}
+		m.browser.RandomSleep(ctx, 1.0, 2.0)
+
+		stored, err := m.captureIncomingMessages(ctx, profile)
+		if err != nil {
+			m.logger.Warn("Failed to capture thread messages", zap.String("url", profile.LinkedInURL), zap.Error(err))
+			continue
+		}
+		if stored == 0 {
+			continue
+		}
+
+		storedCount += stored
+		repliedCount++
+		m.logger.Info("Reply detected", zap.String("url", profile.LinkedInURL), zap.Int("new_messages", stored))
+
+		if err := m.repository.UpdateProfileStatus(ctx, profile.LinkedInURL, core.ProfileStatusReplied); err != nil {
+			m.logger.Error("Failed to mark profile as replied", zap.Error(err))
+		}
+
+		history := core.NewHistory("Reply", core.HistoryDetails{ProfileURL: profile.LinkedInURL})
+		if err := m.repository.CreateHistory(ctx, history); err != nil {
+			m.logger.Warn("Failed to save history", zap.Error(err))
+		}
+	}
+
+	m.logger.Info("Reply scan complete",
+		zap.Int("conversations", len(profiles)),
+		zap.Int("replied", repliedCount),
+		zap.Int("messages_stored", storedCount),
+	)
+
+	return nil
+}
+
+// captureIncomingMessages reads the open thread and stores the other party's messages
+// for a known profile, skipping messages already stored by an earlier scan. It returns
+// the number of new messages stored.
+func (m *MessagingWorkflow) captureIncomingMessages(ctx context.Context, profile *core.Profile) (int, error) {
+	// Threads are only extracted for profiles we track
+	if profile == nil || profile.ID == 0 {
+		return 0, fmt.Errorf("profile is not in the database")
+	}
+
+	messages, err := m.extractThreadMessages(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	maxMessages := m.config.Messaging.ThreadMaxMessages
+	if maxMessages <= 0 {
+		maxMessages = 20 // Default fallback
+	}
+	if len(messages) > maxMessages {
+		messages = messages[len(messages)-maxMessages:]
+	}
+
+	now := time.Now()
+	stored := 0
+	for _, msg := range messages {
+		text := strings.TrimSpace(msg.Text)
+		if !msg.Incoming || text == "" {
+			continue
+		}
+
+		sentAt, ok := utils.ParseThreadTimestamp(msg.Heading, msg.Clock, now)
+		if !ok {
+			m.logger.Debug("Unrecognized thread timestamp",
+				zap.String("heading", msg.Heading),
+				zap.String("clock", msg.Clock),
+			)
+			continue
+		}
+
+		hash := sha256.Sum256([]byte(text))
+		created, err := m.repository.CreateMessageIfNotExists(ctx, &core.Message{
+			ProfileID:   profile.ID,
+			Direction:   core.MessageDirectionIn,
+			Sender:      msg.Sender,
+			Body:        text,
+			ContentHash: hex.EncodeToString(hash[:]),
+			SentAt:      sentAt,
+			Verified:    true,
+		})
+		if err != nil {
+			return stored, fmt.Errorf("failed to store message: %w", err)
+		}
+		if created {
+			stored++
+		}
+	}
+
+	return stored, nil
+}
+
+// extractThreadMessages reads every message bubble of the open thread in a single
+// script call, carrying the date heading and sender down to grouped messages
+func (m *MessagingWorkflow) extractThreadMessages(ctx context.Context) ([]threadMessage, error) {
+	res, err := m.browser.ExecuteScript(ctx, `() => {
+const events = document.querySelectorAll(".msg-s-message-list__event");
+const result = [];
+let heading = "";
+let sender = "";
+let clock = "";
+let incoming = false;
+for (const ev of events) {
+const h = ev.querySelector(".msg-s-message-list__time-heading");
+if (h) heading = h.innerText.trim();
+const group = ev.querySelector(".msg-s-message-group__meta");
+if (group) {
+const name = group.querySelector(".msg-s-message-group__name");
+const time = group.querySelector(".msg-s-message-group__timestamp");
+sender = name ? name.innerText.trim() : "";
+clock = time ? time.innerText.trim() : "";
+}
+const item = ev.querySelector(".msg-s-event-listitem");
+if (item) incoming = item.classList.contains("msg-s-event-listitem--other");
+for (const body of ev.querySelectorAll(".msg-s-event-listitem__body")) {
+result.push({sender: sender, heading: heading, clock: clock, text: body.innerText, incoming: incoming});
+}
+}
+return result;
+}`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract thread messages: %w", err)
+	}
+
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read thread messages: %w", err)
+	}
+
+	var messages []threadMessage
+	if err := json.Unmarshal(raw, &messages); err != nil {
+		return nil, fmt.Errorf("failed to parse thread messages: %w", err)
+	}
+
+	return messages, nil
+}
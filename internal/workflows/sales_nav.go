@@ -0,0 +1,118 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// salesNavBarSelectors match the Sales Navigator top navigation, shown only to signed-in subscribers
+var salesNavBarSelectors = []string{
+	"nav[aria-label*='Sales Navigator']",
+	"header.global-nav",
+	"a[href*='/sales/home']",
+}
+
+// SalesNavWorkflow signs in to Sales Navigator, which needs its own SSO step after the
+// LinkedIn login, and keeps its cookies apart from the LinkedIn session
+type SalesNavWorkflow struct {
+	browser core.BrowserPort
+	config  *core.Config
+	logger  *zap.Logger
+}
+
+// NewSalesNavWorkflow creates a new Sales Navigator workflow
+func NewSalesNavWorkflow(browser core.BrowserPort, config *core.Config, logger *zap.Logger) *SalesNavWorkflow {
+	return &SalesNavWorkflow{
+		browser: browser,
+		config:  config,
+		logger:  logger,
+	}
+}
+
+// Authenticate runs the Sales Navigator SSO login with the current LinkedIn session and
+// saves the resulting cookies to sales_navigator.cookies_path. It fails when the login
+// does not end on the Sales Navigator home page, e.g. for accounts without a subscription.
+func (s *SalesNavWorkflow) Authenticate(ctx context.Context) error {
+	s.logger.Info("Signing in to Sales Navigator")
+
+	if err := s.browser.Navigate(ctx, s.config.LinkedIn.BaseURL+"/sales/sso/login"); err != nil {
+		return fmt.Errorf("failed to navigate to Sales Navigator login: %w", err)
+	}
+	s.browser.RandomSleep(ctx, 3.0, 5.0)
+
+	currentURL, err := s.browser.GetCurrentURL(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current URL: %w", err)
+	}
+	if !strings.Contains(currentURL, "/sales/home") {
+		return fmt.Errorf("Sales Navigator login ended on %s instead of /sales/home (is the account subscribed?)", currentURL)
+	}
+
+	if err := s.browser.SaveCookies(ctx, s.cookiesPath()); err != nil {
+		s.logger.Warn("Failed to save Sales Navigator cookies", zap.Error(err))
+	}
+
+	s.logger.Info("Sales Navigator authentication successful")
+	return nil
+}
+
+// IsAuthenticated opens the Sales Navigator home page and checks for its navigation bar
+func (s *SalesNavWorkflow) IsAuthenticated(ctx context.Context) (bool, error) {
+	if err := s.browser.Navigate(ctx, s.config.LinkedIn.BaseURL+"/sales/home"); err != nil {
+		return false, fmt.Errorf("failed to navigate to Sales Navigator home: %w", err)
+	}
+	s.browser.RandomSleep(ctx, 2.0, 4.0)
+
+	// Signed-out sessions are redirected to the login or upsell page
+	currentURL, err := s.browser.GetCurrentURL(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get current URL: %w", err)
+	}
+	if !strings.Contains(currentURL, "/sales/") || strings.Contains(currentURL, "/sales/login") {
+		return false, nil
+	}
+
+	for _, selector := range salesNavBarSelectors {
+		if exists, _ := s.browser.ElementExists(ctx, selector); exists {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// LoadCookies loads the saved Sales Navigator cookies into the browser. A missing cookie
+// file is not an error.
+func (s *SalesNavWorkflow) LoadCookies(ctx context.Context) error {
+	return s.browser.LoadCookies(ctx, s.cookiesPath())
+}
+
+// EnsureAuthenticated reuses the saved Sales Navigator session when it is still valid and
+// signs in again otherwise
+func (s *SalesNavWorkflow) EnsureAuthenticated(ctx context.Context) error {
+	if err := s.LoadCookies(ctx); err != nil {
+		s.logger.Warn("Failed to load Sales Navigator cookies, will sign in again", zap.Error(err))
+	}
+
+	isAuth, err := s.IsAuthenticated(ctx)
+	if err != nil {
+		return err
+	}
+	if isAuth {
+		s.logger.Info("Already signed in to Sales Navigator, using existing session")
+		return nil
+	}
+
+	return s.Authenticate(ctx)
+}
+
+func (s *SalesNavWorkflow) cookiesPath() string {
+	if path := s.config.SalesNavigator.CookiesPath; path != "" {
+		return path
+	}
+	return "data/sales_nav_cookies.json" // Default fallback
+}
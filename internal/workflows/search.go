@@ -2,14 +2,17 @@ package workflows
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"linkedin-automation/internal/core"
+	"linkedin-automation/pkg/linkedin"
 
 	"go.uber.org/zap"
 )
@@ -20,6 +23,10 @@ type SearchWorkflow struct {
 	repository core.RepositoryPort
 	config     *core.Config
 	logger     *zap.Logger
+	extractor  *ProfileExtractor
+	metrics    *core.AppMetrics
+	salesNav   *SalesNavWorkflow
+	notifier   core.NotifierPort
 }
 
 // NewSearchWorkflow creates a new search workflow
@@ -29,6 +36,8 @@ func NewSearchWorkflow(browser core.BrowserPort, repo core.RepositoryPort, confi
 		repository: repo,
 		config:     config,
 		logger:     logger,
+		extractor:  NewProfileExtractor(browser, logger),
+		salesNav:   NewSalesNavWorkflow(browser, config, logger),
 	}
 }
 
@@ -42,11 +51,23 @@ func (s *SearchWorkflow) Search(ctx context.Context, params *core.SearchParams)
 		return nil, fmt.Errorf("search keyword is required")
 	}
 
+	// Profiles are not discovered for paused or archived campaigns
+	if _, err := ResolveCampaign(ctx, s.repository, params.Campaign); err != nil {
+		return nil, err
+	}
+
 	s.logger.Info("Starting LinkedIn search",
 		zap.String("keyword", params.Keyword),
 		zap.Int("max_results", params.MaxResults),
 	)
 
+	// Search with the Sales Navigator session saved by AuthWorkflow
+	if s.config.Search.Mode == core.SearchModeSalesNavigator {
+		if err := s.salesNav.LoadCookies(ctx); err != nil {
+			return nil, fmt.Errorf("failed to load Sales Navigator cookies: %w", err)
+		}
+	}
+
 	// Build search URL
 	searchURL := s.buildSearchURL(params)
 
@@ -63,6 +84,15 @@ func (s *SearchWorkflow) Search(ctx context.Context, params *core.SearchParams)
 	allProfileURLs := make([]string, 0)
 	page := 1
 
+	// Recorded as a SearchQuery once the search is done
+	pagesCrawled := 0
+	resultsFound := 0
+	newProfileIDs := make([]uint, 0)
+
+	// Inactive profiles are dropped page by page, so -max counts active profiles
+	filterInactive := s.config.Targeting.MaxProfileInactiveDays > 0
+	activity := &activityFilterStats{}
+
 	for len(allProfileURLs) < params.MaxResults {
 		// Wait for search results to load
 		s.browser.RandomSleep(ctx, 2.0, 4.0)
@@ -86,45 +116,68 @@ func (s *SearchWorkflow) Search(ctx context.Context, params *core.SearchParams)
 			}
 			break // Stop if we can't extract anymore
 		}
+		pagesCrawled++
+		resultsFound += len(profileURLs)
 
-		// Add new unique URLs
+		// Save the page as Discovered in one insert; profiles already in the DB are skipped
+		pageProfiles := make([]*core.Profile, 0, len(profileURLs))
 		for _, url := range profileURLs {
-			// Check DB for duplicate
-			existingProfile, err := s.repository.GetProfileByURL(ctx, url)
-			if err == nil && existingProfile != nil {
-				s.logger.Debug("Skipping duplicate profile (already in DB)", zap.String("url", url))
+			pageProfiles = append(pageProfiles, &core.Profile{
+				LinkedInURL:  url,
+				Status:       core.ProfileStatusDiscovered,
+				Campaign:     params.Campaign,
+				Source:       core.ProfileSourceSearch,
+				SourceDetail: params.Keyword,
+			})
+		}
+		created, skipped, err := s.repository.BulkCreateProfiles(ctx, pageProfiles)
+		if err != nil {
+			s.logger.Warn("Failed to save profiles to DB", zap.Int("page", page), zap.Error(err))
+			// Continue anyway, maybe we can still process them in this session
+		} else {
+			s.logger.Debug("Saved new profiles to DB", zap.Int("created", created), zap.Int("skipped", skipped))
+			for _, newProfile := range pageProfiles {
+				if newProfile.ID != 0 {
+					newProfileIDs = append(newProfileIDs, newProfile.ID)
+				}
+			}
+		}
+
+		// Add new unique URLs
+		pageURLs := make([]string, 0, len(pageProfiles))
+		for _, newProfile := range pageProfiles {
+			if err == nil && newProfile.ID == 0 {
+				s.logger.Debug("Skipping duplicate profile (already in DB)", zap.String("url", newProfile.LinkedInURL))
 				continue
 			}
 
-			// Create new profile in DB
-			newProfile := &core.Profile{
-				LinkedInURL: url,
-				Status:      core.ProfileStatusDiscovered,
-				CreatedAt:   time.Now(),
-				UpdatedAt:   time.Now(),
+			if matchBlacklist(ctx, s.repository, s.logger, newProfile) {
+				continue
 			}
-			if err := s.repository.CreateProfile(ctx, newProfile); err != nil {
-				s.logger.Warn("Failed to save profile to DB", zap.String("url", url), zap.Error(err))
-				// Continue anyway, maybe we can still process it in this session
-			} else {
-				s.logger.Debug("Saved new profile to DB", zap.String("url", url))
+
+			url := newProfile.LinkedInURL
+			if !slices.Contains(allProfileURLs, url) && !slices.Contains(pageURLs, url) {
+				pageURLs = append(pageURLs, url)
 			}
+		}
 
-			isDuplicate := false
-			for _, existing := range allProfileURLs {
-				if existing == url {
-					isDuplicate = true
-					break
-				}
+		// Drop profiles that haven't been active recently, then return to the results page
+		if filterInactive && len(pageURLs) > 0 {
+			resultsURL, err := s.browser.GetCurrentURL(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read search results URL: %w", err)
 			}
-			if !isDuplicate {
-				allProfileURLs = append(allProfileURLs, url)
+			pageURLs = s.filterInactiveProfiles(ctx, pageURLs, activity)
+			if err := s.browser.Navigate(ctx, resultsURL); err != nil {
+				return nil, fmt.Errorf("failed to return to search results: %w", err)
 			}
+			s.browser.RandomSleep(ctx, 2.0, 4.0)
 		}
+		allProfileURLs = append(allProfileURLs, pageURLs...)
 
-		s.logger.Info("Extracted profiles", 
-			zap.Int("page", page), 
-			zap.Int("new_profiles", len(profileURLs)), 
+		s.logger.Info("Extracted profiles",
+			zap.Int("page", page),
+			zap.Int("new_profiles", len(profileURLs)),
 			zap.Int("total_profiles", len(allProfileURLs)),
 		)
 
@@ -136,7 +189,7 @@ func (s *SearchWorkflow) Search(ctx context.Context, params *core.SearchParams)
 		// Go to next page
 		page++
 		nextPageButton := fmt.Sprintf("button[aria-label='Page %d']", page)
-		
+
 		// Check if next page button exists
 		exists, err := s.browser.ElementExists(ctx, nextPageButton)
 		if err != nil || !exists {
@@ -157,13 +210,121 @@ func (s *SearchWorkflow) Search(ctx context.Context, params *core.SearchParams)
 		allProfileURLs = allProfileURLs[:params.MaxResults]
 	}
 
+	if filterInactive {
+		s.recordActivityFilter(ctx, activity)
+	}
+
 	s.logger.Info("Search completed",
 		zap.Int("profiles_found", len(allProfileURLs)),
 	)
 
+	// Record the keyword so reports can rank keywords by results
+	history := core.NewSearchHistory(params.Keyword, len(allProfileURLs))
+	if err := s.repository.CreateHistory(ctx, history); err != nil {
+		s.logger.Warn("Failed to save history", zap.Error(err))
+	}
+
+	// Record the query's yield so stats can follow its profiles to acceptance
+	query, err := core.NewSearchQuery(params)
+	if err == nil {
+		query.PagesCrawled = pagesCrawled
+		query.ResultsFound = resultsFound
+		query.NewProfiles = len(newProfileIDs)
+		err = s.repository.CreateSearchQuery(ctx, query, newProfileIDs)
+	}
+	if err != nil {
+		s.logger.Warn("Failed to save search query", zap.Error(err))
+	}
+
 	return allProfileURLs, nil
 }
 
+// activityFilterStats adds up the activity filter's results over a search
+type activityFilterStats struct {
+	Checked  int
+	Filtered int
+	Unknown  int // No activity indicator on the profile
+}
+
+// filterInactiveProfiles visits each profile briefly to read its last activity and drops
+// profiles inactive longer than Targeting.MaxProfileInactiveDays. Profiles without an
+// activity indicator are kept, since LinkedIn only shows it for some members.
+func (s *SearchWorkflow) filterInactiveProfiles(ctx context.Context, profileURLs []string, stats *activityFilterStats) []string {
+	maxDays := s.config.Targeting.MaxProfileInactiveDays
+	cutoff := time.Now().AddDate(0, 0, -maxDays)
+
+	kept := make([]string, 0, len(profileURLs))
+	unknownCount := 0
+	defer func() {
+		stats.Checked += len(profileURLs)
+		stats.Filtered += len(profileURLs) - len(kept)
+		stats.Unknown += unknownCount
+	}()
+
+	for _, profileURL := range profileURLs {
+		select {
+		case <-ctx.Done():
+			return kept
+		default:
+		}
+
+		if err := s.browser.Navigate(ctx, profileURL); err != nil {
+			s.logger.Warn("Failed to open profile for activity check", zap.String("url", profileURL), zap.Error(err))
+			kept = append(kept, profileURL)
+			continue
+		}
+		s.browser.RandomSleep(ctx, 1.5, 3.0)
+
+		lastActive, err := s.extractor.ExtractLastActive(ctx)
+		if err != nil || lastActive == nil {
+			unknownCount++
+			kept = append(kept, profileURL)
+			continue
+		}
+
+		if err := s.repository.UpdateProfileLastActive(ctx, profileURL, lastActive); err != nil {
+			s.logger.Warn("Failed to store last active time", zap.String("url", profileURL), zap.Error(err))
+		}
+
+		if lastActive.Before(cutoff) {
+			s.logger.Info("Skipping inactive profile",
+				zap.String("url", profileURL),
+				zap.Time("last_active", *lastActive),
+			)
+			if err := s.repository.UpdateProfileStatus(ctx, profileURL, core.ProfileStatusIgnored); err != nil {
+				s.logger.Warn("Failed to update profile status", zap.Error(err))
+			}
+			continue
+		}
+
+		kept = append(kept, profileURL)
+	}
+
+	return kept
+}
+
+// recordActivityFilter logs the activity filter's results for a search and records them
+// for later analysis
+func (s *SearchWorkflow) recordActivityFilter(ctx context.Context, stats *activityFilterStats) {
+	maxDays := s.config.Targeting.MaxProfileInactiveDays
+	s.logger.Info("Activity filter applied",
+		zap.Int("checked", stats.Checked),
+		zap.Int("filtered_inactive", stats.Filtered),
+		zap.Int("unknown_activity", stats.Unknown),
+		zap.Int("max_inactive_days", maxDays),
+	)
+
+	history := core.NewHistory("ActivityFilter", core.HistoryDetails{Counts: map[string]int{
+		"checked":           stats.Checked,
+		"filtered":          stats.Filtered,
+		"max_inactive_days": maxDays,
+		"no_indicator":      stats.Unknown,
+	}})
+	if err := s.repository.CreateHistory(ctx, history); err != nil {
+		s.logger.Warn("Failed to save history", zap.Error(err))
+	}
+}
+
 // buildSearchURL constructs the LinkedIn search URL with parameters
 func (s *SearchWorkflow) buildSearchURL(params *core.SearchParams) string {
 	baseURL := s.config.LinkedIn.SearchURL
@@ -176,6 +337,13 @@ func (s *SearchWorkflow) buildSearchURL(params *core.SearchParams) string {
 		queryParams.Set("geoUrn", params.Location)
 	}
 
+	// Skills are passed as a JSON array of names: skills=["Software Engineering","Python"]
+	if skills := linkedin.NormalizeSkills(params.Skills); len(skills) > 0 {
+		if encoded, err := json.Marshal(skills); err == nil {
+			queryParams.Set("skills", string(encoded))
+		}
+	}
+
 	// Note: Industry filtering might require different parameter format
 	// LinkedIn search URL format: /search/results/people/?keywords=...
 	fullURL := baseURL + "?" + queryParams.Encode()
@@ -186,9 +354,9 @@ func (s *SearchWorkflow) buildSearchURL(params *core.SearchParams) string {
 // ExtractProfileURLs extracts profile URLs from search results
 func (s *SearchWorkflow) ExtractProfileURLs(ctx context.Context) ([]string, error) {
 	// Wait for search results container (use extended timeout + retry and include current URL on failure)
-	if err := s.browser.WaitForElement(ctx, s.config.Selectors.SearchResults, 20*time.Second); err != nil {
+	if err := s.browser.WaitForElement(ctx, s.config.Selectors.SearchResults, 2*elementTimeout(&s.config.Selectors)); err != nil {
 		s.logger.Debug("Initial wait for search results failed, retrying with shorter timeout", zap.Error(err))
-		if err2 := s.browser.WaitForElement(ctx, s.config.Selectors.SearchResults, 10*time.Second); err2 != nil {
+		if err2 := s.browser.WaitForElement(ctx, s.config.Selectors.SearchResults, elementTimeout(&s.config.Selectors)); err2 != nil {
 			curURL, _ := s.browser.GetCurrentURL(ctx)
 
 			// Dump HTML for debugging
@@ -207,7 +375,7 @@ func (s *SearchWorkflow) ExtractProfileURLs(ctx context.Context) ([]string, erro
 	// We append the anchor tag selector to target the profile link within the result container
 	// This uses the robust data-view-name selector defined in config
 	selector := fmt.Sprintf("%s a[href*='/in/']", s.config.Selectors.SearchResults)
-	
+
 	rawURLs, err := s.browser.GetAttributes(ctx, selector, "href")
 	if err != nil {
 		// Fallback to legacy selectors if the new one fails
@@ -220,19 +388,23 @@ func (s *SearchWorkflow) ExtractProfileURLs(ctx context.Context) ([]string, erro
 	seen := make(map[string]bool)
 
 	for _, urlStr := range rawURLs {
-		// Ensure it's a valid LinkedIn profile URL
-		if !strings.Contains(urlStr, "/in/") || strings.Contains(urlStr, "/search") {
-			continue
-		}
-
 		// Ensure full URL
 		if !strings.HasPrefix(urlStr, "http") {
 			urlStr = s.config.LinkedIn.BaseURL + urlStr
 		}
 
-		// Remove query parameters
-		urlStr = strings.Split(urlStr, "?")[0]
-		urlStr = strings.Split(urlStr, "#")[0]
+		// Drop company pages, job listings, sub-pages and truncated links
+		if !s.isPersonProfileURL(urlStr) {
+			continue
+		}
+
+		// Ensure it's a valid LinkedIn profile URL
+		if !strings.Contains(urlStr, "/in/") || strings.Contains(urlStr, "/search") {
+			continue
+		}
+
+		// Remove query parameters and use one form per profile
+		urlStr = core.NormalizeProfileURL(urlStr)
 
 		// Remove duplicates
 		if seen[urlStr] {
@@ -275,21 +447,20 @@ func (s *SearchWorkflow) extractProfileURLsFallback(ctx context.Context) ([]stri
 				}
 			}
 		}
-		
+
 		if href == "" {
 			continue
 		}
-		
-		// Clean and validate URL
-		if strings.Contains(href, "/in/") && !strings.Contains(href, "/search") {
-			// Make sure it's a full URL
-			if !strings.HasPrefix(href, "http") {
-				href = s.config.LinkedIn.BaseURL + href
-			}
 
-			// Remove query parameters
-			href = strings.Split(href, "?")[0]
-			href = strings.Split(href, "#")[0]
+		// Make sure it's a full URL
+		if !strings.HasPrefix(href, "http") {
+			href = s.config.LinkedIn.BaseURL + href
+		}
+
+		// Clean and validate URL
+		if s.isPersonProfileURL(href) && strings.Contains(href, "/in/") && !strings.Contains(href, "/search") {
+			// Remove query parameters and use one form per profile
+			href = core.NormalizeProfileURL(href)
 
 			// Check for duplicates
 			isDuplicate := false
@@ -308,6 +479,29 @@ func (s *SearchWorkflow) extractProfileURLsFallback(ctx context.Context) ([]stri
 	return profileURLs, nil
 }
 
+// SetMetrics makes URL validation failures count toward metrics
+func (s *SearchWorkflow) SetMetrics(metrics *core.AppMetrics) {
+	s.metrics = metrics
+}
+
+// SetNotifier makes security challenges that need solving by hand send an alert
+func (s *SearchWorkflow) SetNotifier(notifier core.NotifierPort) {
+	s.notifier = notifier
+}
+
+// isPersonProfileURL reports whether urlStr passes linkedin.ValidateProfileURL, logging
+// and counting the reason when it does not
+func (s *SearchWorkflow) isPersonProfileURL(urlStr string) bool {
+	if err := linkedin.ValidateProfileURL(urlStr); err != nil {
+		s.logger.Debug("Skipping search result URL", zap.Error(err))
+		if s.metrics != nil {
+			s.metrics.ProfileURLValidationErrors.Add(1)
+		}
+		return false
+	}
+	return true
+}
+
 // handleSecurityChallenge checks for security challenges and pauses for manual intervention
 func (s *SearchWorkflow) handleSecurityChallenge(ctx context.Context) error {
 	_, err := s.browser.GetPageHTML(ctx)
@@ -341,6 +535,10 @@ func (s *SearchWorkflow) handleSecurityChallenge(ctx context.Context) error {
 
 	if challengeReason != "" {
 		s.logger.Warn("⚠️ SECURITY CHALLENGE DETECTED! ⚠️", zap.String("reason", challengeReason))
+		notify(ctx, s.notifier, core.Alert{
+			Event: core.AlertEventCaptcha,
+			Text:  "Security check during search needs solving in the browser within 5 minutes (" + challengeReason + ")",
+		})
 		s.logger.Warn("The bot has been presented with a security check (CAPTCHA/Arkose).")
 		s.logger.Warn("Please switch to the browser window and solve the challenge MANUALLY.")
 		s.logger.Warn("The bot will check every 5 seconds if the challenge is resolved.")
@@ -383,5 +581,3 @@ func (s *SearchWorkflow) handleSecurityChallenge(ctx context.Context) error {
 
 	return nil
 }
-
-
@@ -0,0 +1,83 @@
+package workflows
+
+import (
+	"context"
+	"regexp"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// TemplateEnricher supplies note template variables that can't be read from the LinkedIn
+// page, e.g. from an external API. Enrich returns an empty map when it has nothing for
+// the profile.
+type TemplateEnricher interface {
+	Enrich(ctx context.Context, profile *core.Profile) (map[string]string, error)
+}
+
+// TemplateContext holds the values substituted into a template: Variables are known up
+// front, Enrichers are asked for the rest
+type TemplateContext struct {
+	Profile   *core.Profile
+	Variables map[string]string
+	Enrichers []TemplateEnricher
+}
+
+// Enrich returns a copy of the context with the variables of every enricher added.
+// Variables already set are kept. A failing enricher is logged and skipped.
+func (t *TemplateContext) Enrich(ctx context.Context, logger *zap.Logger) *TemplateContext {
+	enriched := &TemplateContext{
+		Profile:   t.Profile,
+		Variables: make(map[string]string, len(t.Variables)),
+		Enrichers: t.Enrichers,
+	}
+	for key, value := range t.Variables {
+		enriched.Variables[key] = value
+	}
+	if t.Profile == nil {
+		return enriched
+	}
+
+	for _, enricher := range t.Enrichers {
+		values, err := enricher.Enrich(ctx, t.Profile)
+		if err != nil {
+			logger.Warn("Template enricher failed", zap.String("url", t.Profile.LinkedInURL), zap.Error(err))
+			continue
+		}
+		for key, value := range values {
+			if _, ok := enriched.Variables[key]; !ok && value != "" {
+				enriched.Variables[key] = value
+			}
+		}
+	}
+
+	return enriched
+}
+
+// templatePlaceholder matches {{Name}} as well as the text/template style {{.Name}}
+var templatePlaceholder = regexp.MustCompile(`{{\s*\.?([A-Za-z][A-Za-z0-9]*)\s*}}`)
+
+// TemplateEngine fills in {{Variable}} placeholders
+type TemplateEngine struct{}
+
+// NewTemplateEngine creates a new template engine
+func NewTemplateEngine() *TemplateEngine {
+	return &TemplateEngine{}
+}
+
+// Render substitutes data's variables into template. Placeholders without a value
+// are left in place and their names returned, so callers can decide not to send the text.
+func (e *TemplateEngine) Render(template string, data *TemplateContext) (string, []string) {
+	missing := make([]string, 0)
+	rendered := templatePlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+		name := templatePlaceholder.FindStringSubmatch(placeholder)[1]
+		if value, ok := data.Variables[name]; ok {
+			return value
+		}
+		missing = append(missing, name)
+		return placeholder
+	})
+
+	return rendered, missing
+}
@@ -0,0 +1,320 @@
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"linkedin-automation/internal/core"
+	"linkedin-automation/internal/personalization"
+
+	"go.uber.org/zap"
+)
+
+// External APIs used by the template enrichers
+const (
+	newsAPIURL       = "https://newsapi.org/v2/everything"
+	bingNewsURL      = "https://api.bing.microsoft.com/v7.0/news/search"
+	crunchbaseAPIURL = "https://api.crunchbase.com/api/v4"
+)
+
+// recentNewsWindow and recentFundingWindow bound how old a headline or funding round
+// may be to still count as recent
+const (
+	recentNewsWindow    = 30 * 24 * time.Hour
+	recentFundingWindow = 365 * 24 * time.Hour
+)
+
+// NewTemplateEnrichers returns the enrichers enabled in config: those with an API key and
+// company research
+func NewTemplateEnrichers(repository core.RepositoryPort, config *core.Config, logger *zap.Logger) []TemplateEnricher {
+	enrichers := make([]TemplateEnricher, 0, 3)
+	if config.Enrichers.NewsAPIKey != "" {
+		enrichers = append(enrichers, NewNewsEnricher(&config.Enrichers, logger))
+	}
+	if config.Enrichers.CrunchbaseAPIKey != "" {
+		enrichers = append(enrichers, NewFundingEnricher(&config.Enrichers, logger))
+	}
+	if config.Personalization.CompanyResearch.Enabled {
+		researcher := personalization.NewCompanyResearcher(repository, config, logger)
+		enrichers = append(enrichers, NewTalkingPointEnricher(researcher))
+	}
+	return enrichers
+}
+
+// TalkingPointEnricher provides {{TalkingPoint}}, one of the talking points about the
+// profile's current company, picked at random so notes to colleagues differ
+type TalkingPointEnricher struct {
+	researcher *personalization.CompanyResearcher
+}
+
+// NewTalkingPointEnricher creates a talking point enricher
+func NewTalkingPointEnricher(researcher *personalization.CompanyResearcher) *TalkingPointEnricher {
+	return &TalkingPointEnricher{researcher: researcher}
+}
+
+// Enrich picks a talking point about the profile's current company
+func (t *TalkingPointEnricher) Enrich(ctx context.Context, profile *core.Profile) (map[string]string, error) {
+	points, err := t.researcher.GetTalkingPoints(ctx, profile.CurrentCompany)
+	if err != nil || len(points) == 0 {
+		return nil, err
+	}
+
+	return map[string]string{"TalkingPoint": points[rand.Intn(len(points))]}, nil
+}
+
+// NewsEnricher provides {{LatestCompanyNews}}, a recent headline about the profile's
+// current company from NewsAPI or Bing News
+type NewsEnricher struct {
+	provider string
+	apiKey   string
+	client   *http.Client
+	logger   *zap.Logger
+	cache    *companyCache
+}
+
+// NewNewsEnricher creates a news enricher using enrichers.news_provider
+func NewNewsEnricher(config *core.EnrichersConfig, logger *zap.Logger) *NewsEnricher {
+	return &NewsEnricher{
+		provider: config.NewsProvider,
+		apiKey:   config.NewsAPIKey,
+		client:   &http.Client{Timeout: enricherTimeout(config)},
+		logger:   logger,
+		cache:    newCompanyCache(),
+	}
+}
+
+// Enrich looks up the most recent headline mentioning the profile's current company
+func (n *NewsEnricher) Enrich(ctx context.Context, profile *core.Profile) (map[string]string, error) {
+	headline, err := n.cache.get(profile.CurrentCompany, func(company string) (string, error) {
+		if n.provider == core.NewsProviderBing {
+			return n.bingHeadline(ctx, company)
+		}
+		return n.newsAPIHeadline(ctx, company)
+	})
+	if err != nil || headline == "" {
+		return nil, err
+	}
+
+	return map[string]string{"LatestCompanyNews": headline}, nil
+}
+
+// newsAPIHeadline queries NewsAPI's /v2/everything endpoint
+func (n *NewsEnricher) newsAPIHeadline(ctx context.Context, company string) (string, error) {
+	query := url.Values{
+		"q":        {`"` + company + `"`},
+		"from":     {time.Now().Add(-recentNewsWindow).Format("2006-01-02")},
+		"sortBy":   {"publishedAt"},
+		"language": {"en"},
+		"pageSize": {"1"},
+	}
+	var response struct {
+		Articles []struct {
+			Title string `json:"title"`
+		} `json:"articles"`
+	}
+	if err := getJSON(ctx, n.client, newsAPIURL+"?"+query.Encode(), map[string]string{"X-Api-Key": n.apiKey}, &response); err != nil {
+		return "", fmt.Errorf("NewsAPI request failed: %w", err)
+	}
+	if len(response.Articles) == 0 {
+		return "", nil
+	}
+
+	n.logger.Debug("Company news found", zap.String("company", company), zap.String("headline", response.Articles[0].Title))
+	return strings.TrimSpace(response.Articles[0].Title), nil
+}
+
+// bingHeadline queries the Bing News Search API
+func (n *NewsEnricher) bingHeadline(ctx context.Context, company string) (string, error) {
+	query := url.Values{
+		"q":         {`"` + company + `"`},
+		"sortBy":    {"Date"},
+		"freshness": {"Month"},
+		"count":     {"1"},
+	}
+	var response struct {
+		Value []struct {
+			Name string `json:"name"`
+		} `json:"value"`
+	}
+	if err := getJSON(ctx, n.client, bingNewsURL+"?"+query.Encode(), map[string]string{"Ocp-Apim-Subscription-Key": n.apiKey}, &response); err != nil {
+		return "", fmt.Errorf("Bing News request failed: %w", err)
+	}
+	if len(response.Value) == 0 {
+		return "", nil
+	}
+
+	n.logger.Debug("Company news found", zap.String("company", company), zap.String("headline", response.Value[0].Name))
+	return strings.TrimSpace(response.Value[0].Name), nil
+}
+
+// FundingEnricher provides {{LatestFunding}}, the profile's current company's last
+// funding round from Crunchbase (e.g. "Series B in March 2026") when it was recent
+type FundingEnricher struct {
+	apiKey string
+	client *http.Client
+	logger *zap.Logger
+	cache  *companyCache
+}
+
+// NewFundingEnricher creates a Crunchbase funding enricher
+func NewFundingEnricher(config *core.EnrichersConfig, logger *zap.Logger) *FundingEnricher {
+	return &FundingEnricher{
+		apiKey: config.CrunchbaseAPIKey,
+		client: &http.Client{Timeout: enricherTimeout(config)},
+		logger: logger,
+		cache:  newCompanyCache(),
+	}
+}
+
+// Enrich looks up the company on Crunchbase and describes its last funding round
+func (f *FundingEnricher) Enrich(ctx context.Context, profile *core.Profile) (map[string]string, error) {
+	funding, err := f.cache.get(profile.CurrentCompany, func(company string) (string, error) {
+		return f.latestFunding(ctx, company)
+	})
+	if err != nil || funding == "" {
+		return nil, err
+	}
+
+	return map[string]string{"LatestFunding": funding}, nil
+}
+
+// latestFunding resolves the company to a Crunchbase organization and reads its last
+// funding type and date
+func (f *FundingEnricher) latestFunding(ctx context.Context, company string) (string, error) {
+	headers := map[string]string{"X-cb-user-key": f.apiKey}
+
+	query := url.Values{
+		"query":          {company},
+		"collection_ids": {"organizations"},
+		"limit":          {"1"},
+	}
+	var matches struct {
+		Entities []struct {
+			Identifier struct {
+				Permalink string `json:"permalink"`
+			} `json:"identifier"`
+		} `json:"entities"`
+	}
+	if err := getJSON(ctx, f.client, crunchbaseAPIURL+"/autocompletes?"+query.Encode(), headers, &matches); err != nil {
+		return "", fmt.Errorf("Crunchbase search failed: %w", err)
+	}
+	if len(matches.Entities) == 0 || matches.Entities[0].Identifier.Permalink == "" {
+		return "", nil
+	}
+
+	permalink := matches.Entities[0].Identifier.Permalink
+	var organization struct {
+		Properties struct {
+			LastFundingType string `json:"last_funding_type"`
+			LastFundingAt   string `json:"last_funding_at"`
+		} `json:"properties"`
+	}
+	path := crunchbaseAPIURL + "/entities/organizations/" + url.PathEscape(permalink) + "?field_ids=last_funding_type,last_funding_at"
+	if err := getJSON(ctx, f.client, path, headers, &organization); err != nil {
+		return "", fmt.Errorf("Crunchbase organization lookup failed: %w", err)
+	}
+
+	fundedAt, err := time.Parse("2006-01-02", organization.Properties.LastFundingAt)
+	if err != nil || organization.Properties.LastFundingType == "" || time.Since(fundedAt) > recentFundingWindow {
+		return "", nil
+	}
+
+	f.logger.Debug("Company funding found", zap.String("company", company), zap.String("permalink", permalink))
+	return fundingRoundName(organization.Properties.LastFundingType) + " in " + fundedAt.Format("January 2006"), nil
+}
+
+// fundingRoundName turns a Crunchbase funding type like "series_b" into "Series B"
+func fundingRoundName(fundingType string) string {
+	words := strings.Fields(strings.ReplaceAll(fundingType, "_", " "))
+	for i, word := range words {
+		if len(word) == 1 {
+			words[i] = strings.ToUpper(word)
+			continue
+		}
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// enricherTimeout returns the per-request timeout of the enricher APIs
+func enricherTimeout(config *core.EnrichersConfig) time.Duration {
+	timeout := config.TimeoutSeconds
+	if timeout <= 0 {
+		timeout = 10 // Default fallback
+	}
+	return time.Duration(timeout) * time.Second
+}
+
+// getJSON fetches rawURL with headers and decodes the JSON response into out
+func getJSON(ctx context.Context, client *http.Client, rawURL string, headers map[string]string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+
+	return json.Unmarshal(body, out)
+}
+
+// companyCache remembers one lookup per company for the lifetime of the enricher, so a
+// batch of profiles from the same company costs a single API call
+type companyCache struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func newCompanyCache() *companyCache {
+	return &companyCache{values: make(map[string]string)}
+}
+
+// get returns the cached value for company, calling lookup on a miss. Failed lookups are
+// not cached. An empty company yields an empty value.
+func (c *companyCache) get(company string, lookup func(company string) (string, error)) (string, error) {
+	company = strings.TrimSpace(company)
+	if company == "" {
+		return "", nil
+	}
+	key := strings.ToLower(company)
+
+	c.mu.Lock()
+	value, ok := c.values[key]
+	c.mu.Unlock()
+	if ok {
+		return value, nil
+	}
+
+	value, err := lookup(company)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	c.values[key] = value
+	c.mu.Unlock()
+	return value, nil
+}
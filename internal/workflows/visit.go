@@ -0,0 +1,148 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// VisitWorkflow views profiles without interacting with them. The view shows up in the
+// prospect's "Who viewed your profile", which often prompts a visit back or makes a
+// later invitation more likely to be accepted.
+type VisitWorkflow struct {
+	browser    core.BrowserPort
+	repository core.RepositoryPort
+	config     *core.Config
+	logger     *zap.Logger
+}
+
+// NewVisitWorkflow creates a new visit workflow
+func NewVisitWorkflow(browser core.BrowserPort, repo core.RepositoryPort, config *core.Config, logger *zap.Logger) *VisitWorkflow {
+	return &VisitWorkflow{
+		browser:    browser,
+		repository: repo,
+		config:     config,
+		logger:     logger,
+	}
+}
+
+// VisitPending visits up to limit discovered profiles that have not been visited yet
+func (v *VisitWorkflow) VisitPending(ctx context.Context, limit int) (int, error) {
+	if limit <= 0 {
+		return 0, nil
+	}
+
+	profiles, err := v.repository.GetProfilesToVisit(ctx, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load profiles to visit: %w", err)
+	}
+
+	urls := make([]string, 0, len(profiles))
+	for _, profile := range profiles {
+		urls = append(urls, profile.LinkedInURL)
+	}
+
+	return v.VisitProfiles(ctx, urls)
+}
+
+// VisitProfiles visits each profile in turn, stopping at the daily visit limit.
+// It returns the number of profiles visited.
+func (v *VisitWorkflow) VisitProfiles(ctx context.Context, profileURLs []string) (int, error) {
+	if len(profileURLs) == 0 {
+		v.logger.Info("No profiles to visit")
+		return 0, nil
+	}
+
+	maxVisits := v.config.Visits.MaxPerDay
+	if maxVisits <= 0 {
+		maxVisits = 30 // Default fallback
+	}
+
+	v.logger.Info("Starting profile visits", zap.Int("count", len(profileURLs)))
+
+	visitedCount := 0
+	for _, profileURL := range profileURLs {
+		select {
+		case <-ctx.Done():
+			return visitedCount, ctx.Err()
+		default:
+		}
+
+		canVisit, err := v.repository.CanPerformAction(ctx, "Visit", maxVisits)
+		if err != nil {
+			v.logger.Warn("Failed to check visit rate limit", zap.Error(err))
+		} else if !canVisit {
+			v.logger.Warn("Daily visit limit reached, stopping", zap.Int("limit", maxVisits))
+			break
+		}
+
+		if err := v.VisitProfile(ctx, profileURL); err != nil {
+			v.logger.Warn("Failed to visit profile", zap.String(core.LogFieldProfileURL, profileURL), zap.Error(err))
+			continue
+		}
+		visitedCount++
+
+		v.browser.RandomSleep(ctx, 5.0, 12.0)
+	}
+
+	v.logger.Info("Profile visits complete",
+		zap.Int("requested", len(profileURLs)),
+		zap.Int("visited", visitedCount),
+	)
+
+	return visitedCount, nil
+}
+
+// VisitProfile opens a profile, reads it for a while and scrolls lightly. It never clicks
+// anything on the page.
+func (v *VisitWorkflow) VisitProfile(ctx context.Context, profileURL string) error {
+	if profileURL == "" {
+		return fmt.Errorf("profile URL is required")
+	}
+	logger := ProfileLogger(v.logger, profileURL)
+
+	if err := v.browser.Navigate(ctx, profileURL); err != nil {
+		return fmt.Errorf("failed to navigate to profile: %w", err)
+	}
+
+	dwellMin := v.config.Visits.DwellMin
+	if dwellMin <= 0 {
+		dwellMin = 8.0 // Default fallback
+	}
+	dwellMax := v.config.Visits.DwellMax
+	if dwellMax < dwellMin {
+		dwellMax = dwellMin * 2
+	}
+
+	// Read the top card, skim down a little, then glance back up
+	v.browser.RandomSleep(ctx, dwellMin/2, dwellMax/2)
+	for i := 0; i < 1+rand.Intn(3); i++ {
+		if err := v.browser.HumanScroll(ctx, "down", 300+rand.Intn(400)); err != nil {
+			logger.Debug("Failed to scroll profile", zap.Error(err))
+		}
+		v.browser.RandomSleep(ctx, 1.5, 4.0)
+	}
+	if err := v.browser.HumanScroll(ctx, "up", 400); err != nil {
+		logger.Debug("Failed to scroll profile", zap.Error(err))
+	}
+	v.browser.RandomSleep(ctx, dwellMin/2, dwellMax/2)
+
+	now := time.Now()
+	if err := v.repository.MarkProfileVisited(ctx, profileURL, now); err != nil {
+		logger.Warn("Failed to store visit", zap.Error(err))
+	}
+
+	history := core.NewHistory("Visit", core.HistoryDetails{ProfileURL: profileURL})
+	history.Timestamp = now
+	if err := v.repository.CreateHistory(ctx, history); err != nil {
+		logger.Warn("Failed to save history", zap.Error(err))
+	}
+
+	logger.Info("Visited profile", actionField("Visit"))
+	return nil
+}
@@ -0,0 +1,63 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"linkedin-automation/internal/core"
+
+	"go.uber.org/zap"
+)
+
+// WarmdownWorkflow ends a session the way a person winds down: a few minutes of reading
+// the feed without liking, commenting or connecting, instead of closing the browser
+// straight after the last action
+type WarmdownWorkflow struct {
+	browser    core.BrowserPort
+	repository core.RepositoryPort
+	config     *core.Config
+	logger     *zap.Logger
+}
+
+// NewWarmdownWorkflow creates a new warmdown workflow
+func NewWarmdownWorkflow(browser core.BrowserPort, repo core.RepositoryPort, config *core.Config, logger *zap.Logger) *WarmdownWorkflow {
+	return &WarmdownWorkflow{
+		browser:    browser,
+		repository: repo,
+		config:     config,
+		logger:     logger,
+	}
+}
+
+// Run opens the home feed and reads it for 3-6 scrolls
+func (w *WarmdownWorkflow) Run(ctx context.Context) error {
+	w.logger.Info("Warming down before ending the session")
+
+	if err := w.browser.Navigate(ctx, w.config.LinkedIn.BaseURL+"/feed/"); err != nil {
+		return fmt.Errorf("failed to navigate to feed: %w", err)
+	}
+	w.browser.RandomSleep(ctx, 3.0, 6.0)
+
+	scrolls := 3 + rand.Intn(4)
+	for i := 0; i < scrolls; i++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if err := w.browser.HumanScroll(ctx, "down", 600+rand.Intn(600)); err != nil {
+			return fmt.Errorf("failed to scroll feed: %w", err)
+		}
+		// Read what scrolled into view
+		w.browser.RandomSleep(ctx, 4.0, 10.0)
+	}
+
+	history := core.NewHistory("Warmdown", core.HistoryDetails{Counts: map[string]int{"scrolls": scrolls}})
+	if err := w.repository.CreateHistory(ctx, history); err != nil {
+		w.logger.Warn("Failed to save history", zap.Error(err))
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package linkedin
+
+import "strings"
+
+// Skills maps common lower-case skill spellings to the name LinkedIn's skill
+// typeahead returns for them. People search matches skills by these names, so
+// normalizing avoids filters that silently match nothing ("golang" vs "Go (Programming Language)").
+var Skills = map[string]string{
+	"software engineering":  "Software Engineering",
+	"software development":  "Software Development",
+	"python":                "Python (Programming Language)",
+	"java":                  "Java",
+	"javascript":            "JavaScript",
+	"js":                    "JavaScript",
+	"typescript":            "TypeScript",
+	"go":                    "Go (Programming Language)",
+	"golang":                "Go (Programming Language)",
+	"rust":                  "Rust (Programming Language)",
+	"c++":                   "C++",
+	"c#":                    "C#",
+	"ruby":                  "Ruby",
+	"php":                   "PHP",
+	"sql":                   "SQL",
+	"react":                 "React.js",
+	"react.js":              "React.js",
+	"node":                  "Node.js",
+	"node.js":               "Node.js",
+	"kubernetes":            "Kubernetes",
+	"k8s":                   "Kubernetes",
+	"docker":                "Docker Products",
+	"aws":                   "Amazon Web Services (AWS)",
+	"amazon web services":   "Amazon Web Services (AWS)",
+	"gcp":                   "Google Cloud Platform (GCP)",
+	"google cloud platform": "Google Cloud Platform (GCP)",
+	"azure":                 "Microsoft Azure",
+	"machine learning":      "Machine Learning",
+	"ml":                    "Machine Learning",
+	"deep learning":         "Deep Learning",
+	"data science":          "Data Science",
+	"data analysis":         "Data Analysis",
+	"devops":                "DevOps",
+	"project management":    "Project Management",
+	"product management":    "Product Management",
+	"agile":                 "Agile Methodologies",
+	"scrum":                 "Scrum",
+	"sales":                 "Sales",
+	"marketing":             "Marketing",
+	"digital marketing":     "Digital Marketing",
+	"recruiting":            "Recruiting",
+	"leadership":            "Leadership",
+	"ux":                    "User Experience (UX)",
+	"user experience":       "User Experience (UX)",
+}
+
+// NormalizeSkill returns LinkedIn's name for a skill. Unknown skills are returned trimmed
+// but otherwise unchanged.
+func NormalizeSkill(skill string) string {
+	trimmed := strings.TrimSpace(skill)
+	if name, ok := Skills[strings.ToLower(trimmed)]; ok {
+		return name
+	}
+	return trimmed
+}
+
+// NormalizeSkills normalizes a list of skills, dropping empty entries and duplicates
+func NormalizeSkills(skills []string) []string {
+	seen := make(map[string]bool)
+	result := make([]string, 0, len(skills))
+	for _, skill := range skills {
+		name := NormalizeSkill(skill)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		result = append(result, name)
+	}
+	return result
+}
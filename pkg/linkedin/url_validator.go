@@ -0,0 +1,57 @@
+package linkedin
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// profileURLPrefix is the only form of profile URL the validator accepts
+const profileURLPrefix = "https://www.linkedin.com/in/"
+
+// vanityNamePattern matches the name segment of a person's profile URL. Member ID
+// slugs (ACoAA...) match too; percent-encoded non-ASCII names do not.
+var vanityNamePattern = regexp.MustCompile(`^[a-zA-Z0-9\-]{3,100}$`)
+
+// BotAccountPatterns match name segments that are not real people: placeholders left
+// by half-rendered result cards and obvious automation or test accounts
+var BotAccountPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)^(undefined|null|unknown|none)$`),
+	regexp.MustCompile(`(?i)^linkedin-?(member|user|guest)`),
+	regexp.MustCompile(`(?i)^test-?(user|account|profile)`),
+	regexp.MustCompile(`(?i)(^|-)bot(-|$)`),
+}
+
+// ErrInvalidProfileURL is wrapped by every ValidateProfileURL error
+var ErrInvalidProfileURL = errors.New("invalid profile URL")
+
+// ValidateProfileURL checks that url is a person's profile URL of the form
+// https://www.linkedin.com/in/<name>/ and returns why it is not otherwise. The query
+// string and fragment are ignored. Company pages, job listings, sub-pages such as
+// /in/<name>/details/ and truncated or placeholder names are rejected.
+func ValidateProfileURL(url string) error {
+	trimmed := strings.TrimSpace(url)
+	if i := strings.IndexAny(trimmed, "?#"); i >= 0 {
+		trimmed = trimmed[:i]
+	}
+
+	if !strings.HasPrefix(trimmed, profileURLPrefix) {
+		return fmt.Errorf("%w: %q does not start with %s", ErrInvalidProfileURL, url, profileURLPrefix)
+	}
+
+	name := strings.TrimSuffix(strings.TrimPrefix(trimmed, profileURLPrefix), "/")
+	if strings.Contains(name, "/") {
+		return fmt.Errorf("%w: %q has path segments after the profile name", ErrInvalidProfileURL, url)
+	}
+	if !vanityNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: %q has a malformed profile name", ErrInvalidProfileURL, url)
+	}
+	for _, pattern := range BotAccountPatterns {
+		if pattern.MatchString(name) {
+			return fmt.Errorf("%w: %q looks like a bot or placeholder account", ErrInvalidProfileURL, url)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package pacing
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// jitterFraction is how far a paced delay is randomly moved either way, so delays stay
+// irregular even when the bot is exactly on target
+const jitterFraction = 0.15
+
+// Pacer picks the delay before the next action so that a target number of actions per
+// hour is reached, within the configured cooldown bounds
+type Pacer struct {
+	targetPerHour int
+	minDelay      time.Duration
+	maxDelay      time.Duration
+	windowEnd     time.Time
+	now           func() time.Time
+}
+
+// NewPacer creates a pacer aiming for targetPerHour actions. Delays are always within
+// [minDelay, maxDelay]. A non-zero windowEnd is the end of the working hours window; the
+// pacer only plans for the part of the hour before it. With targetPerHour <= 0 the
+// delay is simply random within the bounds.
+func NewPacer(targetPerHour int, minDelay, maxDelay time.Duration, windowEnd time.Time) *Pacer {
+	if minDelay < 0 {
+		minDelay = 0
+	}
+	if maxDelay < minDelay {
+		maxDelay = minDelay
+	}
+
+	return &Pacer{
+		targetPerHour: targetPerHour,
+		minDelay:      minDelay,
+		maxDelay:      maxDelay,
+		windowEnd:     windowEnd,
+		now:           time.Now,
+	}
+}
+
+// NextDelay returns the delay before the next action, given the actions taken in the
+// current hour and the minutes elapsed since it started. The time left in the hour
+// (or until the working hours window ends, if sooner) is split evenly over the actions
+// still needed to reach the target, so the delay shrinks when behind and grows when
+// ahead. Actions the window leaves no time for are not chased.
+func (p *Pacer) NextDelay(actionsThisHour int, elapsedMinutes float64) time.Duration {
+	if p.targetPerHour <= 0 {
+		return p.random()
+	}
+
+	if elapsedMinutes < 0 {
+		elapsedMinutes = 0
+	}
+	hourMinutes := math.Max(60, elapsedMinutes)
+	if !p.windowEnd.IsZero() {
+		untilEnd := p.windowEnd.Sub(p.now()).Minutes()
+		if untilEnd <= 0 {
+			return p.maxDelay
+		}
+		hourMinutes = math.Min(hourMinutes, elapsedMinutes+untilEnd)
+	}
+
+	// The target scales down with the part of the hour the window leaves
+	target := float64(p.targetPerHour) * hourMinutes / 60
+	remainingActions := target - float64(actionsThisHour)
+	remainingMinutes := hourMinutes - elapsedMinutes
+	if remainingActions <= 0 || remainingMinutes <= 0 {
+		return p.maxDelay
+	}
+
+	minutes := remainingMinutes / math.Max(1, remainingActions)
+	minutes *= 1 + jitterFraction*(2*rand.Float64()-1)
+	return p.clamp(time.Duration(minutes * float64(time.Minute)))
+}
+
+// random returns a uniformly random delay within the bounds
+func (p *Pacer) random() time.Duration {
+	if p.maxDelay == p.minDelay {
+		return p.minDelay
+	}
+	return p.minDelay + time.Duration(rand.Int63n(int64(p.maxDelay-p.minDelay)+1))
+}
+
+// clamp keeps delay within [minDelay, maxDelay]
+func (p *Pacer) clamp(delay time.Duration) time.Duration {
+	if delay < p.minDelay {
+		return p.minDelay
+	}
+	if delay > p.maxDelay {
+		return p.maxDelay
+	}
+	return delay
+}
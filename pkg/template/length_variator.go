@@ -0,0 +1,102 @@
+package template
+
+import (
+	"math/rand"
+	"strings"
+	"unicode/utf8"
+)
+
+// LengthVariator varies the length of rendered notes so that notes from the same template
+// don't all end up with the same character count
+type LengthVariator struct {
+	closingPhrases []string
+}
+
+// NewLengthVariator creates a variator that pads short notes with one of closingPhrases
+func NewLengthVariator(closingPhrases []string) *LengthVariator {
+	return &LengthVariator{closingPhrases: closingPhrases}
+}
+
+// Vary picks a target length in [min, max] characters. Longer notes are cut at the last
+// sentence boundary within the range, shorter ones get a closing phrase that keeps them
+// within max. A note is returned unchanged when no cut or phrase fits, or when the range
+// is not set (max <= 0).
+func (v *LengthVariator) Vary(rendered string, min, max int) string {
+	if max <= 0 || min > max {
+		return rendered
+	}
+	if min < 0 {
+		min = 0
+	}
+
+	rendered = strings.TrimSpace(rendered)
+	target := min + rand.Intn(max-min+1)
+	length := utf8.RuneCountInString(rendered)
+
+	if length > target {
+		if cut, ok := cutAtSentence(rendered, min, target); ok {
+			return cut
+		}
+		if length > max {
+			if cut, ok := cutAtSentence(rendered, min, max); ok {
+				return cut
+			}
+		}
+		return rendered
+	}
+
+	if length < target {
+		return v.addClosingPhrase(rendered, length, min, max)
+	}
+
+	return rendered
+}
+
+// addClosingPhrase appends a random closing phrase that keeps the note within max
+// characters, preferring phrases that bring it up to min
+func (v *LengthVariator) addClosingPhrase(rendered string, length, min, max int) string {
+	fitting := make([]string, 0, len(v.closingPhrases))
+	reachingMin := make([]string, 0, len(v.closingPhrases))
+	for _, phrase := range v.closingPhrases {
+		phrase = strings.TrimSpace(phrase)
+		if phrase == "" || strings.Contains(rendered, phrase) {
+			continue
+		}
+		padded := length + 1 + utf8.RuneCountInString(phrase)
+		if padded > max {
+			continue
+		}
+		fitting = append(fitting, phrase)
+		if padded >= min {
+			reachingMin = append(reachingMin, phrase)
+		}
+	}
+	if len(reachingMin) > 0 {
+		fitting = reachingMin
+	}
+	if len(fitting) == 0 {
+		return rendered
+	}
+
+	return rendered + " " + fitting[rand.Intn(len(fitting))]
+}
+
+// cutAtSentence returns text up to the last sentence end whose length is within
+// [min, max] characters
+func cutAtSentence(text string, min, max int) (string, bool) {
+	runes := []rune(text)
+	if max > len(runes) {
+		max = len(runes)
+	}
+
+	for i := max; i >= min && i > 0; i-- {
+		switch runes[i-1] {
+		case '.', '!', '?':
+			if i == len(runes) || runes[i] == ' ' || runes[i] == '\n' {
+				return string(runes[:i]), true
+			}
+		}
+	}
+
+	return "", false
+}
@@ -3,6 +3,9 @@ package utils
 import (
 	"fmt"
 	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -35,6 +38,35 @@ func IsWithinWorkingHours(startTime, endTime string) (bool, error) {
 	return now.After(startToday) && now.Before(endToday), nil
 }
 
+// WorkingHoursEnd returns when the working hours window containing, or next starting
+// after, the current time ends
+func WorkingHoursEnd(startTime, endTime string) (time.Time, error) {
+	now := time.Now()
+
+	start, err := time.Parse("15:04", startTime)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid start time format: %w", err)
+	}
+	end, err := time.Parse("15:04", endTime)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid end time format: %w", err)
+	}
+
+	startToday := time.Date(now.Year(), now.Month(), now.Day(), start.Hour(), start.Minute(), 0, 0, now.Location())
+	endToday := time.Date(now.Year(), now.Month(), now.Day(), end.Hour(), end.Minute(), 0, 0, now.Location())
+
+	// Overnight windows (e.g. 23:00 to 02:00) end the next day, unless we are already
+	// past midnight inside the window that started yesterday
+	if endToday.Before(startToday) && !now.Before(startToday) {
+		endToday = endToday.Add(24 * time.Hour)
+	}
+	if !endToday.After(now) {
+		endToday = endToday.Add(24 * time.Hour)
+	}
+
+	return endToday, nil
+}
+
 // RandomCooldown returns a random cooldown duration between min and max minutes
 func RandomCooldown(minMinutes, maxMinutes int) time.Duration {
 	if minMinutes < 0 {
@@ -63,3 +95,142 @@ func FormatDuration(d time.Duration) string {
 	return fmt.Sprintf("%dm", minutes)
 }
 
+
+// relativeTimePattern matches phrases like "2 days ago" or "1 week ago"
+var relativeTimePattern = regexp.MustCompile(`(\d+)\s*(minute|min|hour|hr|day|week|wk|month|mo|year|yr)s?\s+ago`)
+
+// absoluteDateLayouts are the date formats LinkedIn uses on connection cards
+var absoluteDateLayouts = []string{
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"January 2 2006",
+	"1/2/2006",
+}
+
+// ParseRelativeTime converts LinkedIn-style relative time text ("today", "3 days ago",
+// "Connected on March 4, 2024") into an approximate absolute time
+func ParseRelativeTime(text string, now time.Time) (time.Time, bool) {
+	lower := strings.ToLower(strings.TrimSpace(text))
+	if lower == "" {
+		return time.Time{}, false
+	}
+
+	switch {
+	case strings.Contains(lower, "just now"), strings.Contains(lower, "today"), strings.Contains(lower, " now"):
+		return now, true
+	case strings.Contains(lower, "yesterday"):
+		return now.AddDate(0, 0, -1), true
+	}
+
+	if match := relativeTimePattern.FindStringSubmatch(lower); match != nil {
+		n, err := strconv.Atoi(match[1])
+		if err != nil {
+			return time.Time{}, false
+		}
+
+		switch match[2] {
+		case "minute", "min":
+			return now.Add(-time.Duration(n) * time.Minute), true
+		case "hour", "hr":
+			return now.Add(-time.Duration(n) * time.Hour), true
+		case "day":
+			return now.AddDate(0, 0, -n), true
+		case "week", "wk":
+			return now.AddDate(0, 0, -7*n), true
+		case "month", "mo":
+			return now.AddDate(0, -n, 0), true
+		case "year", "yr":
+			return now.AddDate(-n, 0, 0), true
+		}
+	}
+
+	// Absolute dates, e.g. "Connected on March 4, 2024"
+	dateText := strings.TrimSpace(text)
+	if idx := strings.Index(strings.ToLower(dateText), " on "); idx >= 0 {
+		dateText = strings.TrimSpace(dateText[idx+4:])
+	}
+	for _, layout := range absoluteDateLayouts {
+		if t, err := time.ParseInLocation(layout, dateText, now.Location()); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// threadDateLayouts are the date headings LinkedIn shows in conversation threads
+var threadDateLayouts = []string{
+	"Jan 2, 2006",
+	"January 2, 2006",
+	"Jan 2",
+	"January 2",
+}
+
+// threadClockLayouts are the message timestamps shown within a conversation thread
+var threadClockLayouts = []string{
+	"3:04 PM",
+	"3:04PM",
+	"15:04",
+}
+
+// ParseThreadTimestamp combines a conversation date heading ("Today", "Monday", "Jan 5")
+// with a message clock time ("10:32 AM") into an absolute time
+func ParseThreadTimestamp(heading, clock string, now time.Time) (time.Time, bool) {
+	heading = strings.TrimSpace(heading)
+	lower := strings.ToLower(heading)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var day time.Time
+	switch lower {
+	case "", "today":
+		day = today
+	case "yesterday":
+		day = today.AddDate(0, 0, -1)
+	default:
+		for wd := time.Sunday; wd <= time.Saturday; wd++ {
+			if lower == strings.ToLower(wd.String()) {
+				// Weekday headings refer to the past week
+				diff := (int(today.Weekday()) - int(wd) + 7) % 7
+				if diff == 0 {
+					diff = 7
+				}
+				day = today.AddDate(0, 0, -diff)
+				break
+			}
+		}
+
+		if day.IsZero() {
+			for _, layout := range threadDateLayouts {
+				t, err := time.ParseInLocation(layout, heading, now.Location())
+				if err != nil {
+					continue
+				}
+				if t.Year() == 0 {
+					// Headings without a year are within the last twelve months
+					t = t.AddDate(now.Year(), 0, 0)
+					if t.After(today) {
+						t = t.AddDate(-1, 0, 0)
+					}
+				}
+				day = t
+				break
+			}
+		}
+	}
+
+	if day.IsZero() {
+		return time.Time{}, false
+	}
+
+	clock = strings.ToUpper(strings.TrimSpace(clock))
+	if clock == "" {
+		return day, true
+	}
+	for _, layout := range threadClockLayouts {
+		if t, err := time.Parse(layout, clock); err == nil {
+			return day.Add(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute), true
+		}
+	}
+
+	return day, true
+}
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRelativeTime(t *testing.T) {
+	now := time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		text string
+		want time.Time
+		ok   bool
+	}{
+		{"Connected today", now, true},
+		{"just now", now, true},
+		{"Connected yesterday", now.AddDate(0, 0, -1), true},
+		{"Connected 1 day ago", now.AddDate(0, 0, -1), true},
+		{"Connected 2 days ago", now.AddDate(0, 0, -2), true},
+		{"3 weeks ago", now.AddDate(0, 0, -21), true},
+		{"Connected 1 month ago", now.AddDate(0, -1, 0), true},
+		{"Connected 5 months ago", now.AddDate(0, -5, 0), true},
+		{"2 years ago", now.AddDate(-2, 0, 0), true},
+		{"4 hours ago", now.Add(-4 * time.Hour), true},
+		{"Connected on March 4, 2024", time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC), true},
+		{"", time.Time{}, false},
+		{"Message", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseRelativeTime(tt.text, now)
+		if ok != tt.ok || !got.Equal(tt.want) {
+			t.Errorf("ParseRelativeTime(%q) = %v, %v; want %v, %v", tt.text, got, ok, tt.want, tt.ok)
+		}
+	}
+}